@@ -0,0 +1,155 @@
+package cdcconfig
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/TIA-PARTNERS-GROUP/tia-api/cdc/graphschema"
+	"github.com/go-sql-driver/mysql"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+var topicName = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+
+var memgraphSchemes = map[string]bool{
+	"bolt": true, "bolt+s": true, "bolt+ssc": true,
+	"neo4j": true, "neo4j+s": true, "neo4j+ssc": true,
+}
+
+type Config struct {
+	KafkaBrokers     []string
+	TopicPrefix      string
+	Database         string
+	Tables           []string
+	GroupID          string
+	MemgraphURI      string
+	MemgraphUser     string
+	MemgraphPassword string
+	MySQLDSN         string
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func SplitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func FromEnv(defaultGroupID string) Config {
+	memgraphURI := os.Getenv("MEMGRAPH_URI")
+	if memgraphURI == "" {
+		memgraphURI = fmt.Sprintf("bolt://%s:%s", getEnv("MEMGRAPH_HOST", "memgraph"), getEnv("MEMGRAPH_PORT", "7687"))
+	}
+	return Config{
+		KafkaBrokers:     SplitList(getEnv("KAFKA_BROKERS", "kafka:29092")),
+		TopicPrefix:      getEnv("CDC_TOPIC_PREFIX", "tia-db"),
+		Database:         getEnv("CDC_DATABASE", "tia-dev"),
+		Tables:           SplitList(os.Getenv("CDC_TABLES")),
+		GroupID:          getEnv("CDC_GROUP_ID", defaultGroupID),
+		MemgraphURI:      memgraphURI,
+		MemgraphUser:     os.Getenv("MEMGRAPH_USER"),
+		MemgraphPassword: os.Getenv("MEMGRAPH_PASSWORD"),
+		MySQLDSN:         getEnv("DATABASE_URL", "root:tia-dev-password@tcp(database:3306)/tia-dev?parseTime=true"),
+	}
+}
+
+func (c *Config) RegisterFlags(flags *flag.FlagSet) {
+	list := func(target *[]string) func(string) error {
+		return func(value string) error {
+			*target = SplitList(value)
+			return nil
+		}
+	}
+	flags.Func("kafka-brokers", "comma-separated Kafka brokers (env KAFKA_BROKERS)", list(&c.KafkaBrokers))
+	flags.StringVar(&c.TopicPrefix, "topic-prefix", c.TopicPrefix, "Debezium topic prefix (env CDC_TOPIC_PREFIX)")
+	flags.StringVar(&c.Database, "database", c.Database, "captured database name (env CDC_DATABASE)")
+	flags.Func("tables", "comma-separated tables to process (env CDC_TABLES, default: all)", list(&c.Tables))
+	flags.StringVar(&c.GroupID, "group-id", c.GroupID, "Kafka consumer group (env CDC_GROUP_ID)")
+	flags.StringVar(&c.MemgraphURI, "memgraph-uri", c.MemgraphURI, "Memgraph bolt URI (env MEMGRAPH_URI)")
+	flags.StringVar(&c.MemgraphUser, "memgraph-user", c.MemgraphUser, "Memgraph user (env MEMGRAPH_USER)")
+	flags.StringVar(&c.MemgraphPassword, "memgraph-password", c.MemgraphPassword, "Memgraph password (env MEMGRAPH_PASSWORD)")
+	flags.StringVar(&c.MySQLDSN, "mysql-dsn", c.MySQLDSN, "MySQL DSN (env DATABASE_URL)")
+}
+
+func (c Config) Validate() error {
+	var errs []error
+	if len(c.KafkaBrokers) == 0 {
+		errs = append(errs, errors.New("at least one Kafka broker is required"))
+	}
+	if !topicName.MatchString(c.TopicPrefix) {
+		errs = append(errs, fmt.Errorf("invalid topic prefix %q", c.TopicPrefix))
+	}
+	if !topicName.MatchString(c.Database) {
+		errs = append(errs, fmt.Errorf("invalid database name %q", c.Database))
+	}
+	if _, err := graphschema.Select(c.Tables); err != nil {
+		errs = append(errs, err)
+	}
+	if strings.TrimSpace(c.GroupID) == "" {
+		errs = append(errs, errors.New("consumer group ID is required"))
+	}
+	if err := c.ValidateMemgraph(); err != nil {
+		errs = append(errs, err)
+	}
+	if dsn, err := mysql.ParseDSN(c.MySQLDSN); err != nil {
+		errs = append(errs, fmt.Errorf("invalid MySQL DSN: %v", err))
+	} else if dsn.DBName != c.Database {
+		errs = append(errs, fmt.Errorf("MySQL DSN database %q does not match CDC database %q", dsn.DBName, c.Database))
+	}
+	return errors.Join(errs...)
+}
+
+func (c Config) ValidateMemgraph() error {
+	var errs []error
+	if u, err := url.Parse(c.MemgraphURI); err != nil || !memgraphSchemes[u.Scheme] || u.Host == "" {
+		errs = append(errs, fmt.Errorf("invalid Memgraph URI %q", c.MemgraphURI))
+	}
+	if c.MemgraphPassword != "" && c.MemgraphUser == "" {
+		errs = append(errs, errors.New("Memgraph password is set without a user"))
+	}
+	return errors.Join(errs...)
+}
+
+func (c Config) SelectedTables() []graphschema.Table {
+	tables, _ := graphschema.Select(c.Tables)
+	return tables
+}
+
+func (c Config) Topic(table string) string {
+	return c.TopicPrefix + "." + c.Database + "." + table
+}
+
+func (c Config) SchemaChangeTopic() string {
+	return c.TopicPrefix
+}
+
+func (c Config) Topics() []string {
+	tables := c.SelectedTables()
+	topics := make([]string, 0, len(tables)+1)
+	for _, table := range tables {
+		topics = append(topics, c.Topic(table.Name))
+	}
+	return append(topics, c.SchemaChangeTopic())
+}
+
+func (c Config) MemgraphAuth() neo4j.AuthToken {
+	if c.MemgraphUser == "" {
+		return neo4j.NoAuth()
+	}
+	return neo4j.BasicAuth(c.MemgraphUser, c.MemgraphPassword, "")
+}
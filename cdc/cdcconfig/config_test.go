@@ -0,0 +1,64 @@
+package cdcconfig
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromEnvDefaultsAreValid(t *testing.T) {
+	for _, key := range []string{"KAFKA_BROKERS", "CDC_TOPIC_PREFIX", "CDC_DATABASE", "CDC_TABLES", "CDC_GROUP_ID", "MEMGRAPH_URI", "MEMGRAPH_HOST", "MEMGRAPH_PORT", "MEMGRAPH_USER", "MEMGRAPH_PASSWORD", "DATABASE_URL"} {
+		t.Setenv(key, "")
+	}
+	cfg := FromEnv("kg-builder-group")
+
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, []string{"kafka:29092"}, cfg.KafkaBrokers)
+	assert.Equal(t, "bolt://memgraph:7687", cfg.MemgraphURI)
+	assert.Equal(t, "kg-builder-group", cfg.GroupID)
+	assert.Contains(t, cfg.Topics(), "tia-db.tia-dev.users")
+	assert.Equal(t, "tia-db", cfg.Topics()[len(cfg.Topics())-1])
+}
+
+func TestFlagsOverrideEnvAndDeriveTopics(t *testing.T) {
+	t.Setenv("CDC_TOPIC_PREFIX", "tia-prod")
+	t.Setenv("CDC_DATABASE", "tia")
+	t.Setenv("DATABASE_URL", "kg:secret@tcp(db:3306)/tia?parseTime=true")
+	cfg := FromEnv("kg-builder-group")
+
+	flags := flag.NewFlagSet("kg-builder", flag.ContinueOnError)
+	cfg.RegisterFlags(flags)
+	require.NoError(t, flags.Parse([]string{"-tables", "users, businesses", "-group-id", "kg-builder-prod", "-kafka-brokers", "k1:9092,k2:9092"}))
+
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, []string{"k1:9092", "k2:9092"}, cfg.KafkaBrokers)
+	assert.Equal(t, "kg-builder-prod", cfg.GroupID)
+	assert.Equal(t, []string{"tia-prod.tia.users", "tia-prod.tia.businesses", "tia-prod"}, cfg.Topics())
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	cfg := Config{
+		TopicPrefix:      "tia db",
+		Database:         "tia-dev",
+		Tables:           []string{"invoices"},
+		MemgraphURI:      "http://memgraph:7687",
+		MemgraphPassword: "secret",
+		MySQLDSN:         "root:pw@tcp(database:3306)/tia-staging",
+	}
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	for _, message := range []string{
+		"at least one Kafka broker is required",
+		`invalid topic prefix "tia db"`,
+		`unknown table "invoices"`,
+		"consumer group ID is required",
+		`invalid Memgraph URI "http://memgraph:7687"`,
+		"Memgraph password is set without a user",
+		`MySQL DSN database "tia-staging" does not match CDC database "tia-dev"`,
+	} {
+		assert.Contains(t, err.Error(), message)
+	}
+}
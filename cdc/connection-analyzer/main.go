@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"net/http"
@@ -9,8 +10,11 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/TIA-PARTNERS-GROUP/tia-api/cdc/cdcconfig"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/cdc/telemetry"
 	"github.com/gin-gonic/gin"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 type ConnectionAnalyzer struct {
@@ -62,20 +66,22 @@ type ProjectMatch struct {
 }
 
 func main() {
-	fmt.Println("Starting Connection Analysis Service...")
+	cfg := cdcconfig.FromEnv("connection-analyzer-group")
+	flags := flag.NewFlagSet("connection-analyzer", flag.ExitOnError)
+	cfg.RegisterFlags(flags)
+	flags.Parse(os.Args[1:])
+	if err := cfg.ValidateMemgraph(); err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
 
-	memgraphHost := getEnv("MEMGRAPH_HOST", "memgraph")
-	memgraphPort := getEnv("MEMGRAPH_PORT", "7687")
+	fmt.Println("Starting Connection Analysis Service...")
 	
 	var driver neo4j.DriverWithContext
 	var err error
 	
 	for i := 0; i < 5; i++ {
-		fmt.Printf("Attempting to connect to Memgraph at %s:%s (attempt %d/5)...\n", memgraphHost, memgraphPort, i+1)
-		driver, err = neo4j.NewDriverWithContext(
-			fmt.Sprintf("bolt://%s:%s", memgraphHost, memgraphPort),
-			neo4j.NoAuth(),
-		)
+		fmt.Printf("Attempting to connect to Memgraph at %s (attempt %d/5)...\n", cfg.MemgraphURI, i+1)
+		driver, err = neo4j.NewDriverWithContext(cfg.MemgraphURI, cfg.MemgraphAuth())
 		if err == nil {
 			ctx := context.Background()
 			err = driver.VerifyConnectivity(ctx)
@@ -114,6 +120,10 @@ func main() {
 		c.Next()
 	})
 
+	router.GET("/healthz", gin.WrapF(telemetry.Healthz))
+	router.GET("/readyz", gin.WrapF(telemetry.Readyz(map[string]telemetry.Check{"memgraph": driver.VerifyConnectivity})))
+	router.GET("/metrics", gin.WrapH(promhttp.HandlerFor(metrics, promhttp.HandlerOpts{})))
+
 	api := router.Group("/api/v1")
 	{
 		api.GET("/connections/complementary/:userId", analyzer.getComplementaryPartners)
@@ -139,33 +149,10 @@ func (ca *ConnectionAnalyzer) getComplementaryPartners(c *gin.Context) {
 	session := ca.driver.NewSession(context.Background(), neo4j.SessionConfig{})
 	defer session.Close(context.Background())
 
-	query := `
-	MATCH (u:User {id: $userId})-[:OPERATES]->(b1:Business)
-	MATCH (b2:Business)<-[:OPERATES]-(u2:User)
-	WHERE b1.id <> b2.id AND u.id <> u2.id
-	  AND (
-		(b1.businessType = b2.businessType AND b1.businessCategory <> b2.businessCategory) OR
-		(b1.businessType <> b2.businessType AND b1.businessCategory = b2.businessCategory)
-	  )
-	WITH u, b1, b2, u2,
-		CASE 
-			WHEN b1.businessType = b2.businessType AND b1.businessCategory <> b2.businessCategory THEN 0.9
-			WHEN b1.businessType <> b2.businessType AND b1.businessCategory = b2.businessCategory THEN 0.8
-			ELSE 0.7
-		END as compatibilityScore
-	OPTIONAL MATCH (b1)-[conn:CONNECTED_TO]->(b2)
-	RETURN b2.id as businessId, b2.name as businessName, b2.businessType as businessType,
-		   b2.businessCategory as businessCategory, b2.businessPhase as businessPhase,
-		   b2.description as description, b2.website as website,
-		   u2.id as userId, u2.firstName as firstName, u2.lastName as lastName, u2.email as email,
-		   compatibilityScore, 
-		   CASE WHEN conn IS NOT NULL THEN conn.status ELSE 'not_connected' END as connectionStatus
-	ORDER BY compatibilityScore DESC
-	LIMIT 20
-	`
-
-	result, err := session.Run(context.Background(), query, map[string]interface{}{"userId": userID})
+	started := time.Now()
+	result, err := session.Run(context.Background(), complementaryPartnersQuery, map[string]interface{}{"userId": userID})
 	if err != nil {
+		observeQuery("complementary", started, err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to query complementary partners"})
 		return
 	}
@@ -193,6 +180,9 @@ func (ca *ConnectionAnalyzer) getComplementaryPartners(c *gin.Context) {
 		
 		score := record.Values[11].(float64)
 		connectionStatus := record.Values[12].(string)
+		completeness := record.Values[13].(int64)
+		rankedScore := record.Values[14].(float64)
+		sharedClientTags := record.Values[15].(int64)
 		
 		reason := fmt.Sprintf("Complementary business types: %s + %s", 
 			getBusinessType(record.Values[2].(string)), 
@@ -200,18 +190,21 @@ func (ca *ConnectionAnalyzer) getComplementaryPartners(c *gin.Context) {
 		
 		compatibilityFactors := []string{
 			fmt.Sprintf("Business type compatibility: %.0f%%", score*100),
+			fmt.Sprintf("Shared client segments: %d", sharedClientTags),
+			fmt.Sprintf("Profile completeness: %d%%", completeness),
 			fmt.Sprintf("Current connection status: %s", connectionStatus),
 		}
 		
 		recommendations = append(recommendations, ConnectionRecommendation{
 			Type:                "COMPLEMENTARY_PARTNER",
-			Score:               score,
+			Score:               rankedScore,
 			Reason:              reason,
 			Business:            businessInfo,
 			User:                userInfo,
 			CompatibilityFactors: compatibilityFactors,
 		})
 	}
+	observeQuery("complementary", started, result.Err())
 
 	c.JSON(http.StatusOK, gin.H{
 		"type": "COMPLEMENTARY_PARTNERS",
@@ -231,35 +224,10 @@ func (ca *ConnectionAnalyzer) getAlliancePartners(c *gin.Context) {
 	session := ca.driver.NewSession(context.Background(), neo4j.SessionConfig{})
 	defer session.Close(context.Background())
 
-	query := `
-	MATCH (u:User {id: $userId})-[:OPERATES]->(b1:Business)
-	MATCH (u)-[:HAS_SKILL]->(s:Skill)<-[:HAS_SKILL]-(u2:User)-[:OPERATES]->(b2:Business)
-	WHERE b1.id <> b2.id AND u.id <> u2.id
-	WITH u, b1, b2, u2, collect(s) as sharedSkills
-	WITH u, b1, b2, u2, sharedSkills,
-		size(sharedSkills) * 0.4 + 
-		CASE 
-			WHEN b1.businessPhase = b2.businessPhase THEN 0.3
-			ELSE 0.1
-		END +
-		CASE 
-			WHEN b1.businessCategory = b2.businessCategory THEN 0.3
-			ELSE 0.1
-		END as allianceScore
-	WHERE allianceScore > 0.5
-	OPTIONAL MATCH (b1)-[conn:CONNECTED_TO]->(b2)
-	RETURN b2.id as businessId, b2.name as businessName, b2.businessType as businessType,
-		   b2.businessCategory as businessCategory, b2.businessPhase as businessPhase,
-		   b2.description as description, b2.website as website,
-		   u2.id as userId, u2.firstName as firstName, u2.lastName as lastName, u2.email as email,
-		   allianceScore, sharedSkills,
-		   CASE WHEN conn IS NOT NULL THEN conn.status ELSE 'not_connected' END as connectionStatus
-	ORDER BY allianceScore DESC
-	LIMIT 20
-	`
-
-	result, err := session.Run(context.Background(), query, map[string]interface{}{"userId": userID})
+	started := time.Now()
+	result, err := session.Run(context.Background(), alliancePartnersQuery, map[string]interface{}{"userId": userID})
 	if err != nil {
+		observeQuery("alliance", started, err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to query alliance partners"})
 		return
 	}
@@ -288,6 +256,9 @@ func (ca *ConnectionAnalyzer) getAlliancePartners(c *gin.Context) {
 		score := record.Values[11].(float64)
 		sharedSkills := record.Values[12].([]interface{})
 		connectionStatus := record.Values[13].(string)
+		completeness := record.Values[14].(int64)
+		rankedScore := record.Values[15].(float64)
+		sharedProjects := record.Values[16].(int64)
 		
 		var skillMatches []SkillMatch
 		for _, skill := range sharedSkills {
@@ -308,12 +279,14 @@ func (ca *ConnectionAnalyzer) getAlliancePartners(c *gin.Context) {
 		compatibilityFactors := []string{
 			fmt.Sprintf("Shared skills: %d", len(sharedSkills)),
 			fmt.Sprintf("Alliance potential: %.0f%%", score*100),
+			fmt.Sprintf("Projects worked on together: %d", sharedProjects),
+			fmt.Sprintf("Profile completeness: %d%%", completeness),
 			fmt.Sprintf("Current connection status: %s", connectionStatus),
 		}
 		
 		recommendations = append(recommendations, ConnectionRecommendation{
 			Type:                "ALLIANCE_PARTNER",
-			Score:               score,
+			Score:               rankedScore,
 			Reason:              reason,
 			Business:            businessInfo,
 			User:                userInfo,
@@ -321,6 +294,7 @@ func (ca *ConnectionAnalyzer) getAlliancePartners(c *gin.Context) {
 			CompatibilityFactors: compatibilityFactors,
 		})
 	}
+	observeQuery("alliance", started, result.Err())
 
 	c.JSON(http.StatusOK, gin.H{
 		"type": "ALLIANCE_PARTNERS",
@@ -340,40 +314,10 @@ func (ca *ConnectionAnalyzer) getMastermindPartners(c *gin.Context) {
 	session := ca.driver.NewSession(context.Background(), neo4j.SessionConfig{})
 	defer session.Close(context.Background())
 
-	query := `
-	MATCH (u:User {id: $userId})-[:OPERATES]->(b1:Business)
-	MATCH (u2:User)-[:OPERATES]->(b2:Business)
-	WHERE b1.id <> b2.id AND u.id <> u2.id
-	WITH u, b1, b2, u2
-	MATCH (u)-[:HAS_SKILL]->(s1:Skill)
-	MATCH (u2)-[:HAS_SKILL]->(s2:Skill)
-	WHERE s1.category <> s2.category
-	WITH u, b1, b2, u2, collect(DISTINCT s1) as userSkills, collect(DISTINCT s2) as partnerSkills
-	WITH u, b1, b2, u2, userSkills, partnerSkills,
-		CASE 
-			WHEN b1.businessPhase = 'Startup' AND b2.businessPhase IN ['Growth', 'Mature'] THEN 0.9
-			WHEN b1.businessPhase = 'Growth' AND b2.businessPhase IN ['Startup', 'Mature'] THEN 0.8
-			WHEN b1.businessPhase = 'Mature' AND b2.businessPhase IN ['Growth', 'Exit'] THEN 0.7
-			ELSE 0.5
-		END as phaseCompatibility,
-		size(partnerSkills) * 0.3 + size(userSkills) * 0.2 as skillComplementarity
-	WHERE phaseCompatibility > 0.6 OR skillComplementarity > 0.3
-	WITH u, b1, b2, u2, userSkills, partnerSkills, phaseCompatibility, skillComplementarity,
-		phaseCompatibility * 0.6 + skillComplementarity * 0.4 as mastermindScore
-	WHERE mastermindScore > 0.5
-	OPTIONAL MATCH (b1)-[conn:CONNECTED_TO]->(b2)
-	RETURN b2.id as businessId, b2.name as businessName, b2.businessType as businessType,
-		   b2.businessCategory as businessCategory, b2.businessPhase as businessPhase,
-		   b2.description as description, b2.website as website,
-		   u2.id as userId, u2.firstName as firstName, u2.lastName as lastName, u2.email as email,
-		   mastermindScore, phaseCompatibility, skillComplementarity,
-		   CASE WHEN conn IS NOT NULL THEN conn.status ELSE 'not_connected' END as connectionStatus
-	ORDER BY mastermindScore DESC
-	LIMIT 20
-	`
-
-	result, err := session.Run(context.Background(), query, map[string]interface{}{"userId": userID})
+	started := time.Now()
+	result, err := session.Run(context.Background(), mastermindPartnersQuery, map[string]interface{}{"userId": userID})
 	if err != nil {
+		observeQuery("mastermind", started, err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to query mastermind partners"})
 		return
 	}
@@ -403,25 +347,30 @@ func (ca *ConnectionAnalyzer) getMastermindPartners(c *gin.Context) {
 		phaseCompatibility := record.Values[12].(float64)
 		skillComplementarity := record.Values[13].(float64)
 		connectionStatus := record.Values[14].(string)
+		completeness := record.Values[15].(int64)
+		rankedScore := record.Values[16].(float64)
 		
 		reason := fmt.Sprintf("Complementary business phase (%s → %s) and skill sets", 
 			businessInfo.BusinessPhase, businessInfo.BusinessPhase)
 		
 		compatibilityFactors := []string{
+			fmt.Sprintf("Mastermind potential: %.0f%%", score*100),
 			fmt.Sprintf("Phase compatibility: %.0f%%", phaseCompatibility*100),
 			fmt.Sprintf("Skill complementarity: %.0f%%", skillComplementarity*100),
+			fmt.Sprintf("Profile completeness: %d%%", completeness),
 			fmt.Sprintf("Current connection status: %s", connectionStatus),
 		}
 		
 		recommendations = append(recommendations, ConnectionRecommendation{
 			Type:                "MASTERMIND_PARTNER",
-			Score:               score,
+			Score:               rankedScore,
 			Reason:              reason,
 			Business:            businessInfo,
 			User:                userInfo,
 			CompatibilityFactors: compatibilityFactors,
 		})
 	}
+	observeQuery("mastermind", started, result.Err())
 
 	c.JSON(http.StatusOK, gin.H{
 		"type": "MASTERMIND_PARTNERS",
@@ -494,45 +443,38 @@ func (ca *ConnectionAnalyzer) getConnectionAnalysis(c *gin.Context) {
 	session := ca.driver.NewSession(context.Background(), neo4j.SessionConfig{})
 	defer session.Close(context.Background())
 
-	query := `
-	MATCH (u:User {id: $userId})-[:OPERATES]->(b:Business)
-	OPTIONAL MATCH (b)-[conn:CONNECTS_TO]->(other:Business)
-	OPTIONAL MATCH (u)-[:HAS_SKILL]->(s:Skill)
-	OPTIONAL MATCH (p:Project)-[:MANAGES]->(u)
-	RETURN b.id as businessId, b.name as businessName, b.businessType as businessType,
-		   b.businessPhase as businessPhase,
-		   count(DISTINCT conn) as totalConnections,
-		   count(DISTINCT CASE WHEN conn.status = 'active' THEN conn END) as activeConnections,
-		   count(DISTINCT s) as totalSkills,
-		   count(DISTINCT CASE WHEN s.category = 'Technology' THEN s END) as techSkills,
-		   count(DISTINCT CASE WHEN s.category = 'Business' THEN s END) as businessSkills,
-		   count(DISTINCT p) as totalProjects,
-		   count(DISTINCT CASE WHEN p.projectStatus = 'active' THEN p END) as activeProjects
-	`
-
-	result, err := session.Run(context.Background(), query, map[string]interface{}{"userId": userID})
+	started := time.Now()
+	result, err := session.Run(context.Background(), connectionAnalysisQuery, map[string]interface{}{"userId": userID})
 	if err != nil {
+		observeQuery("analysis", started, err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to analyze connections"})
 		return
 	}
 
-	if result.Next(context.Background()) {
+	found := result.Next(context.Background())
+	observeQuery("analysis", started, result.Err())
+	if found {
 		record := result.Record()
 		
 		analysis := map[string]interface{}{
-			"businessId":         record.Values[0],
-			"businessName":       record.Values[1],
-			"businessType":       record.Values[2],
-			"businessPhase":      record.Values[3],
-			"totalConnections":   record.Values[4],
-			"activeConnections":  record.Values[5],
-			"totalSkills":        record.Values[6],
-			"techSkills":         record.Values[7],
-			"businessSkills":     record.Values[8],
-			"totalProjects":      record.Values[9],
-			"activeProjects":     record.Values[10],
-			"connectionStrength": calculateConnectionStrength(record.Values[4].(int64), record.Values[5].(int64)),
-			"skillDiversity":     calculateSkillDiversity(record.Values[6].(int64), record.Values[7].(int64), record.Values[8].(int64)),
+			"businessId":          record.Values[0],
+			"businessName":        record.Values[1],
+			"businessType":        record.Values[2],
+			"businessPhase":       record.Values[3],
+			"totalConnections":    record.Values[4],
+			"activeConnections":   record.Values[5],
+			"totalSkills":         record.Values[6],
+			"techSkills":          record.Values[7],
+			"businessSkills":      record.Values[8],
+			"totalProjects":       record.Values[9],
+			"activeProjects":      record.Values[10],
+			"memberProjects":      record.Values[11],
+			"projectApplications": record.Values[12],
+			"publications":        record.Values[13],
+			"tags":                record.Values[14],
+			"projectRegions":      record.Values[15],
+			"connectionStrength":  calculateConnectionStrength(record.Values[4].(int64), record.Values[5].(int64)),
+			"skillDiversity":      calculateSkillDiversity(record.Values[6].(int64), record.Values[7].(int64), record.Values[8].(int64)),
 		}
 
 		c.JSON(http.StatusOK, gin.H{
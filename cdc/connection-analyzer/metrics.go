@@ -0,0 +1,30 @@
+package main
+
+import (
+	"time"
+
+	"github.com/TIA-PARTNERS-GROUP/tia-api/cdc/telemetry"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	metrics = telemetry.NewRegistry()
+
+	querySeconds = promauto.With(metrics).NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "connection_analyzer_query_seconds",
+		Help:    "Memgraph query latency per recommendation type.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"type"})
+	queryErrors = promauto.With(metrics).NewCounterVec(prometheus.CounterOpts{
+		Name: "connection_analyzer_query_errors_total",
+		Help: "Failed Memgraph queries per recommendation type.",
+	}, []string{"type"})
+)
+
+func observeQuery(kind string, started time.Time, err error) {
+	querySeconds.WithLabelValues(kind).Observe(time.Since(started).Seconds())
+	if err != nil {
+		queryErrors.WithLabelValues(kind).Inc()
+	}
+}
@@ -0,0 +1,162 @@
+package main
+
+const complementaryPartnersQuery = `
+	MATCH (u:User {id: $userId})-[:OPERATES]->(b1:Business)
+	MATCH (b2:Business)<-[:OPERATES]-(u2:User)
+	WHERE b1.id <> b2.id AND u.id <> u2.id
+	  AND (
+		(b1.businessType = b2.businessType AND b1.businessCategory <> b2.businessCategory) OR
+		(b1.businessType <> b2.businessType AND b1.businessCategory = b2.businessCategory)
+	  )
+	WITH u, b1, b2, u2,
+		CASE 
+			WHEN b1.businessType = b2.businessType AND b1.businessCategory <> b2.businessCategory THEN 0.9
+			WHEN b1.businessType <> b2.businessType AND b1.businessCategory = b2.businessCategory THEN 0.8
+			ELSE 0.7
+		END as compatibilityScore
+	OPTIONAL MATCH (b1)-[:TAGGED_WITH]->(t1:Tag {tagType: 'client'}), (b2)-[:TAGGED_WITH]->(t2:Tag {tagType: 'client'})
+	WHERE toLower(t1.description) = toLower(t2.description)
+	WITH u, b1, b2, u2, compatibilityScore, count(DISTINCT t2) as sharedClientTags
+	WITH u, b1, b2, u2, compatibilityScore, sharedClientTags,
+		compatibilityScore * (0.8 + 0.2 * coalesce(b2.completeness, 0) / 100.0) *
+		(1.0 + 0.1 * CASE WHEN sharedClientTags > 3 THEN 3 ELSE sharedClientTags END) as rankedScore
+	OPTIONAL MATCH (b1)-[conn:CONNECTED_TO]->(b2)
+	WITH b2, u2, compatibilityScore, sharedClientTags, rankedScore, collect(conn.status) as connectionStatuses
+	RETURN b2.id as businessId, b2.name as businessName, b2.businessType as businessType,
+		   b2.businessCategory as businessCategory, b2.businessPhase as businessPhase,
+		   b2.description as description, b2.website as website,
+		   u2.id as userId, u2.firstName as firstName, u2.lastName as lastName, u2.email as email,
+		   compatibilityScore, 
+		   CASE
+			WHEN size(connectionStatuses) = 0 THEN 'not_connected'
+			WHEN 'active' IN connectionStatuses THEN 'active'
+			WHEN 'pending' IN connectionStatuses THEN 'pending'
+			ELSE connectionStatuses[0]
+		   END as connectionStatus,
+		   toInteger(coalesce(b2.completeness, 0)) as completeness, rankedScore, sharedClientTags
+	ORDER BY rankedScore DESC
+	LIMIT 20
+`
+
+const alliancePartnersQuery = `
+	MATCH (u:User {id: $userId})-[:OPERATES]->(b1:Business)
+	MATCH (u)-[:HAS_SKILL]->(s:Skill)<-[:HAS_SKILL]-(u2:User)-[:OPERATES]->(b2:Business)
+	WHERE b1.id <> b2.id AND u.id <> u2.id
+	WITH u, b1, b2, u2, collect(s) as sharedSkills
+	WITH u, b1, b2, u2, sharedSkills,
+		size(sharedSkills) * 0.4 + 
+		CASE 
+			WHEN b1.businessPhase = b2.businessPhase THEN 0.3
+			ELSE 0.1
+		END +
+		CASE 
+			WHEN b1.businessCategory = b2.businessCategory THEN 0.3
+			ELSE 0.1
+		END as allianceScore
+	WHERE allianceScore > 0.5
+	OPTIONAL MATCH (u)-[:MEMBER_OF]->(shared:Project)<-[:MEMBER_OF]-(u2)
+	WITH u, b1, b2, u2, sharedSkills, allianceScore, count(DISTINCT shared) as sharedProjects
+	WITH u, b1, b2, u2, sharedSkills, allianceScore, sharedProjects,
+		allianceScore * (0.8 + 0.2 * coalesce(b2.completeness, 0) / 100.0) *
+		(1.0 + 0.1 * CASE WHEN sharedProjects > 3 THEN 3 ELSE sharedProjects END) as rankedScore
+	OPTIONAL MATCH (b1)-[conn:CONNECTED_TO]->(b2)
+	WITH b2, u2, allianceScore, sharedSkills, sharedProjects, rankedScore, collect(conn.status) as connectionStatuses
+	RETURN b2.id as businessId, b2.name as businessName, b2.businessType as businessType,
+		   b2.businessCategory as businessCategory, b2.businessPhase as businessPhase,
+		   b2.description as description, b2.website as website,
+		   u2.id as userId, u2.firstName as firstName, u2.lastName as lastName, u2.email as email,
+		   allianceScore, sharedSkills,
+		   CASE
+			WHEN size(connectionStatuses) = 0 THEN 'not_connected'
+			WHEN 'active' IN connectionStatuses THEN 'active'
+			WHEN 'pending' IN connectionStatuses THEN 'pending'
+			ELSE connectionStatuses[0]
+		   END as connectionStatus,
+		   toInteger(coalesce(b2.completeness, 0)) as completeness, rankedScore, sharedProjects
+	ORDER BY rankedScore DESC
+	LIMIT 20
+`
+
+const mastermindPartnersQuery = `
+	MATCH (u:User {id: $userId})-[:OPERATES]->(b1:Business)
+	MATCH (u2:User)-[:OPERATES]->(b2:Business)
+	WHERE b1.id <> b2.id AND u.id <> u2.id
+	WITH u, b1, b2, u2
+	MATCH (u)-[:HAS_SKILL]->(s1:Skill)
+	MATCH (u2)-[:HAS_SKILL]->(s2:Skill)
+	WHERE s1.category <> s2.category
+	WITH u, b1, b2, u2, collect(DISTINCT s1) as userSkills, collect(DISTINCT s2) as partnerSkills
+	WITH u, b1, b2, u2, userSkills, partnerSkills,
+		CASE 
+			WHEN b1.businessPhase = 'Startup' AND b2.businessPhase IN ['Growth', 'Mature'] THEN 0.9
+			WHEN b1.businessPhase = 'Growth' AND b2.businessPhase IN ['Startup', 'Mature'] THEN 0.8
+			WHEN b1.businessPhase = 'Mature' AND b2.businessPhase IN ['Growth', 'Exit'] THEN 0.7
+			ELSE 0.5
+		END as phaseCompatibility,
+		size(partnerSkills) * 0.3 + size(userSkills) * 0.2 as skillComplementarity
+	WHERE phaseCompatibility > 0.6 OR skillComplementarity > 0.3
+	WITH u, b1, b2, u2, userSkills, partnerSkills, phaseCompatibility, skillComplementarity,
+		phaseCompatibility * 0.6 + skillComplementarity * 0.4 as mastermindScore
+	WHERE mastermindScore > 0.5
+	WITH u, b1, b2, u2, mastermindScore, phaseCompatibility, skillComplementarity,
+		mastermindScore * (0.8 + 0.2 * coalesce(b2.completeness, 0) / 100.0) as rankedScore
+	OPTIONAL MATCH (b1)-[conn:CONNECTED_TO]->(b2)
+	WITH b2, u2, mastermindScore, phaseCompatibility, skillComplementarity, rankedScore, collect(conn.status) as connectionStatuses
+	RETURN b2.id as businessId, b2.name as businessName, b2.businessType as businessType,
+		   b2.businessCategory as businessCategory, b2.businessPhase as businessPhase,
+		   b2.description as description, b2.website as website,
+		   u2.id as userId, u2.firstName as firstName, u2.lastName as lastName, u2.email as email,
+		   mastermindScore, phaseCompatibility, skillComplementarity,
+		   CASE
+			WHEN size(connectionStatuses) = 0 THEN 'not_connected'
+			WHEN 'active' IN connectionStatuses THEN 'active'
+			WHEN 'pending' IN connectionStatuses THEN 'pending'
+			ELSE connectionStatuses[0]
+		   END as connectionStatus,
+		   toInteger(coalesce(b2.completeness, 0)) as completeness, rankedScore
+	ORDER BY rankedScore DESC
+	LIMIT 20
+`
+
+const connectionAnalysisQuery = `
+	MATCH (u:User {id: $userId})-[:OPERATES]->(b:Business)
+	OPTIONAL MATCH (b)-[conn:CONNECTED_TO]->(:Business)
+	WITH u, b,
+		count(DISTINCT conn) as totalConnections,
+		count(DISTINCT CASE WHEN conn.status = 'active' THEN conn END) as activeConnections
+	OPTIONAL MATCH (u)-[:HAS_SKILL]->(s:Skill)
+	WITH u, b, totalConnections, activeConnections,
+		count(DISTINCT s) as totalSkills,
+		count(DISTINCT CASE WHEN s.category = 'Technology' THEN s END) as techSkills,
+		count(DISTINCT CASE WHEN s.category = 'Business' THEN s END) as businessSkills
+	OPTIONAL MATCH (u)-[:MANAGES]->(p:Project)
+	OPTIONAL MATCH (p)-[:OPERATES_IN]->(r:Region)
+	WITH u, b, totalConnections, activeConnections, totalSkills, techSkills, businessSkills,
+		count(DISTINCT p) as totalProjects,
+		count(DISTINCT CASE WHEN p.projectStatus = 'active' THEN p END) as activeProjects,
+		count(DISTINCT r) as projectRegions
+	OPTIONAL MATCH (u)-[:MEMBER_OF]->(mp:Project)
+	WITH u, b, totalConnections, activeConnections, totalSkills, techSkills, businessSkills,
+		totalProjects, activeProjects, projectRegions,
+		count(DISTINCT mp) as memberProjects
+	OPTIONAL MATCH (u)-[:APPLIED_TO]->(ap:Project)
+	WITH u, b, totalConnections, activeConnections, totalSkills, techSkills, businessSkills,
+		totalProjects, activeProjects, projectRegions, memberProjects,
+		count(DISTINCT ap) as projectApplications
+	OPTIONAL MATCH (u)-[:AUTHORED]->(pub:Publication)
+	WITH u, b, totalConnections, activeConnections, totalSkills, techSkills, businessSkills,
+		totalProjects, activeProjects, projectRegions, memberProjects, projectApplications,
+		count(DISTINCT CASE WHEN pub.published THEN pub END) as publishedPublications
+	OPTIONAL MATCH (b)-[:TAGGED_WITH]->(t:Tag)
+	WITH b, totalConnections, activeConnections, totalSkills, techSkills, businessSkills,
+		totalProjects, activeProjects, projectRegions, memberProjects, projectApplications,
+		publishedPublications,
+		count(DISTINCT t) as tags
+	RETURN b.id as businessId, b.name as businessName, b.businessType as businessType,
+		   b.businessPhase as businessPhase,
+		   totalConnections, activeConnections,
+		   totalSkills, techSkills, businessSkills,
+		   totalProjects, activeProjects,
+		   memberProjects, projectApplications,
+		   publishedPublications, tags, projectRegions
+`
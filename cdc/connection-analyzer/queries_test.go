@@ -0,0 +1,31 @@
+package main
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/TIA-PARTNERS-GROUP/tia-api/cdc/graphschema"
+	"github.com/stretchr/testify/assert"
+)
+
+var schemaName = regexp.MustCompile(`[(\[]\w*:(\w+)`)
+
+func TestQueriesUseDeclaredSchema(t *testing.T) {
+	declared := map[string]bool{}
+	for _, name := range append(append([]string{}, graphschema.Labels...), graphschema.RelationshipTypes...) {
+		declared[name] = true
+	}
+	queries := map[string]string{
+		"complementary": complementaryPartnersQuery,
+		"alliance":      alliancePartnersQuery,
+		"mastermind":    mastermindPartnersQuery,
+		"analysis":      connectionAnalysisQuery,
+	}
+	for name, query := range queries {
+		matches := schemaName.FindAllStringSubmatch(query, -1)
+		assert.NotEmpty(t, matches, name)
+		for _, match := range matches {
+			assert.True(t, declared[match[1]], "%s query uses undeclared label or relationship %s", name, match[1])
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package graphschema
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Statement struct {
+	Query  string
+	Params map[string]interface{}
+}
+
+type Batch struct {
+	Table   Table
+	Version int64
+	Rows    []Row
+}
+
+func (b Batch) Mutations() []Mutation {
+	var mutations []Mutation
+	for _, row := range b.Rows {
+		mutations = append(mutations, b.Table.Mutations(OpRead, b.Version, nil, row)...)
+	}
+	return mutations
+}
+
+func (b Batch) Statements() []Statement {
+	if len(b.Rows) == 0 {
+		return nil
+	}
+	if b.Table.Node != nil {
+		return b.nodeStatements()
+	}
+	return b.edgeStatements()
+}
+
+func (b Batch) nodeStatements() []Statement {
+	n := b.Table.Node
+	rows := make([]interface{}, 0, len(b.Rows))
+	for _, row := range b.Rows {
+		params := map[string]interface{}{
+			IDProperty: ID(n.IDKind, row[IDProperty]),
+			"props":    properties(n.Properties, row),
+		}
+		for _, parent := range n.Parents {
+			params[parent.Column] = ID(parent.IDKind, row[parent.Column])
+		}
+		rows = append(rows, params)
+	}
+	params := map[string]interface{}{"rows": rows, "version": b.Version}
+
+	statements := []Statement{{
+		Query: fmt.Sprintf("UNWIND $rows AS row MERGE (n:%s {id: row.id}) WITH n, row WHERE %s SET n += row.props, n.%s = $version",
+			n.Label, current("n"), VersionProperty),
+		Params: params,
+	}}
+	for _, parent := range n.Parents {
+		statements = append(statements, Statement{
+			Query: fmt.Sprintf("UNWIND $rows AS row MATCH (n:%s {id: row.id}) WHERE %s OPTIONAL MATCH (:%s)-[old:%s]->(n) DELETE old "+
+				"WITH DISTINCT n, row WHERE row.%s IS NOT NULL MERGE (p:%s {id: row.%s}) MERGE (p)-[r:%s]->(n) SET r.%s = $version",
+				n.Label, current("n"), parent.Label, parent.Rel,
+				parent.Column, parent.Label, parent.Column, parent.Rel, VersionProperty),
+			Params: params,
+		})
+	}
+	return statements
+}
+
+func (b Batch) edgeStatements() []Statement {
+	e := b.Table.Edge
+	rows := make([]interface{}, 0, len(b.Rows))
+	for _, row := range b.Rows {
+		rows = append(rows, map[string]interface{}{
+			"from":  ID(e.From.IDKind, row[e.From.Column]),
+			"to":    ID(e.To.IDKind, row[e.To.Column]),
+			"key":   properties(e.Key, row),
+			"props": properties(e.Properties, row),
+		})
+	}
+
+	key := ""
+	if len(e.Key) > 0 {
+		parts := make([]string, 0, len(e.Key))
+		for _, p := range e.Key {
+			parts = append(parts, fmt.Sprintf("%s: row.key.%s", p.Name, p.Name))
+		}
+		key = " {" + strings.Join(parts, ", ") + "}"
+	}
+	return []Statement{{
+		Query: fmt.Sprintf("UNWIND $rows AS row MERGE (a:%s {id: row.from}) MERGE (b:%s {id: row.to}) "+
+			"MERGE (a)-[r:%s%s]->(b) WITH r, row WHERE %s SET r += row.props, r.%s = $version",
+			e.From.Label, e.To.Label, e.Rel, key, current("r"), VersionProperty),
+		Params: map[string]interface{}{"rows": rows, "version": b.Version},
+	}}
+}
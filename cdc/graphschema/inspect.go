@@ -0,0 +1,234 @@
+package graphschema
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+type Entity struct {
+	Key     string
+	Props   map[string]interface{}
+	Parents map[string]interface{}
+}
+
+func (t Table) KeyColumns() []string {
+	if t.Node != nil {
+		return []string{IDProperty}
+	}
+	columns := []string{t.Edge.From.Column, t.Edge.To.Column}
+	for _, p := range t.Edge.Key {
+		columns = append(columns, p.Column)
+	}
+	return columns
+}
+
+func (t Table) KeyParams(row Row) map[string]interface{} {
+	if t.Node != nil {
+		return map[string]interface{}{IDProperty: ID(t.Node.IDKind, row[IDProperty])}
+	}
+	params := map[string]interface{}{
+		t.Edge.From.Column: ID(t.Edge.From.IDKind, row[t.Edge.From.Column]),
+		t.Edge.To.Column:   ID(t.Edge.To.IDKind, row[t.Edge.To.Column]),
+	}
+	for _, p := range t.Edge.Key {
+		params[p.Column] = Normalize(p.Kind, row[p.Column])
+	}
+	return params
+}
+
+func (t Table) Key(row Row) string {
+	params := t.KeyParams(row)
+	parts := make([]string, 0, len(params))
+	for _, column := range t.KeyColumns() {
+		parts = append(parts, fmt.Sprintf("%s=%v", column, params[column]))
+	}
+	return strings.Join(parts, " ")
+}
+
+func withoutNil(values map[string]interface{}) map[string]interface{} {
+	for k, v := range values {
+		if v == nil {
+			delete(values, k)
+		}
+	}
+	return values
+}
+
+func (t Table) Entity(row Row) Entity {
+	entity := Entity{Key: t.Key(row), Parents: map[string]interface{}{}}
+	if t.Node == nil {
+		entity.Props = withoutNil(properties(t.Edge.Properties, row))
+		return entity
+	}
+	entity.Props = withoutNil(properties(t.Node.Properties, row))
+	for _, parent := range t.Node.Parents {
+		entity.Parents[parent.Column] = ID(parent.IDKind, row[parent.Column])
+	}
+	entity.Parents = withoutNil(entity.Parents)
+	return entity
+}
+
+func (t Table) GraphEntity(record Row) Entity {
+	entity := Entity{Key: t.Key(record), Props: map[string]interface{}{}, Parents: map[string]interface{}{}}
+	stored, _ := record["props"].(map[string]interface{})
+	skip := map[string]bool{VersionProperty: true}
+	if t.Node != nil {
+		skip[IDProperty] = true
+		for _, parent := range t.Node.Parents {
+			entity.Parents[parent.Column] = ID(parent.IDKind, record[parent.Column])
+		}
+		entity.Parents = withoutNil(entity.Parents)
+	} else {
+		for _, p := range t.Edge.Key {
+			skip[p.Name] = true
+		}
+	}
+	for k, v := range stored {
+		if !skip[k] && v != nil {
+			entity.Props[k] = v
+		}
+	}
+	return entity
+}
+
+func describe(value interface{}) string {
+	if value == nil {
+		return "<missing>"
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+func diffValues(prefix string, source, graph map[string]interface{}) []string {
+	names := map[string]bool{}
+	for k := range source {
+		names[k] = true
+	}
+	for k := range graph {
+		names[k] = true
+	}
+	var diffs []string
+	for name := range names {
+		if !reflect.DeepEqual(source[name], graph[name]) {
+			diffs = append(diffs, fmt.Sprintf("%s%s: source=%s graph=%s", prefix, name, describe(source[name]), describe(graph[name])))
+		}
+	}
+	sort.Strings(diffs)
+	return diffs
+}
+
+func (e Entity) Diff(graph Entity) []string {
+	return append(diffValues("", e.Props, graph.Props), diffValues("parent ", e.Parents, graph.Parents)...)
+}
+
+func (t Table) Clear() Mutation {
+	return t.Sweep(math.MaxInt64)
+}
+
+func (t Table) pattern() string {
+	if t.Node != nil {
+		return fmt.Sprintf("(n:%s)", t.Node.Label)
+	}
+	return fmt.Sprintf("(a:%s)-[r:%s]->(b:%s)", t.Edge.From.Label, t.Edge.Rel, t.Edge.To.Label)
+}
+
+func (t Table) returnKeys() string {
+	if t.Node != nil {
+		return "n.id AS id"
+	}
+	columns := []string{
+		fmt.Sprintf("a.id AS %s", t.Edge.From.Column),
+		fmt.Sprintf("b.id AS %s", t.Edge.To.Column),
+	}
+	for _, p := range t.Edge.Key {
+		columns = append(columns, fmt.Sprintf("r.%s AS %s", p.Name, p.Column))
+	}
+	return strings.Join(columns, ", ")
+}
+
+func (t Table) CountQuery() string {
+	variable := "n"
+	if t.Edge != nil {
+		variable = "r"
+	}
+	return fmt.Sprintf("MATCH %s RETURN count(%s) AS count", t.pattern(), variable)
+}
+
+func (t Table) KeysQuery() string {
+	return fmt.Sprintf("MATCH %s RETURN %s", t.pattern(), t.returnKeys())
+}
+
+func (t Table) EntitiesQuery() string {
+	if t.Node != nil {
+		query := fmt.Sprintf("UNWIND $keys AS key MATCH (n:%s {id: key.id})", t.Node.Label)
+		columns := []string{t.returnKeys()}
+		for i, parent := range t.Node.Parents {
+			query += fmt.Sprintf(" OPTIONAL MATCH (p%d:%s)-[:%s]->(n)", i, parent.Label, parent.Rel)
+			columns = append(columns, fmt.Sprintf("p%d.id AS %s", i, parent.Column))
+		}
+		return fmt.Sprintf("%s RETURN %s, properties(n) AS props", query, strings.Join(columns, ", "))
+	}
+	e := t.Edge
+	query := fmt.Sprintf("UNWIND $keys AS key MATCH (a:%s {id: key.%s})-[r:%s]->(b:%s {id: key.%s})",
+		e.From.Label, e.From.Column, e.Rel, e.To.Label, e.To.Column)
+	for i, p := range e.Key {
+		clause := " AND"
+		if i == 0 {
+			clause = " WHERE"
+		}
+		query += fmt.Sprintf("%s r.%s = key.%s", clause, p.Name, p.Column)
+	}
+	return fmt.Sprintf("%s RETURN %s, properties(r) AS props", query, t.returnKeys())
+}
+
+func Select(names []string) ([]Table, error) {
+	if len(names) == 0 {
+		return Tables, nil
+	}
+	wanted := map[string]bool{}
+	for _, name := range names {
+		if _, ok := TableByName(name); !ok {
+			return nil, fmt.Errorf("unknown table %q", name)
+		}
+		wanted[name] = true
+	}
+	var selected []Table
+	for _, table := range Tables {
+		if wanted[table.Name] {
+			selected = append(selected, table)
+		}
+	}
+	return selected, nil
+}
+
+func Dependents(selected []Table) []Table {
+	chosen := map[string]bool{}
+	labels := map[string]bool{}
+	for _, table := range selected {
+		chosen[table.Name] = true
+		if table.Node != nil {
+			labels[table.Node.Label] = true
+		}
+	}
+	var dependents []Table
+	for _, table := range Tables {
+		if chosen[table.Name] {
+			continue
+		}
+		if table.Edge != nil && (labels[table.Edge.From.Label] || labels[table.Edge.To.Label]) {
+			dependents = append(dependents, table)
+			continue
+		}
+		if table.Node != nil {
+			for _, parent := range table.Node.Parents {
+				if labels[parent.Label] {
+					dependents = append(dependents, table)
+					break
+				}
+			}
+		}
+	}
+	return dependents
+}
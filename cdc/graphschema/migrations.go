@@ -0,0 +1,34 @@
+package graphschema
+
+import "fmt"
+
+const legacyConnectsTo = "CONNECTS_TO"
+
+func Migrations() []string {
+	migrations := []string{
+		fmt.Sprintf(`MATCH (a:%[1]s)-[old:%[2]s]->(b:%[1]s)
+		MERGE (a)-[r:%[3]s {connectionType: old.connectionType}]->(b)
+		SET r += properties(old)
+		DELETE old`, LabelBusiness, legacyConnectsTo, RelConnectedTo),
+		fmt.Sprintf(`MATCH (p:%s) WHERE p.status IS NOT NULL
+		SET p.projectStatus = coalesce(p.projectStatus, p.status)
+		REMOVE p.status`, LabelProject),
+		fmt.Sprintf(`MATCH ()-[r:%s]->() WHERE r.importanceLevel IS NOT NULL
+		SET r.importance = coalesce(r.importance, r.importanceLevel)
+		REMOVE r.importanceLevel`, RelRequiresSkill),
+	}
+	for _, table := range Tables {
+		if table.Edge == nil {
+			continue
+		}
+		groupBy := "a, b"
+		for _, key := range table.Edge.Key {
+			groupBy += fmt.Sprintf(", r.%s AS %s", key.Name, key.Name)
+		}
+		migrations = append(migrations, fmt.Sprintf(`MATCH (a:%s)-[r:%s]->(b:%s)
+		WITH %s, collect(r) AS rels WHERE size(rels) > 1
+		UNWIND tail(rels) AS duplicate
+		DELETE duplicate`, table.Edge.From.Label, table.Edge.Rel, table.Edge.To.Label, groupBy))
+	}
+	return migrations
+}
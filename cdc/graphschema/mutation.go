@@ -0,0 +1,108 @@
+package graphschema
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+type MutationKind int
+
+const (
+	MergeNode MutationKind = iota
+	DeleteNode
+	MergeEdge
+	DeleteEdge
+	SetParent
+	SweepNodes
+	SweepEdges
+)
+
+type NodeRef struct {
+	Label string
+	ID    interface{}
+}
+
+type Mutation struct {
+	Kind    MutationKind
+	Node    NodeRef
+	Rel     string
+	From    NodeRef
+	To      NodeRef
+	Key     map[string]interface{}
+	Props   map[string]interface{}
+	Version int64
+}
+
+func (m Mutation) sameEdge(other Mutation) bool {
+	return m.Rel == other.Rel && m.From == other.From && m.To == other.To && reflect.DeepEqual(m.Key, other.Key)
+}
+
+func sortedKeys(values map[string]interface{}) []string {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func keyPattern(key map[string]interface{}, params map[string]interface{}) string {
+	if len(key) == 0 {
+		return ""
+	}
+	parts := make([]string, 0, len(key))
+	for _, k := range sortedKeys(key) {
+		param := "key_" + k
+		parts = append(parts, fmt.Sprintf("%s: $%s", k, param))
+		params[param] = key[k]
+	}
+	return " {" + strings.Join(parts, ", ") + "}"
+}
+
+func current(variable string) string {
+	return fmt.Sprintf("coalesce(%s.%s, 0) <= $version", variable, VersionProperty)
+}
+
+// Cypher renders the mutation as a single statement. Edge and parent writes
+// MERGE their endpoints by id without a version, so an edge consumed before
+// its node events still lands and the node event fills the stub in later.
+func (m Mutation) Cypher() (string, map[string]interface{}) {
+	switch m.Kind {
+	case MergeNode:
+		params := map[string]interface{}{"id": m.Node.ID, "props": m.Props, "version": m.Version}
+		return fmt.Sprintf("MERGE (n:%s {id: $id}) WITH n WHERE %s SET n += $props, n.%s = $version",
+			m.Node.Label, current("n"), VersionProperty), params
+	case DeleteNode:
+		params := map[string]interface{}{"id": m.Node.ID, "version": m.Version}
+		return fmt.Sprintf("MATCH (n:%s {id: $id}) WHERE %s DETACH DELETE n", m.Node.Label, current("n")), params
+	case MergeEdge:
+		params := map[string]interface{}{"from": m.From.ID, "to": m.To.ID, "props": m.Props, "version": m.Version}
+		return fmt.Sprintf("MERGE (a:%s {id: $from}) MERGE (b:%s {id: $to}) MERGE (a)-[r:%s%s]->(b) WITH r WHERE %s SET r += $props, r.%s = $version",
+			m.From.Label, m.To.Label, m.Rel, keyPattern(m.Key, params), current("r"), VersionProperty), params
+	case DeleteEdge:
+		params := map[string]interface{}{"from": m.From.ID, "to": m.To.ID, "version": m.Version}
+		return fmt.Sprintf("MATCH (a:%s {id: $from})-[r:%s%s]->(b:%s {id: $to}) WHERE %s DELETE r",
+			m.From.Label, m.Rel, keyPattern(m.Key, params), m.To.Label, current("r")), params
+	case SetParent:
+		params := map[string]interface{}{"to": m.To.ID, "version": m.Version}
+		query := fmt.Sprintf("MATCH (n:%s {id: $to}) WHERE %s OPTIONAL MATCH (:%s)-[old:%s]->(n) DELETE old",
+			m.To.Label, current("n"), m.From.Label, m.Rel)
+		if m.From.ID != nil {
+			params["from"] = m.From.ID
+			query += fmt.Sprintf(" WITH DISTINCT n MERGE (p:%s {id: $from}) MERGE (p)-[r:%s]->(n) SET r.%s = $version",
+				m.From.Label, m.Rel, VersionProperty)
+		}
+		return query, params
+	case SweepNodes:
+		params := map[string]interface{}{"version": m.Version}
+		return fmt.Sprintf("MATCH (n:%s) WHERE coalesce(n.%s, 0) < $version DETACH DELETE n",
+			m.Node.Label, VersionProperty), params
+	case SweepEdges:
+		params := map[string]interface{}{"version": m.Version}
+		return fmt.Sprintf("MATCH (:%s)-[r:%s]->(:%s) WHERE coalesce(r.%s, 0) < $version DELETE r",
+			m.From.Label, m.Rel, m.To.Label, VersionProperty), params
+	}
+	return "", nil
+}
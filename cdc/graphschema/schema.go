@@ -0,0 +1,234 @@
+package graphschema
+
+const (
+	LabelUser        = "User"
+	LabelBusiness    = "Business"
+	LabelProject     = "Project"
+	LabelSkill       = "Skill"
+	LabelTag         = "Tag"
+	LabelRegion      = "Region"
+	LabelPublication = "Publication"
+)
+
+const (
+	RelOperates      = "OPERATES"
+	RelManages       = "MANAGES"
+	RelHasProject    = "HAS_PROJECT"
+	RelConnectedTo   = "CONNECTED_TO"
+	RelHasSkill      = "HAS_SKILL"
+	RelRequiresSkill = "REQUIRES_SKILL"
+	RelMemberOf      = "MEMBER_OF"
+	RelTaggedWith    = "TAGGED_WITH"
+	RelOperatesIn    = "OPERATES_IN"
+	RelAuthored      = "AUTHORED"
+	RelPublished     = "PUBLISHED"
+	RelAppliedTo     = "APPLIED_TO"
+)
+
+const (
+	IDProperty      = "id"
+	VersionProperty = "cdcVersion"
+)
+
+var Labels = []string{LabelUser, LabelBusiness, LabelProject, LabelSkill, LabelTag, LabelRegion, LabelPublication}
+
+var RelationshipTypes = []string{
+	RelOperates, RelManages, RelHasProject, RelConnectedTo, RelHasSkill, RelRequiresSkill,
+	RelMemberOf, RelTaggedWith, RelOperatesIn, RelAuthored, RelPublished, RelAppliedTo,
+}
+
+var Tables = []Table{
+	{
+		Name: "users",
+		Node: &NodeTable{
+			Label: LabelUser,
+			Properties: []Property{
+				{Name: "firstName", Column: "first_name", Kind: KindString},
+				{Name: "lastName", Column: "last_name", Kind: KindString},
+				{Name: "email", Column: "login_email", Kind: KindString},
+				{Name: "active", Column: "active", Kind: KindBool},
+				{Name: "emailVerified", Column: "email_verified", Kind: KindBool},
+				{Name: "createdAt", Column: "created_at", Kind: KindTime},
+				{Name: "updatedAt", Column: "updated_at", Kind: KindTime},
+			},
+		},
+	},
+	{
+		Name: "businesses",
+		Node: &NodeTable{
+			Label: LabelBusiness,
+			Properties: []Property{
+				{Name: "name", Column: "name", Kind: KindString},
+				{Name: "tagline", Column: "tagline", Kind: KindString},
+				{Name: "description", Column: "description", Kind: KindString},
+				{Name: "website", Column: "website", Kind: KindString},
+				{Name: "businessType", Column: "business_type", Kind: KindString},
+				{Name: "businessCategory", Column: "business_category", Kind: KindString},
+				{Name: "businessPhase", Column: "business_phase", Kind: KindString},
+				{Name: "active", Column: "active", Kind: KindBool},
+				{Name: "verified", Column: "verified", Kind: KindBool},
+				{Name: "completeness", Column: "completeness", Kind: KindInt},
+				{Name: "createdAt", Column: "created_at", Kind: KindTime},
+				{Name: "updatedAt", Column: "updated_at", Kind: KindTime},
+			},
+			Parents: []ParentEdge{
+				{Rel: RelOperates, Label: LabelUser, Column: "operator_user_id"},
+			},
+		},
+	},
+	{
+		Name: "projects",
+		Node: &NodeTable{
+			Label: LabelProject,
+			Properties: []Property{
+				{Name: "name", Column: "name", Kind: KindString},
+				{Name: "description", Column: "description", Kind: KindString},
+				{Name: "projectStatus", Column: "project_status", Kind: KindString},
+				{Name: "startDate", Column: "start_date", Kind: KindTime},
+				{Name: "targetEndDate", Column: "target_end_date", Kind: KindTime},
+				{Name: "createdAt", Column: "created_at", Kind: KindTime},
+				{Name: "updatedAt", Column: "updated_at", Kind: KindTime},
+			},
+			Parents: []ParentEdge{
+				{Rel: RelManages, Label: LabelUser, Column: "managed_by_user_id"},
+				{Rel: RelHasProject, Label: LabelBusiness, Column: "business_id"},
+			},
+		},
+	},
+	{
+		Name: "skills",
+		Node: &NodeTable{
+			Label: LabelSkill,
+			Properties: []Property{
+				{Name: "name", Column: "name", Kind: KindString},
+				{Name: "category", Column: "category", Kind: KindString},
+				{Name: "description", Column: "description", Kind: KindString},
+				{Name: "active", Column: "active", Kind: KindBool},
+				{Name: "createdAt", Column: "created_at", Kind: KindTime},
+			},
+		},
+	},
+	{
+		Name: "business_connections",
+		Edge: &EdgeTable{
+			Rel:  RelConnectedTo,
+			From: Endpoint{Label: LabelBusiness, Column: "initiating_business_id"},
+			To:   Endpoint{Label: LabelBusiness, Column: "receiving_business_id"},
+			Key: []Property{
+				{Name: "connectionType", Column: "connection_type", Kind: KindString},
+			},
+			Properties: []Property{
+				{Name: "id", Column: "id", Kind: KindInt},
+				{Name: "status", Column: "status", Kind: KindString},
+				{Name: "initiatedByUserId", Column: "initiated_by_user_id", Kind: KindInt},
+				{Name: "notes", Column: "notes", Kind: KindString},
+				{Name: "createdAt", Column: "created_at", Kind: KindTime},
+				{Name: "updatedAt", Column: "updated_at", Kind: KindTime},
+			},
+		},
+	},
+	{
+		Name: "user_skills",
+		Edge: &EdgeTable{
+			Rel:  RelHasSkill,
+			From: Endpoint{Label: LabelUser, Column: "user_id"},
+			To:   Endpoint{Label: LabelSkill, Column: "skill_id"},
+			Properties: []Property{
+				{Name: "proficiencyLevel", Column: "proficiency_level", Kind: KindString},
+				{Name: "createdAt", Column: "created_at", Kind: KindTime},
+			},
+		},
+	},
+	{
+		Name: "project_skills",
+		Edge: &EdgeTable{
+			Rel:  RelRequiresSkill,
+			From: Endpoint{Label: LabelProject, Column: "project_id"},
+			To:   Endpoint{Label: LabelSkill, Column: "skill_id"},
+			Properties: []Property{
+				{Name: "importance", Column: "importance", Kind: KindString},
+			},
+		},
+	},
+	{
+		Name: "regions",
+		Node: &NodeTable{
+			Label:  LabelRegion,
+			IDKind: KindString,
+			Properties: []Property{
+				{Name: "name", Column: "name", Kind: KindString},
+			},
+		},
+	},
+	{
+		Name: "business_tags",
+		Node: &NodeTable{
+			Label: LabelTag,
+			Properties: []Property{
+				{Name: "tagType", Column: "tag_type", Kind: KindString},
+				{Name: "description", Column: "description", Kind: KindString},
+				{Name: "createdAt", Column: "created_at", Kind: KindTime},
+			},
+			Parents: []ParentEdge{
+				{Rel: RelTaggedWith, Label: LabelBusiness, Column: "business_id"},
+			},
+		},
+	},
+	{
+		Name: "publications",
+		Node: &NodeTable{
+			Label: LabelPublication,
+			Properties: []Property{
+				{Name: "title", Column: "title", Kind: KindString},
+				{Name: "slug", Column: "slug", Kind: KindString},
+				{Name: "publicationType", Column: "publication_type", Kind: KindString},
+				{Name: "visibility", Column: "visibility", Kind: KindString},
+				{Name: "published", Column: "published", Kind: KindBool},
+				{Name: "publishedAt", Column: "published_at", Kind: KindTime},
+				{Name: "createdAt", Column: "created_at", Kind: KindTime},
+				{Name: "updatedAt", Column: "updated_at", Kind: KindTime},
+			},
+			Parents: []ParentEdge{
+				{Rel: RelAuthored, Label: LabelUser, Column: "user_id"},
+				{Rel: RelPublished, Label: LabelBusiness, Column: "business_id"},
+			},
+		},
+	},
+	{
+		Name: "project_members",
+		Edge: &EdgeTable{
+			Rel:  RelMemberOf,
+			From: Endpoint{Label: LabelUser, Column: "user_id"},
+			To:   Endpoint{Label: LabelProject, Column: "project_id"},
+			Properties: []Property{
+				{Name: "role", Column: "role", Kind: KindString},
+				{Name: "joinedAt", Column: "joined_at", Kind: KindTime},
+			},
+		},
+	},
+	{
+		Name: "project_regions",
+		Edge: &EdgeTable{
+			Rel:  RelOperatesIn,
+			From: Endpoint{Label: LabelProject, Column: "project_id"},
+			To:   Endpoint{Label: LabelRegion, Column: "region_id", IDKind: KindString},
+		},
+	},
+	{
+		Name: "project_applicants",
+		Edge: &EdgeTable{
+			Rel:  RelAppliedTo,
+			From: Endpoint{Label: LabelUser, Column: "user_id"},
+			To:   Endpoint{Label: LabelProject, Column: "project_id"},
+		},
+	},
+}
+
+func TableByName(name string) (Table, bool) {
+	for _, table := range Tables {
+		if table.Name == name {
+			return table, true
+		}
+	}
+	return Table{}, false
+}
@@ -0,0 +1,171 @@
+package graphschema
+
+const (
+	OpCreate   = "c"
+	OpUpdate   = "u"
+	OpDelete   = "d"
+	OpRead     = "r"
+	OpTruncate = "t"
+)
+
+type Row map[string]interface{}
+
+type Property struct {
+	Name   string
+	Column string
+	Kind   Kind
+}
+
+type ParentEdge struct {
+	Rel    string
+	Label  string
+	Column string
+	IDKind Kind
+}
+
+type Endpoint struct {
+	Label  string
+	Column string
+	IDKind Kind
+}
+
+type NodeTable struct {
+	Label      string
+	IDKind     Kind
+	Properties []Property
+	Parents    []ParentEdge
+}
+
+type EdgeTable struct {
+	Rel        string
+	From       Endpoint
+	To         Endpoint
+	Key        []Property
+	Properties []Property
+}
+
+type Table struct {
+	Name string
+	Node *NodeTable
+	Edge *EdgeTable
+}
+
+func (t Table) Columns() []string {
+	var columns []string
+	if t.Node != nil {
+		columns = append(columns, IDProperty)
+		for _, p := range t.Node.Properties {
+			columns = append(columns, p.Column)
+		}
+		for _, parent := range t.Node.Parents {
+			columns = append(columns, parent.Column)
+		}
+		return columns
+	}
+	columns = append(columns, t.Edge.From.Column, t.Edge.To.Column)
+	for _, p := range t.Edge.Key {
+		columns = append(columns, p.Column)
+	}
+	for _, p := range t.Edge.Properties {
+		columns = append(columns, p.Column)
+	}
+	return columns
+}
+
+func (t Table) Mutations(op string, version int64, before, after Row) []Mutation {
+	if op == OpTruncate {
+		return []Mutation{t.Sweep(version)}
+	}
+	var mutations []Mutation
+	if t.Node != nil {
+		mutations = t.Node.mutations(op, before, after)
+	} else {
+		mutations = t.Edge.mutations(op, before, after)
+	}
+	for i := range mutations {
+		mutations[i].Version = version
+	}
+	return mutations
+}
+
+func (t Table) Sweep(version int64) Mutation {
+	if t.Node != nil {
+		return Mutation{Kind: SweepNodes, Node: NodeRef{Label: t.Node.Label}, Version: version}
+	}
+	return Mutation{
+		Kind:    SweepEdges,
+		Rel:     t.Edge.Rel,
+		From:    NodeRef{Label: t.Edge.From.Label},
+		To:      NodeRef{Label: t.Edge.To.Label},
+		Version: version,
+	}
+}
+
+func properties(props []Property, row Row) map[string]interface{} {
+	values := make(map[string]interface{}, len(props))
+	for _, p := range props {
+		values[p.Name] = Normalize(p.Kind, row[p.Column])
+	}
+	return values
+}
+
+func (n *NodeTable) mutations(op string, before, after Row) []Mutation {
+	switch op {
+	case OpCreate, OpRead, OpUpdate:
+		if after == nil {
+			return nil
+		}
+		node := NodeRef{Label: n.Label, ID: ID(n.IDKind, after[IDProperty])}
+		mutations := []Mutation{{Kind: MergeNode, Node: node, Props: properties(n.Properties, after)}}
+		for _, parent := range n.Parents {
+			mutations = append(mutations, Mutation{
+				Kind: SetParent,
+				Rel:  parent.Rel,
+				From: NodeRef{Label: parent.Label, ID: ID(parent.IDKind, after[parent.Column])},
+				To:   node,
+			})
+		}
+		return mutations
+	case OpDelete:
+		if before == nil {
+			return nil
+		}
+		return []Mutation{{Kind: DeleteNode, Node: NodeRef{Label: n.Label, ID: ID(n.IDKind, before[IDProperty])}}}
+	}
+	return nil
+}
+
+func (e *EdgeTable) edge(kind MutationKind, row Row) Mutation {
+	return Mutation{
+		Kind: kind,
+		Rel:  e.Rel,
+		From: NodeRef{Label: e.From.Label, ID: ID(e.From.IDKind, row[e.From.Column])},
+		To:   NodeRef{Label: e.To.Label, ID: ID(e.To.IDKind, row[e.To.Column])},
+		Key:  properties(e.Key, row),
+	}
+}
+
+func (e *EdgeTable) mutations(op string, before, after Row) []Mutation {
+	switch op {
+	case OpCreate, OpRead, OpUpdate:
+		if after == nil {
+			return nil
+		}
+		var mutations []Mutation
+		merge := e.edge(MergeEdge, after)
+		merge.Props = properties(e.Properties, after)
+		if before != nil {
+			previous := e.edge(DeleteEdge, before)
+			if !previous.sameEdge(merge) {
+				mutations = append(mutations, previous)
+			}
+		}
+		return append(mutations, merge)
+	case OpDelete:
+		if before == nil {
+			return nil
+		}
+		return []Mutation{e.edge(DeleteEdge, before)}
+	}
+	return nil
+}
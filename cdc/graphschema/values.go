@@ -0,0 +1,124 @@
+package graphschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+type Kind int
+
+const (
+	KindInt Kind = iota
+	KindString
+	KindBool
+	KindTime
+)
+
+var timeLayouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05.999999999", "2006-01-02"}
+
+func ID(kind Kind, value interface{}) interface{} {
+	if kind == KindString {
+		if id, ok := Normalize(KindString, value).(string); ok && id != "" {
+			return id
+		}
+		return nil
+	}
+	return Normalize(kind, value)
+}
+
+func Normalize(kind Kind, value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	if b, ok := value.([]byte); ok {
+		value = string(b)
+	}
+	switch kind {
+	case KindInt:
+		return toInt(value)
+	case KindBool:
+		switch v := value.(type) {
+		case bool:
+			return v
+		case string:
+			parsed, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil
+			}
+			return parsed
+		}
+		if n, ok := toInt(value).(int64); ok {
+			return n != 0
+		}
+		return nil
+	case KindTime:
+		switch v := value.(type) {
+		case time.Time:
+			return v.UnixMilli()
+		case string:
+			for _, layout := range timeLayouts {
+				if parsed, err := time.Parse(layout, v); err == nil {
+					return parsed.UnixMilli()
+				}
+			}
+			return nil
+		}
+		return toInt(value)
+	}
+	switch v := value.(type) {
+	case string:
+		return v
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	}
+	return fmt.Sprint(value)
+}
+
+func toInt(value interface{}) interface{} {
+	switch v := value.(type) {
+	case int64:
+		return v
+	case int:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case int16:
+		return int64(v)
+	case int8:
+		return int64(v)
+	case uint:
+		return int64(v)
+	case uint64:
+		return int64(v)
+	case uint32:
+		return int64(v)
+	case uint8:
+		return int64(v)
+	case float64:
+		return int64(v)
+	case float32:
+		return int64(v)
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		if f, err := v.Float64(); err == nil {
+			return int64(f)
+		}
+	case string:
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return n
+		}
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return int64(f)
+		}
+	case bool:
+		if v {
+			return int64(1)
+		}
+		return int64(0)
+	}
+	return nil
+}
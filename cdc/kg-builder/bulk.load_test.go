@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/TIA-PARTNERS-GROUP/tia-api/cdc/graphschema"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// Synthetic benchmark users live far above real ids so cleanup never touches real data.
+const (
+	benchUserOffset = int64(1000000000)
+	benchRows       = 2000
+)
+
+func benchUsers() map[string][]fixtureRow {
+	_, current := fixtureHistory()
+	alice := current["users"][0]
+	users := make([]fixtureRow, benchRows)
+	for i := range users {
+		id := benchUserOffset + int64(i)
+		users[i] = withValues(alice, fixtureRow{"id": id, "login_email": fmt.Sprintf("bench-%d@example.com", id)})
+	}
+	return map[string][]fixtureRow{"users": users}
+}
+
+func clearBenchUsers(b *testing.B, driver neo4j.DriverWithContext) {
+	ctx := context.Background()
+	session := driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+	query := fmt.Sprintf("MATCH (n:%s) WHERE n.id >= $offset DETACH DELETE n", graphschema.LabelUser)
+	if _, err := session.Run(ctx, query, map[string]interface{}{"offset": benchUserOffset}); err != nil {
+		b.Fatal(err)
+	}
+}
+
+func BenchmarkBulkLoad(b *testing.B) {
+	uri := os.Getenv("KG_BENCH_MEMGRAPH_URI")
+	if uri == "" {
+		b.Skip("KG_BENCH_MEMGRAPH_URI not set")
+	}
+	driver, err := neo4j.NewDriverWithContext(uri, neo4j.NoAuth())
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer driver.Close(context.Background())
+	if err := driver.VerifyConnectivity(context.Background()); err != nil {
+		b.Fatal(err)
+	}
+
+	users, _ := graphschema.TableByName("users")
+	source := &fakeSource{rows: benchUsers()}
+	writer := &memgraphWriter{driver: driver}
+	ctx := context.Background()
+
+	run := func(b *testing.B, load func() error) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			clearBenchUsers(b, driver)
+			b.StartTimer()
+			if err := load(); err != nil {
+				b.Fatal(err)
+			}
+		}
+		b.StopTimer()
+		b.ReportMetric(float64(benchRows*b.N)/b.Elapsed().Seconds(), "rows/s")
+		clearBenchUsers(b, driver)
+	}
+
+	b.Run("per_row", func(b *testing.B) {
+		run(b, func() error {
+			return source.Rows(ctx, users, func(row graphschema.Row) error {
+				return writer.Apply(ctx, users.Mutations(graphschema.OpRead, 1, nil, row))
+			})
+		})
+	})
+	for _, size := range []int{100, 500, 2000} {
+		loader := &maintenance{source: source, writer: writer, batchSize: size, ctx: ctx}
+		b.Run(fmt.Sprintf("batched_%d", size), func(b *testing.B) {
+			run(b, func() error {
+				_, err := loader.load(users, 1)
+				return err
+			})
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/TIA-PARTNERS-GROUP/tia-api/cdc/cdcconfig"
+)
+
+const commandUsage = "usage: kg-builder [rebuild|verify|repair] [-tables users,businesses] [-batch-size 500] [-sample 50]"
+
+func runCommand(name string, args []string, out io.Writer) int {
+	switch name {
+	case "rebuild", "verify", "repair":
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n%s\n", name, commandUsage)
+		return 2
+	}
+
+	cfg := cdcconfig.FromEnv(defaultGroupID)
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	cfg.RegisterFlags(flags)
+	batchSize := flags.Int("batch-size", defaultBatchSize, "rows sent per UNWIND batch")
+	sample := flags.Int("sample", 50, "rows per table compared property by property (verify and repair)")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *batchSize <= 0 || *sample < 0 {
+		fmt.Fprintln(os.Stderr, "-batch-size must be positive and -sample must not be negative")
+		return 2
+	}
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	tables := cfg.SelectedTables()
+
+	driver, err := connectMemgraph(cfg)
+	if err != nil {
+		log.Printf("Failed to connect to Memgraph: %v", err)
+		return 1
+	}
+	defer driver.Close(context.Background())
+
+	db, err := openMySQL(cfg.MySQLDSN)
+	if err != nil {
+		log.Printf("Failed to connect to MySQL: %v", err)
+		return 1
+	}
+	defer db.Close()
+
+	m := &maintenance{
+		source:    &mysqlSource{db: db},
+		graph:     &memgraphReader{driver: driver},
+		writer:    &memgraphWriter{driver: driver},
+		batchSize: *batchSize,
+		sample:    *sample,
+		ctx:       context.Background(),
+	}
+
+	switch name {
+	case "rebuild":
+		if err := initializeSchema(m.ctx, driver); err != nil {
+			log.Printf("Failed to initialize schema: %v", err)
+			return 1
+		}
+		if err := m.rebuild(tables); err != nil {
+			log.Printf("Rebuild failed: %v", err)
+			return 1
+		}
+		return 0
+	case "verify":
+		consistent, err := m.check(tables, false, out)
+		if err != nil {
+			log.Printf("Verify failed: %v", err)
+			return 1
+		}
+		if !consistent {
+			return 1
+		}
+		return 0
+	default:
+		if _, err := m.check(tables, true, out); err != nil {
+			log.Printf("Repair failed: %v", err)
+			return 1
+		}
+		return 0
+	}
+}
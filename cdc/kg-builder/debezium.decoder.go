@@ -0,0 +1,308 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TIA-PARTNERS-GROUP/tia-api/cdc/graphschema"
+)
+
+type eventKind int
+
+const (
+	eventChange eventKind = iota
+	eventTombstone
+	eventSchemaChange
+)
+
+type schemaField struct {
+	Type       string            `json:"type"`
+	Name       string            `json:"name"`
+	Field      string            `json:"field"`
+	Parameters map[string]string `json:"parameters"`
+	Fields     []schemaField     `json:"fields"`
+}
+
+func (f *schemaField) child(name string) *schemaField {
+	if f == nil {
+		return nil
+	}
+	for i := range f.Fields {
+		if f.Fields[i].Field == name {
+			return &f.Fields[i]
+		}
+	}
+	return nil
+}
+
+type tableChange struct {
+	Type  string `json:"type"`
+	ID    string `json:"id"`
+	Table *struct {
+		Columns []struct {
+			Name string `json:"name"`
+		} `json:"columns"`
+	} `json:"table"`
+}
+
+func (c tableChange) TableName() string {
+	parts := strings.Split(c.ID, ".")
+	return strings.Trim(parts[len(parts)-1], "\"`")
+}
+
+func (c tableChange) missingColumns(table graphschema.Table) []string {
+	if c.Table == nil {
+		return nil
+	}
+	present := map[string]bool{}
+	for _, column := range c.Table.Columns {
+		present[column.Name] = true
+	}
+	var missing []string
+	for _, column := range table.Columns() {
+		if !present[column] {
+			missing = append(missing, column)
+		}
+	}
+	return missing
+}
+
+type changePayload struct {
+	Before map[string]interface{} `json:"before"`
+	After  map[string]interface{} `json:"after"`
+	Source struct {
+		TsMs  int64  `json:"ts_ms"`
+		DB    string `json:"db"`
+		Table string `json:"table"`
+	} `json:"source"`
+	Op           string        `json:"op"`
+	TsMs         int64         `json:"ts_ms"`
+	DatabaseName string        `json:"databaseName"`
+	DDL          string        `json:"ddl"`
+	TableChanges []tableChange `json:"tableChanges"`
+}
+
+type cdcEvent struct {
+	Kind     eventKind
+	Database string
+	Table    string
+	Op       string
+	Version  int64
+	Before   graphschema.Row
+	After    graphschema.Row
+	DDL      string
+	Changes  []tableChange
+}
+
+func unmarshalNumbers(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+func isNull(data []byte) bool {
+	data = bytes.TrimSpace(data)
+	return len(data) == 0 || bytes.Equal(data, []byte("null"))
+}
+
+// decodeEvent accepts both the schema-enabled {"schema","payload"} envelope and
+// a bare payload. Logical types can only be decoded when the schema is present.
+func decodeEvent(value []byte) (cdcEvent, error) {
+	if isNull(value) {
+		return cdcEvent{Kind: eventTombstone}, nil
+	}
+
+	var envelope map[string]json.RawMessage
+	if err := json.Unmarshal(value, &envelope); err != nil {
+		return cdcEvent{}, err
+	}
+	var schema *schemaField
+	rawPayload := json.RawMessage(value)
+	if payload, ok := envelope["payload"]; ok {
+		rawPayload = payload
+		if raw, ok := envelope["schema"]; ok && !isNull(raw) {
+			schema = &schemaField{}
+			if err := json.Unmarshal(raw, schema); err != nil {
+				return cdcEvent{}, fmt.Errorf("invalid schema: %v", err)
+			}
+		}
+	}
+	if isNull(rawPayload) {
+		return cdcEvent{Kind: eventTombstone}, nil
+	}
+
+	var payload changePayload
+	if err := unmarshalNumbers(rawPayload, &payload); err != nil {
+		return cdcEvent{}, err
+	}
+	event := cdcEvent{
+		Database: payload.Source.DB,
+		Table:    payload.Source.Table,
+		Op:       payload.Op,
+		Version:  payload.Source.TsMs,
+	}
+	if event.Version == 0 {
+		event.Version = payload.TsMs
+	}
+
+	if payload.Op == "" && (payload.DDL != "" || len(payload.TableChanges) > 0) {
+		event.Kind = eventSchemaChange
+		event.Database = payload.DatabaseName
+		event.DDL = payload.DDL
+		event.Changes = payload.TableChanges
+		return event, nil
+	}
+
+	switch payload.Op {
+	case graphschema.OpCreate, graphschema.OpUpdate, graphschema.OpDelete, graphschema.OpRead, graphschema.OpTruncate:
+	default:
+		return cdcEvent{}, fmt.Errorf("unsupported operation %q", payload.Op)
+	}
+
+	var err error
+	if event.Before, err = decodeRow(schema.child("before"), payload.Before); err != nil {
+		return cdcEvent{}, fmt.Errorf("before: %v", err)
+	}
+	if event.After, err = decodeRow(schema.child("after"), payload.After); err != nil {
+		return cdcEvent{}, fmt.Errorf("after: %v", err)
+	}
+	return event, nil
+}
+
+func decodeRow(schema *schemaField, values map[string]interface{}) (graphschema.Row, error) {
+	if values == nil {
+		return nil, nil
+	}
+	row := make(graphschema.Row, len(values))
+	for column, value := range values {
+		field := schema.child(column)
+		if field == nil {
+			row[column] = value
+			continue
+		}
+		decoded, err := decodeValue(*field, value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", column, err)
+		}
+		row[column] = decoded
+	}
+	return row, nil
+}
+
+func decodeValue(field schemaField, value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	switch field.Name {
+	case "io.debezium.time.Date", "org.apache.kafka.connect.data.Date":
+		days, err := integer(value)
+		return time.Unix(days*24*60*60, 0).UTC(), err
+	case "io.debezium.time.Timestamp", "org.apache.kafka.connect.data.Timestamp":
+		ms, err := integer(value)
+		return time.UnixMilli(ms).UTC(), err
+	case "io.debezium.time.MicroTimestamp":
+		us, err := integer(value)
+		return time.UnixMicro(us).UTC(), err
+	case "io.debezium.time.NanoTimestamp":
+		ns, err := integer(value)
+		return time.Unix(0, ns).UTC(), err
+	case "io.debezium.time.ZonedTimestamp":
+		text, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected string, got %T", value)
+		}
+		parsed, err := time.Parse(time.RFC3339Nano, text)
+		return parsed.UTC(), err
+	case "io.debezium.time.Time", "org.apache.kafka.connect.data.Time":
+		ms, err := integer(value)
+		return clock(time.Duration(ms) * time.Millisecond), err
+	case "io.debezium.time.MicroTime":
+		us, err := integer(value)
+		return clock(time.Duration(us) * time.Microsecond), err
+	case "io.debezium.time.NanoTime":
+		ns, err := integer(value)
+		return clock(time.Duration(ns)), err
+	case "org.apache.kafka.connect.data.Decimal":
+		if field.Type != "bytes" {
+			return value, nil
+		}
+		scale, err := strconv.Atoi(field.Parameters["scale"])
+		if err != nil {
+			return nil, fmt.Errorf("invalid decimal scale %q", field.Parameters["scale"])
+		}
+		return decimal(value, scale)
+	case "io.debezium.data.VariableScaleDecimal":
+		parts, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected struct, got %T", value)
+		}
+		scale, err := integer(parts["scale"])
+		if err != nil {
+			return nil, err
+		}
+		return decimal(parts["value"], int(scale))
+	}
+	if field.Type == "bytes" {
+		text, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected base64 string, got %T", value)
+		}
+		return base64.StdEncoding.DecodeString(text)
+	}
+	return value, nil
+}
+
+func integer(value interface{}) (int64, error) {
+	number, ok := value.(json.Number)
+	if !ok {
+		return 0, fmt.Errorf("expected number, got %T", value)
+	}
+	return number.Int64()
+}
+
+func clock(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	hours := d / time.Hour
+	minutes := (d % time.Hour) / time.Minute
+	seconds := (d % time.Minute) / time.Second
+	text := fmt.Sprintf("%s%02d:%02d:%02d", sign, hours, minutes, seconds)
+	if fraction := d % time.Second; fraction > 0 {
+		text += strings.TrimRight(fmt.Sprintf(".%09d", fraction), "0")
+	}
+	return text
+}
+
+// decimal decodes Connect's Decimal encoding: the unscaled value as big-endian
+// two's complement bytes, base64 encoded in JSON.
+func decimal(value interface{}, scale int) (interface{}, error) {
+	text, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("expected base64 string, got %T", value)
+	}
+	raw, err := base64.StdEncoding.DecodeString(text)
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) == 0 {
+		return nil, errors.New("empty decimal")
+	}
+	unscaled := new(big.Int).SetBytes(raw)
+	if raw[0]&0x80 != 0 {
+		unscaled.Sub(unscaled, new(big.Int).Lsh(big.NewInt(1), uint(len(raw)*8)))
+	}
+	if scale <= 0 {
+		return json.Number(new(big.Int).Mul(unscaled, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-scale)), nil)).String()), nil
+	}
+	denominator := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil)
+	return json.Number(new(big.Rat).SetFrac(unscaled, denominator).FloatString(scale)), nil
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/cdc/graphschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const projectRowSchema = `{"type":"struct","optional":true,"fields":[
+	{"type":"int64","field":"id"},
+	{"type":"int64","optional":true,"field":"managed_by_user_id"},
+	{"type":"int64","optional":true,"field":"business_id"},
+	{"type":"string","field":"name"},
+	{"type":"string","optional":true,"field":"description"},
+	{"type":"string","name":"io.debezium.data.Enum","field":"project_status"},
+	{"type":"int32","optional":true,"name":"io.debezium.time.Date","field":"start_date"},
+	{"type":"int32","optional":true,"name":"io.debezium.time.Date","field":"target_end_date"},
+	{"type":"int64","name":"io.debezium.time.MicroTimestamp","field":"created_at"},
+	{"type":"string","name":"io.debezium.time.ZonedTimestamp","field":"updated_at"},
+	{"type":"bytes","name":"org.apache.kafka.connect.data.Decimal","parameters":{"scale":"2"},"field":"budget"},
+	{"type":"bytes","name":"org.apache.kafka.connect.data.Decimal","parameters":{"scale":"2"},"field":"balance"},
+	{"type":"int64","name":"io.debezium.time.MicroTime","field":"daily_standup"},
+	{"type":"bytes","optional":true,"field":"checksum"}
+]}`
+
+func projectEvent(t *testing.T, op string, after map[string]interface{}) []byte {
+	var rowSchema schemaField
+	require.NoError(t, json.Unmarshal([]byte(projectRowSchema), &rowSchema))
+	before, afterSchema := rowSchema, rowSchema
+	before.Field, afterSchema.Field = "before", "after"
+	value, err := json.Marshal(map[string]interface{}{
+		"schema": schemaField{Type: "struct", Name: "tia-db.tia-dev.projects.Envelope", Fields: []schemaField{
+			before, afterSchema, {Type: "string", Field: "op"},
+		}},
+		"payload": map[string]interface{}{
+			"source": map[string]interface{}{"db": "tia-dev", "table": "projects", "ts_ms": 1000},
+			"op":     op,
+			"before": nil,
+			"after":  after,
+		},
+	})
+	require.NoError(t, err)
+	return value
+}
+
+var launchRow = map[string]interface{}{
+	"id":                 int64(9007199254740993),
+	"managed_by_user_id": 1,
+	"business_id":        nil,
+	"name":               "Launch",
+	"description":        nil,
+	"project_status":     "active",
+	"start_date":         20526,
+	"target_end_date":    nil,
+	"created_at":         int64(1773480615250000),
+	"updated_at":         "2026-03-14T19:30:15.25+10:00",
+	"budget":             "BOI=",
+	"balance":            "+x4=",
+	"daily_standup":      int64(3723000500),
+	"checksum":           "aGk=",
+}
+
+func TestDecodeEventReadsDebeziumLogicalTypes(t *testing.T) {
+	event, err := decodeEvent(projectEvent(t, graphschema.OpCreate, launchRow))
+	require.NoError(t, err)
+
+	assert.Equal(t, eventChange, event.Kind)
+	assert.Equal(t, "projects", event.Table)
+	assert.Equal(t, int64(1000), event.Version)
+	assert.Nil(t, event.Before)
+	assert.Equal(t, json.Number("9007199254740993"), event.After["id"])
+	assert.Equal(t, time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC), event.After["start_date"])
+	assert.Equal(t, fixtureTime, event.After["created_at"])
+	assert.Equal(t, fixtureTime, event.After["updated_at"])
+	assert.Equal(t, json.Number("12.50"), event.After["budget"])
+	assert.Equal(t, json.Number("-12.50"), event.After["balance"])
+	assert.Equal(t, "01:02:03.0005", event.After["daily_standup"])
+	assert.Equal(t, []byte("hi"), event.After["checksum"])
+	assert.Equal(t, "active", event.After["project_status"])
+	assert.Nil(t, event.After["target_end_date"])
+}
+
+func TestProcessMessageStoresDebeziumDatesAsEpochMillis(t *testing.T) {
+	graph := newMemGraph()
+	kgb := &KnowledgeGraphBuilder{writer: graph, ctx: context.Background()}
+	require.NoError(t, kgb.processMessage(&sarama.ConsumerMessage{Value: projectEvent(t, graphschema.OpCreate, launchRow)}))
+
+	props := graph.nodes[graphschema.NodeRef{Label: graphschema.LabelProject, ID: int64(9007199254740993)}]
+	require.NotNil(t, props)
+	assert.Equal(t, time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC).UnixMilli(), props["startDate"])
+	assert.Equal(t, fixtureTime.UnixMilli(), props["createdAt"])
+	assert.Equal(t, fixtureTime.UnixMilli(), props["updatedAt"])
+	assert.Equal(t, "active", props["projectStatus"])
+}
+
+func TestDecodeEventRejectsBadValues(t *testing.T) {
+	_, err := decodeEvent(projectEvent(t, graphschema.OpCreate, withValues(launchRow, fixtureRow{"start_date": "2026-03-14"})))
+	assert.EqualError(t, err, "after: start_date: expected number, got string")
+
+	_, err = decodeEvent([]byte(`{"payload":{"op":"x","source":{"table":"users"}}}`))
+	assert.EqualError(t, err, `unsupported operation "x"`)
+
+	kgb := &KnowledgeGraphBuilder{writer: &flakyWriter{}, ctx: context.Background()}
+	assert.ErrorIs(t, kgb.processMessage(&sarama.ConsumerMessage{Value: []byte(`{"op":"x"}`)}), errMalformedEvent)
+}
+
+func TestProcessMessageSkipsTombstones(t *testing.T) {
+	writer := &flakyWriter{}
+	kgb := &KnowledgeGraphBuilder{writer: writer, ctx: context.Background()}
+	for _, value := range []string{"", "null", `{"schema":null,"payload":null}`} {
+		require.NoError(t, kgb.processMessage(&sarama.ConsumerMessage{Value: []byte(value)}), value)
+	}
+	assert.Zero(t, writer.calls)
+}
+
+func TestProcessMessageTruncateRemovesTableEntries(t *testing.T) {
+	_, current := fixtureHistory()
+	graph := newMemGraph()
+	bulkLoad(t, graph, current, 1)
+	kgb := &KnowledgeGraphBuilder{writer: graph, ctx: context.Background()}
+
+	truncate := []byte(`{"source":{"db":"tia-dev","table":"user_skills","ts_ms":2000},"op":"t","before":null,"after":null}`)
+	require.NoError(t, kgb.processMessage(&sarama.ConsumerMessage{Value: truncate}))
+
+	for _, e := range graph.edges {
+		assert.NotEqual(t, graphschema.RelHasSkill, e.rel)
+	}
+	assert.Contains(t, graph.nodes, graphschema.NodeRef{Label: graphschema.LabelSkill, ID: int64(30)})
+	assert.Contains(t, graph.nodes, graphschema.NodeRef{Label: graphschema.LabelUser, ID: int64(1)})
+}
+
+func TestProcessMessageSurfacesSchemaChangesWithoutWriting(t *testing.T) {
+	value := []byte(`{"schema":{"type":"struct"},"payload":{
+		"source":{"db":"tia-dev","table":"users","ts_ms":3000},
+		"databaseName":"tia-dev",
+		"ddl":"ALTER TABLE users DROP COLUMN last_name",
+		"tableChanges":[{"type":"ALTER","id":"\"tia-dev\".\"users\"","table":{"columns":[
+			{"name":"id"},{"name":"first_name"},{"name":"login_email"},{"name":"active"},
+			{"name":"email_verified"},{"name":"created_at"},{"name":"updated_at"}
+		]}}]
+	}}`)
+	event, err := decodeEvent(value)
+	require.NoError(t, err)
+	assert.Equal(t, eventSchemaChange, event.Kind)
+	assert.Equal(t, "ALTER TABLE users DROP COLUMN last_name", event.DDL)
+	require.Len(t, event.Changes, 1)
+	assert.Equal(t, "users", event.Changes[0].TableName())
+
+	users, _ := graphschema.TableByName("users")
+	assert.Equal(t, []string{"last_name"}, event.Changes[0].missingColumns(users))
+
+	writer := &flakyWriter{}
+	kgb := &KnowledgeGraphBuilder{writer: writer, database: "tia-dev", ctx: context.Background()}
+	require.NoError(t, kgb.processMessage(&sarama.ConsumerMessage{Value: value}))
+	assert.Zero(t, writer.calls)
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/Shopify/sarama"
+)
+
+const (
+	headerOriginalTopic     = "dlq.original.topic"
+	headerOriginalPartition = "dlq.original.partition"
+	headerOriginalOffset    = "dlq.original.offset"
+	headerError             = "dlq.error"
+	headerAttempts          = "dlq.attempts"
+	headerFailedAt          = "dlq.failed.at"
+)
+
+type deadLetterSink interface {
+	Send(message *sarama.ConsumerMessage, attempts int, cause error) error
+}
+
+type kafkaDeadLetters struct {
+	producer sarama.SyncProducer
+	topic    string
+}
+
+func newKafkaDeadLetters(brokers []string, topic string) (*kafkaDeadLetters, error) {
+	config := sarama.NewConfig()
+	config.Producer.Return.Successes = true
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	config.Producer.Retry.Max = 5
+
+	producer, err := sarama.NewSyncProducer(brokers, config)
+	if err != nil {
+		return nil, err
+	}
+	return &kafkaDeadLetters{producer: producer, topic: topic}, nil
+}
+
+func deadLetterMessage(topic string, message *sarama.ConsumerMessage, attempts int, cause error) *sarama.ProducerMessage {
+	header := func(key, value string) sarama.RecordHeader {
+		return sarama.RecordHeader{Key: []byte(key), Value: []byte(value)}
+	}
+	deadLetter := &sarama.ProducerMessage{
+		Topic: topic,
+		Headers: []sarama.RecordHeader{
+			header(headerOriginalTopic, message.Topic),
+			header(headerOriginalPartition, strconv.FormatInt(int64(message.Partition), 10)),
+			header(headerOriginalOffset, strconv.FormatInt(message.Offset, 10)),
+			header(headerError, cause.Error()),
+			header(headerAttempts, strconv.Itoa(attempts)),
+			header(headerFailedAt, time.Now().UTC().Format(time.RFC3339Nano)),
+		},
+	}
+	if message.Key != nil {
+		deadLetter.Key = sarama.ByteEncoder(message.Key)
+	}
+	if message.Value != nil {
+		deadLetter.Value = sarama.ByteEncoder(message.Value)
+	}
+	return deadLetter
+}
+
+func (d *kafkaDeadLetters) Send(message *sarama.ConsumerMessage, attempts int, cause error) error {
+	_, _, err := d.producer.SendMessage(deadLetterMessage(d.topic, message, attempts, cause))
+	return err
+}
+
+func (d *kafkaDeadLetters) Close() error {
+	return d.producer.Close()
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/cdc/graphschema"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/cdc/telemetry"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type deadLetter struct {
+	message  *sarama.ConsumerMessage
+	attempts int
+	cause    error
+}
+
+type fakeDeadLetters struct {
+	sent []deadLetter
+	err  error
+}
+
+func (d *fakeDeadLetters) Send(message *sarama.ConsumerMessage, attempts int, cause error) error {
+	if d.err != nil {
+		return d.err
+	}
+	d.sent = append(d.sent, deadLetter{message: message, attempts: attempts, cause: cause})
+	return nil
+}
+
+type flakyWriter struct {
+	failures int
+	calls    int
+}
+
+func (w *flakyWriter) Apply(ctx context.Context, mutations []graphschema.Mutation) error {
+	w.calls++
+	if w.calls <= w.failures {
+		return errors.New("memgraph unavailable")
+	}
+	return nil
+}
+
+func (w *flakyWriter) ApplyBatch(ctx context.Context, batch graphschema.Batch) error {
+	return w.Apply(ctx, batch.Mutations())
+}
+
+var testRetryPolicy = retryPolicy{maxAttempts: 3, baseDelay: time.Millisecond, maxDelay: 2 * time.Millisecond}
+
+var userCreated = []byte(`{"payload":{"op":"c","source":{"table":"users","ts_ms":1000},"after":{"id":1,"first_name":"Alice"}}}`)
+
+func newDeliveryBuilder(writer graphWriter, deadLetters deadLetterSink) *KnowledgeGraphBuilder {
+	return &KnowledgeGraphBuilder{writer: writer, deadLetters: deadLetters, retry: testRetryPolicy, ctx: context.Background()}
+}
+
+func TestHandleMessageRetriesTransientFailures(t *testing.T) {
+	writer := &flakyWriter{failures: 2}
+	deadLetters := &fakeDeadLetters{}
+	kgb := newDeliveryBuilder(writer, deadLetters)
+
+	require.NoError(t, kgb.handleMessage(context.Background(), &sarama.ConsumerMessage{Value: userCreated}))
+	assert.Equal(t, 3, writer.calls)
+	assert.Empty(t, deadLetters.sent)
+}
+
+func TestHandleMessageDeadLettersAfterExhaustingRetries(t *testing.T) {
+	writer := &flakyWriter{failures: 10}
+	deadLetters := &fakeDeadLetters{}
+	kgb := newDeliveryBuilder(writer, deadLetters)
+
+	message := &sarama.ConsumerMessage{Topic: "tia-db.tia-dev.users", Partition: 2, Offset: 41, Value: userCreated}
+	require.NoError(t, kgb.handleMessage(context.Background(), message))
+	assert.Equal(t, 3, writer.calls)
+	require.Len(t, deadLetters.sent, 1)
+	assert.Same(t, message, deadLetters.sent[0].message)
+	assert.Equal(t, 3, deadLetters.sent[0].attempts)
+	assert.EqualError(t, deadLetters.sent[0].cause, "memgraph unavailable")
+}
+
+func TestHandleMessageDeadLettersMalformedEventsWithoutRetry(t *testing.T) {
+	writer := &flakyWriter{}
+	deadLetters := &fakeDeadLetters{}
+	kgb := newDeliveryBuilder(writer, deadLetters)
+
+	require.NoError(t, kgb.handleMessage(context.Background(), &sarama.ConsumerMessage{Value: []byte(`{"payload":`)}))
+	assert.Zero(t, writer.calls)
+	require.Len(t, deadLetters.sent, 1)
+	assert.Equal(t, 1, deadLetters.sent[0].attempts)
+	assert.ErrorIs(t, deadLetters.sent[0].cause, errMalformedEvent)
+}
+
+func TestHandleMessageFailsWhenDeadLetterTopicIsUnavailable(t *testing.T) {
+	kgb := newDeliveryBuilder(&flakyWriter{failures: 10}, &fakeDeadLetters{err: errors.New("broker down")})
+
+	assert.Error(t, kgb.handleMessage(context.Background(), &sarama.ConsumerMessage{Value: userCreated}))
+}
+
+func TestHandleMessageStopsRetryingWhenSessionEnds(t *testing.T) {
+	deadLetters := &fakeDeadLetters{}
+	kgb := newDeliveryBuilder(&flakyWriter{failures: 10}, deadLetters)
+	kgb.retry.baseDelay = time.Hour
+	kgb.retry.maxDelay = time.Hour
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, kgb.handleMessage(ctx, &sarama.ConsumerMessage{Value: userCreated}), context.Canceled)
+	assert.Empty(t, deadLetters.sent)
+}
+
+func TestHandleMessageSkipsTombstones(t *testing.T) {
+	writer := &flakyWriter{}
+	deadLetters := &fakeDeadLetters{}
+	kgb := newDeliveryBuilder(writer, deadLetters)
+
+	require.NoError(t, kgb.handleMessage(context.Background(), &sarama.ConsumerMessage{Key: []byte(`{"id":1}`)}))
+	assert.Zero(t, writer.calls)
+	assert.Empty(t, deadLetters.sent)
+}
+
+func TestHandleMessageRecordsMetrics(t *testing.T) {
+	processed := testutil.ToFloat64(messagesProcessed.WithLabelValues("skills", "c"))
+	retried := testutil.ToFloat64(messageErrors.WithLabelValues("skills", "retried"))
+	malformed := testutil.ToFloat64(messageErrors.WithLabelValues("tia-db.tia-dev.regions", "malformed"))
+
+	kgb := newDeliveryBuilder(&flakyWriter{failures: 1}, &fakeDeadLetters{})
+	skillCreated := []byte(`{"payload":{"op":"c","source":{"table":"skills","ts_ms":1000},"after":{"id":30,"name":"Go"}}}`)
+	require.NoError(t, kgb.handleMessage(context.Background(), &sarama.ConsumerMessage{Value: skillCreated}))
+	require.NoError(t, kgb.handleMessage(context.Background(), &sarama.ConsumerMessage{Topic: "tia-db.tia-dev.regions", Value: []byte(`{"payload":`)}))
+
+	assert.Equal(t, processed+1, testutil.ToFloat64(messagesProcessed.WithLabelValues("skills", "c")))
+	assert.Equal(t, retried+1, testutil.ToFloat64(messageErrors.WithLabelValues("skills", "retried")))
+	assert.Equal(t, malformed+1, testutil.ToFloat64(messageErrors.WithLabelValues("tia-db.tia-dev.regions", "malformed")))
+
+	recorder := httptest.NewRecorder()
+	telemetry.Handler(metrics, nil).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Contains(t, recorder.Body.String(), `kg_builder_message_processing_seconds_count{table="skills"}`)
+	assert.Contains(t, recorder.Body.String(), "go_goroutines")
+}
+
+func TestCheckMembershipFollowsConsumerGroupSession(t *testing.T) {
+	kgb := newDeliveryBuilder(&flakyWriter{}, &fakeDeadLetters{})
+	assert.Error(t, kgb.checkMembership(context.Background()))
+	session := &fakeSession{ctx: context.Background()}
+	require.NoError(t, kgb.Setup(session))
+	assert.NoError(t, kgb.checkMembership(context.Background()))
+	require.NoError(t, kgb.Cleanup(session))
+	assert.Error(t, kgb.checkMembership(context.Background()))
+}
+
+func TestDeadLetterMessageKeepsOriginalPayloadAndError(t *testing.T) {
+	message := &sarama.ConsumerMessage{Topic: "tia-db.tia-dev.users", Partition: 2, Offset: 41, Key: []byte(`{"id":1}`), Value: userCreated}
+	deadLetter := deadLetterMessage("kg.dlq", message, 5, errors.New("memgraph unavailable"))
+
+	assert.Equal(t, "kg.dlq", deadLetter.Topic)
+	assert.Equal(t, sarama.ByteEncoder(userCreated), deadLetter.Value)
+	assert.Equal(t, sarama.ByteEncoder(`{"id":1}`), deadLetter.Key)
+
+	headers := map[string]string{}
+	for _, header := range deadLetter.Headers {
+		headers[string(header.Key)] = string(header.Value)
+	}
+	assert.Equal(t, "tia-db.tia-dev.users", headers[headerOriginalTopic])
+	assert.Equal(t, "2", headers[headerOriginalPartition])
+	assert.Equal(t, "41", headers[headerOriginalOffset])
+	assert.Equal(t, "memgraph unavailable", headers[headerError])
+	assert.Equal(t, "5", headers[headerAttempts])
+	assert.NotEmpty(t, headers[headerFailedAt])
+}
+
+func TestRetryPolicyBacksOffExponentiallyUpToTheCap(t *testing.T) {
+	policy := retryPolicy{maxAttempts: 10, baseDelay: 100 * time.Millisecond, maxDelay: time.Second}
+	assert.Equal(t, 100*time.Millisecond, policy.delay(1))
+	assert.Equal(t, 200*time.Millisecond, policy.delay(2))
+	assert.Equal(t, 800*time.Millisecond, policy.delay(4))
+	assert.Equal(t, time.Second, policy.delay(5))
+	assert.Equal(t, time.Second, policy.delay(60))
+}
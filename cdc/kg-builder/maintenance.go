@@ -0,0 +1,293 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/TIA-PARTNERS-GROUP/tia-api/cdc/graphschema"
+)
+
+const (
+	reportLimit      = 20
+	progressInterval = 5 * time.Second
+)
+
+type mismatch struct {
+	Row   graphschema.Row
+	Diffs []string
+}
+
+type tableReport struct {
+	Table       graphschema.Table
+	SourceCount int64
+	GraphCount  int64
+	Sampled     int
+	Missing     []graphschema.Row
+	Extra       []graphschema.Row
+	Mismatched  []mismatch
+}
+
+func (r tableReport) consistent() bool {
+	return r.SourceCount == r.GraphCount && len(r.Missing) == 0 && len(r.Extra) == 0 && len(r.Mismatched) == 0
+}
+
+func (r tableReport) print(w io.Writer) {
+	status := "ok"
+	if !r.consistent() {
+		status = "DRIFT"
+	}
+	fmt.Fprintf(w, "%-22s %-5s source=%d graph=%d sampled=%d missing=%d extra=%d mismatched=%d\n",
+		r.Table.Name, status, r.SourceCount, r.GraphCount, r.Sampled, len(r.Missing), len(r.Extra), len(r.Mismatched))
+
+	lines := 0
+	line := func(format string, args ...interface{}) {
+		if lines < reportLimit {
+			fmt.Fprintf(w, "  "+format+"\n", args...)
+		}
+		lines++
+	}
+	for _, row := range r.Missing {
+		line("missing  %s", r.Table.Key(row))
+	}
+	for _, row := range r.Extra {
+		line("extra    %s", r.Table.Key(row))
+	}
+	for _, m := range r.Mismatched {
+		line("mismatch %s: %s", r.Table.Key(m.Row), strings.Join(m.Diffs, "; "))
+	}
+	if lines > reportLimit {
+		fmt.Fprintf(w, "  ... and %d more\n", lines-reportLimit)
+	}
+}
+
+type maintenance struct {
+	source    sourceReader
+	graph     graphReader
+	writer    graphWriter
+	batchSize int
+	sample    int
+	ctx       context.Context
+}
+
+func sortedRows(table graphschema.Table, rows map[string]graphschema.Row) []graphschema.Row {
+	keys := make([]string, 0, len(rows))
+	for key := range rows {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	sorted := make([]graphschema.Row, 0, len(keys))
+	for _, key := range keys {
+		sorted = append(sorted, rows[key])
+	}
+	return sorted
+}
+
+func (m *maintenance) verify(table graphschema.Table) (tableReport, error) {
+	report := tableReport{Table: table}
+	var err error
+	if report.SourceCount, err = m.source.Count(m.ctx, table); err != nil {
+		return report, fmt.Errorf("failed to count %s rows: %v", table.Name, err)
+	}
+	if report.GraphCount, err = m.graph.Count(m.ctx, table); err != nil {
+		return report, fmt.Errorf("failed to count %s graph entries: %v", table.Name, err)
+	}
+
+	keys, err := m.graph.Keys(m.ctx, table)
+	if err != nil {
+		return report, fmt.Errorf("failed to list %s graph entries: %v", table.Name, err)
+	}
+	extra := make(map[string]graphschema.Row, len(keys))
+	for _, key := range keys {
+		extra[table.Key(key)] = key
+	}
+	missing := map[string]graphschema.Row{}
+	err = m.source.Rows(m.ctx, table, func(row graphschema.Row) error {
+		key := table.Key(row)
+		if _, ok := extra[key]; ok {
+			delete(extra, key)
+			return nil
+		}
+		missing[key] = row
+		return nil
+	})
+	if err != nil {
+		return report, fmt.Errorf("failed to read %s rows: %v", table.Name, err)
+	}
+	report.Extra = sortedRows(table, extra)
+
+	if m.sample > 0 {
+		sample, err := m.source.Sample(m.ctx, table, m.sample)
+		if err != nil {
+			return report, fmt.Errorf("failed to sample %s rows: %v", table.Name, err)
+		}
+		report.Sampled = len(sample)
+		records, err := m.graph.Entities(m.ctx, table, sample)
+		if err != nil {
+			return report, fmt.Errorf("failed to read sampled %s graph entries: %v", table.Name, err)
+		}
+		found := make(map[string]graphschema.Entity, len(records))
+		for _, record := range records {
+			entity := table.GraphEntity(record)
+			found[entity.Key] = entity
+		}
+		for _, row := range sample {
+			expected := table.Entity(row)
+			actual, ok := found[expected.Key]
+			if !ok {
+				missing[expected.Key] = row
+				continue
+			}
+			if diffs := expected.Diff(actual); len(diffs) > 0 {
+				report.Mismatched = append(report.Mismatched, mismatch{Row: row, Diffs: diffs})
+			}
+		}
+	}
+
+	report.Missing = sortedRows(table, missing)
+	return report, nil
+}
+
+func (m *maintenance) upsert(table graphschema.Table, rows []graphschema.Row, version int64) error {
+	for start := 0; start < len(rows); start += m.batchSize {
+		end := start + m.batchSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+		if err := m.writer.ApplyBatch(m.ctx, graphschema.Batch{Table: table, Version: version, Rows: rows[start:end]}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *maintenance) repair(report tableReport, version int64) error {
+	table := report.Table
+	rows := append([]graphschema.Row{}, report.Missing...)
+	for _, mismatched := range report.Mismatched {
+		rows = append(rows, mismatched.Row)
+	}
+	if err := m.upsert(table, rows, version); err != nil {
+		return fmt.Errorf("failed to repair %s: %v", table.Name, err)
+	}
+
+	var deletes []graphschema.Mutation
+	for _, row := range report.Extra {
+		deletes = append(deletes, table.Mutations(graphschema.OpDelete, version, row, nil)...)
+	}
+	if err := m.writer.Apply(m.ctx, deletes); err != nil {
+		return fmt.Errorf("failed to remove extra %s entries: %v", table.Name, err)
+	}
+	return nil
+}
+
+func (m *maintenance) check(tables []graphschema.Table, repair bool, w io.Writer) (bool, error) {
+	version, err := m.source.Now(m.ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to read source clock: %v", err)
+	}
+
+	consistent := true
+	for _, table := range tables {
+		report, err := m.verify(table)
+		if err != nil {
+			return false, err
+		}
+		report.print(w)
+		if report.consistent() {
+			continue
+		}
+		consistent = false
+		if repair {
+			if err := m.repair(report, version); err != nil {
+				return false, err
+			}
+			fmt.Fprintf(w, "  repaired: upserted %d, removed %d\n", len(report.Missing)+len(report.Mismatched), len(report.Extra))
+		}
+	}
+	return consistent, nil
+}
+
+func rowsPerSecond(rows int, elapsed time.Duration) float64 {
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(rows) / elapsed.Seconds()
+}
+
+func (m *maintenance) load(table graphschema.Table, version int64) (int, error) {
+	total, err := m.source.Count(m.ctx, table)
+	if err != nil {
+		return 0, err
+	}
+
+	loaded := 0
+	started := time.Now()
+	reported := started
+	batch := graphschema.Batch{Table: table, Version: version}
+	flush := func() error {
+		if len(batch.Rows) == 0 {
+			return nil
+		}
+		if err := m.writer.ApplyBatch(m.ctx, batch); err != nil {
+			return err
+		}
+		loaded += len(batch.Rows)
+		batch.Rows = nil
+		if time.Since(reported) >= progressInterval {
+			reported = time.Now()
+			log.Printf("Loading %s: %d/%d rows (%.0f rows/s)", table.Name, loaded, total, rowsPerSecond(loaded, time.Since(started)))
+		}
+		return nil
+	}
+
+	err = m.source.Rows(m.ctx, table, func(row graphschema.Row) error {
+		batch.Rows = append(batch.Rows, row)
+		if len(batch.Rows) >= m.batchSize {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return loaded, err
+	}
+	if err := flush(); err != nil {
+		return loaded, err
+	}
+	elapsed := time.Since(started)
+	log.Printf("Loaded %d %s rows in %s (%.0f rows/s)", loaded, table.Name, elapsed.Round(time.Millisecond), rowsPerSecond(loaded, elapsed))
+	return loaded, nil
+}
+
+func (m *maintenance) rebuild(tables []graphschema.Table) error {
+	version, err := m.source.Now(m.ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read source clock: %v", err)
+	}
+
+	reload := map[string]bool{}
+	for _, table := range tables {
+		log.Printf("Clearing %s from the graph", table.Name)
+		if err := m.writer.Apply(m.ctx, []graphschema.Mutation{table.Clear()}); err != nil {
+			return fmt.Errorf("failed to clear %s: %v", table.Name, err)
+		}
+		reload[table.Name] = true
+	}
+	for _, table := range graphschema.Dependents(tables) {
+		reload[table.Name] = true
+	}
+
+	for _, table := range graphschema.Tables {
+		if !reload[table.Name] {
+			continue
+		}
+		if loaded, err := m.load(table, version); err != nil {
+			return fmt.Errorf("failed to load %s after %d rows: %v", table.Name, loaded, err)
+		}
+	}
+	return nil
+}
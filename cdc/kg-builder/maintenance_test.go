@@ -0,0 +1,245 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/TIA-PARTNERS-GROUP/tia-api/cdc/graphschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSource struct {
+	rows map[string][]fixtureRow
+	now  int64
+}
+
+func (s *fakeSource) Now(ctx context.Context) (int64, error) { return s.now, nil }
+
+func (s *fakeSource) Count(ctx context.Context, table graphschema.Table) (int64, error) {
+	return int64(len(s.rows[table.Name])), nil
+}
+
+func (s *fakeSource) Rows(ctx context.Context, table graphschema.Table, each func(graphschema.Row) error) error {
+	rows := &fakeRows{columns: table.Columns()}
+	for _, fixture := range s.rows[table.Name] {
+		row := mysqlRow(fixture)
+		values := make([]interface{}, len(rows.columns))
+		for i, column := range rows.columns {
+			value, ok := row[column]
+			if !ok {
+				return fmt.Errorf("fixture for %s is missing column %s", table.Name, column)
+			}
+			values[i] = value
+		}
+		rows.rows = append(rows.rows, values)
+	}
+	return scanRows(rows, each)
+}
+
+func (s *fakeSource) Sample(ctx context.Context, table graphschema.Table, limit int) ([]graphschema.Row, error) {
+	var sample []graphschema.Row
+	for _, row := range s.rows[table.Name] {
+		if len(sample) == limit {
+			break
+		}
+		sample = append(sample, mysqlRow(row))
+	}
+	return sample, nil
+}
+
+func (g *memGraph) records(table graphschema.Table) []graphschema.Row {
+	var records []graphschema.Row
+	if table.Node != nil {
+		for node, props := range g.nodes {
+			if node.Label != table.Node.Label {
+				continue
+			}
+			record := graphschema.Row{graphschema.IDProperty: node.ID, "props": props}
+			for _, parent := range table.Node.Parents {
+				for _, e := range g.edges {
+					if e.rel == parent.Rel && e.to == node && e.from.Label == parent.Label {
+						record[parent.Column] = e.from.ID
+					}
+				}
+			}
+			records = append(records, record)
+		}
+		return records
+	}
+	edge := table.Edge
+	for _, e := range g.edges {
+		if e.rel != edge.Rel || e.from.Label != edge.From.Label || e.to.Label != edge.To.Label {
+			continue
+		}
+		record := graphschema.Row{edge.From.Column: e.from.ID, edge.To.Column: e.to.ID, "props": e.props}
+		for _, key := range edge.Key {
+			record[key.Column] = e.props[key.Name]
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func (g *memGraph) Count(ctx context.Context, table graphschema.Table) (int64, error) {
+	return int64(len(g.records(table))), nil
+}
+
+func (g *memGraph) Keys(ctx context.Context, table graphschema.Table) ([]graphschema.Row, error) {
+	return g.records(table), nil
+}
+
+func (g *memGraph) Entities(ctx context.Context, table graphschema.Table, keys []graphschema.Row) ([]graphschema.Row, error) {
+	wanted := map[string]bool{}
+	for _, key := range keys {
+		wanted[table.Key(key)] = true
+	}
+	var found []graphschema.Row
+	for _, record := range g.records(table) {
+		if wanted[table.Key(record)] {
+			found = append(found, record)
+		}
+	}
+	return found, nil
+}
+
+func newMaintenance(source *fakeSource, graph *memGraph) *maintenance {
+	return &maintenance{source: source, graph: graph, writer: graph, batchSize: 2, sample: 100, ctx: context.Background()}
+}
+
+func freshGraph(t *testing.T, current map[string][]fixtureRow) *memGraph {
+	graph := newMemGraph()
+	bulkLoad(t, graph, current, 1)
+	return graph
+}
+
+func TestRebuildReplacesDriftedGraph(t *testing.T) {
+	_, current := fixtureHistory()
+	graph := newMemGraph()
+	departed := fixtureRow{"id": int64(99), "first_name": "Gone", "last_name": nil, "login_email": "gone@example.com", "active": int64(1), "email_verified": int64(0), "created_at": fixtureTime, "updated_at": fixtureTime}
+	require.NoError(t, graph.Apply(context.Background(), graphschema.Tables[0].Mutations(graphschema.OpRead, 1, nil, departed)))
+
+	m := newMaintenance(&fakeSource{rows: current, now: 5000}, graph)
+	require.NoError(t, m.rebuild(graphschema.Tables))
+
+	expected := freshGraph(t, current)
+	assert.Equal(t, expected.nodes, graph.nodes)
+	assert.Equal(t, expected.edges, graph.edges)
+
+	consistent, err := m.check(graphschema.Tables, false, &bytes.Buffer{})
+	require.NoError(t, err)
+	assert.True(t, consistent)
+}
+
+func TestRebuildOfOneTableReloadsItsDependents(t *testing.T) {
+	_, current := fixtureHistory()
+	graph := freshGraph(t, current)
+
+	tables, err := graphschema.Select([]string{"users"})
+	require.NoError(t, err)
+	require.NoError(t, newMaintenance(&fakeSource{rows: current, now: 5000}, graph).rebuild(tables))
+
+	expected := freshGraph(t, current)
+	assert.Equal(t, expected.nodes, graph.nodes)
+	assert.Equal(t, expected.edges, graph.edges)
+
+	_, err = graphschema.Select([]string{"users", "invoices"})
+	assert.EqualError(t, err, `unknown table "invoices"`)
+}
+
+func TestVerifyReportsDriftAndRepairFixesIt(t *testing.T) {
+	_, current := fixtureHistory()
+	graph := freshGraph(t, current)
+	ctx := context.Background()
+
+	users, _ := graphschema.TableByName("users")
+	businesses, _ := graphschema.TableByName("businesses")
+	skills, _ := graphschema.TableByName("skills")
+	bob := current["users"][1]
+	renamed := withValues(current["businesses"][0], fixtureRow{"name": "Acme Corp"})
+	stray := fixtureRow{"id": int64(99), "name": "Cobol", "category": "Programming", "description": nil, "active": int64(1), "created_at": fixtureTime}
+	require.NoError(t, graph.Apply(ctx, users.Mutations(graphschema.OpDelete, 2, bob, nil)))
+	require.NoError(t, graph.Apply(ctx, businesses.Mutations(graphschema.OpUpdate, 2, nil, renamed)))
+	require.NoError(t, graph.Apply(ctx, skills.Mutations(graphschema.OpCreate, 2, nil, stray)))
+
+	m := newMaintenance(&fakeSource{rows: current, now: 5000}, graph)
+	var out bytes.Buffer
+	consistent, err := m.check(graphschema.Tables, false, &out)
+	require.NoError(t, err)
+	assert.False(t, consistent)
+	assert.Contains(t, out.String(), "users                  DRIFT source=2 graph=1")
+	assert.Contains(t, out.String(), "missing  id=2")
+	assert.Contains(t, out.String(), "mismatch id=10: name: source=Acme graph=Acme Corp")
+	assert.Contains(t, out.String(), "mismatch id=11: parent operator_user_id: source=2 graph=<missing>")
+	assert.Contains(t, out.String(), "extra    id=99")
+	assert.Contains(t, out.String(), "missing  user_id=2 skill_id=30")
+
+	consistent, err = m.check(graphschema.Tables, true, &bytes.Buffer{})
+	require.NoError(t, err)
+	assert.False(t, consistent)
+
+	consistent, err = m.check(graphschema.Tables, false, &out)
+	require.NoError(t, err)
+	assert.True(t, consistent)
+	expected := freshGraph(t, current)
+	assert.Equal(t, expected.nodes, graph.nodes)
+	assert.Equal(t, expected.edges, graph.edges)
+}
+
+func TestVerifyComparesKeysWhenCountsMatch(t *testing.T) {
+	_, current := fixtureHistory()
+	graph := freshGraph(t, current)
+	ctx := context.Background()
+
+	skills, _ := graphschema.TableByName("skills")
+	sales := current["skills"][1]
+	stray := fixtureRow{"id": int64(99), "name": "Cobol", "category": "Programming", "description": nil, "active": int64(1), "created_at": fixtureTime}
+	require.NoError(t, graph.Apply(ctx, skills.Mutations(graphschema.OpDelete, 2, sales, nil)))
+	require.NoError(t, graph.Apply(ctx, skills.Mutations(graphschema.OpCreate, 2, nil, stray)))
+
+	m := newMaintenance(&fakeSource{rows: current, now: 5000}, graph)
+	m.sample = 0
+	var out bytes.Buffer
+	consistent, err := m.check([]graphschema.Table{skills}, false, &out)
+	require.NoError(t, err)
+	assert.False(t, consistent)
+	assert.Contains(t, out.String(), "skills                 DRIFT source=2 graph=2")
+	assert.Contains(t, out.String(), fmt.Sprintf("missing  id=%v", sales["id"]))
+	assert.Contains(t, out.String(), "extra    id=99")
+
+	_, err = m.check([]graphschema.Table{skills}, true, &bytes.Buffer{})
+	require.NoError(t, err)
+	consistent, err = m.check([]graphschema.Table{skills}, false, &bytes.Buffer{})
+	require.NoError(t, err)
+	assert.True(t, consistent)
+}
+
+func TestBatchStatementsRenderDeclaredSchema(t *testing.T) {
+	declared := map[string]bool{}
+	for _, name := range append(append([]string{}, graphschema.Labels...), graphschema.RelationshipTypes...) {
+		declared[name] = true
+	}
+	_, current := fixtureHistory()
+	for _, table := range graphschema.Tables {
+		var rows []graphschema.Row
+		for _, row := range current[table.Name] {
+			rows = append(rows, mysqlRow(row))
+		}
+		statements := graphschema.Batch{Table: table, Version: 1, Rows: rows}.Statements()
+		require.NotEmpty(t, statements, table.Name)
+		queries := []string{table.CountQuery(), table.KeysQuery(), table.EntitiesQuery()}
+		for _, statement := range statements {
+			queries = append(queries, statement.Query)
+			for name := range statement.Params {
+				assert.Contains(t, statement.Query, "$"+name)
+			}
+		}
+		for _, query := range queries {
+			for _, name := range schemaNames(query) {
+				assert.True(t, declared[name], "%s renders undeclared name %s: %s", table.Name, name, query)
+			}
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+
+	"github.com/TIA-PARTNERS-GROUP/tia-api/cdc/graphschema"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+type graphReader interface {
+	Count(ctx context.Context, table graphschema.Table) (int64, error)
+	Keys(ctx context.Context, table graphschema.Table) ([]graphschema.Row, error)
+	Entities(ctx context.Context, table graphschema.Table, keys []graphschema.Row) ([]graphschema.Row, error)
+}
+
+type memgraphReader struct {
+	driver neo4j.DriverWithContext
+}
+
+func (r *memgraphReader) query(ctx context.Context, query string, params map[string]interface{}) ([]graphschema.Row, error) {
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		records, err := tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+		var rows []graphschema.Row
+		for records.Next(ctx) {
+			rows = append(rows, graphschema.Row(records.Record().AsMap()))
+		}
+		return rows, records.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	rows, _ := result.([]graphschema.Row)
+	return rows, nil
+}
+
+func (r *memgraphReader) Count(ctx context.Context, table graphschema.Table) (int64, error) {
+	rows, err := r.query(ctx, table.CountQuery(), nil)
+	if err != nil || len(rows) == 0 {
+		return 0, err
+	}
+	count, _ := rows[0]["count"].(int64)
+	return count, nil
+}
+
+func (r *memgraphReader) Keys(ctx context.Context, table graphschema.Table) ([]graphschema.Row, error) {
+	return r.query(ctx, table.KeysQuery(), nil)
+}
+
+func (r *memgraphReader) Entities(ctx context.Context, table graphschema.Table, keys []graphschema.Row) ([]graphschema.Row, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	params := make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		params = append(params, table.KeyParams(key))
+	}
+	return r.query(ctx, table.EntitiesQuery(), map[string]interface{}{"keys": params})
+}
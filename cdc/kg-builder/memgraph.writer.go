@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+
+	"github.com/TIA-PARTNERS-GROUP/tia-api/cdc/graphschema"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+type graphWriter interface {
+	Apply(ctx context.Context, mutations []graphschema.Mutation) error
+	ApplyBatch(ctx context.Context, batch graphschema.Batch) error
+}
+
+type memgraphWriter struct {
+	driver neo4j.DriverWithContext
+}
+
+func (w *memgraphWriter) Apply(ctx context.Context, mutations []graphschema.Mutation) error {
+	if len(mutations) == 0 {
+		return nil
+	}
+	session := w.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		for _, mutation := range mutations {
+			query, params := mutation.Cypher()
+			if _, err := tx.Run(ctx, query, params); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	return err
+}
+
+func (w *memgraphWriter) ApplyBatch(ctx context.Context, batch graphschema.Batch) error {
+	statements := batch.Statements()
+	if len(statements) == 0 {
+		return nil
+	}
+	session := w.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		for _, statement := range statements {
+			if _, err := tx.Run(ctx, statement.Query, statement.Params); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	return err
+}
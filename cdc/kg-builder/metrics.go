@@ -0,0 +1,43 @@
+package main
+
+import (
+	"github.com/TIA-PARTNERS-GROUP/tia-api/cdc/telemetry"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	metrics = telemetry.NewRegistry()
+
+	messagesProcessed = promauto.With(metrics).NewCounterVec(prometheus.CounterOpts{
+		Name: "kg_builder_messages_processed_total",
+		Help: "CDC messages applied to the knowledge graph.",
+	}, []string{"table", "op"})
+	processingSeconds = promauto.With(metrics).NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "kg_builder_message_processing_seconds",
+		Help:    "Time to apply a CDC message, including retries.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"table"})
+	messageErrors = promauto.With(metrics).NewCounterVec(prometheus.CounterOpts{
+		Name: "kg_builder_message_errors_total",
+		Help: "CDC message failures: retried attempts, malformed events, failed writes and dead-letter failures.",
+	}, []string{"table", "reason"})
+	consumerLag = promauto.With(metrics).NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kg_builder_consumer_lag",
+		Help: "Messages between the last processed offset and the partition high-water mark.",
+	}, []string{"topic", "partition"})
+)
+
+func eventLabels(event cdcEvent, topic string) (string, string) {
+	table := event.Table
+	if table == "" {
+		table = topic
+	}
+	switch event.Kind {
+	case eventTombstone:
+		return table, "tombstone"
+	case eventSchemaChange:
+		return table, "schema_change"
+	}
+	return table, event.Op
+}
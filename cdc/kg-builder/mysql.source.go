@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/TIA-PARTNERS-GROUP/tia-api/cdc/graphschema"
+)
+
+type rowScanner interface {
+	Columns() ([]string, error)
+	Next() bool
+	Scan(dest ...interface{}) error
+	Err() error
+}
+
+type sourceReader interface {
+	Now(ctx context.Context) (int64, error)
+	Count(ctx context.Context, table graphschema.Table) (int64, error)
+	Rows(ctx context.Context, table graphschema.Table, each func(graphschema.Row) error) error
+	Sample(ctx context.Context, table graphschema.Table, limit int) ([]graphschema.Row, error)
+}
+
+type sqlQueryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+type mysqlSource struct {
+	db sqlQueryer
+}
+
+func (s *mysqlSource) Now(ctx context.Context) (int64, error) {
+	var nowMs int64
+	err := s.db.QueryRowContext(ctx, "SELECT CAST(UNIX_TIMESTAMP(NOW(3)) * 1000 AS SIGNED)").Scan(&nowMs)
+	return nowMs, err
+}
+
+func (s *mysqlSource) Count(ctx context.Context, table graphschema.Table) (int64, error) {
+	var count int64
+	err := s.db.QueryRowContext(ctx, fmt.Sprintf("SELECT COUNT(*) FROM %s", table.Name)).Scan(&count)
+	return count, err
+}
+
+func selectColumns(table graphschema.Table) string {
+	return fmt.Sprintf("SELECT %s FROM %s", strings.Join(table.Columns(), ", "), table.Name)
+}
+
+func (s *mysqlSource) Rows(ctx context.Context, table graphschema.Table, each func(graphschema.Row) error) error {
+	rows, err := s.db.QueryContext(ctx, selectColumns(table))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	return scanRows(rows, each)
+}
+
+func (s *mysqlSource) Sample(ctx context.Context, table graphschema.Table, limit int) ([]graphschema.Row, error) {
+	rows, err := s.db.QueryContext(ctx, selectColumns(table)+" ORDER BY RAND() LIMIT ?", limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sample []graphschema.Row
+	err = scanRows(rows, func(row graphschema.Row) error {
+		sample = append(sample, row)
+		return nil
+	})
+	return sample, err
+}
+
+func scanRows(rows rowScanner, each func(graphschema.Row) error) error {
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		dest := make([]interface{}, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return err
+		}
+
+		row := make(graphschema.Row, len(columns))
+		for i, column := range columns {
+			row[column] = values[i]
+		}
+		if err := each(row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,485 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/cdc/graphschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memEdge struct {
+	rel   string
+	from  graphschema.NodeRef
+	to    graphschema.NodeRef
+	props map[string]interface{}
+}
+
+type memGraph struct {
+	nodes    map[graphschema.NodeRef]map[string]interface{}
+	edges    map[string]*memEdge
+	versions map[string]int64
+}
+
+func newMemGraph() *memGraph {
+	return &memGraph{
+		nodes:    map[graphschema.NodeRef]map[string]interface{}{},
+		edges:    map[string]*memEdge{},
+		versions: map[string]int64{},
+	}
+}
+
+func nodeID(node graphschema.NodeRef) string {
+	return fmt.Sprintf("%s:%v", node.Label, node.ID)
+}
+
+func edgeID(rel string, from, to graphschema.NodeRef, key map[string]interface{}) string {
+	keys := make([]string, 0, len(key))
+	for k, v := range key {
+		keys = append(keys, fmt.Sprintf("%s=%v", k, v))
+	}
+	sort.Strings(keys)
+	return fmt.Sprintf("%s-[%s {%s}]->%s", nodeID(from), rel, strings.Join(keys, ","), nodeID(to))
+}
+
+func setProps(target, props map[string]interface{}) {
+	for k, v := range props {
+		if v == nil {
+			delete(target, k)
+			continue
+		}
+		target[k] = v
+	}
+}
+
+func (g *memGraph) current(id string, version int64) bool {
+	return g.versions[id] <= version
+}
+
+func (g *memGraph) stub(node graphschema.NodeRef) {
+	if g.nodes[node] == nil {
+		g.nodes[node] = map[string]interface{}{graphschema.IDProperty: node.ID}
+	}
+}
+
+func (g *memGraph) deleteNode(node graphschema.NodeRef) {
+	delete(g.nodes, node)
+	delete(g.versions, nodeID(node))
+	for id, e := range g.edges {
+		if e.from == node || e.to == node {
+			g.deleteEdge(id)
+		}
+	}
+}
+
+func (g *memGraph) deleteEdge(id string) {
+	delete(g.edges, id)
+	delete(g.versions, id)
+}
+
+func (g *memGraph) Apply(ctx context.Context, mutations []graphschema.Mutation) error {
+	for _, m := range mutations {
+		switch m.Kind {
+		case graphschema.MergeNode:
+			if !g.current(nodeID(m.Node), m.Version) {
+				continue
+			}
+			if g.nodes[m.Node] == nil {
+				g.nodes[m.Node] = map[string]interface{}{graphschema.IDProperty: m.Node.ID}
+			}
+			setProps(g.nodes[m.Node], m.Props)
+			g.versions[nodeID(m.Node)] = m.Version
+		case graphschema.DeleteNode:
+			if g.nodes[m.Node] != nil && g.current(nodeID(m.Node), m.Version) {
+				g.deleteNode(m.Node)
+			}
+		case graphschema.MergeEdge:
+			g.stub(m.From)
+			g.stub(m.To)
+			id := edgeID(m.Rel, m.From, m.To, m.Key)
+			if !g.current(id, m.Version) {
+				continue
+			}
+			if g.edges[id] == nil {
+				props := map[string]interface{}{}
+				setProps(props, m.Key)
+				g.edges[id] = &memEdge{rel: m.Rel, from: m.From, to: m.To, props: props}
+			}
+			setProps(g.edges[id].props, m.Props)
+			g.versions[id] = m.Version
+		case graphschema.DeleteEdge:
+			id := edgeID(m.Rel, m.From, m.To, m.Key)
+			if g.edges[id] != nil && g.current(id, m.Version) {
+				g.deleteEdge(id)
+			}
+		case graphschema.SetParent:
+			if g.nodes[m.To] == nil || !g.current(nodeID(m.To), m.Version) {
+				continue
+			}
+			for id, e := range g.edges {
+				if e.rel == m.Rel && e.to == m.To && e.from.Label == m.From.Label {
+					g.deleteEdge(id)
+				}
+			}
+			if m.From.ID != nil {
+				g.stub(m.From)
+				id := edgeID(m.Rel, m.From, m.To, nil)
+				g.edges[id] = &memEdge{rel: m.Rel, from: m.From, to: m.To, props: map[string]interface{}{}}
+				g.versions[id] = m.Version
+			}
+		case graphschema.SweepNodes:
+			for node := range g.nodes {
+				if node.Label == m.Node.Label && g.versions[nodeID(node)] < m.Version {
+					g.deleteNode(node)
+				}
+			}
+		case graphschema.SweepEdges:
+			for id, e := range g.edges {
+				if e.rel == m.Rel && e.from.Label == m.From.Label && e.to.Label == m.To.Label && g.versions[id] < m.Version {
+					g.deleteEdge(id)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (g *memGraph) ApplyBatch(ctx context.Context, batch graphschema.Batch) error {
+	return g.Apply(ctx, batch.Mutations())
+}
+
+type fakeRows struct {
+	columns []string
+	rows    [][]interface{}
+	next    int
+}
+
+func (r *fakeRows) Columns() ([]string, error) { return r.columns, nil }
+func (r *fakeRows) Err() error                 { return nil }
+
+func (r *fakeRows) Next() bool {
+	r.next++
+	return r.next <= len(r.rows)
+}
+
+func (r *fakeRows) Scan(dest ...interface{}) error {
+	for i, value := range r.rows[r.next-1] {
+		*dest[i].(*interface{}) = value
+	}
+	return nil
+}
+
+type fixtureRow = map[string]interface{}
+
+var fixtureTime = time.Date(2026, 3, 14, 9, 30, 15, 250000000, time.UTC)
+
+func mysqlRow(values fixtureRow) fixtureRow {
+	row := fixtureRow{}
+	for k, v := range values {
+		if s, ok := v.(string); ok {
+			row[k] = []byte(s)
+			continue
+		}
+		row[k] = v
+	}
+	return row
+}
+
+func debeziumRow(values fixtureRow) map[string]interface{} {
+	if values == nil {
+		return nil
+	}
+	row := map[string]interface{}{}
+	for k, v := range values {
+		if t, ok := v.(time.Time); ok {
+			row[k] = t.UnixMilli()
+			continue
+		}
+		row[k] = v
+	}
+	return row
+}
+
+func withValues(base fixtureRow, changes fixtureRow) fixtureRow {
+	row := fixtureRow{}
+	for k, v := range base {
+		row[k] = v
+	}
+	for k, v := range changes {
+		row[k] = v
+	}
+	return row
+}
+
+type changeEvent struct {
+	table         string
+	op            string
+	before, after fixtureRow
+}
+
+func replayEvent(t *testing.T, kgb *KnowledgeGraphBuilder, event changeEvent, tsMs int64) {
+	value, err := json.Marshal(map[string]interface{}{"payload": map[string]interface{}{
+		"source": map[string]interface{}{"table": event.table, "ts_ms": tsMs},
+		"op":     event.op,
+		"before": debeziumRow(event.before),
+		"after":  debeziumRow(event.after),
+	}})
+	require.NoError(t, err)
+	require.NoError(t, kgb.processMessage(&sarama.ConsumerMessage{Value: value}))
+}
+
+func replayHistory(t *testing.T, kgb *KnowledgeGraphBuilder, events []changeEvent) {
+	for i, event := range events {
+		replayEvent(t, kgb, event, int64(i+1)*1000)
+	}
+}
+
+func bulkLoad(t *testing.T, writer graphWriter, current map[string][]fixtureRow, version int64) {
+	loader := &maintenance{source: &fakeSource{rows: current}, writer: writer, batchSize: 3, ctx: context.Background()}
+	for _, table := range graphschema.Tables {
+		_, err := loader.load(table, version)
+		require.NoError(t, err)
+	}
+}
+
+func fixtureHistory() ([]changeEvent, map[string][]fixtureRow) {
+	alice := fixtureRow{"id": int64(1), "first_name": "Alice", "last_name": "Nguyen", "login_email": "alice@example.com", "active": int64(1), "email_verified": int64(0), "created_at": fixtureTime, "updated_at": fixtureTime}
+	bob := fixtureRow{"id": int64(2), "first_name": "Bob", "last_name": nil, "login_email": "bob@example.com", "active": int64(1), "email_verified": int64(1), "created_at": fixtureTime, "updated_at": fixtureTime}
+	acme := fixtureRow{"id": int64(10), "operator_user_id": int64(1), "name": "Acme", "tagline": "We build", "description": "Builders", "website": "https://acme.example", "business_type": "Technology", "business_category": "B2B", "business_phase": "Growth", "active": int64(1), "verified": int64(0), "completeness": int64(45), "created_at": fixtureTime, "updated_at": fixtureTime}
+	globex := fixtureRow{"id": int64(11), "operator_user_id": int64(1), "name": "Globex", "tagline": nil, "description": nil, "website": nil, "business_type": "Consulting", "business_category": "B2B", "business_phase": "Startup", "active": int64(1), "verified": int64(1), "completeness": int64(9), "created_at": fixtureTime, "updated_at": fixtureTime}
+	globexMoved := withValues(globex, fixtureRow{"operator_user_id": int64(2), "updated_at": fixtureTime.Add(time.Hour)})
+	launch := fixtureRow{"id": int64(20), "managed_by_user_id": int64(1), "business_id": int64(10), "name": "Launch", "description": "Go to market", "project_status": "planning", "start_date": fixtureTime, "target_end_date": nil, "created_at": fixtureTime, "updated_at": fixtureTime}
+	launchActive := withValues(launch, fixtureRow{"project_status": "active", "business_id": nil})
+	golang := fixtureRow{"id": int64(30), "name": "Go", "category": "Programming", "description": nil, "active": int64(1), "created_at": fixtureTime}
+	sales := fixtureRow{"id": int64(31), "name": "Sales", "category": "Business", "description": "Selling", "active": int64(1), "created_at": fixtureTime}
+	partnership := fixtureRow{"id": int64(40), "initiating_business_id": int64(10), "receiving_business_id": int64(11), "connection_type": "Partnership", "status": "pending", "initiated_by_user_id": int64(1), "notes": nil, "created_at": fixtureTime, "updated_at": fixtureTime}
+	partnershipActive := withValues(partnership, fixtureRow{"status": "active", "notes": "Signed"})
+	aliceGo := fixtureRow{"user_id": int64(1), "skill_id": int64(30), "proficiency_level": "advanced", "created_at": fixtureTime}
+	bobGo := fixtureRow{"user_id": int64(2), "skill_id": int64(30), "proficiency_level": "beginner", "created_at": fixtureTime}
+	bobGoExpert := withValues(bobGo, fixtureRow{"proficiency_level": "expert"})
+	bobSales := fixtureRow{"user_id": int64(2), "skill_id": int64(31), "proficiency_level": "intermediate", "created_at": fixtureTime}
+	launchGo := fixtureRow{"project_id": int64(20), "skill_id": int64(30), "importance": "required"}
+	nsw := fixtureRow{"id": "NSW", "name": "New South Wales"}
+	vic := fixtureRow{"id": "VIC", "name": "Victoria"}
+	acmeClient := fixtureRow{"id": int64(50), "business_id": int64(10), "tag_type": "client", "description": "Retailers", "created_at": fixtureTime}
+	acmeService := fixtureRow{"id": int64(51), "business_id": int64(10), "tag_type": "service", "description": "Web apps", "created_at": fixtureTime}
+	caseStudy := fixtureRow{"id": int64(60), "user_id": int64(1), "business_id": int64(10), "title": "Launch story", "slug": "launch-story", "publication_type": "case_study", "visibility": "members", "published": int64(0), "published_at": nil, "created_at": fixtureTime, "updated_at": fixtureTime}
+	casePublished := withValues(caseStudy, fixtureRow{"business_id": nil, "published": int64(1), "published_at": fixtureTime, "visibility": "public"})
+	bobMember := fixtureRow{"project_id": int64(20), "user_id": int64(2), "role": "contributor", "joined_at": fixtureTime}
+	bobReviewer := withValues(bobMember, fixtureRow{"role": "reviewer"})
+	launchNSW := fixtureRow{"project_id": int64(20), "region_id": "NSW"}
+	launchVIC := fixtureRow{"project_id": int64(20), "region_id": "VIC"}
+	bobApplied := fixtureRow{"project_id": int64(20), "user_id": int64(2)}
+
+	events := []changeEvent{
+		{"users", graphschema.OpCreate, nil, alice},
+		{"users", graphschema.OpCreate, nil, bob},
+		{"businesses", graphschema.OpCreate, nil, acme},
+		{"businesses", graphschema.OpCreate, nil, globex},
+		{"businesses", graphschema.OpUpdate, globex, globexMoved},
+		{"projects", graphschema.OpCreate, nil, launch},
+		{"projects", graphschema.OpUpdate, launch, launchActive},
+		{"skills", graphschema.OpCreate, nil, golang},
+		{"skills", graphschema.OpCreate, nil, sales},
+		{"business_connections", graphschema.OpCreate, nil, partnership},
+		{"business_connections", graphschema.OpUpdate, partnership, partnershipActive},
+		{"user_skills", graphschema.OpCreate, nil, aliceGo},
+		{"user_skills", graphschema.OpCreate, nil, bobGo},
+		{"user_skills", graphschema.OpUpdate, bobGo, bobGoExpert},
+		{"user_skills", graphschema.OpCreate, nil, bobSales},
+		{"user_skills", graphschema.OpDelete, bobSales, nil},
+		{"project_skills", graphschema.OpCreate, nil, launchGo},
+		{"regions", graphschema.OpCreate, nil, nsw},
+		{"regions", graphschema.OpCreate, nil, vic},
+		{"business_tags", graphschema.OpCreate, nil, acmeClient},
+		{"business_tags", graphschema.OpCreate, nil, acmeService},
+		{"business_tags", graphschema.OpDelete, acmeService, nil},
+		{"publications", graphschema.OpCreate, nil, caseStudy},
+		{"publications", graphschema.OpUpdate, caseStudy, casePublished},
+		{"project_members", graphschema.OpCreate, nil, bobMember},
+		{"project_members", graphschema.OpUpdate, bobMember, bobReviewer},
+		{"project_regions", graphschema.OpCreate, nil, launchNSW},
+		{"project_regions", graphschema.OpCreate, nil, launchVIC},
+		{"project_regions", graphschema.OpDelete, launchVIC, nil},
+		{"project_applicants", graphschema.OpCreate, nil, bobApplied},
+	}
+	current := map[string][]fixtureRow{
+		"users":                {alice, bob},
+		"businesses":           {acme, globexMoved},
+		"projects":             {launchActive},
+		"skills":               {golang, sales},
+		"business_connections": {partnershipActive},
+		"user_skills":          {aliceGo, bobGoExpert},
+		"project_skills":       {launchGo},
+		"regions":              {nsw, vic},
+		"business_tags":        {acmeClient},
+		"publications":         {casePublished},
+		"project_members":      {bobReviewer},
+		"project_regions":      {launchNSW},
+		"project_applicants":   {bobApplied},
+	}
+	return events, current
+}
+
+func TestReplayAndBulkLoadProduceIdenticalGraphs(t *testing.T) {
+	events, current := fixtureHistory()
+
+	replayed := newMemGraph()
+	replayHistory(t, &KnowledgeGraphBuilder{writer: replayed, ctx: context.Background()}, events)
+
+	loaded := newMemGraph()
+	bulkLoad(t, loaded, current, 1)
+
+	assert.Equal(t, loaded.nodes, replayed.nodes)
+	assert.Equal(t, loaded.edges, replayed.edges)
+
+	assert.Len(t, replayed.nodes, 11)
+	project := replayed.nodes[graphschema.NodeRef{Label: graphschema.LabelProject, ID: int64(20)}]
+	assert.Equal(t, "active", project["projectStatus"])
+	assert.Equal(t, fixtureTime.UnixMilli(), project["createdAt"])
+	globexNode := replayed.nodes[graphschema.NodeRef{Label: graphschema.LabelBusiness, ID: int64(11)}]
+	assert.Equal(t, true, globexNode["verified"])
+	assert.NotContains(t, globexNode, "description")
+	region := replayed.nodes[graphschema.NodeRef{Label: graphschema.LabelRegion, ID: "NSW"}]
+	assert.Equal(t, "New South Wales", region["name"])
+	publication := replayed.nodes[graphschema.NodeRef{Label: graphschema.LabelPublication, ID: int64(60)}]
+	assert.Equal(t, true, publication["published"])
+	assert.Equal(t, fixtureTime.UnixMilli(), publication["publishedAt"])
+
+	declared := map[string]bool{}
+	for _, rel := range graphschema.RelationshipTypes {
+		declared[rel] = true
+	}
+	rels := map[string]int{}
+	for _, e := range replayed.edges {
+		assert.True(t, declared[e.rel], "undeclared relationship type %s", e.rel)
+		rels[e.rel]++
+	}
+	assert.Equal(t, map[string]int{
+		graphschema.RelOperates:      2,
+		graphschema.RelManages:       1,
+		graphschema.RelConnectedTo:   1,
+		graphschema.RelHasSkill:      2,
+		graphschema.RelRequiresSkill: 1,
+		graphschema.RelTaggedWith:    1,
+		graphschema.RelAuthored:      1,
+		graphschema.RelMemberOf:      1,
+		graphschema.RelOperatesIn:    1,
+		graphschema.RelAppliedTo:     1,
+	}, rels)
+	for _, e := range replayed.edges {
+		if e.rel == graphschema.RelMemberOf {
+			assert.Equal(t, "reviewer", e.props["role"])
+		}
+	}
+}
+
+func TestReplayWithEdgeEventsBeforeNodeEvents(t *testing.T) {
+	events, _ := fixtureHistory()
+
+	inOrder := newMemGraph()
+	replayHistory(t, &KnowledgeGraphBuilder{writer: inOrder, ctx: context.Background()}, events)
+
+	edgesFirst := newMemGraph()
+	kgb := &KnowledgeGraphBuilder{writer: edgesFirst, ctx: context.Background()}
+	for _, nodes := range []bool{false, true} {
+		for i, event := range events {
+			table, ok := graphschema.TableByName(event.table)
+			require.True(t, ok)
+			if (table.Node != nil) == nodes {
+				replayEvent(t, kgb, event, int64(i+1)*1000)
+			}
+		}
+	}
+
+	assert.Equal(t, inOrder.nodes, edgesFirst.nodes)
+	assert.Equal(t, inOrder.edges, edgesFirst.edges)
+}
+
+func TestReplayingEventsAgainIsIdempotent(t *testing.T) {
+	events, _ := fixtureHistory()
+
+	once := newMemGraph()
+	replayHistory(t, &KnowledgeGraphBuilder{writer: once, ctx: context.Background()}, events)
+
+	twice := newMemGraph()
+	kgb := &KnowledgeGraphBuilder{writer: twice, ctx: context.Background()}
+	replayHistory(t, kgb, events)
+	replayHistory(t, kgb, events)
+	assert.Equal(t, once.nodes, twice.nodes)
+	assert.Equal(t, once.edges, twice.edges)
+
+	replayEvent(t, kgb, events[3], 4000)
+	globex := twice.nodes[graphschema.NodeRef{Label: graphschema.LabelBusiness, ID: int64(11)}]
+	assert.Equal(t, fixtureTime.Add(time.Hour).UnixMilli(), globex["updatedAt"])
+	assert.Equal(t, once.edges, twice.edges)
+}
+
+func TestSnapshotHandoffSkipsCoveredEventsAndSweepsStaleEntries(t *testing.T) {
+	events, current := fixtureHistory()
+	const watermark = int64(1000000)
+
+	graph := newMemGraph()
+	kgb := &KnowledgeGraphBuilder{writer: graph, ctx: context.Background()}
+	departed := fixtureRow{"id": int64(99), "first_name": "Gone", "last_name": nil, "login_email": "gone@example.com", "active": int64(1), "email_verified": int64(0), "created_at": fixtureTime, "updated_at": fixtureTime}
+	replayEvent(t, kgb, changeEvent{"users", graphschema.OpCreate, nil, departed}, 500)
+	require.Contains(t, graph.nodes, graphschema.NodeRef{Label: graphschema.LabelUser, ID: int64(99)})
+
+	bulkLoad(t, graph, current, watermark)
+	require.NoError(t, kgb.completeSnapshot(graphschema.Tables, watermark))
+	assert.NotContains(t, graph.nodes, graphschema.NodeRef{Label: graphschema.LabelUser, ID: int64(99)})
+
+	snapshot := newMemGraph()
+	bulkLoad(t, snapshot, current, watermark)
+
+	replayHistory(t, kgb, events)
+	assert.Equal(t, snapshot.nodes, graph.nodes)
+	assert.Equal(t, snapshot.edges, graph.edges)
+
+	alice := current["users"][0]
+	replayEvent(t, kgb, changeEvent{"users", graphschema.OpUpdate, alice, withValues(alice, fixtureRow{"first_name": "Alicia"})}, watermark+1)
+	assert.Equal(t, "Alicia", graph.nodes[graphschema.NodeRef{Label: graphschema.LabelUser, ID: int64(1)}]["firstName"])
+}
+
+func TestMutationsRenderDeclaredSchema(t *testing.T) {
+	declared := map[string]bool{}
+	for _, name := range append(append([]string{}, graphschema.Labels...), graphschema.RelationshipTypes...) {
+		declared[name] = true
+	}
+	row := fixtureRow{"id": int64(1), "operator_user_id": int64(2), "managed_by_user_id": int64(2), "business_id": nil,
+		"initiating_business_id": int64(1), "receiving_business_id": int64(3), "connection_type": "Client",
+		"user_id": int64(2), "skill_id": int64(4), "project_id": int64(1), "region_id": "NSW"}
+	for _, table := range graphschema.Tables {
+		mutations := append(table.Mutations(graphschema.OpCreate, 1, nil, row), table.Mutations(graphschema.OpDelete, 2, row, nil)...)
+		mutations = append(mutations, table.Sweep(3))
+		require.NotEmpty(t, mutations, table.Name)
+		for _, m := range mutations {
+			query, params := m.Cypher()
+			require.NotEmpty(t, query)
+			for _, name := range schemaNames(query) {
+				assert.True(t, declared[name], "%s renders undeclared name %s: %s", table.Name, name, query)
+			}
+			for name := range params {
+				assert.Contains(t, query, "$"+name)
+			}
+		}
+	}
+}
+
+var schemaName = regexp.MustCompile(`[(\[]\w*:(\w+)`)
+
+func schemaNames(query string) []string {
+	var names []string
+	for _, match := range schemaName.FindAllStringSubmatch(query, -1) {
+		names = append(names, match[1])
+	}
+	return names
+}
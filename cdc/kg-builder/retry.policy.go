@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+)
+
+var errMalformedEvent = errors.New("malformed CDC event")
+
+type retryPolicy struct {
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+}
+
+var defaultRetryPolicy = retryPolicy{maxAttempts: 5, baseDelay: 500 * time.Millisecond, maxDelay: 30 * time.Second}
+
+func (p retryPolicy) delay(attempt int) time.Duration {
+	delay := p.baseDelay
+	for i := 1; i < attempt && delay < p.maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > p.maxDelay {
+		return p.maxDelay
+	}
+	return delay
+}
+
+func (p retryPolicy) run(ctx context.Context, fn func() error) (int, error) {
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || errors.Is(err, errMalformedEvent) || attempt >= p.maxAttempts {
+			return attempt, err
+		}
+		delay := p.delay(attempt)
+		log.Printf("Attempt %d/%d failed: %v. Retrying in %s", attempt, p.maxAttempts, err, delay)
+		select {
+		case <-ctx.Done():
+			return attempt, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
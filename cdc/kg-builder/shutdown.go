@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/Shopify/sarama"
+)
+
+const defaultShutdownTimeout = 30 * time.Second
+
+// watchShutdown restores default signal handling once shutdown starts, so a
+// second signal kills the process, and exits if cleanup overruns the timeout.
+func watchShutdown(ctx context.Context, stop context.CancelFunc, timeout time.Duration, exit func(int)) {
+	<-ctx.Done()
+	stop()
+	log.Printf("Shutdown requested, finishing in-flight messages (timeout %s)", timeout)
+	time.Sleep(timeout)
+	log.Printf("Shutdown did not finish within %s, exiting", timeout)
+	exit(1)
+}
+
+func (kgb *KnowledgeGraphBuilder) consume(ctx context.Context, topics []string) {
+	for ctx.Err() == nil {
+		err := kgb.consumer.Consume(ctx, topics, kgb)
+		if err == nil {
+			continue
+		}
+		if errors.Is(err, sarama.ErrClosedConsumerGroup) {
+			return
+		}
+		log.Printf("Error from consumer: %v", err)
+		select {
+		case <-ctx.Done():
+		case <-time.After(kgb.retry.maxDelay):
+		}
+	}
+}
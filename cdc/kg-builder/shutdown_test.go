@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/cdc/graphschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSession struct {
+	sarama.ConsumerGroupSession
+	ctx       context.Context
+	marked    []int64
+	committed bool
+}
+
+func (s *fakeSession) Context() context.Context { return s.ctx }
+
+func (s *fakeSession) MarkMessage(message *sarama.ConsumerMessage, metadata string) {
+	s.marked = append(s.marked, message.Offset)
+}
+
+func (s *fakeSession) Commit() { s.committed = true }
+
+type fakeClaim struct {
+	sarama.ConsumerGroupClaim
+	messages chan *sarama.ConsumerMessage
+}
+
+func (c *fakeClaim) Messages() <-chan *sarama.ConsumerMessage { return c.messages }
+
+func (c *fakeClaim) HighWaterMarkOffset() int64 { return int64(len(c.messages)) + 1 }
+
+type blockingWriter struct {
+	started chan struct{}
+	release chan struct{}
+	calls   int
+}
+
+func (w *blockingWriter) Apply(ctx context.Context, mutations []graphschema.Mutation) error {
+	w.calls++
+	w.started <- struct{}{}
+	<-w.release
+	return nil
+}
+
+func (w *blockingWriter) ApplyBatch(ctx context.Context, batch graphschema.Batch) error {
+	return w.Apply(ctx, batch.Mutations())
+}
+
+type fakeGroup struct {
+	sarama.ConsumerGroup
+	consumes int
+	entered  chan struct{}
+	err      error
+}
+
+func (g *fakeGroup) Consume(ctx context.Context, topics []string, handler sarama.ConsumerGroupHandler) error {
+	g.consumes++
+	if g.err != nil {
+		return g.err
+	}
+	g.entered <- struct{}{}
+	<-ctx.Done()
+	return nil
+}
+
+func TestConsumeClaimFinishesInFlightMessageOnShutdown(t *testing.T) {
+	writer := &blockingWriter{started: make(chan struct{}), release: make(chan struct{})}
+	kgb := newDeliveryBuilder(writer, &fakeDeadLetters{})
+	ctx, cancel := context.WithCancel(context.Background())
+	session := &fakeSession{ctx: ctx}
+	claim := &fakeClaim{messages: make(chan *sarama.ConsumerMessage, 2)}
+	claim.messages <- &sarama.ConsumerMessage{Topic: "tia-db.tia-dev.users", Offset: 7, Value: userCreated}
+	claim.messages <- &sarama.ConsumerMessage{Topic: "tia-db.tia-dev.users", Offset: 8, Value: userCreated}
+
+	done := make(chan error)
+	go func() { done <- kgb.ConsumeClaim(session, claim) }()
+	<-writer.started
+	cancel()
+	close(writer.release)
+
+	require.NoError(t, <-done)
+	assert.Equal(t, []int64{7}, session.marked)
+	assert.Equal(t, 1, writer.calls)
+
+	require.NoError(t, kgb.Cleanup(session))
+	assert.True(t, session.committed)
+}
+
+func TestConsumeStopsWhenContextIsCancelled(t *testing.T) {
+	group := &fakeGroup{entered: make(chan struct{})}
+	kgb := &KnowledgeGraphBuilder{consumer: group, retry: testRetryPolicy}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan struct{})
+	go func() {
+		kgb.consume(ctx, []string{"tia-db.tia-dev.users"})
+		close(done)
+	}()
+	<-group.entered
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("consume did not return after cancellation")
+	}
+	assert.Equal(t, 1, group.consumes)
+
+	group.err = sarama.ErrClosedConsumerGroup
+	kgb.consume(context.Background(), []string{"tia-db.tia-dev.users"})
+	assert.Equal(t, 2, group.consumes)
+}
+
+func TestWatchShutdownExitsAfterTimeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := false
+	exited := make(chan int, 1)
+	cancel()
+
+	watchShutdown(ctx, func() { stopped = true }, time.Millisecond, func(code int) { exited <- code })
+	assert.True(t, stopped)
+	assert.Equal(t, 1, <-exited)
+}
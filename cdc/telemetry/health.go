@@ -0,0 +1,63 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const checkTimeout = 2 * time.Second
+
+type Check func(ctx context.Context) error
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func Healthz(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func Readyz(checks map[string]Check) http.HandlerFunc {
+	names := make([]string, 0, len(checks))
+	for name := range checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		status := http.StatusOK
+		results := make(map[string]string, len(checks))
+		for _, name := range names {
+			ctx, cancel := context.WithTimeout(r.Context(), checkTimeout)
+			err := checks[name](ctx)
+			cancel()
+			if err != nil {
+				status = http.StatusServiceUnavailable
+				results[name] = err.Error()
+				continue
+			}
+			results[name] = "ok"
+		}
+		state := "ok"
+		if status != http.StatusOK {
+			state = "unavailable"
+		}
+		writeJSON(w, status, map[string]interface{}{"status": state, "checks": results})
+	}
+}
+
+func Handler(gatherer prometheus.Gatherer, checks map[string]Check) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", Healthz)
+	mux.Handle("/readyz", Readyz(checks))
+	mux.Handle("/metrics", promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{}))
+	return mux
+}
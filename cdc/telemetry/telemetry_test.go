@@ -0,0 +1,52 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsIncludeRuntimeCollectors(t *testing.T) {
+	registry := NewRegistry()
+	processed := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "messages_total", Help: "Messages processed."}, []string{"table", "op"})
+	registry.MustRegister(processed)
+	processed.WithLabelValues("users", "c").Add(3)
+
+	recorder := httptest.NewRecorder()
+	Handler(registry, nil).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	body := recorder.Body.String()
+	assert.Contains(t, body, `messages_total{op="c",table="users"} 3`)
+	assert.Contains(t, body, "go_goroutines")
+	assert.Contains(t, body, "go_memstats_alloc_bytes")
+	assert.Contains(t, body, "process_open_fds")
+}
+
+func TestReadyzReportsFailingChecks(t *testing.T) {
+	handler := Handler(NewRegistry(), map[string]Check{
+		"memgraph": func(ctx context.Context) error { return nil },
+		"kafka":    func(ctx context.Context) error { return errors.New("not a member of the consumer group") },
+	})
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	var body struct {
+		Status string            `json:"status"`
+		Checks map[string]string `json:"checks"`
+	}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+	assert.Equal(t, "unavailable", body.Status)
+	assert.Equal(t, map[string]string{"memgraph": "ok", "kafka": "not a member of the consumer group"}, body.Checks)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+}
@@ -1,7 +1,14 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/TIA-PARTNERS-GROUP/tia-api/configs"
 	_ "github.com/TIA-PARTNERS-GROUP/tia-api/docs"
@@ -11,6 +18,9 @@ import (
 	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/constants"
 	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/core/services"
 	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/models"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/pkg/geo"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/pkg/storage"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/pkg/verification"
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -45,12 +55,22 @@ func main() {
 		&models.Project{},
 		&models.Skill{},
 		&models.Publication{},
+		&models.PublicationRevision{},
+		&models.PublicationSlugHistory{},
+		&models.PublicationComment{},
+		&models.PublicationReaction{},
 		&models.Notification{},
 		&models.UserSkill{},
 		&models.ProjectSkill{},
 		&models.ProjectMember{},
 		&models.BusinessConnection{},
+		&models.BusinessConnectionHistory{},
+		&models.IntroductionRequest{},
+		&models.Referral{},
+		&models.BusinessVerification{},
 		&models.BusinessTag{},
+		&models.BusinessMember{},
+		&models.BusinessInvite{},
 		&models.Feedback{},
 		&models.ProjectApplicant{},
 		&models.DailyActivity{},
@@ -64,6 +84,8 @@ func main() {
 		&models.Region{},
 		&models.ProjectRegion{},
 		&models.InferredConnection{},
+		&models.Media{},
+		&models.MediaVariant{},
 	)
 	if err != nil {
 		log.Fatalf("Failed to migrate database: %v", err)
@@ -71,9 +93,43 @@ func main() {
 	log.Println("Database migration successful.")
 	userService := services.NewUserService(db)
 	authService := services.NewAuthService(db)
-	businessService := services.NewBusinessService(db)
+	postcodeGeocoder := geo.NewAUPostcodeGeocoder()
+	if config.GeoPostcodeFile != "" {
+		postcodeGeocoder, err = geo.LoadAUPostcodeGeocoder(config.GeoPostcodeFile)
+		if err != nil {
+			log.Fatalf("Failed to load postcode centroids: %v", err)
+		}
+	} else {
+		log.Printf("GEO_POSTCODE_FILE is not set; using the built-in sample of %d postcodes. Set it to a full AU postcode centroid CSV so every business can be located.", postcodeGeocoder.Postcodes())
+	}
+	var geocoder geo.Geocoder = postcodeGeocoder
+	businessService := services.NewBusinessService(db, geocoder)
 	businessConnectionService := services.NewBusinessConnectionService(db)
 	businessTagService := services.NewBusinessTagService(db)
+	businessMemberService := services.NewBusinessMemberService(db)
+	if err := businessMemberService.EnsureOperatorMemberships(context.Background()); err != nil {
+		log.Fatalf("Failed to backfill business owner memberships: %v", err)
+	}
+	merged, err := businessConnectionService.MergeDuplicateConnections(context.Background())
+	if err != nil {
+		log.Fatalf("Failed to merge duplicate business connections: %v", err)
+	}
+	if merged > 0 {
+		log.Printf("Merged %d duplicate business connection(s)", merged)
+	}
+	located, unlocated, err := businessService.GeocodeMissingBusinesses(context.Background())
+	if err != nil {
+		log.Fatalf("Failed to backfill business coordinates: %v", err)
+	}
+	if located > 0 {
+		log.Printf("Geocoded %d existing business(es)", located)
+	}
+	if unlocated > 0 {
+		log.Printf("Could not locate %d business(es) from their postcode; they are excluded from near searches", unlocated)
+	}
+	introductionRequestService := services.NewIntroductionRequestService(db)
+	referralService := services.NewReferralService(db)
+	businessVerificationService := services.NewBusinessVerificationService(db, verification.NewNetResolver(config.VerificationLookupTimeout))
 	dailyActivityService := services.NewDailyActivityService(db)
 	dailyActivityEnrolmentService := services.NewDailyActivityEnrolmentService(db)
 	eventService := services.NewEventService(db)
@@ -87,17 +143,47 @@ func main() {
 	projectRegionService := services.NewProjectRegionService(db)
 	projectSkillService := services.NewProjectSkillService(db)
 	publicationService := services.NewPublicationService(db)
+	renderedPublications, err := publicationService.RenderMissingContent(context.Background())
+	if err != nil {
+		log.Fatalf("Failed to backfill rendered publication content: %v", err)
+	}
+	if renderedPublications > 0 {
+		log.Printf("Rendered content for %d existing publication(s)", renderedPublications)
+	}
+	publicationCommentService := services.NewPublicationCommentService(db)
+	publicationReactionService := services.NewPublicationReactionService(db)
+	publicationScheduler := services.NewPublicationScheduler(publicationService, config.PublicationSchedulerInterval)
 	skillService := services.NewSkillService(db)
 	subscriptionService := services.NewSubscriptionService(db)
 	userSubscriptionService := services.NewUserSubscriptionService(db)
 	userConfigService := services.NewUserConfigService(db)
 	userSkillService := services.NewUserSkillService(db)
+	var mediaStorage storage.Storage
+	if config.MediaStorageDriver == "s3" {
+		mediaStorage, err = storage.NewS3Storage(storage.S3Config{
+			Endpoint:  config.MediaS3Endpoint,
+			Bucket:    config.MediaS3Bucket,
+			Region:    config.MediaS3Region,
+			AccessKey: config.MediaS3AccessKey,
+			SecretKey: config.MediaS3SecretKey,
+		}, nil)
+	} else {
+		mediaStorage, err = storage.NewLocalStorage(config.MediaLocalDir)
+	}
+	if err != nil {
+		log.Fatalf("Failed to initialise media storage: %v", err)
+	}
+	mediaService := services.NewMediaService(db, mediaStorage, config.MediaMaxUploadBytes)
 
 	userHandler := handlers.NewUserHandler(userService, &constants.AppRoutes)
 	authHandler := handlers.NewAuthHandler(authService, &constants.AppRoutes)
 	businessHandler := handlers.NewBusinessHandler(businessService, &constants.AppRoutes)
-	businessConnectionHandler := handlers.NewBusinessConnectionHandler(businessConnectionService, &constants.AppRoutes)
-	businessTagHandler := handlers.NewBusinessTagHandler(businessTagService, &constants.AppRoutes)
+	businessConnectionHandler := handlers.NewBusinessConnectionHandler(businessConnectionService, businessMemberService, &constants.AppRoutes)
+	businessTagHandler := handlers.NewBusinessTagHandler(businessTagService, businessMemberService, &constants.AppRoutes)
+	businessMemberHandler := handlers.NewBusinessMemberHandler(businessMemberService, &constants.AppRoutes)
+	introductionRequestHandler := handlers.NewIntroductionRequestHandler(introductionRequestService, &constants.AppRoutes)
+	referralHandler := handlers.NewReferralHandler(referralService, &constants.AppRoutes)
+	businessVerificationHandler := handlers.NewBusinessVerificationHandler(businessVerificationService, &constants.AppRoutes)
 	dailyActivityHandler := handlers.NewDailyActivityHandler(dailyActivityService, &constants.AppRoutes)
 	dailyActivityEnrolmentHandler := handlers.NewDailyActivityEnrolmentHandler(dailyActivityEnrolmentService, &constants.AppRoutes)
 	eventHandler := handlers.NewEventHandler(eventService, &constants.AppRoutes)
@@ -111,23 +197,33 @@ func main() {
 	projectRegionHandler := handlers.NewProjectRegionHandler(projectRegionService, projectService, &constants.AppRoutes)
 	projectSkillHandler := handlers.NewProjectSkillHandler(projectSkillService, projectService, &constants.AppRoutes)
 	publicationHandler := handlers.NewPublicationHandler(publicationService, &constants.AppRoutes)
+	publicationCommentHandler := handlers.NewPublicationCommentHandler(publicationCommentService, publicationService, &constants.AppRoutes)
+	publicationReactionHandler := handlers.NewPublicationReactionHandler(publicationReactionService, publicationService, &constants.AppRoutes)
+	publicationFeedHandler := handlers.NewPublicationFeedHandler(publicationService, config.FeedSiteURL, config.FeedCacheMaxAge, &constants.AppRoutes)
 	skillHandler := handlers.NewSkillHandler(skillService, &constants.AppRoutes)
 	subscriptionHandler := handlers.NewSubscriptionHandler(subscriptionService, &constants.AppRoutes)
 	userSubscriptionHandler := handlers.NewUserSubscriptionHandler(userSubscriptionService, &constants.AppRoutes)
 	userConfigHandler := handlers.NewUserConfigHandler(userConfigService, &constants.AppRoutes)
 	userSkillHandler := handlers.NewUserSkillHandler(userSkillService, &constants.AppRoutes)
 	connectionHandler := handlers.NewConnectionHandler(&constants.AppRoutes)
+	mediaHandler := handlers.NewMediaHandler(mediaService, &constants.AppRoutes)
 
 	authMiddleware := middleware.AuthMiddleware(authService, &constants.AppRoutes)
+	optionalAuthMiddleware := middleware.OptionalAuthMiddleware(authService, &constants.AppRoutes)
 
 	deps := &routes.RouterDependencies{
 		AuthMiddleware:                authMiddleware,
+		OptionalAuthMiddleware:        optionalAuthMiddleware,
 		UserHandler:                   userHandler,
 		AuthHandler:                   authHandler,
 		BusinessHandler:               businessHandler,
 		ProjectHandler:                projectHandler,
 		BusinessConnectionHandler:     businessConnectionHandler,
 		BusinessTagHandler:            businessTagHandler,
+		BusinessMemberHandler:         businessMemberHandler,
+		IntroductionRequestHandler:    introductionRequestHandler,
+		ReferralHandler:               referralHandler,
+		BusinessVerificationHandler:   businessVerificationHandler,
 		DailyActivityHandler:          dailyActivityHandler,
 		DailyActivityEnrolmentHandler: dailyActivityEnrolmentHandler,
 		EventHandler:                  eventHandler,
@@ -140,6 +236,10 @@ func main() {
 		ProjectRegionHandler:          projectRegionHandler,
 		ProjectSkillHandler:           projectSkillHandler,
 		PublicationHandler:            publicationHandler,
+		PublicationCommentHandler:     publicationCommentHandler,
+		PublicationReactionHandler:    publicationReactionHandler,
+		PublicationFeedHandler:        publicationFeedHandler,
+		MediaHandler:                  mediaHandler,
 		SkillHandler:                  skillHandler,
 		SubscriptionHandler:           subscriptionHandler,
 		UserSubscriptionHandler:       userSubscriptionHandler,
@@ -148,13 +248,25 @@ func main() {
 		ConnectionHandler:             connectionHandler,
 		Routes:                        constants.AppRoutes,
 	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go publicationScheduler.Run(ctx)
 	router := gin.Default()
 
 	routes.RegisterRoutes(router, deps)
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	server := &http.Server{Addr: ":8080", Handler: router}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Printf("Server shutdown failed: %v", err)
+		}
+	}()
 	log.Println("Starting server on http:")
 	log.Println("Swagger UI available on http:")
-	if err := router.Run(":8080"); err != nil {
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 }
@@ -2,10 +2,26 @@ package configs
 import (
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 	"github.com/joho/godotenv"
 )
 type Config struct {
-	DatabaseURL string
+	DatabaseURL                  string
+	PublicationSchedulerInterval time.Duration
+	FeedSiteURL                  string
+	FeedCacheMaxAge              time.Duration
+	MediaStorageDriver           string
+	MediaLocalDir                string
+	MediaMaxUploadBytes          int64
+	MediaS3Endpoint              string
+	MediaS3Bucket                string
+	MediaS3Region                string
+	MediaS3AccessKey             string
+	MediaS3SecretKey             string
+	VerificationLookupTimeout    time.Duration
+	GeoPostcodeFile              string
 }
 func LoadConfig() *Config {
 	if err := godotenv.Load(); err != nil {
@@ -15,7 +31,72 @@ func LoadConfig() *Config {
 	if dbURL == "" {
 		log.Fatal("DATABASE_URL environment variable is required")
 	}
+	schedulerInterval := time.Minute
+	if raw := os.Getenv("PUBLICATION_SCHEDULER_INTERVAL"); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil || parsed <= 0 {
+			log.Fatalf("Invalid PUBLICATION_SCHEDULER_INTERVAL %q", raw)
+		}
+		schedulerInterval = parsed
+	}
+	feedSiteURL := strings.TrimRight(os.Getenv("FEED_SITE_URL"), "/")
+	if feedSiteURL == "" {
+		feedSiteURL = "http://localhost:8080"
+	}
+	feedCacheMaxAge := 5 * time.Minute
+	if raw := os.Getenv("FEED_CACHE_MAX_AGE"); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil || parsed < 0 {
+			log.Fatalf("Invalid FEED_CACHE_MAX_AGE %q", raw)
+		}
+		feedCacheMaxAge = parsed
+	}
+	mediaStorageDriver := os.Getenv("MEDIA_STORAGE_DRIVER")
+	if mediaStorageDriver == "" {
+		mediaStorageDriver = "local"
+	}
+	if mediaStorageDriver != "local" && mediaStorageDriver != "s3" {
+		log.Fatalf("Invalid MEDIA_STORAGE_DRIVER %q", mediaStorageDriver)
+	}
+	mediaLocalDir := os.Getenv("MEDIA_LOCAL_DIR")
+	if mediaLocalDir == "" {
+		mediaLocalDir = "./uploads"
+	}
+	var mediaMaxUploadBytes int64 = 10 << 20
+	if raw := os.Getenv("MEDIA_MAX_UPLOAD_BYTES"); raw != "" {
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || parsed <= 0 {
+			log.Fatalf("Invalid MEDIA_MAX_UPLOAD_BYTES %q", raw)
+		}
+		mediaMaxUploadBytes = parsed
+	}
+	mediaS3Endpoint := os.Getenv("MEDIA_S3_ENDPOINT")
+	mediaS3Bucket := os.Getenv("MEDIA_S3_BUCKET")
+	if mediaStorageDriver == "s3" && (mediaS3Endpoint == "" || mediaS3Bucket == "") {
+		log.Fatal("MEDIA_S3_ENDPOINT and MEDIA_S3_BUCKET are required when MEDIA_STORAGE_DRIVER is s3")
+	}
+	verificationLookupTimeout := 10 * time.Second
+	if raw := os.Getenv("VERIFICATION_LOOKUP_TIMEOUT"); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil || parsed <= 0 {
+			log.Fatalf("Invalid VERIFICATION_LOOKUP_TIMEOUT %q", raw)
+		}
+		verificationLookupTimeout = parsed
+	}
 	return &Config{
-		DatabaseURL: dbURL,
+		DatabaseURL:                  dbURL,
+		PublicationSchedulerInterval: schedulerInterval,
+		FeedSiteURL:                  feedSiteURL,
+		FeedCacheMaxAge:              feedCacheMaxAge,
+		MediaStorageDriver:           mediaStorageDriver,
+		MediaLocalDir:                mediaLocalDir,
+		MediaMaxUploadBytes:          mediaMaxUploadBytes,
+		MediaS3Endpoint:              mediaS3Endpoint,
+		MediaS3Bucket:                mediaS3Bucket,
+		MediaS3Region:                os.Getenv("MEDIA_S3_REGION"),
+		MediaS3AccessKey:             os.Getenv("MEDIA_S3_ACCESS_KEY"),
+		MediaS3SecretKey:             os.Getenv("MEDIA_S3_SECRET_KEY"),
+		VerificationLookupTimeout:    verificationLookupTimeout,
+		GeoPostcodeFile:              os.Getenv("GEO_POSTCODE_FILE"),
 	}
 }
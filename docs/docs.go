@@ -1134,6 +1134,13 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "409": {
+                        "description": "ErrBusinessConnectionAlreadyExists",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -1149,7 +1156,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Deletes a specific connection record. Restricted to the initiating user or the receiving business's operator.",
+                "description": "Deletes a specific connection record. A pending request can only be withdrawn by admins of the initiating business; other connections can be removed by admins of either business. Rejected connections cannot be deleted, and the status history is kept.",
                 "produces": [
                     "application/json"
                 ],
@@ -1198,6 +1205,13 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "409": {
+                        "description": "ErrRejectedConnectionNotDeletable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -8319,19 +8333,6 @@ const docTemplate = `{
                 },
                 "notes": {
                     "type": "string"
-                },
-                "status": {
-                    "enum": [
-                        "pending",
-                        "active",
-                        "rejected",
-                        "inactive"
-                    ],
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/models.BusinessConnectionStatus"
-                        }
-                    ]
                 }
             }
         },
@@ -8,6 +8,7 @@ import (
 	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/constants"
 	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/core/services"
 	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/ports"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/pkg/geo"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 )
@@ -78,7 +79,7 @@ func (h *BusinessHandler) CreateBusiness(c *gin.Context) {
 }
 
 // @Summary Get Business by ID
-// @Description Retrieves a business profile by its unique ID.
+// @Description Retrieves a business profile by its unique ID, including its profile completeness score and the onboarding checklist items still missing.
 // @Tags businesses
 // @Produce json
 // @Param id path int true "Business ID"
@@ -104,7 +105,14 @@ func (h *BusinessHandler) GetBusinessByID(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve business"})
 		return
 	}
-	c.JSON(http.StatusOK, ports.MapBusinessToResponse(business))
+	completeness, err := h.businessService.GetBusinessCompleteness(c.Request.Context(), business)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve business"})
+		return
+	}
+	resp := ports.MapBusinessToResponse(business)
+	resp.Completeness = completeness
+	c.JSON(http.StatusOK, resp)
 }
 
 // @Summary List All Businesses
@@ -115,9 +123,12 @@ func (h *BusinessHandler) GetBusinessByID(c *gin.Context) {
 // @Param business_type query string false "Filter by business type"
 // @Param business_category query string false "Filter by business category"
 // @Param business_phase query string false "Filter by business phase"
+// @Param verified query bool false "Filter by verified status"
 // @Param search query string false "Search by name or description"
+// @Param near query string false "Only return businesses within radius_km of this point, nearest first (lat,lng)"
+// @Param radius_km query number false "Search radius in kilometres for near (default 25, max 500)"
 // @Success 200 {array} ports.BusinessResponse "List of businesses"
-// @Failure 400 {object} map[string]interface{} "Invalid query parameters"
+// @Failure 400 {object} map[string]interface{} "Invalid query parameters, ErrInvalidNearQuery or ErrInvalidRadius"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /businesses [get]
 func (h *BusinessHandler) GetBusinesses(c *gin.Context) {
@@ -126,6 +137,12 @@ func (h *BusinessHandler) GetBusinesses(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
 		return
 	}
+	origin, _, err := ports.NearOrigin(filters)
+	var apiErr *ports.ApiError
+	if errors.As(err, &apiErr) {
+		c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+		return
+	}
 	businesses, err := h.businessService.GetBusinesses(c.Request.Context(), filters)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve businesses"})
@@ -134,6 +151,10 @@ func (h *BusinessHandler) GetBusinesses(c *gin.Context) {
 	businessResponses := make([]ports.BusinessResponse, len(businesses))
 	for i, biz := range businesses {
 		businessResponses[i] = ports.MapBusinessToResponse(&biz)
+		if origin != nil && biz.Latitude != nil && biz.Longitude != nil {
+			distance := geo.HaversineKm(*origin, geo.Point{Lat: *biz.Latitude, Lng: *biz.Longitude})
+			businessResponses[i].DistanceKm = &distance
+		}
 	}
 	c.JSON(http.StatusOK, businessResponses)
 }
@@ -176,6 +197,10 @@ func (h *BusinessHandler) UpdateBusiness(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body: " + err.Error()})
 		return
 	}
+	if err := h.validate.Struct(input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	business, err := h.businessService.UpdateBusiness(c.Request.Context(), uint(id), authUserID, input)
 	if err != nil {
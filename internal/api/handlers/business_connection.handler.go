@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"strconv"
@@ -14,21 +15,46 @@ import (
 )
 
 type BusinessConnectionHandler struct {
-	service  *services.BusinessConnectionService
-	validate *validator.Validate
-	routes   *constants.Routes
+	service       *services.BusinessConnectionService
+	memberService *services.BusinessMemberService
+	validate      *validator.Validate
+	routes        *constants.Routes
 }
 
-func NewBusinessConnectionHandler(service *services.BusinessConnectionService, routes *constants.Routes) *BusinessConnectionHandler {
+func NewBusinessConnectionHandler(service *services.BusinessConnectionService, memberService *services.BusinessMemberService, routes *constants.Routes) *BusinessConnectionHandler {
 	return &BusinessConnectionHandler{
-		service:  service,
-		validate: validator.New(),
-		routes:   routes,
+		service:       service,
+		memberService: memberService,
+		validate:      validator.New(),
+		routes:        routes,
 	}
 }
 
+func (h *BusinessConnectionHandler) checkBusinessAdmin(c *gin.Context, businessID, authUserID uint) *ports.ApiError {
+	err := h.memberService.AuthorizeBusinessRole(c.Request.Context(), businessID, authUserID, models.BusinessRoleAdmin)
+	if err == nil {
+		return nil
+	}
+	var apiErr *ports.ApiError
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+	return ports.ErrDatabase
+}
+
+func (h *BusinessConnectionHandler) authorizeConnection(c *gin.Context, connectionID, authUserID uint, action ports.BusinessConnectionAction) *ports.ApiError {
+	if _, err := h.service.AuthorizeBusinessConnectionAction(c.Request.Context(), connectionID, authUserID, action); err != nil {
+		var apiErr *ports.ApiError
+		if errors.As(err, &apiErr) {
+			return apiErr
+		}
+		return ports.ErrDatabase
+	}
+	return nil
+}
+
 // @Summary Initiate Business Connection Request
-// @Description Creates a new connection request between two businesses. The initiating user is taken from the auth context.
+// @Description Creates a new connection request between two businesses. The initiating user is taken from the auth context and must be an admin of the initiating business. A request in either direction counts as the same relationship: an existing pending or active one is a conflict, a withdrawn or inactive one is re-opened, and a rejected one can only be requested again by the same initiating business after a 30-day cooldown.
 // @Tags business_connections
 // @Accept json
 // @Produce json
@@ -37,7 +63,8 @@ func NewBusinessConnectionHandler(service *services.BusinessConnectionService, r
 // @Success 201 {object} ports.BusinessConnectionResponse "Connection request created successfully"
 // @Failure 400 {object} map[string]interface{} "Invalid request body, validation failed, or ErrCannotConnectToSelf"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
-// @Failure 409 {object} map[string]interface{} "ErrBusinessConnectionAlreadyExists"
+// @Failure 403 {object} map[string]interface{} "Forbidden (Not an admin of the initiating business)"
+// @Failure 409 {object} map[string]interface{} "ErrBusinessConnectionAlreadyExists or ErrConnectionCooldown"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /connections [post]
 func (h *BusinessConnectionHandler) CreateBusinessConnection(c *gin.Context) {
@@ -58,6 +85,10 @@ func (h *BusinessConnectionHandler) CreateBusinessConnection(c *gin.Context) {
 		return
 	}
 	input.InitiatedByUserID = userID
+	if apiErr := h.checkBusinessAdmin(c, input.InitiatingBusinessID, userID); apiErr != nil {
+		c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+		return
+	}
 	connection, err := h.service.CreateBusinessConnection(c.Request.Context(), input)
 	if err != nil {
 		var apiErr *ports.ApiError
@@ -118,7 +149,7 @@ func (h *BusinessConnectionHandler) GetBusinessConnection(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Business ID"
-// @Param status query string false "Filter by connection status (pending, active, rejected, inactive)"
+// @Param status query string false "Filter by connection status (pending, active, rejected, inactive, withdrawn)"
 // @Param type query string false "Filter by connection type (Partnership, Client, Supplier, etc.)"
 // @Success 200 {object} ports.BusinessConnectionsResponse "List of connections"
 // @Failure 400 {object} map[string]interface{} "Invalid business ID or query parameters"
@@ -160,8 +191,87 @@ func (h *BusinessConnectionHandler) GetBusinessConnections(c *gin.Context) {
 	c.JSON(http.StatusOK, ports.MapToBusinessConnectionsResponse(connections))
 }
 
+// @Summary Get Business Connection Network
+// @Description Returns the network around a business as nodes (businesses) and edges (active connections) up to the requested number of hops. Each node carries its hop distance, the number of mutual connections it shares with the business, and the business IDs it is connected via.
+// @Tags business_connections
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Business ID"
+// @Param depth query int false "Number of hops to traverse (1-3, default 2)"
+// @Success 200 {object} ports.BusinessNetworkResponse "Business network graph"
+// @Failure 400 {object} map[string]interface{} "Invalid business ID or ErrInvalidNetworkDepth"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "ErrBusinessNotFound"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /businesses/{id}/network [get]
+func (h *BusinessConnectionHandler) GetBusinessNetwork(c *gin.Context) {
+	businessID, err := strconv.ParseUint(c.Param(h.routes.ParamKeyID), 10, 32)
+	if err != nil || businessID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid business ID"})
+		return
+	}
+	depth := 2
+	if depthStr := c.Query("depth"); depthStr != "" {
+		depth, err = strconv.Atoi(depthStr)
+		if err != nil {
+			c.JSON(ports.ErrInvalidNetworkDepth.StatusCode, gin.H{"error": ports.ErrInvalidNetworkDepth.Message})
+			return
+		}
+	}
+
+	network, err := h.service.GetBusinessNetwork(c.Request.Context(), uint(businessID), depth)
+	if err != nil {
+		var apiErr *ports.ApiError
+		if errors.As(err, &apiErr) {
+			c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve business network"})
+		return
+	}
+	c.JSON(http.StatusOK, network)
+}
+
+// @Summary List Mutual Connections
+// @Description Lists businesses that have an active connection with both the given business and the other business. Useful for finding an introducer before requesting a warm introduction.
+// @Tags business_connections
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Business ID"
+// @Param otherID path int true "Other Business ID"
+// @Success 200 {object} ports.MutualConnectionsResponse "Mutual connections"
+// @Failure 400 {object} map[string]interface{} "Invalid business ID or ErrCannotConnectToSelf"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "ErrBusinessNotFound"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /businesses/{id}/mutual-connections/{otherID} [get]
+func (h *BusinessConnectionHandler) GetMutualConnections(c *gin.Context) {
+	businessID, err := strconv.ParseUint(c.Param(h.routes.ParamKeyID), 10, 32)
+	if err != nil || businessID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid business ID"})
+		return
+	}
+	otherBusinessID, err := strconv.ParseUint(c.Param(h.routes.ParamKeyOtherID), 10, 32)
+	if err != nil || otherBusinessID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid other business ID"})
+		return
+	}
+
+	businesses, err := h.service.GetMutualConnections(c.Request.Context(), uint(businessID), uint(otherBusinessID))
+	if err != nil {
+		var apiErr *ports.ApiError
+		if errors.As(err, &apiErr) {
+			c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve mutual connections"})
+		return
+	}
+	c.JSON(http.StatusOK, ports.MapToMutualConnectionsResponse(uint(businessID), uint(otherBusinessID), businesses))
+}
+
 // @Summary Update Business Connection Details
-// @Description Updates modifiable fields of an existing connection (e.g., Notes, Type). This is typically restricted to the initiating user.
+// @Description Updates modifiable fields of an existing connection (e.g., Notes, Type). Restricted to admins of either business in the connection. Status changes go through the accept, reject, withdraw, deactivate and reactivate endpoints.
 // @Tags business_connections
 // @Accept json
 // @Produce json
@@ -171,8 +281,9 @@ func (h *BusinessConnectionHandler) GetBusinessConnections(c *gin.Context) {
 // @Success 200 {object} ports.BusinessConnectionResponse "Connection updated successfully"
 // @Failure 400 {object} map[string]interface{} "Invalid request body or connection ID"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
-// @Failure 403 {object} map[string]interface{} "Forbidden (Not authorized to update)"
+// @Failure 403 {object} map[string]interface{} "ErrNotConnectionParticipant or ErrNotConnectionReceiver"
 // @Failure 404 {object} map[string]interface{} "ErrBusinessConnectionNotFound"
+// @Failure 409 {object} map[string]interface{} "ErrBusinessConnectionAlreadyExists"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /connections/{id} [put]
 func (h *BusinessConnectionHandler) UpdateBusinessConnection(c *gin.Context) {
@@ -199,6 +310,10 @@ func (h *BusinessConnectionHandler) UpdateBusinessConnection(c *gin.Context) {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authentication context"})
 		return
 	}
+	if apiErr := h.authorizeConnection(c, uint(id), authUserID, ports.ConnectionActionUpdate); apiErr != nil {
+		c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+		return
+	}
 	connection, err := h.service.UpdateBusinessConnection(c.Request.Context(), uint(id), input)
 	if err != nil {
 		var apiErr *ports.ApiError
@@ -213,7 +328,7 @@ func (h *BusinessConnectionHandler) UpdateBusinessConnection(c *gin.Context) {
 }
 
 // @Summary Accept Pending Connection
-// @Description Updates the status of a specific connection request to 'active'. Restricted to the receiving business's operator.
+// @Description Updates the status of a specific connection request to 'active'. Restricted to admins of the receiving business.
 // @Tags business_connections
 // @Produce json
 // @Security BearerAuth
@@ -221,7 +336,7 @@ func (h *BusinessConnectionHandler) UpdateBusinessConnection(c *gin.Context) {
 // @Success 200 {object} ports.BusinessConnectionResponse "Connection successfully accepted and set to active"
 // @Failure 400 {object} map[string]interface{} "Invalid connection ID or ErrConnectionNotPending"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
-// @Failure 403 {object} map[string]interface{} "Forbidden (Not the receiving business's operator)"
+// @Failure 403 {object} map[string]interface{} "ErrNotConnectionReceiver"
 // @Failure 404 {object} map[string]interface{} "ErrBusinessConnectionNotFound"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /connections/{id}/accept [patch]
@@ -240,7 +355,11 @@ func (h *BusinessConnectionHandler) AcceptBusinessConnection(c *gin.Context) {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authentication context"})
 		return
 	}
-	connection, err := h.service.AcceptBusinessConnection(c.Request.Context(), uint(id))
+	if apiErr := h.authorizeConnection(c, uint(id), authUserID, ports.ConnectionActionRespond); apiErr != nil {
+		c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+		return
+	}
+	connection, err := h.service.AcceptBusinessConnection(c.Request.Context(), uint(id), authUserID)
 	if err != nil {
 		var apiErr *ports.ApiError
 		if errors.As(err, &apiErr) {
@@ -254,7 +373,7 @@ func (h *BusinessConnectionHandler) AcceptBusinessConnection(c *gin.Context) {
 }
 
 // @Summary Reject Pending Connection
-// @Description Updates the status of a specific connection request to 'rejected'. Restricted to the receiving business's operator.
+// @Description Updates the status of a specific connection request to 'rejected'. Restricted to admins of the receiving business.
 // @Tags business_connections
 // @Produce json
 // @Security BearerAuth
@@ -262,7 +381,7 @@ func (h *BusinessConnectionHandler) AcceptBusinessConnection(c *gin.Context) {
 // @Success 200 {object} ports.BusinessConnectionResponse "Connection successfully rejected"
 // @Failure 400 {object} map[string]interface{} "Invalid connection ID or ErrConnectionNotPending"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
-// @Failure 403 {object} map[string]interface{} "Forbidden (Not the receiving business's operator)"
+// @Failure 403 {object} map[string]interface{} "ErrNotConnectionReceiver"
 // @Failure 404 {object} map[string]interface{} "ErrBusinessConnectionNotFound"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /connections/{id}/reject [patch]
@@ -281,7 +400,11 @@ func (h *BusinessConnectionHandler) RejectBusinessConnection(c *gin.Context) {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authentication context"})
 		return
 	}
-	connection, err := h.service.RejectBusinessConnection(c.Request.Context(), uint(id))
+	if apiErr := h.authorizeConnection(c, uint(id), authUserID, ports.ConnectionActionRespond); apiErr != nil {
+		c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+		return
+	}
+	connection, err := h.service.RejectBusinessConnection(c.Request.Context(), uint(id), authUserID)
 	if err != nil {
 		var apiErr *ports.ApiError
 		if errors.As(err, &apiErr) {
@@ -295,7 +418,7 @@ func (h *BusinessConnectionHandler) RejectBusinessConnection(c *gin.Context) {
 }
 
 // @Summary Delete Business Connection
-// @Description Deletes a specific connection record. Restricted to the initiating user or the receiving business's operator.
+// @Description Deletes a specific connection record. A pending request can only be withdrawn by admins of the initiating business; other connections can be removed by admins of either business. Rejected connections cannot be deleted, and the status history is kept.
 // @Tags business_connections
 // @Produce json
 // @Security BearerAuth
@@ -303,8 +426,9 @@ func (h *BusinessConnectionHandler) RejectBusinessConnection(c *gin.Context) {
 // @Success 204 "Connection deleted successfully (No Content)"
 // @Failure 400 {object} map[string]interface{} "Invalid connection ID"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
-// @Failure 403 {object} map[string]interface{} "Forbidden (Not authorized to delete)"
+// @Failure 403 {object} map[string]interface{} "ErrNotConnectionInitiator or ErrNotConnectionParticipant"
 // @Failure 404 {object} map[string]interface{} "ErrBusinessConnectionNotFound"
+// @Failure 409 {object} map[string]interface{} "ErrRejectedConnectionNotDeletable"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /connections/{id} [delete]
 func (h *BusinessConnectionHandler) DeleteBusinessConnection(c *gin.Context) {
@@ -322,6 +446,10 @@ func (h *BusinessConnectionHandler) DeleteBusinessConnection(c *gin.Context) {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authentication context"})
 		return
 	}
+	if apiErr := h.authorizeConnection(c, uint(id), authUserID, ports.ConnectionActionDelete); apiErr != nil {
+		c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+		return
+	}
 	if err := h.service.DeleteBusinessConnection(c.Request.Context(), uint(id)); err != nil {
 		var apiErr *ports.ApiError
 		if errors.As(err, &apiErr) {
@@ -333,3 +461,124 @@ func (h *BusinessConnectionHandler) DeleteBusinessConnection(c *gin.Context) {
 	}
 	c.Status(http.StatusNoContent)
 }
+
+func (h *BusinessConnectionHandler) transitionConnection(c *gin.Context, action ports.BusinessConnectionAction, transition func(ctx context.Context, id, actorUserID uint) (*models.BusinessConnection, error), failureMessage string) {
+	idStr := c.Param(h.routes.ParamKeyID)
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid connection ID"})
+		return
+	}
+
+	authUserIDVal, _ := c.Get(h.routes.ContextKeyUserID)
+	authUserID, _ := authUserIDVal.(uint)
+	if authUserID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authentication context"})
+		return
+	}
+	if apiErr := h.authorizeConnection(c, uint(id), authUserID, action); apiErr != nil {
+		c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+		return
+	}
+	connection, err := transition(c.Request.Context(), uint(id), authUserID)
+	if err != nil {
+		var apiErr *ports.ApiError
+		if errors.As(err, &apiErr) {
+			c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": failureMessage})
+		return
+	}
+	c.JSON(http.StatusOK, ports.MapToBusinessConnectionResponse(connection))
+}
+
+// @Summary Withdraw Pending Connection
+// @Description Withdraws a pending connection request, setting its status to 'withdrawn'. Restricted to admins of the initiating business. A withdrawn relationship can be requested again at any time.
+// @Tags business_connections
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Connection ID"
+// @Success 200 {object} ports.BusinessConnectionResponse "Connection request withdrawn"
+// @Failure 400 {object} map[string]interface{} "Invalid connection ID or ErrConnectionNotPending"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "ErrNotConnectionInitiator"
+// @Failure 404 {object} map[string]interface{} "ErrBusinessConnectionNotFound"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /connections/{id}/withdraw [post]
+func (h *BusinessConnectionHandler) WithdrawBusinessConnection(c *gin.Context) {
+	h.transitionConnection(c, ports.ConnectionActionWithdraw, h.service.WithdrawBusinessConnection, "Failed to withdraw connection")
+}
+
+// @Summary Deactivate Active Connection
+// @Description Ends an active connection, setting its status to 'inactive'. Admins of either business can deactivate.
+// @Tags business_connections
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Connection ID"
+// @Success 200 {object} ports.BusinessConnectionResponse "Connection deactivated"
+// @Failure 400 {object} map[string]interface{} "Invalid connection ID or ErrConnectionNotActive"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "ErrNotConnectionParticipant"
+// @Failure 404 {object} map[string]interface{} "ErrBusinessConnectionNotFound"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /connections/{id}/deactivate [post]
+func (h *BusinessConnectionHandler) DeactivateBusinessConnection(c *gin.Context) {
+	h.transitionConnection(c, ports.ConnectionActionDeactivate, h.service.DeactivateBusinessConnection, "Failed to deactivate connection")
+}
+
+// @Summary Reactivate Inactive Connection
+// @Description Asks to resume an inactive connection. Admins of either business can reactivate; the connection goes back to 'pending' with the caller's business as the initiator, so the other side has to accept again.
+// @Tags business_connections
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Connection ID"
+// @Success 200 {object} ports.BusinessConnectionResponse "Connection pending re-acceptance"
+// @Failure 400 {object} map[string]interface{} "Invalid connection ID or ErrConnectionNotInactive"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "ErrNotConnectionParticipant"
+// @Failure 404 {object} map[string]interface{} "ErrBusinessConnectionNotFound"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /connections/{id}/reactivate [post]
+func (h *BusinessConnectionHandler) ReactivateBusinessConnection(c *gin.Context) {
+	h.transitionConnection(c, ports.ConnectionActionReactivate, h.service.ReactivateBusinessConnection, "Failed to reactivate connection")
+}
+
+// @Summary Get Connection Status History
+// @Description Lists every status change of a connection, oldest first. Visible to members of either business.
+// @Tags business_connections
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Connection ID"
+// @Success 200 {object} ports.BusinessConnectionHistoriesResponse "Status history"
+// @Failure 400 {object} map[string]interface{} "Invalid connection ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "ErrNotConnectionParticipant"
+// @Failure 404 {object} map[string]interface{} "ErrBusinessConnectionNotFound"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /connections/{id}/history [get]
+func (h *BusinessConnectionHandler) GetBusinessConnectionHistory(c *gin.Context) {
+	idStr := c.Param(h.routes.ParamKeyID)
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid connection ID"})
+		return
+	}
+
+	authUserIDVal, _ := c.Get(h.routes.ContextKeyUserID)
+	authUserID, _ := authUserIDVal.(uint)
+	if authUserID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authentication context"})
+		return
+	}
+	if apiErr := h.authorizeConnection(c, uint(id), authUserID, ports.ConnectionActionView); apiErr != nil {
+		c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+		return
+	}
+	history, err := h.service.GetBusinessConnectionHistory(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve connection history"})
+		return
+	}
+	c.JSON(http.StatusOK, ports.MapToBusinessConnectionHistoriesResponse(history))
+}
@@ -0,0 +1,386 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/constants"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/core/services"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/ports"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type BusinessMemberHandler struct {
+	service  *services.BusinessMemberService
+	validate *validator.Validate
+	routes   *constants.Routes
+}
+
+func NewBusinessMemberHandler(service *services.BusinessMemberService, routes *constants.Routes) *BusinessMemberHandler {
+	return &BusinessMemberHandler{
+		service:  service,
+		validate: validator.New(),
+		routes:   routes,
+	}
+}
+
+func (h *BusinessMemberHandler) getAuthUserID(c *gin.Context) (uint, error) {
+	authUserIDVal, exists := c.Get(h.routes.ContextKeyUserID)
+	if !exists {
+		return 0, errors.New("invalid authentication context")
+	}
+	authUserID, ok := authUserIDVal.(uint)
+	if !ok || authUserID == 0 {
+		return 0, errors.New("invalid authentication context")
+	}
+	return authUserID, nil
+}
+
+func (h *BusinessMemberHandler) parseIDParam(c *gin.Context, key, label string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param(key), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + label + " ID format"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func (h *BusinessMemberHandler) handleError(c *gin.Context, err error) {
+	var apiErr *ports.ApiError
+	if errors.As(err, &apiErr) {
+		c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "An internal error occurred"})
+}
+
+// @Summary List Business Members
+// @Description Lists the team of a business with each member's role. Any member of the business (viewer or above) can see the team.
+// @Tags businesses, members
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Business ID"
+// @Success 200 {object} ports.BusinessMembersResponse "Business members"
+// @Failure 400 {object} map[string]interface{} "Invalid business ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden (Not a member of the business)"
+// @Failure 404 {object} map[string]interface{} "ErrBusinessNotFound"
+// @Router /businesses/{id}/members [get]
+func (h *BusinessMemberHandler) GetBusinessMembers(c *gin.Context) {
+	authUserID, err := h.getAuthUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	businessID, ok := h.parseIDParam(c, h.routes.ParamKeyID, "business")
+	if !ok {
+		return
+	}
+	members, err := h.service.GetBusinessMembers(c.Request.Context(), businessID, authUserID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, ports.MapBusinessMembersToResponse(members))
+}
+
+// @Summary Change Member Role
+// @Description Changes the role of a business member. Requires the admin role or above, and the caller must outrank both the member's current role and the new role. The owner's role cannot be changed here; use ownership transfer instead.
+// @Tags businesses, members
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Business ID"
+// @Param userID path int true "Member user ID"
+// @Param role body ports.UpdateBusinessMemberRoleInput true "New role (admin, editor, viewer)"
+// @Success 200 {object} ports.BusinessMemberResponse "Updated member"
+// @Failure 400 {object} map[string]interface{} "Invalid ID, request body or owner role change"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden (Insufficient role)"
+// @Failure 404 {object} map[string]interface{} "ErrBusinessMemberNotFound"
+// @Router /businesses/{id}/members/{userID} [put]
+func (h *BusinessMemberHandler) UpdateBusinessMemberRole(c *gin.Context) {
+	authUserID, err := h.getAuthUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	businessID, ok := h.parseIDParam(c, h.routes.ParamKeyID, "business")
+	if !ok {
+		return
+	}
+	userID, ok := h.parseIDParam(c, h.routes.ParamKeyUserID, "user")
+	if !ok {
+		return
+	}
+	var input ports.UpdateBusinessMemberRoleInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body: " + err.Error()})
+		return
+	}
+	if err := h.validate.Struct(input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	member, err := h.service.UpdateBusinessMemberRole(c.Request.Context(), businessID, userID, authUserID, input)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, ports.MapBusinessMemberToResponse(member))
+}
+
+// @Summary Remove Business Member
+// @Description Removes a member from a business. Members can always remove themselves (leave); removing someone else requires the admin role and outranking them. The owner cannot be removed.
+// @Tags businesses, members
+// @Security BearerAuth
+// @Param id path int true "Business ID"
+// @Param userID path int true "Member user ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]interface{} "Invalid ID or owner removal"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden (Insufficient role)"
+// @Failure 404 {object} map[string]interface{} "ErrBusinessMemberNotFound"
+// @Router /businesses/{id}/members/{userID} [delete]
+func (h *BusinessMemberHandler) RemoveBusinessMember(c *gin.Context) {
+	authUserID, err := h.getAuthUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	businessID, ok := h.parseIDParam(c, h.routes.ParamKeyID, "business")
+	if !ok {
+		return
+	}
+	userID, ok := h.parseIDParam(c, h.routes.ParamKeyUserID, "user")
+	if !ok {
+		return
+	}
+	if err := h.service.RemoveBusinessMember(c.Request.Context(), businessID, userID, authUserID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Transfer Business Ownership
+// @Description Transfers ownership of a business to another existing member. Only the current owner can do this; they remain on the team as an admin.
+// @Tags businesses, members
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Business ID"
+// @Param transfer body ports.TransferBusinessOwnershipInput true "New owner"
+// @Success 200 {object} ports.BusinessResponse "Business with its new operator"
+// @Failure 400 {object} map[string]interface{} "Invalid request, or target is not a member"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden (Not the owner)"
+// @Failure 404 {object} map[string]interface{} "ErrBusinessNotFound"
+// @Router /businesses/{id}/transfer-ownership [post]
+func (h *BusinessMemberHandler) TransferBusinessOwnership(c *gin.Context) {
+	authUserID, err := h.getAuthUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	businessID, ok := h.parseIDParam(c, h.routes.ParamKeyID, "business")
+	if !ok {
+		return
+	}
+	var input ports.TransferBusinessOwnershipInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body: " + err.Error()})
+		return
+	}
+	if err := h.validate.Struct(input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	business, err := h.service.TransferBusinessOwnership(c.Request.Context(), businessID, authUserID, input)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, ports.MapBusinessToResponse(business))
+}
+
+// @Summary Invite Business Member
+// @Description Invites someone by email to join a business with the given role. Requires the admin role, and the caller must outrank the offered role. Invites expire after seven days; if the email belongs to an existing user they are notified.
+// @Tags businesses, members
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Business ID"
+// @Param invite body ports.CreateBusinessInviteInput true "Invite details"
+// @Success 201 {object} ports.BusinessInviteResponse "Invite created"
+// @Failure 400 {object} map[string]interface{} "Invalid request body"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden (Insufficient role)"
+// @Failure 404 {object} map[string]interface{} "ErrBusinessNotFound"
+// @Failure 409 {object} map[string]interface{} "Already a member or already invited"
+// @Router /businesses/{id}/invites [post]
+func (h *BusinessMemberHandler) CreateBusinessInvite(c *gin.Context) {
+	authUserID, err := h.getAuthUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	businessID, ok := h.parseIDParam(c, h.routes.ParamKeyID, "business")
+	if !ok {
+		return
+	}
+	var input ports.CreateBusinessInviteInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body: " + err.Error()})
+		return
+	}
+	if err := h.validate.Struct(input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	invite, err := h.service.CreateBusinessInvite(c.Request.Context(), businessID, authUserID, input)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, ports.MapBusinessInviteToResponse(invite))
+}
+
+// @Summary List Business Invites
+// @Description Lists all invites sent by a business, including answered and expired ones. Requires the admin role.
+// @Tags businesses, members
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Business ID"
+// @Success 200 {object} ports.BusinessInvitesResponse "Business invites"
+// @Failure 400 {object} map[string]interface{} "Invalid business ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden (Insufficient role)"
+// @Router /businesses/{id}/invites [get]
+func (h *BusinessMemberHandler) GetBusinessInvites(c *gin.Context) {
+	authUserID, err := h.getAuthUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	businessID, ok := h.parseIDParam(c, h.routes.ParamKeyID, "business")
+	if !ok {
+		return
+	}
+	invites, err := h.service.GetBusinessInvites(c.Request.Context(), businessID, authUserID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, ports.MapBusinessInvitesToResponse(invites))
+}
+
+// @Summary Revoke Business Invite
+// @Description Revokes a pending invite. Requires the admin role.
+// @Tags businesses, members
+// @Security BearerAuth
+// @Param id path int true "Business ID"
+// @Param inviteID path int true "Invite ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]interface{} "Invalid ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden (Insufficient role)"
+// @Failure 404 {object} map[string]interface{} "ErrBusinessInviteNotFound"
+// @Failure 409 {object} map[string]interface{} "ErrBusinessInviteNotPending"
+// @Router /businesses/{id}/invites/{inviteID} [delete]
+func (h *BusinessMemberHandler) RevokeBusinessInvite(c *gin.Context) {
+	authUserID, err := h.getAuthUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	businessID, ok := h.parseIDParam(c, h.routes.ParamKeyID, "business")
+	if !ok {
+		return
+	}
+	inviteID, ok := h.parseIDParam(c, h.routes.ParamKeyInviteID, "invite")
+	if !ok {
+		return
+	}
+	if err := h.service.RevokeBusinessInvite(c.Request.Context(), businessID, inviteID, authUserID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary List My Business Invites
+// @Description Lists pending, unexpired invites addressed to the authenticated user's login email.
+// @Tags businesses, members
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} ports.BusinessInvitesResponse "Pending invites"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /business-invites [get]
+func (h *BusinessMemberHandler) GetMyBusinessInvites(c *gin.Context) {
+	authUserID, err := h.getAuthUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	invites, err := h.service.GetInvitesForUser(c.Request.Context(), authUserID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, ports.MapBusinessInvitesToResponse(invites))
+}
+
+// @Summary Accept Business Invite
+// @Description Accepts an invite addressed to the authenticated user and joins the business with the offered role.
+// @Tags businesses, members
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Invite ID"
+// @Success 200 {object} ports.BusinessInviteResponse "Accepted invite"
+// @Failure 400 {object} map[string]interface{} "Invalid invite ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "ErrBusinessInviteNotFound"
+// @Failure 409 {object} map[string]interface{} "Invite already answered or already a member"
+// @Failure 410 {object} map[string]interface{} "ErrBusinessInviteExpired"
+// @Router /business-invites/{id}/accept [post]
+func (h *BusinessMemberHandler) AcceptBusinessInvite(c *gin.Context) {
+	h.respondToInvite(c, true)
+}
+
+// @Summary Decline Business Invite
+// @Description Declines an invite addressed to the authenticated user.
+// @Tags businesses, members
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Invite ID"
+// @Success 200 {object} ports.BusinessInviteResponse "Declined invite"
+// @Failure 400 {object} map[string]interface{} "Invalid invite ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "ErrBusinessInviteNotFound"
+// @Failure 409 {object} map[string]interface{} "ErrBusinessInviteNotPending"
+// @Failure 410 {object} map[string]interface{} "ErrBusinessInviteExpired"
+// @Router /business-invites/{id}/decline [post]
+func (h *BusinessMemberHandler) DeclineBusinessInvite(c *gin.Context) {
+	h.respondToInvite(c, false)
+}
+
+func (h *BusinessMemberHandler) respondToInvite(c *gin.Context, accept bool) {
+	authUserID, err := h.getAuthUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	inviteID, ok := h.parseIDParam(c, h.routes.ParamKeyID, "invite")
+	if !ok {
+		return
+	}
+	invite, err := h.service.RespondToBusinessInvite(c.Request.Context(), inviteID, authUserID, accept)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, ports.MapBusinessInviteToResponse(invite))
+}
@@ -7,27 +7,42 @@ import (
 
 	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/constants"
 	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/core/services"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/models"
 	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/ports"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 )
 
 type BusinessTagHandler struct {
-	service  *services.BusinessTagService
-	validate *validator.Validate
-	routes   *constants.Routes
+	service       *services.BusinessTagService
+	memberService *services.BusinessMemberService
+	validate      *validator.Validate
+	routes        *constants.Routes
 }
 
-func NewBusinessTagHandler(s *services.BusinessTagService, routes *constants.Routes) *BusinessTagHandler {
+func NewBusinessTagHandler(s *services.BusinessTagService, memberService *services.BusinessMemberService, routes *constants.Routes) *BusinessTagHandler {
 	return &BusinessTagHandler{
-		service:  s,
-		validate: validator.New(),
-		routes:   routes,
+		service:       s,
+		memberService: memberService,
+		validate:      validator.New(),
+		routes:        routes,
 	}
 }
 
+func (h *BusinessTagHandler) checkBusinessEditor(c *gin.Context, businessID, authUserID uint) *ports.ApiError {
+	err := h.memberService.AuthorizeBusinessRole(c.Request.Context(), businessID, authUserID, models.BusinessRoleEditor)
+	if err == nil {
+		return nil
+	}
+	var apiErr *ports.ApiError
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+	return ports.ErrDatabase
+}
+
 // @Summary Add Tag to Business
-// @Description Creates a new tag (e.g., 'client', 'service') and associates it with a specific business.
+// @Description Creates a new tag (e.g., 'client', 'service') and associates it with a specific business. Requires the editor role or above in the business.
 // @Tags businesses, tags
 // @Accept json
 // @Produce json
@@ -37,6 +52,8 @@ func NewBusinessTagHandler(s *services.BusinessTagService, routes *constants.Rou
 // @Success 201 {object} ports.BusinessTagResponse "Tag created and associated successfully"
 // @Failure 400 {object} map[string]interface{} "Invalid business ID, request body, or validation failed"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden (Not an editor of the business)"
+// @Failure 404 {object} map[string]interface{} "ErrBusinessNotFound"
 // @Failure 409 {object} map[string]interface{} "ErrBusinessTagAlreadyExists"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /businesses/{id}/tags [post]
@@ -56,7 +73,10 @@ func (h *BusinessTagHandler) CreateBusinessTag(c *gin.Context) {
 		return
 	}
 
-	_ = authUserID
+	if apiErr := h.checkBusinessEditor(c, uint(businessID), authUserID); apiErr != nil {
+		c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+		return
+	}
 	var input ports.CreateBusinessTagInput
 	if err := c.ShouldBindJSON(&input); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body: " + err.Error()})
@@ -107,7 +127,7 @@ func (h *BusinessTagHandler) GetBusinessTags(c *gin.Context) {
 }
 
 // @Summary Delete Business Tag
-// @Description Deletes a specific business tag entry by its unique Tag ID.
+// @Description Deletes a specific business tag entry by its unique Tag ID. Requires the editor role or above in the tag's business.
 // @Tags businesses, tags
 // @Produce json
 // @Security BearerAuth
@@ -115,6 +135,7 @@ func (h *BusinessTagHandler) GetBusinessTags(c *gin.Context) {
 // @Success 204 "Tag deleted successfully (No Content)"
 // @Failure 400 {object} map[string]interface{} "Invalid tag ID"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden (Not an editor of the business)"
 // @Failure 404 {object} map[string]interface{} "ErrBusinessTagNotFound"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /tags/{id} [delete]
@@ -134,7 +155,20 @@ func (h *BusinessTagHandler) DeleteBusinessTag(c *gin.Context) {
 		return
 	}
 
-	_ = authUserID
+	tag, err := h.service.GetBusinessTag(c.Request.Context(), uint(tagID))
+	if err != nil {
+		var apiErr *ports.ApiError
+		if errors.As(err, &apiErr) {
+			c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete tag"})
+		return
+	}
+	if apiErr := h.checkBusinessEditor(c, tag.BusinessID, authUserID); apiErr != nil {
+		c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+		return
+	}
 
 	if err := h.service.DeleteBusinessTag(c.Request.Context(), uint(tagID)); err != nil {
 		var apiErr *ports.ApiError
@@ -0,0 +1,303 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/constants"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/core/services"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/ports"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type BusinessVerificationHandler struct {
+	service  *services.BusinessVerificationService
+	validate *validator.Validate
+	routes   *constants.Routes
+}
+
+func NewBusinessVerificationHandler(service *services.BusinessVerificationService, routes *constants.Routes) *BusinessVerificationHandler {
+	return &BusinessVerificationHandler{
+		service:  service,
+		validate: validator.New(),
+		routes:   routes,
+	}
+}
+
+func (h *BusinessVerificationHandler) getAuthUserID(c *gin.Context) (uint, error) {
+	authUserIDVal, exists := c.Get(h.routes.ContextKeyUserID)
+	if !exists {
+		return 0, errors.New("invalid authentication context")
+	}
+	authUserID, ok := authUserIDVal.(uint)
+	if !ok || authUserID == 0 {
+		return 0, errors.New("invalid authentication context")
+	}
+	return authUserID, nil
+}
+
+func (h *BusinessVerificationHandler) parseIDParam(c *gin.Context, label string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param(h.routes.ParamKeyID), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + label + " ID format"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func (h *BusinessVerificationHandler) handleError(c *gin.Context, err error) {
+	var apiErr *ports.ApiError
+	if errors.As(err, &apiErr) {
+		c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "An internal error occurred"})
+}
+
+// @Summary Request Business Verification
+// @Description Starts verification of the business's website domain. Returns a token to publish either as a DNS TXT record on the domain or as a file at /.well-known/tia-verification.txt. Requires the admin role in the business.
+// @Tags businesses, verification
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Business ID"
+// @Param verification body ports.RequestVerificationInput true "Verification method"
+// @Success 201 {object} ports.BusinessVerificationResponse "Verification requested"
+// @Failure 400 {object} map[string]interface{} "Invalid input or ErrVerificationNoWebsite"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden (Insufficient role in the business)"
+// @Failure 404 {object} map[string]interface{} "ErrBusinessNotFound"
+// @Failure 409 {object} map[string]interface{} "ErrBusinessAlreadyVerified or ErrVerificationInProgress"
+// @Router /businesses/{id}/verification [post]
+func (h *BusinessVerificationHandler) RequestVerification(c *gin.Context) {
+	authUserID, err := h.getAuthUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	businessID, ok := h.parseIDParam(c, "business")
+	if !ok {
+		return
+	}
+	var input ports.RequestVerificationInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body: " + err.Error()})
+		return
+	}
+	if err := h.validate.Struct(input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	verification, err := h.service.RequestVerification(c.Request.Context(), businessID, authUserID, input)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, ports.MapToBusinessVerificationResponse(verification))
+}
+
+// @Summary Get Business Verification
+// @Description Retrieves the latest verification request for a business. Visible to members of the business and platform administrators.
+// @Tags businesses, verification
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Business ID"
+// @Success 200 {object} ports.BusinessVerificationResponse "Latest verification"
+// @Failure 400 {object} map[string]interface{} "Invalid business ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden (Not a member of the business)"
+// @Failure 404 {object} map[string]interface{} "ErrBusinessNotFound or ErrVerificationNotFound"
+// @Router /businesses/{id}/verification [get]
+func (h *BusinessVerificationHandler) GetBusinessVerification(c *gin.Context) {
+	authUserID, err := h.getAuthUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	businessID, ok := h.parseIDParam(c, "business")
+	if !ok {
+		return
+	}
+	verification, err := h.service.GetBusinessVerification(c.Request.Context(), businessID, authUserID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, ports.MapToBusinessVerificationResponse(verification))
+}
+
+// @Summary Check Domain Ownership
+// @Description Looks up the verification token on the business domain using the method chosen when verification was requested. When the token is found the request moves to the platform review queue. Requires the admin role in the business.
+// @Tags businesses, verification
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Business ID"
+// @Success 200 {object} ports.BusinessVerificationResponse "Token found, awaiting review"
+// @Failure 400 {object} map[string]interface{} "Invalid business ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden (Insufficient role in the business)"
+// @Failure 404 {object} map[string]interface{} "ErrBusinessNotFound or ErrVerificationNotFound"
+// @Failure 409 {object} map[string]interface{} "ErrVerificationNotPending"
+// @Failure 422 {object} map[string]interface{} "ErrVerificationTokenMissing"
+// @Router /businesses/{id}/verification/check [post]
+func (h *BusinessVerificationHandler) CheckVerification(c *gin.Context) {
+	authUserID, err := h.getAuthUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	businessID, ok := h.parseIDParam(c, "business")
+	if !ok {
+		return
+	}
+	verification, err := h.service.CheckVerification(c.Request.Context(), businessID, authUserID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, ports.MapToBusinessVerificationResponse(verification))
+}
+
+// @Summary Revoke Business Verification
+// @Description Removes the verified badge from a business and records the reason. Admins of the business are notified. Requires platform administrator access.
+// @Tags businesses, verification
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Business ID"
+// @Param revocation body ports.RevokeVerificationInput true "Revocation reason"
+// @Success 200 {object} ports.BusinessVerificationResponse "Verification revoked"
+// @Failure 400 {object} map[string]interface{} "Invalid input or ErrBusinessNotVerified"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "ErrPlatformAdminRequired"
+// @Failure 404 {object} map[string]interface{} "ErrBusinessNotFound"
+// @Router /businesses/{id}/verification/revoke [post]
+func (h *BusinessVerificationHandler) RevokeVerification(c *gin.Context) {
+	authUserID, err := h.getAuthUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	businessID, ok := h.parseIDParam(c, "business")
+	if !ok {
+		return
+	}
+	var input ports.RevokeVerificationInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body: " + err.Error()})
+		return
+	}
+	if err := h.validate.Struct(input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	verification, err := h.service.RevokeVerification(c.Request.Context(), businessID, authUserID, input)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, ports.MapToBusinessVerificationResponse(verification))
+}
+
+// @Summary Verification Review Queue
+// @Description Lists verification requests by status, oldest first. Defaults to requests whose domain check passed and are awaiting review. Requires platform administrator access.
+// @Tags verification
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Filter by status (pending_check, pending_review, approved, rejected, revoked)"
+// @Success 200 {object} ports.BusinessVerificationsResponse "Verification requests"
+// @Failure 400 {object} map[string]interface{} "Invalid query parameters"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "ErrPlatformAdminRequired"
+// @Router /verifications [get]
+func (h *BusinessVerificationHandler) GetVerificationQueue(c *gin.Context) {
+	authUserID, err := h.getAuthUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	var filter ports.VerificationsFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
+		return
+	}
+	if err := h.validate.Struct(filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	verifications, err := h.service.GetVerificationQueue(c.Request.Context(), authUserID, filter)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, ports.MapToBusinessVerificationsResponse(verifications))
+}
+
+// @Summary Approve Verification
+// @Description Approves a verification request awaiting review and marks the business as verified. Requires platform administrator access.
+// @Tags verification
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Verification ID"
+// @Param review body ports.ReviewVerificationInput false "Optional review note"
+// @Success 200 {object} ports.BusinessVerificationResponse "Verification approved"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "ErrPlatformAdminRequired"
+// @Failure 404 {object} map[string]interface{} "ErrVerificationNotFound"
+// @Failure 409 {object} map[string]interface{} "ErrVerificationNotPending"
+// @Router /verifications/{id}/approve [post]
+func (h *BusinessVerificationHandler) ApproveVerification(c *gin.Context) {
+	h.reviewVerification(c, true)
+}
+
+// @Summary Reject Verification
+// @Description Rejects a verification request awaiting review. The business may request verification again. Requires platform administrator access.
+// @Tags verification
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Verification ID"
+// @Param review body ports.ReviewVerificationInput false "Optional review note"
+// @Success 200 {object} ports.BusinessVerificationResponse "Verification rejected"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "ErrPlatformAdminRequired"
+// @Failure 404 {object} map[string]interface{} "ErrVerificationNotFound"
+// @Failure 409 {object} map[string]interface{} "ErrVerificationNotPending"
+// @Router /verifications/{id}/reject [post]
+func (h *BusinessVerificationHandler) RejectVerification(c *gin.Context) {
+	h.reviewVerification(c, false)
+}
+
+func (h *BusinessVerificationHandler) reviewVerification(c *gin.Context, approve bool) {
+	authUserID, err := h.getAuthUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	id, ok := h.parseIDParam(c, "verification")
+	if !ok {
+		return
+	}
+	var input ports.ReviewVerificationInput
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body: " + err.Error()})
+			return
+		}
+	}
+	if err := h.validate.Struct(input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	verification, err := h.service.ReviewVerification(c.Request.Context(), id, authUserID, approve, input)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, ports.MapToBusinessVerificationResponse(verification))
+}
@@ -0,0 +1,215 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/constants"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/core/services"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/models"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/ports"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type IntroductionRequestHandler struct {
+	service  *services.IntroductionRequestService
+	validate *validator.Validate
+	routes   *constants.Routes
+}
+
+func NewIntroductionRequestHandler(service *services.IntroductionRequestService, routes *constants.Routes) *IntroductionRequestHandler {
+	return &IntroductionRequestHandler{
+		service:  service,
+		validate: validator.New(),
+		routes:   routes,
+	}
+}
+
+func (h *IntroductionRequestHandler) getAuthUserID(c *gin.Context) (uint, error) {
+	authUserIDVal, exists := c.Get(h.routes.ContextKeyUserID)
+	if !exists {
+		return 0, errors.New("invalid authentication context")
+	}
+	authUserID, ok := authUserIDVal.(uint)
+	if !ok || authUserID == 0 {
+		return 0, errors.New("invalid authentication context")
+	}
+	return authUserID, nil
+}
+
+func (h *IntroductionRequestHandler) parseIDParam(c *gin.Context, key, label string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param(key), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + label + " ID format"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func (h *IntroductionRequestHandler) handleError(c *gin.Context, err error) {
+	var apiErr *ports.ApiError
+	if errors.As(err, &apiErr) {
+		c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "An internal error occurred"})
+}
+
+// @Summary Request Introduction
+// @Description Asks a mutual connection (the introducer) to introduce the requesting business to a target business. The introducer must be actively connected to both businesses, and the requester must be an admin of the requesting business. Admins of the introducing business are notified.
+// @Tags introductions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param introduction body ports.CreateIntroductionRequestInput true "Introduction details"
+// @Success 201 {object} ports.IntroductionRequestResponse "Introduction requested"
+// @Failure 400 {object} map[string]interface{} "Invalid input, ErrInvalidIntroduction or ErrIntroducerNotMutual"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden (Not an admin of the requesting business)"
+// @Failure 404 {object} map[string]interface{} "ErrBusinessNotFound"
+// @Failure 409 {object} map[string]interface{} "ErrBusinessConnectionAlreadyExists or ErrIntroductionExists"
+// @Router /introductions [post]
+func (h *IntroductionRequestHandler) CreateIntroductionRequest(c *gin.Context) {
+	authUserID, err := h.getAuthUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	var input ports.CreateIntroductionRequestInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body: " + err.Error()})
+		return
+	}
+	if err := h.validate.Struct(input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	intro, err := h.service.CreateIntroductionRequest(c.Request.Context(), authUserID, input)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, ports.MapToIntroductionRequestResponse(intro))
+}
+
+// @Summary Get Introduction Request
+// @Description Retrieves an introduction request. Visible to members of the requesting and introducing businesses, and to members of the target business once the introducer has forwarded it.
+// @Tags introductions
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Introduction Request ID"
+// @Success 200 {object} ports.IntroductionRequestResponse "Introduction request"
+// @Failure 400 {object} map[string]interface{} "Invalid introduction ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "ErrIntroductionNotFound"
+// @Router /introductions/{id} [get]
+func (h *IntroductionRequestHandler) GetIntroductionRequest(c *gin.Context) {
+	authUserID, err := h.getAuthUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	id, ok := h.parseIDParam(c, h.routes.ParamKeyID, "introduction")
+	if !ok {
+		return
+	}
+	intro, err := h.service.GetIntroductionRequest(c.Request.Context(), id, authUserID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, ports.MapToIntroductionRequestResponse(intro))
+}
+
+// @Summary List Business Introductions
+// @Description Lists introduction requests a business is involved in as requester, introducer or target. Requires membership of the business.
+// @Tags businesses, introductions
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Business ID"
+// @Param status query string false "Filter by status (pending_introducer, pending_target, completed, declined)"
+// @Success 200 {object} ports.IntroductionRequestsResponse "Introduction requests"
+// @Failure 400 {object} map[string]interface{} "Invalid business ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden (Not a member of the business)"
+// @Failure 404 {object} map[string]interface{} "ErrBusinessNotFound"
+// @Router /businesses/{id}/introductions [get]
+func (h *IntroductionRequestHandler) GetBusinessIntroductions(c *gin.Context) {
+	authUserID, err := h.getAuthUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	businessID, ok := h.parseIDParam(c, h.routes.ParamKeyID, "business")
+	if !ok {
+		return
+	}
+	var status *models.IntroductionStatus
+	if s := c.Query("status"); s != "" {
+		tempStatus := models.IntroductionStatus(s)
+		status = &tempStatus
+	}
+	intros, err := h.service.GetBusinessIntroductions(c.Request.Context(), businessID, authUserID, status)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, ports.MapToIntroductionRequestsResponse(intros))
+}
+
+// @Summary Accept Introduction Request
+// @Description Accepts the current step of an introduction. While pending with the introducer, an introducer admin forwards it to the target. While pending with the target, a target admin completes it, creating an active Referral connection between the requester and the target.
+// @Tags introductions
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Introduction Request ID"
+// @Success 200 {object} ports.IntroductionRequestResponse "Updated introduction request"
+// @Failure 400 {object} map[string]interface{} "Invalid introduction ID, ErrIntroductionNotPending or ErrIntroducerNotMutual"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden (Not an admin of the business whose turn it is)"
+// @Failure 404 {object} map[string]interface{} "ErrIntroductionNotFound"
+// @Router /introductions/{id}/accept [post]
+func (h *IntroductionRequestHandler) AcceptIntroductionRequest(c *gin.Context) {
+	h.respondToIntroduction(c, true)
+}
+
+// @Summary Decline Introduction Request
+// @Description Declines the current step of an introduction, either by the introducer or by the target. The requester is notified.
+// @Tags introductions
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Introduction Request ID"
+// @Success 200 {object} ports.IntroductionRequestResponse "Declined introduction request"
+// @Failure 400 {object} map[string]interface{} "Invalid introduction ID or ErrIntroductionNotPending"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden (Not an admin of the business whose turn it is)"
+// @Failure 404 {object} map[string]interface{} "ErrIntroductionNotFound"
+// @Router /introductions/{id}/decline [post]
+func (h *IntroductionRequestHandler) DeclineIntroductionRequest(c *gin.Context) {
+	h.respondToIntroduction(c, false)
+}
+
+func (h *IntroductionRequestHandler) respondToIntroduction(c *gin.Context, accept bool) {
+	authUserID, err := h.getAuthUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	id, ok := h.parseIDParam(c, h.routes.ParamKeyID, "introduction")
+	if !ok {
+		return
+	}
+	var intro *models.IntroductionRequest
+	if accept {
+		intro, err = h.service.AcceptIntroductionRequest(c.Request.Context(), id, authUserID)
+	} else {
+		intro, err = h.service.DeclineIntroductionRequest(c.Request.Context(), id, authUserID)
+	}
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, ports.MapToIntroductionRequestResponse(intro))
+}
@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/constants"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/core/services"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/ports"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+const multipartOverheadBytes = 1 << 20
+
+type MediaHandler struct {
+	mediaService *services.MediaService
+	validate     *validator.Validate
+	routes       *constants.Routes
+}
+
+func NewMediaHandler(mediaService *services.MediaService, routes *constants.Routes) *MediaHandler {
+	return &MediaHandler{
+		mediaService: mediaService,
+		validate:     validator.New(),
+		routes:       routes,
+	}
+}
+
+func (h *MediaHandler) getAuthUserID(c *gin.Context) (uint, error) {
+	authUserIDVal, exists := c.Get(h.routes.ContextKeyUserID)
+	if !exists {
+		return 0, errors.New("invalid authentication context")
+	}
+	authUserID, ok := authUserIDVal.(uint)
+	if !ok || authUserID == 0 {
+		return 0, errors.New("invalid authentication context")
+	}
+	return authUserID, nil
+}
+
+func (h *MediaHandler) parseMediaID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param(h.routes.ParamKeyID), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid media ID format"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// @Summary Upload Media
+// @Description Uploads an image (JPEG, PNG or GIF) as multipart/form-data. The content type is sniffed from the file bytes rather than trusted from the client, the size is limited by MEDIA_MAX_UPLOAD_BYTES, and resized variants (thumb, small, medium, large) are generated. The returned ID can be referenced as media_id on publications, logo_media_id on businesses and avatar_media_id on users.
+// @Tags media
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "Image file"
+// @Param purpose formData string false "Intended use (general, thumbnail, logo, avatar)"
+// @Success 201 {object} ports.MediaResponse "Uploaded media"
+// @Failure 400 {object} map[string]interface{} "Missing file or invalid image"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 413 {object} map[string]interface{} "File too large"
+// @Failure 415 {object} map[string]interface{} "Unsupported media type"
+// @Failure 500 {object} map[string]interface{} "Failed to store media"
+// @Router /media [post]
+func (h *MediaHandler) UploadMedia(c *gin.Context) {
+	authUserID, err := h.getAuthUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.mediaService.MaxUploadBytes()+multipartOverheadBytes)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.JSON(ports.ErrMediaTooLarge.StatusCode, gin.H{"error": ports.ErrMediaTooLarge.Message})
+			return
+		}
+		c.JSON(ports.ErrMediaFileRequired.StatusCode, gin.H{"error": ports.ErrMediaFileRequired.Message})
+		return
+	}
+	var input ports.UploadMediaInput
+	if err := c.ShouldBind(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid form data: " + err.Error()})
+		return
+	}
+	if err := h.validate.Struct(input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if fileHeader.Size > h.mediaService.MaxUploadBytes() {
+		c.JSON(ports.ErrMediaTooLarge.StatusCode, gin.H{"error": ports.ErrMediaTooLarge.Message})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(ports.ErrMediaFileRequired.StatusCode, gin.H{"error": ports.ErrMediaFileRequired.Message})
+		return
+	}
+	defer file.Close()
+
+	media, err := h.mediaService.UploadMedia(c.Request.Context(), authUserID, input, fileHeader.Filename, file)
+	if err != nil {
+		var apiErr *ports.ApiError
+		if errors.As(err, &apiErr) {
+			c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "An internal error occurred"})
+		return
+	}
+	c.JSON(http.StatusCreated, ports.MapMediaToResponse(media))
+}
+
+// @Summary Get Media
+// @Description Retrieves media metadata, including the URL of the original and of every generated variant.
+// @Tags media
+// @Produce json
+// @Param id path int true "Media ID"
+// @Success 200 {object} ports.MediaResponse "Media metadata"
+// @Failure 400 {object} map[string]interface{} "Invalid media ID"
+// @Failure 404 {object} map[string]interface{} "Media not found"
+// @Router /media/{id} [get]
+func (h *MediaHandler) GetMedia(c *gin.Context) {
+	id, ok := h.parseMediaID(c)
+	if !ok {
+		return
+	}
+	media, err := h.mediaService.GetMediaByID(c.Request.Context(), id)
+	if err != nil {
+		var apiErr *ports.ApiError
+		if errors.As(err, &apiErr) {
+			c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "An internal error occurred"})
+		return
+	}
+	c.JSON(http.StatusOK, ports.MapMediaToResponse(media))
+}
+
+// @Summary Get Media Content
+// @Description Streams the stored image bytes. When the requested variant was not generated (because the original is smaller than it), the original is returned instead.
+// @Tags media
+// @Produce image/jpeg,image/png,image/gif
+// @Param id path int true "Media ID"
+// @Param variant query string false "Variant (original, thumb, small, medium, large); defaults to original"
+// @Success 200 {file} file "Image bytes"
+// @Failure 400 {object} map[string]interface{} "Invalid media ID or variant"
+// @Failure 404 {object} map[string]interface{} "Media not found"
+// @Router /media/{id}/content [get]
+func (h *MediaHandler) GetMediaContent(c *gin.Context) {
+	id, ok := h.parseMediaID(c)
+	if !ok {
+		return
+	}
+	var query ports.MediaContentQuery
+	if err := c.BindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
+		return
+	}
+	if err := h.validate.Struct(query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	body, contentType, err := h.mediaService.OpenMediaContent(c.Request.Context(), id, query.Variant)
+	if err != nil {
+		var apiErr *ports.ApiError
+		if errors.As(err, &apiErr) {
+			c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "An internal error occurred"})
+		return
+	}
+	defer body.Close()
+	c.DataFromReader(http.StatusOK, -1, contentType, body, map[string]string{
+		"Cache-Control":          "public, max-age=31536000, immutable",
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+// @Summary Delete Media
+// @Description Deletes media and its variants. Only the uploader can perform this action. Any avatar, logo or publication references to it are cleared.
+// @Tags media
+// @Security BearerAuth
+// @Param id path int true "Media ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]interface{} "Invalid media ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Media not found"
+// @Router /media/{id} [delete]
+func (h *MediaHandler) DeleteMedia(c *gin.Context) {
+	authUserID, err := h.getAuthUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	id, ok := h.parseMediaID(c)
+	if !ok {
+		return
+	}
+	if err := h.mediaService.DeleteMedia(c.Request.Context(), id, authUserID); err != nil {
+		var apiErr *ports.ApiError
+		if errors.As(err, &apiErr) {
+			c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "An internal error occurred"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
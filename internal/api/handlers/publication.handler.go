@@ -4,9 +4,11 @@ import (
 	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/constants"
 	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/core/services"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/models"
 	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/ports"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -38,6 +40,41 @@ func (h *PublicationHandler) getAuthUserID(c *gin.Context) (uint, error) {
 	return authUserID, nil
 }
 
+func (h *PublicationHandler) getViewerID(c *gin.Context) *uint {
+	authUserID, err := h.getAuthUserID(c)
+	if err != nil {
+		return nil
+	}
+	return &authUserID
+}
+
+func (h *PublicationHandler) checkPublicationVisibility(c *gin.Context, publication *models.Publication) *ports.ApiError {
+	visible, err := h.publicationService.CanViewPublication(c.Request.Context(), publication, h.getViewerID(c))
+	if err != nil {
+		return ports.ErrDatabase
+	}
+	if !visible {
+		return ports.ErrPublicationNotFound
+	}
+	return nil
+}
+
+func (h *PublicationHandler) attachEngagement(c *gin.Context, responses []ports.PublicationResponse) error {
+	ids := make([]uint, len(responses))
+	for i, resp := range responses {
+		ids[i] = resp.ID
+	}
+	engagement, err := h.publicationService.GetPublicationEngagement(c.Request.Context(), ids)
+	if err != nil {
+		return err
+	}
+	for i := range responses {
+		e := engagement[responses[i].ID]
+		responses[i].Engagement = &e
+	}
+	return nil
+}
+
 func (h *PublicationHandler) checkPublicationOwnership(c *gin.Context, pubID uint) (uint, *ports.ApiError) {
 	authUserID, err := h.getAuthUserID(c)
 	if err != nil {
@@ -52,14 +89,18 @@ func (h *PublicationHandler) checkPublicationOwnership(c *gin.Context, pubID uin
 		return 0, ports.ErrDatabase
 	}
 
-	if publication.UserID != authUserID {
+	canEdit, err := h.publicationService.CanEditPublication(c.Request.Context(), publication, authUserID)
+	if err != nil {
+		return 0, ports.ErrDatabase
+	}
+	if !canEdit {
 		return 0, ports.ErrForbidden
 	}
 	return authUserID, nil
 }
 
 // @Summary Create New Publication
-// @Description Creates a new publication (post, article, case study, etc.). The UserID in the body must match the authenticated user.
+// @Description Creates a new publication (post, article, case study, etc.). The UserID in the body must match the authenticated user. The slug is derived from the title and suffixed (-2, -3, ...) when already taken. Content is rendered server-side from content_format (markdown by default, html or plaintext) into sanitised content_html; an excerpt is generated when none is given, and articles get a table of contents.
 // @Tags publications
 // @Accept json
 // @Produce json
@@ -68,8 +109,7 @@ func (h *PublicationHandler) checkPublicationOwnership(c *gin.Context, pubID uin
 // @Success 201 {object} ports.PublicationResponse "Publication created successfully"
 // @Failure 400 {object} map[string]interface{} "Invalid request body or validation failed"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
-// @Failure 403 {object} map[string]interface{} "Forbidden: Cannot create publication for another user"
-// @Failure 409 {object} map[string]interface{} "ErrPublicationSlugExists"
+// @Failure 403 {object} map[string]interface{} "Forbidden: Cannot create publication for another user, or not an editor of business_id"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /publications [post]
 func (h *PublicationHandler) CreatePublication(c *gin.Context) {
@@ -109,15 +149,15 @@ func (h *PublicationHandler) CreatePublication(c *gin.Context) {
 }
 
 // @Summary Get Publication by ID
-// @Description Retrieves a specific publication record by its unique ID.
+// @Description Retrieves a specific publication record by its unique ID. Authentication is optional: anonymous callers only see published public items, and drafts are only visible to their author.
 // @Tags publications
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Publication ID"
 // @Success 200 {object} ports.PublicationResponse "Publication retrieved successfully"
 // @Failure 400 {object} map[string]interface{} "Invalid publication ID"
-// @Failure 401 {object} map[string]interface{} "Unauthorized"
-// @Failure 404 {object} map[string]interface{} "ErrPublicationNotFound"
+// @Failure 401 {object} map[string]interface{} "Invalid token"
+// @Failure 404 {object} map[string]interface{} "ErrPublicationNotFound (also returned when not visible to the caller)"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /publications/id/{id} [get]
 func (h *PublicationHandler) GetPublicationByID(c *gin.Context) {
@@ -128,11 +168,6 @@ func (h *PublicationHandler) GetPublicationByID(c *gin.Context) {
 		return
 	}
 
-	if _, err := h.getAuthUserID(c); err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
-		return
-	}
-
 	publication, err := h.publicationService.GetPublicationByID(c.Request.Context(), uint(id))
 	if err != nil {
 		var apiErr *ports.ApiError
@@ -143,19 +178,28 @@ func (h *PublicationHandler) GetPublicationByID(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "An internal error occurred"})
 		return
 	}
-	c.JSON(http.StatusOK, ports.MapPublicationToResponse(publication))
+	if apiErr := h.checkPublicationVisibility(c, publication); apiErr != nil {
+		c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+		return
+	}
+	responses := []ports.PublicationResponse{ports.MapPublicationToResponse(publication)}
+	if err := h.attachEngagement(c, responses); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve publication engagement"})
+		return
+	}
+	c.JSON(http.StatusOK, responses[0])
 }
 
 // @Summary Get Publication by Slug
-// @Description Retrieves a specific publication record by its unique URL slug.
+// @Description Retrieves a specific publication record by its URL slug. Slugs retired by a title change still resolve; in that case redirected_from is set and the Location header points at the canonical slug. Authentication is optional, with the same visibility rules as lookup by ID.
 // @Tags publications
 // @Produce json
 // @Security BearerAuth
 // @Param slug path string true "Publication URL Slug"
 // @Success 200 {object} ports.PublicationResponse "Publication retrieved successfully"
 // @Failure 400 {object} map[string]interface{} "Missing slug"
-// @Failure 401 {object} map[string]interface{} "Unauthorized"
-// @Failure 404 {object} map[string]interface{} "ErrPublicationNotFound"
+// @Failure 401 {object} map[string]interface{} "Invalid token"
+// @Failure 404 {object} map[string]interface{} "ErrPublicationNotFound (also returned when not visible to the caller)"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /publications/slug/{slug} [get]
 func (h *PublicationHandler) GetPublicationBySlug(c *gin.Context) {
@@ -165,12 +209,7 @@ func (h *PublicationHandler) GetPublicationBySlug(c *gin.Context) {
 		return
 	}
 
-	if _, err := h.getAuthUserID(c); err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
-		return
-	}
-
-	publication, err := h.publicationService.GetPublicationBySlug(c.Request.Context(), slug)
+	publication, redirected, err := h.publicationService.ResolvePublicationSlug(c.Request.Context(), slug)
 	if err != nil {
 		var apiErr *ports.ApiError
 		if errors.As(err, &apiErr) {
@@ -180,25 +219,47 @@ func (h *PublicationHandler) GetPublicationBySlug(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "An internal error occurred"})
 		return
 	}
-	c.JSON(http.StatusOK, ports.MapPublicationToResponse(publication))
+	if apiErr := h.checkPublicationVisibility(c, publication); apiErr != nil {
+		c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+		return
+	}
+	responses := []ports.PublicationResponse{ports.MapPublicationToResponse(publication)}
+	if err := h.attachEngagement(c, responses); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve publication engagement"})
+		return
+	}
+	resp := responses[0]
+	if redirected {
+		resp.RedirectedFrom = &slug
+		canonical := strings.Replace(h.routes.PublicationBySlug, ":"+h.routes.ParamKeySlug, publication.Slug, 1)
+		c.Header("Location", h.routes.APIPrefix+h.routes.PublicationBase+canonical)
+	}
+	c.JSON(http.StatusOK, resp)
 }
 
 // @Summary Get All Publications
-// @Description Retrieves a list of all publication records.
+// @Description Retrieves publications visible to the caller. Authentication is optional: anonymous callers only see published public items; authenticated callers also see members-only items, connections-only items of businesses they are actively connected to, and their own drafts. Each item includes comment and reaction counts under engagement.
 // @Tags publications
 // @Produce json
 // @Security BearerAuth
+// @Param publication_type query string false "Filter by publication type (post, case_study, testimonial, article)"
+// @Param business_id query int false "Filter by business ID"
+// @Param user_id query int false "Filter by author user ID"
+// @Param published_from query string false "Only items published on or after this date (YYYY-MM-DD)"
+// @Param published_to query string false "Only items published on or before this date (YYYY-MM-DD)"
 // @Success 200 {array} ports.PublicationResponse "List of publications"
-// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 400 {object} map[string]interface{} "Invalid query parameters"
+// @Failure 401 {object} map[string]interface{} "Invalid token"
 // @Failure 500 {object} map[string]interface{} "Failed to retrieve publications"
 // @Router /publications [get]
 func (h *PublicationHandler) GetAllPublications(c *gin.Context) {
-	if _, err := h.getAuthUserID(c); err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+	var filters ports.PublicationsFilter
+	if err := c.BindQuery(&filters); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
 		return
 	}
 
-	publications, err := h.publicationService.FindAllPublications(c.Request.Context())
+	publications, err := h.publicationService.FindAllPublications(c.Request.Context(), h.getViewerID(c), filters)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve publications"})
 		return
@@ -208,11 +269,15 @@ func (h *PublicationHandler) GetAllPublications(c *gin.Context) {
 	for i, pub := range publications {
 		responses[i] = ports.MapPublicationToResponse(&pub)
 	}
+	if err := h.attachEngagement(c, responses); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve publication engagement"})
+		return
+	}
 	c.JSON(http.StatusOK, responses)
 }
 
 // @Summary Update Publication
-// @Description Updates an existing publication record. Only the Author (UserID) can perform this action.
+// @Description Updates an existing publication record. Only the author (UserID) or an editor of the publication's business can perform this action. Content changes are recorded as a new revision and re-rendered into content_html; setting scheduled_at unpublishes the item until the scheduled time.
 // @Tags publications
 // @Accept json
 // @Produce json
@@ -222,7 +287,7 @@ func (h *PublicationHandler) GetAllPublications(c *gin.Context) {
 // @Success 200 {object} ports.PublicationResponse "Publication updated successfully"
 // @Failure 400 {object} map[string]interface{} "Invalid publication ID or request body"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
-// @Failure 403 {object} map[string]interface{} "Forbidden (Not the author)"
+// @Failure 403 {object} map[string]interface{} "Forbidden (Not the author or an editor of its business)"
 // @Failure 404 {object} map[string]interface{} "ErrPublicationNotFound"
 // @Failure 409 {object} map[string]interface{} "ErrPublicationSlugExists"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
@@ -235,7 +300,8 @@ func (h *PublicationHandler) UpdatePublication(c *gin.Context) {
 		return
 	}
 
-	if _, apiErr := h.checkPublicationOwnership(c, uint(pubID)); apiErr != nil {
+	authUserID, apiErr := h.checkPublicationOwnership(c, uint(pubID))
+	if apiErr != nil {
 		c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
 		return
 	}
@@ -249,6 +315,7 @@ func (h *PublicationHandler) UpdatePublication(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	input.EditorUserID = authUserID
 
 	publication, err := h.publicationService.UpdatePublication(c.Request.Context(), uint(pubID), input)
 	if err != nil {
@@ -264,7 +331,7 @@ func (h *PublicationHandler) UpdatePublication(c *gin.Context) {
 }
 
 // @Summary Delete Publication
-// @Description Deletes a publication record. Only the Author (UserID) can perform this action.
+// @Description Deletes a publication record. Only the author (UserID) or an editor of the publication's business can perform this action.
 // @Tags publications
 // @Produce json
 // @Security BearerAuth
@@ -272,7 +339,7 @@ func (h *PublicationHandler) UpdatePublication(c *gin.Context) {
 // @Success 204 "Publication deleted successfully (No Content)"
 // @Failure 400 {object} map[string]interface{} "Invalid publication ID"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
-// @Failure 403 {object} map[string]interface{} "Forbidden (Not the author)"
+// @Failure 403 {object} map[string]interface{} "Forbidden (Not the author or an editor of its business)"
 // @Failure 404 {object} map[string]interface{} "ErrPublicationNotFound"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /publications/{id} [delete]
@@ -301,3 +368,188 @@ func (h *PublicationHandler) DeletePublication(c *gin.Context) {
 	}
 	c.Status(http.StatusNoContent)
 }
+
+func (h *PublicationHandler) parseRevisionParams(c *gin.Context) (uint, uint, bool) {
+	pubID, err := strconv.ParseUint(c.Param(h.routes.ParamKeyID), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid publication ID"})
+		return 0, 0, false
+	}
+	revision, err := strconv.ParseUint(c.Param(h.routes.ParamKeyRevision), 10, 32)
+	if err != nil || revision == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid revision number"})
+		return 0, 0, false
+	}
+	return uint(pubID), uint(revision), true
+}
+
+// @Summary List Publication Revisions
+// @Description Retrieves the revision history of a publication, newest first. Only the author (UserID) or an editor of the publication's business can perform this action.
+// @Tags publications
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Publication ID"
+// @Success 200 {array} ports.PublicationRevisionResponse "List of revisions"
+// @Failure 400 {object} map[string]interface{} "Invalid publication ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden (Not the author or an editor of its business)"
+// @Failure 404 {object} map[string]interface{} "ErrPublicationNotFound"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /publications/{id}/revisions [get]
+func (h *PublicationHandler) GetPublicationRevisions(c *gin.Context) {
+	pubID, err := strconv.ParseUint(c.Param(h.routes.ParamKeyID), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid publication ID"})
+		return
+	}
+
+	if _, apiErr := h.checkPublicationOwnership(c, uint(pubID)); apiErr != nil {
+		c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+		return
+	}
+
+	revisions, err := h.publicationService.GetPublicationRevisions(c.Request.Context(), uint(pubID))
+	if err != nil {
+		var apiErr *ports.ApiError
+		if errors.As(err, &apiErr) {
+			c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "An internal error occurred"})
+		return
+	}
+
+	responses := make([]ports.PublicationRevisionResponse, len(revisions))
+	for i, rev := range revisions {
+		responses[i] = ports.MapPublicationRevisionToResponse(&rev)
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// @Summary Get Publication Revision
+// @Description Retrieves a single revision of a publication by its revision number. Only the author (UserID) or an editor of the publication's business can perform this action.
+// @Tags publications
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Publication ID"
+// @Param revision path int true "Revision number"
+// @Success 200 {object} ports.PublicationRevisionResponse "Revision retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid publication ID or revision number"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden (Not the author or an editor of its business)"
+// @Failure 404 {object} map[string]interface{} "ErrPublicationNotFound or ErrPublicationRevisionNotFound"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /publications/{id}/revisions/{revision} [get]
+func (h *PublicationHandler) GetPublicationRevision(c *gin.Context) {
+	pubID, revision, ok := h.parseRevisionParams(c)
+	if !ok {
+		return
+	}
+
+	if _, apiErr := h.checkPublicationOwnership(c, pubID); apiErr != nil {
+		c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+		return
+	}
+
+	rev, err := h.publicationService.GetPublicationRevision(c.Request.Context(), pubID, revision)
+	if err != nil {
+		var apiErr *ports.ApiError
+		if errors.As(err, &apiErr) {
+			c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "An internal error occurred"})
+		return
+	}
+	c.JSON(http.StatusOK, ports.MapPublicationRevisionToResponse(rev))
+}
+
+// @Summary Diff Publication Revision
+// @Description Returns a line-based content diff from the given revision to another revision (against) or, if omitted, to the current content. Only the author (UserID) or an editor of the publication's business can perform this action.
+// @Tags publications
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Publication ID"
+// @Param revision path int true "Revision number to diff from"
+// @Param against query int false "Revision number to diff against (defaults to current content)"
+// @Success 200 {object} ports.PublicationRevisionDiffResponse "Diff computed successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid publication ID or revision number"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden (Not the author or an editor of its business)"
+// @Failure 404 {object} map[string]interface{} "ErrPublicationNotFound or ErrPublicationRevisionNotFound"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /publications/{id}/revisions/{revision}/diff [get]
+func (h *PublicationHandler) DiffPublicationRevision(c *gin.Context) {
+	pubID, revision, ok := h.parseRevisionParams(c)
+	if !ok {
+		return
+	}
+
+	var against *uint
+	if againstStr := c.Query("against"); againstStr != "" {
+		parsed, err := strconv.ParseUint(againstStr, 10, 32)
+		if err != nil || parsed == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid against revision number"})
+			return
+		}
+		againstRev := uint(parsed)
+		against = &againstRev
+	}
+
+	if _, apiErr := h.checkPublicationOwnership(c, pubID); apiErr != nil {
+		c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+		return
+	}
+
+	diff, err := h.publicationService.DiffPublicationRevision(c.Request.Context(), pubID, revision, against)
+	if err != nil {
+		var apiErr *ports.ApiError
+		if errors.As(err, &apiErr) {
+			c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "An internal error occurred"})
+		return
+	}
+	c.JSON(http.StatusOK, diff)
+}
+
+// @Summary Restore Publication Revision
+// @Description Restores the title, excerpt and content of a previous revision. The restore is recorded as a new revision. Only the author (UserID) or an editor of the publication's business can perform this action.
+// @Tags publications
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Publication ID"
+// @Param revision path int true "Revision number to restore"
+// @Success 200 {object} ports.PublicationResponse "Revision restored successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid publication ID or revision number"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden (Not the author or an editor of its business)"
+// @Failure 404 {object} map[string]interface{} "ErrPublicationNotFound or ErrPublicationRevisionNotFound"
+// @Failure 409 {object} map[string]interface{} "ErrPublicationSlugExists"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /publications/{id}/revisions/{revision}/restore [post]
+func (h *PublicationHandler) RestorePublicationRevision(c *gin.Context) {
+	pubID, revision, ok := h.parseRevisionParams(c)
+	if !ok {
+		return
+	}
+
+	authUserID, apiErr := h.checkPublicationOwnership(c, pubID)
+	if apiErr != nil {
+		c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+		return
+	}
+
+	publication, err := h.publicationService.RestorePublicationRevision(c.Request.Context(), pubID, revision, authUserID)
+	if err != nil {
+		var apiErr *ports.ApiError
+		if errors.As(err, &apiErr) {
+			c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "An internal error occurred"})
+		return
+	}
+	c.JSON(http.StatusOK, ports.MapPublicationToResponse(publication))
+}
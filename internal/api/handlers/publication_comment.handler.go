@@ -0,0 +1,396 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/constants"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/core/services"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/models"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/ports"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type PublicationCommentHandler struct {
+	commentService     *services.PublicationCommentService
+	publicationService *services.PublicationService
+	validate           *validator.Validate
+	routes             *constants.Routes
+}
+
+func NewPublicationCommentHandler(
+	commentService *services.PublicationCommentService,
+	publicationService *services.PublicationService,
+	routes *constants.Routes,
+) *PublicationCommentHandler {
+	return &PublicationCommentHandler{
+		commentService:     commentService,
+		publicationService: publicationService,
+		validate:           validator.New(),
+		routes:             routes,
+	}
+}
+
+func (h *PublicationCommentHandler) getAuthUserID(c *gin.Context) (uint, error) {
+	authUserIDVal, exists := c.Get(h.routes.ContextKeyUserID)
+	if !exists {
+		return 0, errors.New("invalid authentication context")
+	}
+	authUserID, ok := authUserIDVal.(uint)
+	if !ok || authUserID == 0 {
+		return 0, errors.New("invalid authentication context")
+	}
+	return authUserID, nil
+}
+
+func (h *PublicationCommentHandler) getViewerID(c *gin.Context) *uint {
+	authUserID, err := h.getAuthUserID(c)
+	if err != nil {
+		return nil
+	}
+	return &authUserID
+}
+
+func (h *PublicationCommentHandler) getVisiblePublication(c *gin.Context, pubID uint) (*models.Publication, *ports.ApiError) {
+	publication, err := h.publicationService.GetPublicationByID(c.Request.Context(), pubID)
+	if err != nil {
+		if errors.Is(err, ports.ErrPublicationNotFound) {
+			return nil, ports.ErrPublicationNotFound
+		}
+		return nil, ports.ErrDatabase
+	}
+	visible, err := h.publicationService.CanViewPublication(c.Request.Context(), publication, h.getViewerID(c))
+	if err != nil {
+		return nil, ports.ErrDatabase
+	}
+	if !visible {
+		return nil, ports.ErrPublicationNotFound
+	}
+	return publication, nil
+}
+
+func (h *PublicationCommentHandler) parseCommentID(c *gin.Context) (uint, bool) {
+	commentID, err := strconv.ParseUint(c.Param(h.routes.ParamKeyCommentID), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid comment ID"})
+		return 0, false
+	}
+	return uint(commentID), true
+}
+
+func (h *PublicationCommentHandler) getCommentForAuthUser(c *gin.Context, commentID uint) (*models.PublicationComment, uint, *ports.ApiError) {
+	authUserID, err := h.getAuthUserID(c)
+	if err != nil {
+		return nil, 0, ports.ErrInvalidToken
+	}
+	comment, err := h.commentService.GetCommentByID(c.Request.Context(), commentID)
+	if err != nil {
+		if errors.Is(err, ports.ErrCommentNotFound) {
+			return nil, 0, ports.ErrCommentNotFound
+		}
+		return nil, 0, ports.ErrDatabase
+	}
+	if _, apiErr := h.getVisiblePublication(c, comment.PublicationID); apiErr != nil {
+		return nil, 0, ports.ErrCommentNotFound
+	}
+	return comment, authUserID, nil
+}
+
+// @Summary List Publication Comments
+// @Description Retrieves the comment thread of a publication, oldest first, with replies nested under their parent. Deleted comments keep their place in the thread with an empty body; hidden comments are only shown in full to the publication author and the comment author. Authentication is optional, with the same visibility rules as the publication itself.
+// @Tags publications, comments
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Publication ID"
+// @Success 200 {object} ports.PublicationCommentsResponse "Comment thread"
+// @Failure 400 {object} map[string]interface{} "Invalid publication ID"
+// @Failure 401 {object} map[string]interface{} "Invalid token"
+// @Failure 404 {object} map[string]interface{} "ErrPublicationNotFound (also returned when not visible to the caller)"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /publications/{id}/comments [get]
+func (h *PublicationCommentHandler) GetPublicationComments(c *gin.Context) {
+	pubID, err := strconv.ParseUint(c.Param(h.routes.ParamKeyID), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid publication ID"})
+		return
+	}
+
+	publication, apiErr := h.getVisiblePublication(c, uint(pubID))
+	if apiErr != nil {
+		c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+		return
+	}
+
+	comments, err := h.commentService.GetCommentsForPublication(c.Request.Context(), publication.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve comments"})
+		return
+	}
+
+	viewerID := h.getViewerID(c)
+	moderator := func(comment *models.PublicationComment) bool {
+		return viewerID != nil && (*viewerID == publication.UserID || *viewerID == comment.UserID)
+	}
+	c.JSON(http.StatusOK, ports.MapPublicationCommentsToThread(comments, moderator))
+}
+
+// @Summary Create Publication Comment
+// @Description Adds a comment to a publication, optionally as a reply to another comment (parent_id). The body is sanitised (HTML and control characters stripped). The publication author, and the parent comment author for replies, are notified.
+// @Tags publications, comments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Publication ID"
+// @Param comment body ports.CreatePublicationCommentInput true "Comment body and optional parent comment ID"
+// @Success 201 {object} ports.PublicationCommentResponse "Comment created successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid publication ID, request body, empty body or invalid parent comment"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "ErrPublicationNotFound"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /publications/{id}/comments [post]
+func (h *PublicationCommentHandler) CreatePublicationComment(c *gin.Context) {
+	authUserID, err := h.getAuthUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	pubID, err := strconv.ParseUint(c.Param(h.routes.ParamKeyID), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid publication ID"})
+		return
+	}
+
+	if _, apiErr := h.getVisiblePublication(c, uint(pubID)); apiErr != nil {
+		c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+		return
+	}
+
+	var input ports.CreatePublicationCommentInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+	if err := h.validate.Struct(input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	input.PublicationID = uint(pubID)
+	input.UserID = authUserID
+
+	comment, err := h.commentService.CreateComment(c.Request.Context(), input)
+	if err != nil {
+		var apiErr *ports.ApiError
+		if errors.As(err, &apiErr) {
+			c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "An internal error occurred"})
+		return
+	}
+	c.JSON(http.StatusCreated, ports.MapPublicationCommentToResponse(comment, true))
+}
+
+// @Summary Update Publication Comment
+// @Description Edits the body of a comment. Only the comment author can perform this action. The body is sanitised and the comment is marked as edited.
+// @Tags publications, comments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param commentID path int true "Comment ID"
+// @Param comment body ports.UpdatePublicationCommentInput true "New comment body"
+// @Success 200 {object} ports.PublicationCommentResponse "Comment updated successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid comment ID, request body or empty body"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden (Not the comment author)"
+// @Failure 404 {object} map[string]interface{} "ErrCommentNotFound"
+// @Failure 409 {object} map[string]interface{} "ErrCommentDeleted"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /publications/comments/{commentID} [put]
+func (h *PublicationCommentHandler) UpdatePublicationComment(c *gin.Context) {
+	commentID, ok := h.parseCommentID(c)
+	if !ok {
+		return
+	}
+
+	comment, authUserID, apiErr := h.getCommentForAuthUser(c, commentID)
+	if apiErr != nil {
+		c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+		return
+	}
+	if comment.UserID != authUserID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Forbidden: Only the comment author can edit this comment"})
+		return
+	}
+
+	var input ports.UpdatePublicationCommentInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+	if err := h.validate.Struct(input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updated, err := h.commentService.UpdateComment(c.Request.Context(), comment.ID, input)
+	if err != nil {
+		var apiErr *ports.ApiError
+		if errors.As(err, &apiErr) {
+			c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "An internal error occurred"})
+		return
+	}
+	c.JSON(http.StatusOK, ports.MapPublicationCommentToResponse(updated, true))
+}
+
+// @Summary Delete Publication Comment
+// @Description Soft-deletes a comment: its body is cleared but it keeps its place in the thread so replies stay attached. The comment author and the publication author can perform this action.
+// @Tags publications, comments
+// @Produce json
+// @Security BearerAuth
+// @Param commentID path int true "Comment ID"
+// @Success 204 "Comment deleted successfully (No Content)"
+// @Failure 400 {object} map[string]interface{} "Invalid comment ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden (Not the comment or publication author)"
+// @Failure 404 {object} map[string]interface{} "ErrCommentNotFound"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /publications/comments/{commentID} [delete]
+func (h *PublicationCommentHandler) DeletePublicationComment(c *gin.Context) {
+	commentID, ok := h.parseCommentID(c)
+	if !ok {
+		return
+	}
+
+	comment, authUserID, apiErr := h.getCommentForAuthUser(c, commentID)
+	if apiErr != nil {
+		c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+		return
+	}
+	if comment.UserID != authUserID && comment.Publication.UserID != authUserID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Forbidden: Cannot delete this comment"})
+		return
+	}
+
+	if err := h.commentService.DeleteComment(c.Request.Context(), comment.ID); err != nil {
+		var apiErr *ports.ApiError
+		if errors.As(err, &apiErr) {
+			c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "An internal error occurred"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Flag Publication Comment
+// @Description Flags a comment for review by the publication author. Any authenticated user who can see the publication can flag a comment.
+// @Tags publications, comments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param commentID path int true "Comment ID"
+// @Param flag body ports.FlagPublicationCommentInput true "Reason for flagging"
+// @Success 200 {object} ports.PublicationCommentResponse "Comment flagged successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid comment ID or request body"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "ErrCommentNotFound"
+// @Failure 409 {object} map[string]interface{} "ErrCommentDeleted"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /publications/comments/{commentID}/flag [post]
+func (h *PublicationCommentHandler) FlagPublicationComment(c *gin.Context) {
+	commentID, ok := h.parseCommentID(c)
+	if !ok {
+		return
+	}
+
+	comment, authUserID, apiErr := h.getCommentForAuthUser(c, commentID)
+	if apiErr != nil {
+		c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+		return
+	}
+
+	var input ports.FlagPublicationCommentInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+	if err := h.validate.Struct(input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	flagged, err := h.commentService.FlagComment(c.Request.Context(), comment.ID, input)
+	if err != nil {
+		var apiErr *ports.ApiError
+		if errors.As(err, &apiErr) {
+			c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "An internal error occurred"})
+		return
+	}
+	moderator := authUserID == comment.Publication.UserID || authUserID == comment.UserID
+	c.JSON(http.StatusOK, ports.MapPublicationCommentToResponse(flagged, moderator))
+}
+
+// @Summary Moderate Publication Comment
+// @Description Sets the moderation status of a comment to visible (clearing any flag) or hidden. Only the publication author can perform this action.
+// @Tags publications, comments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param commentID path int true "Comment ID"
+// @Param moderation body ports.ModeratePublicationCommentInput true "New status (visible or hidden)"
+// @Success 200 {object} ports.PublicationCommentResponse "Comment moderated successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid comment ID or request body"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden (Not the publication author)"
+// @Failure 404 {object} map[string]interface{} "ErrCommentNotFound"
+// @Failure 409 {object} map[string]interface{} "ErrCommentDeleted"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /publications/comments/{commentID}/moderation [put]
+func (h *PublicationCommentHandler) ModeratePublicationComment(c *gin.Context) {
+	commentID, ok := h.parseCommentID(c)
+	if !ok {
+		return
+	}
+
+	comment, authUserID, apiErr := h.getCommentForAuthUser(c, commentID)
+	if apiErr != nil {
+		c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+		return
+	}
+	if comment.Publication.UserID != authUserID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Forbidden: Only the publication author can moderate comments"})
+		return
+	}
+
+	var input ports.ModeratePublicationCommentInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+	if err := h.validate.Struct(input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	moderated, err := h.commentService.ModerateComment(c.Request.Context(), comment.ID, input)
+	if err != nil {
+		var apiErr *ports.ApiError
+		if errors.As(err, &apiErr) {
+			c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "An internal error occurred"})
+		return
+	}
+	c.JSON(http.StatusOK, ports.MapPublicationCommentToResponse(moderated, true))
+}
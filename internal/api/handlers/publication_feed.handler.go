@@ -0,0 +1,224 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/constants"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/core/services"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/ports"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	feedFormatRSS  = "rss"
+	feedFormatAtom = "atom"
+	feedFormatJSON = "json"
+	feedTitle      = "TIA Partners Publications"
+	feedCacheSize  = 128
+)
+
+var feedContentTypes = map[string]string{
+	feedFormatRSS:  "application/rss+xml; charset=utf-8",
+	feedFormatAtom: "application/atom+xml; charset=utf-8",
+	feedFormatJSON: "application/feed+json; charset=utf-8",
+}
+
+type PublicationFeedHandler struct {
+	publicationService *services.PublicationService
+	validate           *validator.Validate
+	routes             *constants.Routes
+	siteURL            string
+	maxAge             time.Duration
+	cacheMu            sync.RWMutex
+	cache              map[string][]byte
+}
+
+func NewPublicationFeedHandler(
+	publicationService *services.PublicationService,
+	siteURL string,
+	maxAge time.Duration,
+	routes *constants.Routes,
+) *PublicationFeedHandler {
+	return &PublicationFeedHandler{
+		publicationService: publicationService,
+		validate:           validator.New(),
+		routes:             routes,
+		siteURL:            strings.TrimRight(siteURL, "/"),
+		maxAge:             maxAge,
+		cache:              make(map[string][]byte),
+	}
+}
+
+func (h *PublicationFeedHandler) selfURL(c *gin.Context) string {
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	if forwarded := c.GetHeader("X-Forwarded-Proto"); forwarded != "" {
+		scheme = forwarded
+	}
+	return scheme + "://" + c.Request.Host + c.Request.URL.RequestURI()
+}
+
+func feedETag(format string, filter ports.PublicationFeedFilter, state *ports.PublicationFeedState) string {
+	var lastModified int64
+	if state.LastModified != nil {
+		lastModified = state.LastModified.UnixNano()
+	}
+	key := fmt.Sprintf("%s|%d|%d|%d|%d", format, filter.Limit, state.Count, state.Checksum, lastModified)
+	if filter.PublicationType != nil {
+		key += "|" + string(*filter.PublicationType)
+	}
+	if filter.BusinessID != nil {
+		key += fmt.Sprintf("|b%d", *filter.BusinessID)
+	}
+	sum := sha256.Sum256([]byte(key))
+	return `"` + hex.EncodeToString(sum[:12]) + `"`
+}
+
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *PublicationFeedHandler) notModified(c *gin.Context, etag string, lastModified *time.Time) bool {
+	if header := c.GetHeader("If-None-Match"); header != "" {
+		return etagMatches(header, etag)
+	}
+	if header := c.GetHeader("If-Modified-Since"); header != "" && lastModified != nil {
+		since, err := http.ParseTime(header)
+		return err == nil && !lastModified.Truncate(time.Second).After(since)
+	}
+	return false
+}
+
+func (h *PublicationFeedHandler) cachedFeed(key string) ([]byte, bool) {
+	h.cacheMu.RLock()
+	defer h.cacheMu.RUnlock()
+	body, ok := h.cache[key]
+	return body, ok
+}
+
+func (h *PublicationFeedHandler) storeFeed(key string, body []byte) {
+	h.cacheMu.Lock()
+	defer h.cacheMu.Unlock()
+	if len(h.cache) >= feedCacheSize {
+		h.cache = make(map[string][]byte)
+	}
+	h.cache[key] = body
+}
+
+func (h *PublicationFeedHandler) serveFeed(c *gin.Context, format string) {
+	var filter ports.PublicationFeedFilter
+	if err := c.BindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
+		return
+	}
+	if err := h.validate.Struct(filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	state, err := h.publicationService.GetPublicationFeedState(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to build feed"})
+		return
+	}
+
+	etag := feedETag(format, filter, state)
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(h.maxAge.Seconds())))
+	if state.LastModified != nil {
+		c.Header("Last-Modified", state.LastModified.UTC().Format(http.TimeFormat))
+	}
+	if h.notModified(c, etag, state.LastModified) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	selfURL := h.selfURL(c)
+	body, ok := h.cachedFeed(etag + selfURL)
+	if !ok {
+		publications, err := h.publicationService.GetPublicationFeed(c.Request.Context(), filter)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to build feed"})
+			return
+		}
+		feed := ports.MapPublicationsToFeed(publications, feedTitle, h.siteURL, selfURL)
+		switch format {
+		case feedFormatAtom:
+			body, err = utils.RenderAtom(feed)
+		case feedFormatJSON:
+			body, err = utils.RenderJSONFeed(feed)
+		default:
+			body, err = utils.RenderRSS(feed)
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to build feed"})
+			return
+		}
+		h.storeFeed(etag+selfURL, body)
+	}
+	c.Data(http.StatusOK, feedContentTypes[format], body)
+}
+
+// @Summary Publications RSS Feed
+// @Description RSS 2.0 feed of published public publications, newest first. Thumbnails are exposed as enclosures. Supports ETag/If-None-Match and Last-Modified/If-Modified-Since (304 Not Modified).
+// @Tags publications, feeds
+// @Produce xml
+// @Param publication_type query string false "Filter by publication type (post, case_study, testimonial, article)"
+// @Param business_id query int false "Filter by business ID"
+// @Param limit query int false "Maximum number of items (1-100, default 50)"
+// @Success 200 {string} string "RSS 2.0 document"
+// @Success 304 "Not modified"
+// @Failure 400 {object} map[string]interface{} "Invalid query parameters"
+// @Failure 500 {object} map[string]interface{} "Failed to build feed"
+// @Router /publications/feed.rss [get]
+func (h *PublicationFeedHandler) GetRSSFeed(c *gin.Context) {
+	h.serveFeed(c, feedFormatRSS)
+}
+
+// @Summary Publications Atom Feed
+// @Description Atom 1.0 feed of published public publications, newest first. Thumbnails are exposed as enclosure links. Supports ETag/If-None-Match and Last-Modified/If-Modified-Since (304 Not Modified).
+// @Tags publications, feeds
+// @Produce xml
+// @Param publication_type query string false "Filter by publication type (post, case_study, testimonial, article)"
+// @Param business_id query int false "Filter by business ID"
+// @Param limit query int false "Maximum number of items (1-100, default 50)"
+// @Success 200 {string} string "Atom document"
+// @Success 304 "Not modified"
+// @Failure 400 {object} map[string]interface{} "Invalid query parameters"
+// @Failure 500 {object} map[string]interface{} "Failed to build feed"
+// @Router /publications/feed.atom [get]
+func (h *PublicationFeedHandler) GetAtomFeed(c *gin.Context) {
+	h.serveFeed(c, feedFormatAtom)
+}
+
+// @Summary Publications JSON Feed
+// @Description JSON Feed 1.1 of published public publications, newest first. Thumbnails are exposed as image and attachments. Supports ETag/If-None-Match and Last-Modified/If-Modified-Since (304 Not Modified).
+// @Tags publications, feeds
+// @Produce json
+// @Param publication_type query string false "Filter by publication type (post, case_study, testimonial, article)"
+// @Param business_id query int false "Filter by business ID"
+// @Param limit query int false "Maximum number of items (1-100, default 50)"
+// @Success 200 {object} map[string]interface{} "JSON Feed document"
+// @Success 304 "Not modified"
+// @Failure 400 {object} map[string]interface{} "Invalid query parameters"
+// @Failure 500 {object} map[string]interface{} "Failed to build feed"
+// @Router /publications/feed.json [get]
+func (h *PublicationFeedHandler) GetJSONFeed(c *gin.Context) {
+	h.serveFeed(c, feedFormatJSON)
+}
@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/constants"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/core/services"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/ports"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type PublicationReactionHandler struct {
+	reactionService    *services.PublicationReactionService
+	publicationService *services.PublicationService
+	validate           *validator.Validate
+	routes             *constants.Routes
+}
+
+func NewPublicationReactionHandler(
+	reactionService *services.PublicationReactionService,
+	publicationService *services.PublicationService,
+	routes *constants.Routes,
+) *PublicationReactionHandler {
+	return &PublicationReactionHandler{
+		reactionService:    reactionService,
+		publicationService: publicationService,
+		validate:           validator.New(),
+		routes:             routes,
+	}
+}
+
+func (h *PublicationReactionHandler) getAuthUserID(c *gin.Context) (uint, error) {
+	authUserIDVal, exists := c.Get(h.routes.ContextKeyUserID)
+	if !exists {
+		return 0, errors.New("invalid authentication context")
+	}
+	authUserID, ok := authUserIDVal.(uint)
+	if !ok || authUserID == 0 {
+		return 0, errors.New("invalid authentication context")
+	}
+	return authUserID, nil
+}
+
+func (h *PublicationReactionHandler) getViewerID(c *gin.Context) *uint {
+	authUserID, err := h.getAuthUserID(c)
+	if err != nil {
+		return nil
+	}
+	return &authUserID
+}
+
+func (h *PublicationReactionHandler) checkPublicationVisibility(c *gin.Context, pubID uint) *ports.ApiError {
+	publication, err := h.publicationService.GetPublicationByID(c.Request.Context(), pubID)
+	if err != nil {
+		if errors.Is(err, ports.ErrPublicationNotFound) {
+			return ports.ErrPublicationNotFound
+		}
+		return ports.ErrDatabase
+	}
+	visible, err := h.publicationService.CanViewPublication(c.Request.Context(), publication, h.getViewerID(c))
+	if err != nil {
+		return ports.ErrDatabase
+	}
+	if !visible {
+		return ports.ErrPublicationNotFound
+	}
+	return nil
+}
+
+// @Summary Get Publication Reactions
+// @Description Retrieves reaction counts per type for a publication, plus the caller's own reaction when authenticated. Authentication is optional, with the same visibility rules as the publication itself.
+// @Tags publications, reactions
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Publication ID"
+// @Success 200 {object} ports.PublicationReactionsSummaryResponse "Reaction summary"
+// @Failure 400 {object} map[string]interface{} "Invalid publication ID"
+// @Failure 401 {object} map[string]interface{} "Invalid token"
+// @Failure 404 {object} map[string]interface{} "ErrPublicationNotFound (also returned when not visible to the caller)"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /publications/{id}/reactions [get]
+func (h *PublicationReactionHandler) GetPublicationReactions(c *gin.Context) {
+	pubID, err := strconv.ParseUint(c.Param(h.routes.ParamKeyID), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid publication ID"})
+		return
+	}
+
+	if apiErr := h.checkPublicationVisibility(c, uint(pubID)); apiErr != nil {
+		c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+		return
+	}
+
+	summary, err := h.reactionService.GetReactionSummary(c.Request.Context(), uint(pubID), h.getViewerID(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve reactions"})
+		return
+	}
+	c.JSON(http.StatusOK, summary)
+}
+
+// @Summary Set Publication Reaction
+// @Description Sets the caller's reaction to a publication. Each user has at most one reaction per publication; setting a different type replaces it. The publication author is notified of new reactions.
+// @Tags publications, reactions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Publication ID"
+// @Param reaction body ports.SetPublicationReactionInput true "Reaction type (like, insightful, celebrate, support, curious)"
+// @Success 200 {object} ports.PublicationReactionResponse "Reaction set successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid publication ID or request body"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "ErrPublicationNotFound"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /publications/{id}/reactions [put]
+func (h *PublicationReactionHandler) SetPublicationReaction(c *gin.Context) {
+	authUserID, err := h.getAuthUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	pubID, err := strconv.ParseUint(c.Param(h.routes.ParamKeyID), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid publication ID"})
+		return
+	}
+
+	if apiErr := h.checkPublicationVisibility(c, uint(pubID)); apiErr != nil {
+		c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+		return
+	}
+
+	var input ports.SetPublicationReactionInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+	if err := h.validate.Struct(input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reaction, err := h.reactionService.SetReaction(c.Request.Context(), uint(pubID), authUserID, input)
+	if err != nil {
+		var apiErr *ports.ApiError
+		if errors.As(err, &apiErr) {
+			c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "An internal error occurred"})
+		return
+	}
+	c.JSON(http.StatusOK, ports.MapPublicationReactionToResponse(reaction))
+}
+
+// @Summary Remove Publication Reaction
+// @Description Removes the caller's reaction from a publication.
+// @Tags publications, reactions
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Publication ID"
+// @Success 204 "Reaction removed successfully (No Content)"
+// @Failure 400 {object} map[string]interface{} "Invalid publication ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "ErrReactionNotFound"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /publications/{id}/reactions [delete]
+func (h *PublicationReactionHandler) RemovePublicationReaction(c *gin.Context) {
+	authUserID, err := h.getAuthUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	pubID, err := strconv.ParseUint(c.Param(h.routes.ParamKeyID), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid publication ID"})
+		return
+	}
+
+	if err := h.reactionService.RemoveReaction(c.Request.Context(), uint(pubID), authUserID); err != nil {
+		var apiErr *ports.ApiError
+		if errors.As(err, &apiErr) {
+			c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "An internal error occurred"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,274 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/constants"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/core/services"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/ports"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type ReferralHandler struct {
+	service  *services.ReferralService
+	validate *validator.Validate
+	routes   *constants.Routes
+}
+
+func NewReferralHandler(service *services.ReferralService, routes *constants.Routes) *ReferralHandler {
+	return &ReferralHandler{
+		service:  service,
+		validate: validator.New(),
+		routes:   routes,
+	}
+}
+
+func (h *ReferralHandler) getAuthUserID(c *gin.Context) (uint, error) {
+	authUserIDVal, exists := c.Get(h.routes.ContextKeyUserID)
+	if !exists {
+		return 0, errors.New("invalid authentication context")
+	}
+	authUserID, ok := authUserIDVal.(uint)
+	if !ok || authUserID == 0 {
+		return 0, errors.New("invalid authentication context")
+	}
+	return authUserID, nil
+}
+
+func (h *ReferralHandler) parseIDParam(c *gin.Context, label string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param(h.routes.ParamKeyID), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + label + " ID format"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func (h *ReferralHandler) handleError(c *gin.Context, err error) {
+	var apiErr *ports.ApiError
+	if errors.As(err, &apiErr) {
+		c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "An internal error occurred"})
+}
+
+// @Summary Record Referral
+// @Description Records that the referrer business referred a client to the receiving business. Requires the editor role in the referring business. Admins of the receiving business are notified, and the referral is linked to an active Referral connection between the two businesses when one exists.
+// @Tags referrals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param referral body ports.CreateReferralInput true "Referral details"
+// @Success 201 {object} ports.ReferralResponse "Referral recorded"
+// @Failure 400 {object} map[string]interface{} "Invalid input or ErrInvalidReferral"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden (Insufficient role in the referring business)"
+// @Failure 404 {object} map[string]interface{} "ErrBusinessNotFound"
+// @Router /referrals [post]
+func (h *ReferralHandler) CreateReferral(c *gin.Context) {
+	authUserID, err := h.getAuthUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	var input ports.CreateReferralInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body: " + err.Error()})
+		return
+	}
+	if err := h.validate.Struct(input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	referral, err := h.service.CreateReferral(c.Request.Context(), authUserID, input)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, ports.MapToReferralResponse(referral))
+}
+
+// @Summary Get Referral
+// @Description Retrieves a referral. Visible to members of the referring and receiving businesses.
+// @Tags referrals
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Referral ID"
+// @Success 200 {object} ports.ReferralResponse "Referral"
+// @Failure 400 {object} map[string]interface{} "Invalid referral ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "ErrReferralNotFound"
+// @Router /referrals/{id} [get]
+func (h *ReferralHandler) GetReferral(c *gin.Context) {
+	authUserID, err := h.getAuthUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	id, ok := h.parseIDParam(c, "referral")
+	if !ok {
+		return
+	}
+	referral, err := h.service.GetReferral(c.Request.Context(), id, authUserID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, ports.MapToReferralResponse(referral))
+}
+
+// @Summary Update Referral
+// @Description Updates a referral's notes or deal value (editors of either business) or moves its status forward (editors of the receiving business only). Status moves from sent to contacted and then to won or lost; won and lost referrals are closed.
+// @Tags referrals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Referral ID"
+// @Param referral body ports.UpdateReferralInput true "Fields to update"
+// @Success 200 {object} ports.ReferralResponse "Updated referral"
+// @Failure 400 {object} map[string]interface{} "Invalid input, ErrReferralClosed or ErrInvalidReferralStatus"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden or ErrNotReferralReceiver"
+// @Failure 404 {object} map[string]interface{} "ErrReferralNotFound"
+// @Router /referrals/{id} [put]
+func (h *ReferralHandler) UpdateReferral(c *gin.Context) {
+	authUserID, err := h.getAuthUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	id, ok := h.parseIDParam(c, "referral")
+	if !ok {
+		return
+	}
+	var input ports.UpdateReferralInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body: " + err.Error()})
+		return
+	}
+	if err := h.validate.Struct(input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	referral, err := h.service.UpdateReferral(c.Request.Context(), id, authUserID, input)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, ports.MapToReferralResponse(referral))
+}
+
+// @Summary Delete Referral
+// @Description Deletes a referral that the receiving business has not yet actioned. Requires the editor role in the referring business.
+// @Tags referrals
+// @Security BearerAuth
+// @Param id path int true "Referral ID"
+// @Success 204 "Referral deleted"
+// @Failure 400 {object} map[string]interface{} "Invalid referral ID or ErrReferralNotDeletable"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden (Insufficient role in the referring business)"
+// @Failure 404 {object} map[string]interface{} "ErrReferralNotFound"
+// @Router /referrals/{id} [delete]
+func (h *ReferralHandler) DeleteReferral(c *gin.Context) {
+	authUserID, err := h.getAuthUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	id, ok := h.parseIDParam(c, "referral")
+	if !ok {
+		return
+	}
+	if err := h.service.DeleteReferral(c.Request.Context(), id, authUserID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary List Business Referrals
+// @Description Lists referrals a business has given or received. Requires membership of the business.
+// @Tags businesses, referrals
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Business ID"
+// @Param direction query string false "Filter by direction (given, received)"
+// @Param status query string false "Filter by status (sent, contacted, won, lost)"
+// @Success 200 {object} ports.ReferralsResponse "Referrals"
+// @Failure 400 {object} map[string]interface{} "Invalid business ID or query parameters"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden (Not a member of the business)"
+// @Failure 404 {object} map[string]interface{} "ErrBusinessNotFound"
+// @Router /businesses/{id}/referrals [get]
+func (h *ReferralHandler) GetBusinessReferrals(c *gin.Context) {
+	authUserID, err := h.getAuthUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	businessID, ok := h.parseIDParam(c, "business")
+	if !ok {
+		return
+	}
+	var filter ports.ReferralsFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
+		return
+	}
+	if err := h.validate.Struct(filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	referrals, err := h.service.GetBusinessReferrals(c.Request.Context(), businessID, authUserID, filter)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, ports.MapToReferralsResponse(referrals))
+}
+
+// @Summary Referral Report
+// @Description Reports referrals given and received by a business per month or quarter, including how many were won and their total deal value. Defaults to the last twelve months by quarter. Requires membership of the business.
+// @Tags businesses, referrals
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Business ID"
+// @Param from query string false "Start date (YYYY-MM-DD), defaults to one year before 'to'"
+// @Param to query string false "End date inclusive (YYYY-MM-DD), defaults to today"
+// @Param interval query string false "Bucket size (month, quarter); defaults to quarter"
+// @Success 200 {object} ports.ReferralReportResponse "Referral report"
+// @Failure 400 {object} map[string]interface{} "Invalid business ID, query parameters or ErrInvalidReportRange"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden (Not a member of the business)"
+// @Failure 404 {object} map[string]interface{} "ErrBusinessNotFound"
+// @Router /businesses/{id}/referrals/report [get]
+func (h *ReferralHandler) GetReferralReport(c *gin.Context) {
+	authUserID, err := h.getAuthUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	businessID, ok := h.parseIDParam(c, "business")
+	if !ok {
+		return
+	}
+	var query ports.ReferralReportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
+		return
+	}
+	if err := h.validate.Struct(query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	report, err := h.service.GetReferralReport(c.Request.Context(), businessID, authUserID, query)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
@@ -6,6 +6,22 @@ import (
 	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/core/services" 
 	"github.com/gin-gonic/gin"
 )
+func authenticate(c *gin.Context, authService *services.AuthService, routes *constants.Routes, authHeader string) bool {
+	parts := strings.Split(authHeader, " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization header format must be Bearer {token}"})
+		return false
+	}
+	user, session, err := authService.ValidateToken(c.Request.Context(), parts[1])
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+		return false
+	}
+	c.Set(routes.ContextKeyUser, user)
+	c.Set(routes.ContextKeyUserID, user.ID)
+	c.Set(routes.ContextKeySessionID, session.ID)
+	return true
+}
 func AuthMiddleware(authService *services.AuthService, routes *constants.Routes) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
@@ -13,20 +29,18 @@ func AuthMiddleware(authService *services.AuthService, routes *constants.Routes)
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization header is required"})
 			return
 		}
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization header format must be Bearer {token}"})
+		if !authenticate(c, authService, routes, authHeader) {
 			return
 		}
-		token := parts[1]
-		user, session, err := authService.ValidateToken(c.Request.Context(), token)
-		if err != nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+		c.Next()
+	}
+}
+func OptionalAuthMiddleware(authService *services.AuthService, routes *constants.Routes) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.GetHeader("Authorization")
+		if authHeader != "" && !authenticate(c, authService, routes, authHeader) {
 			return
 		}
-		c.Set(routes.ContextKeyUser, user)
-		c.Set(routes.ContextKeyUserID, user.ID)
-		c.Set(routes.ContextKeySessionID, session.ID)
 		c.Next()
 	}
 }
@@ -11,6 +11,8 @@ func SetupBusinessRoutes(api *gin.RouterGroup, deps *RouterDependencies) {
 		businesses.GET(deps.Routes.ParamID, deps.BusinessHandler.GetBusinessByID)
 		
 		businesses.GET(deps.Routes.BusinessConnects, deps.AuthMiddleware, deps.BusinessConnectionHandler.GetBusinessConnections)
+		businesses.GET(deps.Routes.BusinessNetwork, deps.AuthMiddleware, deps.BusinessConnectionHandler.GetBusinessNetwork)
+		businesses.GET(deps.Routes.BusinessMutual, deps.AuthMiddleware, deps.BusinessConnectionHandler.GetMutualConnections)
 		
 		protectedBusinesses := businesses.Group("")
 		protectedBusinesses.Use(deps.AuthMiddleware)
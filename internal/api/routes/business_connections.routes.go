@@ -11,6 +11,10 @@ func SetupConnectionRoutes(api *gin.RouterGroup, deps *RouterDependencies) {
 		connections.PUT(deps.Routes.ParamID, deps.BusinessConnectionHandler.UpdateBusinessConnection)
 		connections.POST(deps.Routes.ConnectAccept, deps.BusinessConnectionHandler.AcceptBusinessConnection)
 		connections.POST(deps.Routes.ConnectReject, deps.BusinessConnectionHandler.RejectBusinessConnection)
+		connections.POST(deps.Routes.ConnectWithdraw, deps.BusinessConnectionHandler.WithdrawBusinessConnection)
+		connections.POST(deps.Routes.ConnectDeactivate, deps.BusinessConnectionHandler.DeactivateBusinessConnection)
+		connections.POST(deps.Routes.ConnectReactivate, deps.BusinessConnectionHandler.ReactivateBusinessConnection)
+		connections.GET(deps.Routes.ConnectHistory, deps.BusinessConnectionHandler.GetBusinessConnectionHistory)
 		connections.DELETE(deps.Routes.ParamID, deps.BusinessConnectionHandler.DeleteBusinessConnection)
 	}
 }
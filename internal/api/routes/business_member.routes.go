@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+func SetupBusinessMemberRoutes(api *gin.RouterGroup, deps *RouterDependencies) {
+	businesses := api.Group(deps.Routes.BusinessBase)
+	businesses.Use(deps.AuthMiddleware)
+	{
+		businesses.GET(deps.Routes.BusinessMembers, deps.BusinessMemberHandler.GetBusinessMembers)
+		businesses.PUT(deps.Routes.BusinessMember, deps.BusinessMemberHandler.UpdateBusinessMemberRole)
+		businesses.DELETE(deps.Routes.BusinessMember, deps.BusinessMemberHandler.RemoveBusinessMember)
+		businesses.POST(deps.Routes.BusinessTransfer, deps.BusinessMemberHandler.TransferBusinessOwnership)
+		businesses.GET(deps.Routes.BusinessInvites, deps.BusinessMemberHandler.GetBusinessInvites)
+		businesses.POST(deps.Routes.BusinessInvites, deps.BusinessMemberHandler.CreateBusinessInvite)
+		businesses.DELETE(deps.Routes.BusinessInvite, deps.BusinessMemberHandler.RevokeBusinessInvite)
+	}
+
+	invites := api.Group(deps.Routes.BusinessInviteBase)
+	invites.Use(deps.AuthMiddleware)
+	{
+		invites.GET("", deps.BusinessMemberHandler.GetMyBusinessInvites)
+		invites.POST(deps.Routes.ConnectAccept, deps.BusinessMemberHandler.AcceptBusinessInvite)
+		invites.POST(deps.Routes.InviteDecline, deps.BusinessMemberHandler.DeclineBusinessInvite)
+	}
+}
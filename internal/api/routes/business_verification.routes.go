@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+func SetupBusinessVerificationRoutes(api *gin.RouterGroup, deps *RouterDependencies) {
+	businesses := api.Group(deps.Routes.BusinessBase)
+	businesses.Use(deps.AuthMiddleware)
+	{
+		businesses.POST(deps.Routes.BusinessVerify, deps.BusinessVerificationHandler.RequestVerification)
+		businesses.GET(deps.Routes.BusinessVerify, deps.BusinessVerificationHandler.GetBusinessVerification)
+		businesses.POST(deps.Routes.BusinessVerCheck, deps.BusinessVerificationHandler.CheckVerification)
+		businesses.POST(deps.Routes.BusinessVerRevoke, deps.BusinessVerificationHandler.RevokeVerification)
+	}
+
+	verifications := api.Group(deps.Routes.VerificationBase)
+	verifications.Use(deps.AuthMiddleware)
+	{
+		verifications.GET("", deps.BusinessVerificationHandler.GetVerificationQueue)
+		verifications.POST(deps.Routes.VerifyApprove, deps.BusinessVerificationHandler.ApproveVerification)
+		verifications.POST(deps.Routes.VerifyReject, deps.BusinessVerificationHandler.RejectVerification)
+	}
+}
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+func SetupIntroductionRequestRoutes(api *gin.RouterGroup, deps *RouterDependencies) {
+	api.GET(deps.Routes.BusinessBase+deps.Routes.BusinessIntros, deps.AuthMiddleware, deps.IntroductionRequestHandler.GetBusinessIntroductions)
+
+	introductions := api.Group(deps.Routes.IntroductionBase)
+	introductions.Use(deps.AuthMiddleware)
+	{
+		introductions.POST("", deps.IntroductionRequestHandler.CreateIntroductionRequest)
+		introductions.GET(deps.Routes.ParamID, deps.IntroductionRequestHandler.GetIntroductionRequest)
+		introductions.POST(deps.Routes.ConnectAccept, deps.IntroductionRequestHandler.AcceptIntroductionRequest)
+		introductions.POST(deps.Routes.InviteDecline, deps.IntroductionRequestHandler.DeclineIntroductionRequest)
+	}
+}
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+func SetupMediaRoutes(api *gin.RouterGroup, deps *RouterDependencies) {
+	publicMedia := api.Group(deps.Routes.MediaBase)
+	{
+		publicMedia.GET(deps.Routes.ParamID, deps.MediaHandler.GetMedia)
+		publicMedia.GET(deps.Routes.MediaContent, deps.MediaHandler.GetMediaContent)
+	}
+
+	media := api.Group(deps.Routes.MediaBase)
+	media.Use(deps.AuthMiddleware)
+	{
+		media.POST("", deps.MediaHandler.UploadMedia)
+		media.DELETE(deps.Routes.ParamID, deps.MediaHandler.DeleteMedia)
+	}
+}
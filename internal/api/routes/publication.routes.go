@@ -5,17 +5,43 @@ import (
 )
 
 func SetupPublicationRoutes(api *gin.RouterGroup, deps *RouterDependencies) {
+	publicPublications := api.Group(deps.Routes.PublicationBase)
+	publicPublications.Use(deps.OptionalAuthMiddleware)
+	{
+		publicPublications.GET("", deps.PublicationHandler.GetAllPublications)
+
+		publicPublications.GET(deps.Routes.PublicationFeedRSS, deps.PublicationFeedHandler.GetRSSFeed)
+		publicPublications.GET(deps.Routes.PublicationFeedAtom, deps.PublicationFeedHandler.GetAtomFeed)
+		publicPublications.GET(deps.Routes.PublicationFeedJSON, deps.PublicationFeedHandler.GetJSONFeed)
+
+		publicPublications.GET(deps.Routes.PublicationByID, deps.PublicationHandler.GetPublicationByID)
+
+		publicPublications.GET(deps.Routes.PublicationBySlug, deps.PublicationHandler.GetPublicationBySlug)
+
+		publicPublications.GET(deps.Routes.PublicationComments, deps.PublicationCommentHandler.GetPublicationComments)
+		publicPublications.GET(deps.Routes.PublicationReactions, deps.PublicationReactionHandler.GetPublicationReactions)
+	}
+
 	publications := api.Group(deps.Routes.PublicationBase)
 	publications.Use(deps.AuthMiddleware)
 	{
 		publications.POST("", deps.PublicationHandler.CreatePublication)
-		publications.GET("", deps.PublicationHandler.GetAllPublications)
-
-		publications.GET(deps.Routes.PublicationByID, deps.PublicationHandler.GetPublicationByID)
-
-		publications.GET(deps.Routes.PublicationBySlug, deps.PublicationHandler.GetPublicationBySlug)
 
 		publications.PUT(deps.Routes.ParamID, deps.PublicationHandler.UpdatePublication)
 		publications.DELETE(deps.Routes.ParamID, deps.PublicationHandler.DeletePublication)
+
+		publications.GET(deps.Routes.PublicationRevisions, deps.PublicationHandler.GetPublicationRevisions)
+		publications.GET(deps.Routes.PublicationRevision, deps.PublicationHandler.GetPublicationRevision)
+		publications.GET(deps.Routes.PublicationRevDiff, deps.PublicationHandler.DiffPublicationRevision)
+		publications.POST(deps.Routes.PublicationRevRestore, deps.PublicationHandler.RestorePublicationRevision)
+
+		publications.POST(deps.Routes.PublicationComments, deps.PublicationCommentHandler.CreatePublicationComment)
+		publications.PUT(deps.Routes.PublicationComment, deps.PublicationCommentHandler.UpdatePublicationComment)
+		publications.DELETE(deps.Routes.PublicationComment, deps.PublicationCommentHandler.DeletePublicationComment)
+		publications.POST(deps.Routes.PublicationCmtFlag, deps.PublicationCommentHandler.FlagPublicationComment)
+		publications.PUT(deps.Routes.PublicationCmtMod, deps.PublicationCommentHandler.ModeratePublicationComment)
+
+		publications.PUT(deps.Routes.PublicationReactions, deps.PublicationReactionHandler.SetPublicationReaction)
+		publications.DELETE(deps.Routes.PublicationReactions, deps.PublicationReactionHandler.RemovePublicationReaction)
 	}
 }
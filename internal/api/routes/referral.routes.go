@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+func SetupReferralRoutes(api *gin.RouterGroup, deps *RouterDependencies) {
+	businesses := api.Group(deps.Routes.BusinessBase)
+	businesses.Use(deps.AuthMiddleware)
+	{
+		businesses.GET(deps.Routes.BusinessReferrals, deps.ReferralHandler.GetBusinessReferrals)
+		businesses.GET(deps.Routes.BusinessRefReport, deps.ReferralHandler.GetReferralReport)
+	}
+
+	referrals := api.Group(deps.Routes.ReferralBase)
+	referrals.Use(deps.AuthMiddleware)
+	{
+		referrals.POST("", deps.ReferralHandler.CreateReferral)
+		referrals.GET(deps.Routes.ParamID, deps.ReferralHandler.GetReferral)
+		referrals.PUT(deps.Routes.ParamID, deps.ReferralHandler.UpdateReferral)
+		referrals.DELETE(deps.Routes.ParamID, deps.ReferralHandler.DeleteReferral)
+	}
+}
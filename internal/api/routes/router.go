@@ -8,12 +8,17 @@ import (
 
 type RouterDependencies struct {
 	AuthMiddleware                gin.HandlerFunc
+	OptionalAuthMiddleware        gin.HandlerFunc
 	UserHandler                   *handlers.UserHandler
 	AuthHandler                   *handlers.AuthHandler
 	BusinessHandler               *handlers.BusinessHandler
 	ProjectHandler                *handlers.ProjectHandler
 	BusinessConnectionHandler     *handlers.BusinessConnectionHandler
 	BusinessTagHandler            *handlers.BusinessTagHandler
+	BusinessMemberHandler         *handlers.BusinessMemberHandler
+	IntroductionRequestHandler    *handlers.IntroductionRequestHandler
+	ReferralHandler               *handlers.ReferralHandler
+	BusinessVerificationHandler   *handlers.BusinessVerificationHandler
 	DailyActivityHandler          *handlers.DailyActivityHandler
 	DailyActivityEnrolmentHandler *handlers.DailyActivityEnrolmentHandler
 	EventHandler                  *handlers.EventHandler
@@ -26,6 +31,10 @@ type RouterDependencies struct {
 	ProjectRegionHandler          *handlers.ProjectRegionHandler    
 	ProjectSkillHandler           *handlers.ProjectSkillHandler     
 	PublicationHandler            *handlers.PublicationHandler      
+	PublicationCommentHandler     *handlers.PublicationCommentHandler
+	PublicationReactionHandler    *handlers.PublicationReactionHandler
+	PublicationFeedHandler        *handlers.PublicationFeedHandler
+	MediaHandler                  *handlers.MediaHandler
 	SkillHandler                  *handlers.SkillHandler            
 	SubscriptionHandler           *handlers.SubscriptionHandler     
 	UserSubscriptionHandler       *handlers.UserSubscriptionHandler 
@@ -43,7 +52,11 @@ func RegisterRoutes(router *gin.Engine, deps *RouterDependencies) {
 	SetupBusinessRoutes(api, deps)
 	SetupProjectRoutes(api, deps)
 	SetupBusinessTagRoutes(api, deps)
+	SetupBusinessMemberRoutes(api, deps)
 	SetupConnectionRoutes(api, deps)
+	SetupIntroductionRequestRoutes(api, deps)
+	SetupReferralRoutes(api, deps)
+	SetupBusinessVerificationRoutes(api, deps)
 	SetupConnectionRecommendationRoutes(api, deps)
 	SetupDailyActivityRoutes(api, deps)
 	SetupEventRoutes(api, deps)
@@ -54,4 +67,5 @@ func RegisterRoutes(router *gin.Engine, deps *RouterDependencies) {
 	SetupPublicationRoutes(api, deps)  
 	SetupSubscriptionRoutes(api, deps) 
 	SetupSkillRoutes(api, deps)
+	SetupMediaRoutes(api, deps)
 }
@@ -21,6 +21,11 @@ type Routes struct {
 	NotifyBase          string
 	DailyActBase        string
 	InferredBase        string
+	MediaBase           string
+	BusinessInviteBase  string
+	IntroductionBase    string
+	ReferralBase        string
+	VerificationBase    string
 	ContextKeyUser      string
 	ContextKeyUserID    string
 	ContextKeySessionID string
@@ -31,10 +36,36 @@ type Routes struct {
 
 	PublicationByID       string 
 	PublicationBySlug     string 
+	PublicationRevisions  string
+	PublicationRevision   string
+	PublicationRevDiff    string
+	PublicationRevRestore string
+	PublicationComments   string
+	PublicationComment    string
+	PublicationCmtFlag    string
+	PublicationCmtMod     string
+	PublicationReactions  string
+	PublicationFeedRSS    string
+	PublicationFeedAtom   string
+	PublicationFeedJSON   string
+	MediaContent          string
 	SubscriptionSubscribe string 
 
 	BusinessTags       string
 	BusinessConnects   string
+	BusinessNetwork    string
+	BusinessMutual     string
+	BusinessIntros     string
+	BusinessReferrals  string
+	BusinessRefReport  string
+	BusinessVerify     string
+	BusinessVerCheck   string
+	BusinessVerRevoke  string
+	BusinessMembers    string
+	BusinessMember     string
+	BusinessInvites    string
+	BusinessInvite     string
+	BusinessTransfer   string
 	ProjectMembers     string
 	ProjectApplicants  string 
 	ProjectApply       string 
@@ -44,8 +75,15 @@ type Routes struct {
 
 	DailyActEnrol string
 
-	ConnectAccept string
-	ConnectReject string
+	ConnectAccept     string
+	ConnectReject     string
+	ConnectWithdraw   string
+	ConnectDeactivate string
+	ConnectReactivate string
+	ConnectHistory    string
+	InviteDecline     string
+	VerifyApprove     string
+	VerifyReject      string
 
 	UserEnrolments    string
 	UserL2EResponses  string
@@ -62,8 +100,12 @@ type Routes struct {
 	ParamKeyRegionID       string 
 	ParamKeySkillID        string 
 	ParamKeySlug           string 
+	ParamKeyRevision       string
+	ParamKeyCommentID      string
 	ParamKeySubscriptionID string 
 	ParamKeyConfigType     string 
+	ParamKeyInviteID       string
+	ParamKeyOtherID        string
 
 	ParamID                string
 	ParamNotificationID    string
@@ -100,6 +142,10 @@ var AppRoutes = Routes{
 	NotifyBase:       "/notifications",
 	DailyActBase:     "/daily-activities",
 	InferredBase:     "/inferred-connections",
+	MediaBase:        "/media",
+	IntroductionBase: "/introductions",
+	ReferralBase:     "/referrals",
+	VerificationBase: "/verifications",
 
 	SkillToggleStatus: "/toggle-status", 
 
@@ -109,8 +155,35 @@ var AppRoutes = Routes{
 	Me:                     "/me",
 	PublicationByID:        "/id/:id",     
 	PublicationBySlug:      "/slug/:slug", 
+	PublicationRevisions:   "/:id/revisions",
+	PublicationRevision:    "/:id/revisions/:revision",
+	PublicationRevDiff:     "/:id/revisions/:revision/diff",
+	PublicationRevRestore:  "/:id/revisions/:revision/restore",
+	PublicationComments:    "/:id/comments",
+	PublicationComment:     "/comments/:commentID",
+	PublicationCmtFlag:     "/comments/:commentID/flag",
+	PublicationCmtMod:      "/comments/:commentID/moderation",
+	PublicationReactions:   "/:id/reactions",
+	PublicationFeedRSS:     "/feed.rss",
+	PublicationFeedAtom:    "/feed.atom",
+	PublicationFeedJSON:    "/feed.json",
+	MediaContent:           "/:id/content",
 	BusinessTags:           "/:id/tags",
 	BusinessConnects:       "/:id/connections",
+	BusinessNetwork:        "/:id/network",
+	BusinessMutual:         "/:id/mutual-connections/:otherID",
+	BusinessIntros:         "/:id/introductions",
+	BusinessReferrals:      "/:id/referrals",
+	BusinessRefReport:      "/:id/referrals/report",
+	BusinessVerify:         "/:id/verification",
+	BusinessVerCheck:       "/:id/verification/check",
+	BusinessVerRevoke:      "/:id/verification/revoke",
+	BusinessMembers:        "/:id/members",
+	BusinessMember:         "/:id/members/:userID",
+	BusinessInvites:        "/:id/invites",
+	BusinessInvite:         "/:id/invites/:inviteID",
+	BusinessTransfer:       "/:id/transfer-ownership",
+	BusinessInviteBase:     "/business-invites",
 	ProjectMembers:         "/:id/members",
 	ProjectApplicants:      "/:id/applicants", 
 	ProjectApply:           "/:id/apply",      
@@ -119,6 +192,13 @@ var AppRoutes = Routes{
 	DailyActEnrol:          "/:id/enrolments",
 	ConnectAccept:          "/:id/accept",
 	ConnectReject:          "/:id/reject",
+	ConnectWithdraw:        "/:id/withdraw",
+	ConnectDeactivate:      "/:id/deactivate",
+	ConnectReactivate:      "/:id/reactivate",
+	ConnectHistory:         "/:id/history",
+	InviteDecline:          "/:id/decline",
+	VerifyApprove:          "/:id/approve",
+	VerifyReject:           "/:id/reject",
 	UserEnrolments:         "/:id/enrolments",
 	UserL2EResponses:       "/:id/l2e-responses",
 	UserNotifications:      "/:id/notifications",
@@ -136,8 +216,12 @@ var AppRoutes = Routes{
 	ParamKeyRegionID:       "regionID",           
 	ParamKeySkillID:        "skillID",            
 	ParamKeySlug:           "slug",               
+	ParamKeyRevision:       "revision",
+	ParamKeyCommentID:      "commentID",
 	ParamKeySubscriptionID: "userSubscriptionID", 
 	ParamKeyConfigType:     "configType",         
+	ParamKeyInviteID:       "inviteID",
+	ParamKeyOtherID:        "otherID",
 	ParamID:                "/:id",
 	ParamNotificationID:    "/:notificationID",
 	ParamUserID:            "/:userID",
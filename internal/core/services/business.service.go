@@ -2,19 +2,74 @@ package services
 import (
 	"context"
 	"errors"
+	"strings"
 	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/models"
 	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/ports"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/pkg/geo"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/pkg/verification"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 type BusinessService struct {
-	db *gorm.DB
+	db       *gorm.DB
+	geocoder geo.Geocoder
 }
-func NewBusinessService(db *gorm.DB) *BusinessService {
-	return &BusinessService{db: db}
+func NewBusinessService(db *gorm.DB, geocoder geo.Geocoder) *BusinessService {
+	return &BusinessService{db: db, geocoder: geocoder}
+}
+const haversineSQL = "? * 2 * ASIN(SQRT(POWER(SIN(RADIANS(latitude - ?) / 2), 2) + COS(RADIANS(?)) * COS(RADIANS(latitude)) * POWER(SIN(RADIANS(longitude - ?) / 2), 2)))"
+func derefOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+func (s *BusinessService) locateBusiness(ctx context.Context, business *models.Business) {
+	business.Latitude, business.Longitude = nil, nil
+	point, err := s.geocoder.Geocode(ctx, geo.Address{
+		Address:    derefOrEmpty(business.Address),
+		City:       derefOrEmpty(business.City),
+		State:      derefOrEmpty(business.State),
+		PostalCode: derefOrEmpty(business.PostalCode),
+		Country:    derefOrEmpty(business.Country),
+	})
+	if err != nil {
+		return
+	}
+	business.Latitude, business.Longitude = &point.Lat, &point.Lng
+}
+func (s *BusinessService) GeocodeMissingBusinesses(ctx context.Context) (int, int, error) {
+	located, unlocated := 0, 0
+	var batch []models.Business
+	err := s.db.WithContext(ctx).
+		Where("latitude IS NULL AND postal_code IS NOT NULL AND postal_code <> ''").
+		FindInBatches(&batch, 200, func(tx *gorm.DB, _ int) error {
+			for i := range batch {
+				s.locateBusiness(ctx, &batch[i])
+				if batch[i].Latitude == nil {
+					unlocated++
+					continue
+				}
+				if err := s.db.WithContext(ctx).Model(&models.Business{}).Where("id = ?", batch[i].ID).
+					UpdateColumns(map[string]interface{}{"latitude": *batch[i].Latitude, "longitude": *batch[i].Longitude}).Error; err != nil {
+					return err
+				}
+				located++
+			}
+			return nil
+		}).Error
+	if err != nil {
+		return located, unlocated, ports.ErrDatabase
+	}
+	return located, unlocated, nil
 }
 func (s *BusinessService) GetBusinesses(ctx context.Context, filters ports.BusinessesFilter) ([]models.Business, error) {
 	var businesses []models.Business
-	query := s.db.WithContext(ctx).Preload("OperatorUser").Order("name asc")
+	origin, radiusKm, err := ports.NearOrigin(filters)
+	if err != nil {
+		return nil, err
+	}
+	query := s.db.WithContext(ctx).Preload("OperatorUser")
 	if filters.BusinessType != nil {
 		query = query.Where("business_type = ?", *filters.BusinessType)
 	}
@@ -27,6 +82,9 @@ func (s *BusinessService) GetBusinesses(ctx context.Context, filters ports.Busin
 	if filters.Active != nil {
 		query = query.Where("active = ?", *filters.Active)
 	}
+	if filters.Verified != nil {
+		query = query.Where("verified = ?", *filters.Verified)
+	}
 	if filters.OperatorUserID != nil {
 		query = query.Where("operator_user_id = ?", *filters.OperatorUserID)
 	}
@@ -34,6 +92,16 @@ func (s *BusinessService) GetBusinesses(ctx context.Context, filters ports.Busin
 		searchQuery := "%" + *filters.Search + "%"
 		query = query.Where("name LIKE ? OR tagline LIKE ? OR description LIKE ?", searchQuery, searchQuery, searchQuery)
 	}
+	if origin != nil {
+		min, max := geo.BoundingBox(*origin, radiusKm)
+		vars := []interface{}{geo.EarthRadiusKm, origin.Lat, origin.Lat, origin.Lng}
+		query = query.
+			Where("latitude BETWEEN ? AND ? AND longitude BETWEEN ? AND ?", min.Lat, max.Lat, min.Lng, max.Lng).
+			Where(haversineSQL+" <= ?", append(vars, radiusKm)...).
+			Order(clause.OrderBy{Expression: clause.Expr{SQL: haversineSQL + " ASC, name ASC", Vars: vars}})
+	} else {
+		query = query.Order("name asc")
+	}
 	if err := query.Find(&businesses).Error; err != nil {
 		return nil, ports.ErrDatabase
 	}
@@ -58,6 +126,14 @@ func (s *BusinessService) CreateBusiness(ctx context.Context, data ports.CreateB
 		}
 		return nil, ports.ErrDatabase
 	}
+	var logoMediaID *uint
+	if data.LogoMediaID != nil {
+		resolved, err := resolveMediaReference(s.db.WithContext(ctx), data.LogoMediaID, data.OperatorUserID)
+		if err != nil {
+			return nil, err
+		}
+		logoMediaID = resolved
+	}
 	business := models.Business{
 		OperatorUserID:   data.OperatorUserID,
 		Name:             data.Name,
@@ -72,13 +148,31 @@ func (s *BusinessService) CreateBusiness(ctx context.Context, data ports.CreateB
 		State:            data.State,
 		Country:          data.Country,
 		PostalCode:       data.PostalCode,
+		Latitude:         data.Latitude,
+		Longitude:        data.Longitude,
+		LogoMediaID:      logoMediaID,
 		Value:            data.Value,
 		BusinessType:     data.BusinessType,
 		BusinessCategory: data.BusinessCategory,
 		BusinessPhase:    data.BusinessPhase,
 		Active:           true,
 	}
-	if err := s.db.WithContext(ctx).Create(&business).Error; err != nil {
+	if business.Latitude == nil || business.Longitude == nil {
+		s.locateBusiness(ctx, &business)
+	}
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		completeness, err := businessCompleteness(tx, &business)
+		if err != nil {
+			return err
+		}
+		business.Completeness = uint8(completeness.Score)
+		if err := tx.Create(&business).Error; err != nil {
+			return err
+		}
+		owner := models.BusinessMember{BusinessID: business.ID, UserID: data.OperatorUserID, Role: models.BusinessRoleOwner}
+		return tx.Create(&owner).Error
+	})
+	if err != nil {
 		return nil, ports.ErrDatabase
 	}
 	business.OperatorUser = user
@@ -94,13 +188,10 @@ func (s *BusinessService) UpdateBusiness(ctx context.Context, id uint, authUserI
 		return nil, ports.ErrDatabase
 	}
 	
-	if business.OperatorUserID != authUserID {
-		
-		
-		return nil, ports.ErrForbidden
+	if _, err := requireBusinessRole(s.db.WithContext(ctx), id, authUserID, models.BusinessRoleAdmin); err != nil {
+		return nil, err
 	}
 	
-	
 	updated := false
 	if input.Name != nil {
 		business.Name = *input.Name
@@ -110,7 +201,13 @@ func (s *BusinessService) UpdateBusiness(ctx context.Context, id uint, authUserI
 		business.Tagline = input.Tagline 
 		updated = true
 	}
+	domainChanged := false
 	if input.Website != nil {
+		if business.Website != nil {
+			oldDomain, _ := verification.DomainFromWebsite(*business.Website)
+			newDomain, _ := verification.DomainFromWebsite(*input.Website)
+			domainChanged = oldDomain != newDomain
+		}
 		business.Website = input.Website
 		updated = true
 	}
@@ -130,24 +227,37 @@ func (s *BusinessService) UpdateBusiness(ctx context.Context, id uint, authUserI
 		business.Description = input.Description
 		updated = true
 	}
-	if input.Address != nil {
-		business.Address = input.Address
-		updated = true
-	}
-	if input.City != nil {
-		business.City = input.City
-		updated = true
-	}
-	if input.State != nil {
-		business.State = input.State
+	if input.Address != nil || input.City != nil || input.State != nil || input.Country != nil || input.PostalCode != nil {
+		if input.Address != nil {
+			business.Address = input.Address
+		}
+		if input.City != nil {
+			business.City = input.City
+		}
+		if input.State != nil {
+			business.State = input.State
+		}
+		if input.Country != nil {
+			business.Country = input.Country
+		}
+		if input.PostalCode != nil {
+			business.PostalCode = input.PostalCode
+		}
+		if input.Latitude == nil {
+			s.locateBusiness(ctx, &business)
+		}
 		updated = true
 	}
-	if input.Country != nil {
-		business.Country = input.Country
+	if input.Latitude != nil && input.Longitude != nil {
+		business.Latitude, business.Longitude = input.Latitude, input.Longitude
 		updated = true
 	}
-	if input.PostalCode != nil {
-		business.PostalCode = input.PostalCode
+	if input.LogoMediaID != nil {
+		logoMediaID, err := resolveMediaReference(s.db.WithContext(ctx), input.LogoMediaID, authUserID)
+		if err != nil {
+			return nil, err
+		}
+		business.LogoMediaID = logoMediaID
 		updated = true
 	}
 	if input.Value != nil {
@@ -175,7 +285,22 @@ func (s *BusinessService) UpdateBusiness(ctx context.Context, id uint, authUserI
 		return nil, ports.ErrNoUpdateData
 	}
 	
-	if err := s.db.WithContext(ctx).Save(&business).Error; err != nil {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("verified", "verified_at").Save(&business).Error; err != nil {
+			return err
+		}
+		if !domainChanged {
+			return nil
+		}
+		if err := cancelOpenVerifications(tx, id, authUserID, "Website domain changed"); err != nil {
+			return err
+		}
+		return revokeBusinessVerification(tx, id, authUserID, "Website domain changed")
+	})
+	if err != nil {
+		return nil, ports.ErrDatabase
+	}
+	if err := refreshBusinessCompleteness(s.db.WithContext(ctx), id); err != nil {
 		return nil, ports.ErrDatabase
 	}
 	
@@ -183,19 +308,10 @@ func (s *BusinessService) UpdateBusiness(ctx context.Context, id uint, authUserI
 }
 func (s *BusinessService) DeleteBusiness(ctx context.Context, id uint, authUserID uint) error { 
 	
-	var business models.Business
-	if err := s.db.WithContext(ctx).Select("operator_user_id").First(&business, id).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return ports.ErrBusinessNotFound 
-		}
-		return ports.ErrDatabase 
-	}
-	if business.OperatorUserID != authUserID {
-		
-		return ports.ErrForbidden 
+	if _, err := requireBusinessRole(s.db.WithContext(ctx), id, authUserID, models.BusinessRoleOwner); err != nil {
+		return err
 	}
 	
-	
 	var projectCount int64
 	if err := s.db.Model(&models.Project{}).Where("business_id = ?", id).Count(&projectCount).Error; err != nil {
 		return ports.ErrDatabase 
@@ -208,17 +324,24 @@ func (s *BusinessService) DeleteBusiness(ctx context.Context, id uint, authUserI
 		return ports.ErrBusinessInUse
 	}
 	
-	result := s.db.WithContext(ctx).Delete(&models.Business{}, id)
-	if result.Error != nil {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("business_id = ?", id).Delete(&models.BusinessInvite{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("business_id = ?", id).Delete(&models.BusinessMember{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Business{}, id).Error
+	})
+	if err != nil {
 		return ports.ErrDatabase
 	}
 	
-	
 	return nil
 }
 func (s *BusinessService) GetUserBusinesses(ctx context.Context, userID uint) ([]models.Business, error) {
 	var businesses []models.Business
-	if err := s.db.WithContext(ctx).Where("operator_user_id = ?", userID).Order("name asc").Find(&businesses).Error; err != nil {
+	if err := s.db.WithContext(ctx).Where("operator_user_id = ? OR id IN (?)", userID, memberBusinessIDs(s.db, userID)).Order("name asc").Find(&businesses).Error; err != nil {
 		return nil, ports.ErrDatabase
 	}
 	return businesses, nil
@@ -252,3 +375,93 @@ func (s *BusinessService) GetBusinessStats(ctx context.Context, id uint) (*ports
 		TotalTags:         tagCount,
 	}, nil
 }
+func (s *BusinessService) GetBusinessCompleteness(ctx context.Context, business *models.Business) (*ports.BusinessCompleteness, error) {
+	completeness, err := businessCompleteness(s.db.WithContext(ctx), business)
+	if err != nil {
+		return nil, ports.ErrDatabase
+	}
+	business.Completeness = uint8(completeness.Score)
+	return completeness, nil
+}
+func businessCompleteness(tx *gorm.DB, business *models.Business) (*ports.BusinessCompleteness, error) {
+	var tagTypes []models.BusinessTagType
+	if err := tx.Model(&models.BusinessTag{}).Where("business_id = ?", business.ID).Distinct().Pluck("tag_type", &tagTypes).Error; err != nil {
+		return nil, err
+	}
+	var skillCount, publicationCount int64
+	if err := tx.Model(&models.UserSkill{}).Where("user_id = ?", business.OperatorUserID).Count(&skillCount).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Model(&models.Publication{}).Where("business_id = ? AND published = ?", business.ID, true).Count(&publicationCount).Error; err != nil {
+		return nil, err
+	}
+	filled := func(v *string) bool {
+		return v != nil && strings.TrimSpace(*v) != ""
+	}
+	hasTag := func(tagType models.BusinessTagType) bool {
+		for _, t := range tagTypes {
+			if t == tagType {
+				return true
+			}
+		}
+		return false
+	}
+	checks := []struct {
+		item ports.BusinessCompletenessItem
+		done bool
+	}{
+		{ports.CompletenessTagline, filled(business.Tagline)},
+		{ports.CompletenessDescription, filled(business.Description)},
+		{ports.CompletenessWebsite, filled(business.Website)},
+		{ports.CompletenessContact, filled(business.ContactName) && (filled(business.ContactEmail) || filled(business.ContactPhoneNo))},
+		{ports.CompletenessLocation, filled(business.City) && filled(business.Country)},
+		{ports.CompletenessLogo, business.LogoMediaID != nil},
+		{ports.CompletenessClientTags, hasTag(models.BusinessTagClient)},
+		{ports.CompletenessServiceTags, hasTag(models.BusinessTagService)},
+		{ports.CompletenessSpecialtyTags, hasTag(models.BusinessTagSpecialty)},
+		{ports.CompletenessOperatorSkills, skillCount > 0},
+		{ports.CompletenessPublications, publicationCount > 0},
+	}
+	completeness := &ports.BusinessCompleteness{
+		Completed: []ports.BusinessCompletenessItem{},
+		Missing:   []ports.BusinessCompletenessItem{},
+	}
+	for _, check := range checks {
+		if check.done {
+			completeness.Completed = append(completeness.Completed, check.item)
+		} else {
+			completeness.Missing = append(completeness.Missing, check.item)
+		}
+	}
+	completeness.Score = (len(completeness.Completed)*100 + len(checks)/2) / len(checks)
+	return completeness, nil
+}
+func refreshBusinessCompleteness(tx *gorm.DB, businessIDs ...uint) error {
+	for _, id := range businessIDs {
+		var business models.Business
+		if err := tx.First(&business, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+			return err
+		}
+		completeness, err := businessCompleteness(tx, &business)
+		if err != nil {
+			return err
+		}
+		if uint8(completeness.Score) == business.Completeness {
+			continue
+		}
+		if err := tx.Model(&models.Business{}).Where("id = ?", id).UpdateColumn("completeness", completeness.Score).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+func refreshOperatorCompleteness(tx *gorm.DB, userID uint) error {
+	var businessIDs []uint
+	if err := tx.Model(&models.Business{}).Where("operator_user_id = ?", userID).Pluck("id", &businessIDs).Error; err != nil {
+		return err
+	}
+	return refreshBusinessCompleteness(tx, businessIDs...)
+}
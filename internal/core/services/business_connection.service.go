@@ -1,16 +1,113 @@
 package services
 import (
 	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
 	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/models"
 	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/ports"
 	"gorm.io/gorm"
 )
+const connectionRequestCooldown = 30 * 24 * time.Hour
 type BusinessConnectionService struct {
 	db *gorm.DB
 }
 func NewBusinessConnectionService(db *gorm.DB) *BusinessConnectionService {
 	return &BusinessConnectionService{db: db}
 }
+func recordConnectionStatus(tx *gorm.DB, connectionID uint, from *models.BusinessConnectionStatus, to models.BusinessConnectionStatus, changedByUserID uint) error {
+	entry := models.BusinessConnectionHistory{
+		BusinessConnectionID: connectionID,
+		FromStatus:           from,
+		ToStatus:             to,
+		ChangedByUserID:      &changedByUserID,
+	}
+	return tx.Create(&entry).Error
+}
+func (s *BusinessConnectionService) loadBusinessConnection(ctx context.Context, id uint) (*models.BusinessConnection, error) {
+	var businessConnection models.BusinessConnection
+	if err := s.db.WithContext(ctx).
+		Preload("InitiatingBusiness").
+		Preload("ReceivingBusiness").
+		Preload("InitiatedByUser").
+		First(&businessConnection, id).Error; err != nil {
+		return nil, ports.ErrDatabase
+	}
+	return &businessConnection, nil
+}
+func (s *BusinessConnectionService) findRelationship(ctx context.Context, businessA, businessB uint, connectionType models.BusinessConnectionType) (*models.BusinessConnection, error) {
+	var existing models.BusinessConnection
+	err := s.db.WithContext(ctx).
+		Where("((initiating_business_id = ? AND receiving_business_id = ?) OR (initiating_business_id = ? AND receiving_business_id = ?)) AND connection_type = ?",
+			businessA, businessB, businessB, businessA, connectionType).
+		Order("updated_at desc").
+		First(&existing).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, ports.ErrDatabase
+	}
+	return &existing, nil
+}
+func (s *BusinessConnectionService) rejectionCooldownActive(ctx context.Context, connection *models.BusinessConnection) (bool, error) {
+	rejectedAt := connection.UpdatedAt
+	var entry models.BusinessConnectionHistory
+	err := s.db.WithContext(ctx).
+		Where("business_connection_id = ? AND to_status = ?", connection.ID, models.ConnectionStatusRejected).
+		Order("created_at desc, id desc").
+		First(&entry).Error
+	if err == nil {
+		rejectedAt = entry.CreatedAt
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, ports.ErrDatabase
+	}
+	return time.Since(rejectedAt) < connectionRequestCooldown, nil
+}
+func (s *BusinessConnectionService) MergeDuplicateConnections(ctx context.Context) (int, error) {
+	var duplicates []models.BusinessConnection
+	err := s.db.WithContext(ctx).
+		Where(`EXISTS (SELECT 1 FROM business_connections r
+			WHERE r.initiating_business_id = business_connections.receiving_business_id
+			AND r.receiving_business_id = business_connections.initiating_business_id
+			AND r.connection_type = business_connections.connection_type)`).
+		Order("LEAST(initiating_business_id, receiving_business_id), GREATEST(initiating_business_id, receiving_business_id), connection_type, updated_at desc, id desc").
+		Find(&duplicates).Error
+	if err != nil {
+		return 0, err
+	}
+	merged := 0
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var keep *models.BusinessConnection
+		for i := range duplicates {
+			conn := &duplicates[i]
+			if keep == nil || !sameRelationship(keep, conn) {
+				keep = conn
+				continue
+			}
+			for _, model := range []interface{}{&models.BusinessConnectionHistory{}, &models.IntroductionRequest{}, &models.Referral{}} {
+				if err := tx.Model(model).Where("business_connection_id = ?", conn.ID).Update("business_connection_id", keep.ID).Error; err != nil {
+					return err
+				}
+			}
+			if err := tx.Delete(&models.BusinessConnection{}, conn.ID).Error; err != nil {
+				return err
+			}
+			merged++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return merged, nil
+}
+func sameRelationship(a, b *models.BusinessConnection) bool {
+	return a.ConnectionType == b.ConnectionType &&
+		a.InitiatingBusinessID == b.ReceivingBusinessID &&
+		a.ReceivingBusinessID == b.InitiatingBusinessID
+}
 func (s *BusinessConnectionService) CreateBusinessConnection(ctx context.Context, data ports.CreateBusinessConnectionInput) (*models.BusinessConnection, error) {
 	var initiatingBusiness models.Business
 	if err := s.db.WithContext(ctx).First(&initiatingBusiness, data.InitiatingBusinessID).Error; err != nil {
@@ -36,15 +133,43 @@ func (s *BusinessConnectionService) CreateBusinessConnection(ctx context.Context
 	if data.InitiatingBusinessID == data.ReceivingBusinessID {
 		return nil, ports.ErrCannotConnectToSelf
 	}
-	var existingConnection models.BusinessConnection
-	err := s.db.WithContext(ctx).
-		Where("initiating_business_id = ? AND receiving_business_id = ? AND connection_type = ?",
-			data.InitiatingBusinessID, data.ReceivingBusinessID, data.ConnectionType).
-		First(&existingConnection).Error
-	if err == nil {
-		return nil, ports.ErrBusinessConnectionAlreadyExists
-	} else if err != gorm.ErrRecordNotFound {
-		return nil, ports.ErrDatabase
+	existing, err := s.findRelationship(ctx, data.InitiatingBusinessID, data.ReceivingBusinessID, data.ConnectionType)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		switch existing.Status {
+		case models.ConnectionStatusPending, models.ConnectionStatusActive:
+			return nil, ports.ErrBusinessConnectionAlreadyExists
+		case models.ConnectionStatusRejected:
+			if existing.InitiatingBusinessID != data.InitiatingBusinessID {
+				break
+			}
+			coolingDown, err := s.rejectionCooldownActive(ctx, existing)
+			if err != nil {
+				return nil, err
+			}
+			if coolingDown {
+				return nil, ports.ErrConnectionCooldown
+			}
+		}
+		previous := existing.Status
+		err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := tx.Model(existing).Updates(map[string]interface{}{
+				"initiating_business_id": data.InitiatingBusinessID,
+				"receiving_business_id":  data.ReceivingBusinessID,
+				"status":                 models.ConnectionStatusPending,
+				"initiated_by_user_id":   data.InitiatedByUserID,
+				"notes":                  data.Notes,
+			}).Error; err != nil {
+				return err
+			}
+			return recordConnectionStatus(tx, existing.ID, &previous, models.ConnectionStatusPending, data.InitiatedByUserID)
+		})
+		if err != nil {
+			return nil, ports.ErrDatabase
+		}
+		return s.loadBusinessConnection(ctx, existing.ID)
 	}
 	businessConnection := models.BusinessConnection{
 		InitiatingBusinessID: data.InitiatingBusinessID,
@@ -54,17 +179,65 @@ func (s *BusinessConnectionService) CreateBusinessConnection(ctx context.Context
 		InitiatedByUserID:    data.InitiatedByUserID,
 		Notes:                data.Notes,
 	}
-	if err := s.db.WithContext(ctx).Create(&businessConnection).Error; err != nil {
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&businessConnection).Error; err != nil {
+			return err
+		}
+		return recordConnectionStatus(tx, businessConnection.ID, nil, models.ConnectionStatusPending, data.InitiatedByUserID)
+	})
+	if err != nil {
 		return nil, ports.ErrDatabase
 	}
-	if err := s.db.WithContext(ctx).
-		Preload("InitiatingBusiness").
-		Preload("ReceivingBusiness").
-		Preload("InitiatedByUser").
-		First(&businessConnection, businessConnection.ID).Error; err != nil {
+	return s.loadBusinessConnection(ctx, businessConnection.ID)
+}
+func (s *BusinessConnectionService) AuthorizeBusinessConnectionAction(ctx context.Context, id, userID uint, action ports.BusinessConnectionAction) (*models.BusinessConnection, error) {
+	db := s.db.WithContext(ctx)
+	var connection models.BusinessConnection
+	if err := db.First(&connection, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ports.ErrBusinessConnectionNotFound
+		}
 		return nil, ports.ErrDatabase
 	}
-	return &businessConnection, nil
+	initiatorRole, err := businessRoleFor(db, connection.InitiatingBusinessID, userID)
+	if err != nil {
+		return nil, err
+	}
+	receiverRole, err := businessRoleFor(db, connection.ReceivingBusinessID, userID)
+	if err != nil {
+		return nil, err
+	}
+	minRole := models.BusinessRoleAdmin
+	if action == ports.ConnectionActionView {
+		minRole = models.BusinessRoleViewer
+	}
+	isInitiator := businessRoleRank[initiatorRole] >= businessRoleRank[minRole]
+	isReceiver := businessRoleRank[receiverRole] >= businessRoleRank[minRole]
+	if action == ports.ConnectionActionDelete {
+		switch connection.Status {
+		case models.ConnectionStatusRejected:
+			return nil, ports.ErrRejectedConnectionNotDeletable
+		case models.ConnectionStatusPending:
+			action = ports.ConnectionActionWithdraw
+		default:
+			action = ports.ConnectionActionDeactivate
+		}
+	}
+	switch action {
+	case ports.ConnectionActionRespond:
+		if !isReceiver {
+			return nil, ports.ErrNotConnectionReceiver
+		}
+	case ports.ConnectionActionWithdraw:
+		if !isInitiator {
+			return nil, ports.ErrNotConnectionInitiator
+		}
+	default:
+		if !isInitiator && !isReceiver {
+			return nil, ports.ErrNotConnectionParticipant
+		}
+	}
+	return &connection, nil
 }
 func (s *BusinessConnectionService) GetBusinessConnection(ctx context.Context, id uint) (*models.BusinessConnection, error) {
 	var businessConnection models.BusinessConnection
@@ -113,91 +286,149 @@ func (s *BusinessConnectionService) UpdateBusinessConnection(ctx context.Context
 	}
 	updates := make(map[string]interface{})
 	if data.ConnectionType != nil {
+		if *data.ConnectionType != businessConnection.ConnectionType {
+			existing, err := s.findRelationship(ctx, businessConnection.InitiatingBusinessID, businessConnection.ReceivingBusinessID, *data.ConnectionType)
+			if err != nil {
+				return nil, err
+			}
+			if existing != nil {
+				return nil, ports.ErrBusinessConnectionAlreadyExists
+			}
+		}
 		updates["connection_type"] = *data.ConnectionType
 	}
-	if data.Status != nil {
-		updates["status"] = *data.Status
-	}
 	if data.Notes != nil {
 		updates["notes"] = *data.Notes
 	}
 	if len(updates) == 0 {
 		return nil, ports.ErrNoUpdateData
 	}
-	if err := s.db.WithContext(ctx).
-		Model(&businessConnection).
-		Updates(updates).Error; err != nil {
-		return nil, ports.ErrDatabase
-	}
-	if err := s.db.WithContext(ctx).
-		Preload("InitiatingBusiness").
-		Preload("ReceivingBusiness").
-		Preload("InitiatedByUser").
-		First(&businessConnection, id).Error; err != nil {
+	if err := s.db.WithContext(ctx).Model(&businessConnection).Updates(updates).Error; err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			return nil, ports.ErrBusinessConnectionAlreadyExists
+		}
 		return nil, ports.ErrDatabase
 	}
-	return &businessConnection, nil
+	return s.loadBusinessConnection(ctx, id)
 }
 func (s *BusinessConnectionService) DeleteBusinessConnection(ctx context.Context, id uint) error {
-	result := s.db.WithContext(ctx).
-		Delete(&models.BusinessConnection{}, id)
-	if result.Error != nil {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var businessConnection models.BusinessConnection
+		if err := tx.First(&businessConnection, id).Error; err != nil {
+			return err
+		}
+		if businessConnection.Status == models.ConnectionStatusRejected {
+			return ports.ErrRejectedConnectionNotDeletable
+		}
+		if err := tx.Model(&models.IntroductionRequest{}).Where("business_connection_id = ?", id).Update("business_connection_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.Referral{}).Where("business_connection_id = ?", id).Update("business_connection_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.BusinessConnection{}, id).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ports.ErrBusinessConnectionNotFound
+		}
+		if errors.Is(err, ports.ErrRejectedConnectionNotDeletable) {
+			return ports.ErrRejectedConnectionNotDeletable
+		}
 		return ports.ErrDatabase
 	}
-	if result.RowsAffected == 0 {
-		return ports.ErrBusinessConnectionNotFound
-	}
 	return nil
 }
-func (s *BusinessConnectionService) AcceptBusinessConnection(ctx context.Context, id uint) (*models.BusinessConnection, error) {
+func (s *BusinessConnectionService) transitionBusinessConnection(ctx context.Context, id, actorUserID uint, from, to models.BusinessConnectionStatus, errWrongStatus error) (*models.BusinessConnection, error) {
 	var businessConnection models.BusinessConnection
-	err := s.db.WithContext(ctx).
-		Where("id = ? AND status = ?", id, models.ConnectionStatusPending).
-		First(&businessConnection).Error
+	err := s.db.WithContext(ctx).First(&businessConnection, id).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, ports.ErrConnectionNotPending
+			return nil, ports.ErrBusinessConnectionNotFound
 		}
 		return nil, ports.ErrDatabase
 	}
-	if err := s.db.WithContext(ctx).
-		Model(&businessConnection).
-		Update("status", models.ConnectionStatusActive).Error; err != nil {
-		return nil, ports.ErrDatabase
+	if businessConnection.Status != from {
+		return nil, errWrongStatus
 	}
-	if err := s.db.WithContext(ctx).
-		Preload("InitiatingBusiness").
-		Preload("ReceivingBusiness").
-		Preload("InitiatedByUser").
-		First(&businessConnection, id).Error; err != nil {
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.BusinessConnection{}).
+			Where("id = ? AND status = ?", id, from).
+			Update("status", to)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errWrongStatus
+		}
+		return recordConnectionStatus(tx, id, &from, to, actorUserID)
+	})
+	if err != nil {
+		if errors.Is(err, errWrongStatus) {
+			return nil, errWrongStatus
+		}
 		return nil, ports.ErrDatabase
 	}
-	return &businessConnection, nil
+	return s.loadBusinessConnection(ctx, id)
+}
+func (s *BusinessConnectionService) AcceptBusinessConnection(ctx context.Context, id, actorUserID uint) (*models.BusinessConnection, error) {
+	return s.transitionBusinessConnection(ctx, id, actorUserID, models.ConnectionStatusPending, models.ConnectionStatusActive, ports.ErrConnectionNotPending)
+}
+func (s *BusinessConnectionService) RejectBusinessConnection(ctx context.Context, id, actorUserID uint) (*models.BusinessConnection, error) {
+	return s.transitionBusinessConnection(ctx, id, actorUserID, models.ConnectionStatusPending, models.ConnectionStatusRejected, ports.ErrConnectionNotPending)
+}
+func (s *BusinessConnectionService) WithdrawBusinessConnection(ctx context.Context, id, actorUserID uint) (*models.BusinessConnection, error) {
+	return s.transitionBusinessConnection(ctx, id, actorUserID, models.ConnectionStatusPending, models.ConnectionStatusWithdrawn, ports.ErrConnectionNotPending)
+}
+func (s *BusinessConnectionService) DeactivateBusinessConnection(ctx context.Context, id, actorUserID uint) (*models.BusinessConnection, error) {
+	return s.transitionBusinessConnection(ctx, id, actorUserID, models.ConnectionStatusActive, models.ConnectionStatusInactive, ports.ErrConnectionNotActive)
 }
-func (s *BusinessConnectionService) RejectBusinessConnection(ctx context.Context, id uint) (*models.BusinessConnection, error) {
+func (s *BusinessConnectionService) ReactivateBusinessConnection(ctx context.Context, id, actorUserID uint) (*models.BusinessConnection, error) {
+	db := s.db.WithContext(ctx)
 	var businessConnection models.BusinessConnection
-	err := s.db.WithContext(ctx).
-		Where("id = ? AND status = ?", id, models.ConnectionStatusPending).
-		First(&businessConnection).Error
-	if err != nil {
+	if err := db.First(&businessConnection, id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, ports.ErrConnectionNotPending
+			return nil, ports.ErrBusinessConnectionNotFound
 		}
 		return nil, ports.ErrDatabase
 	}
-	if err := s.db.WithContext(ctx).
-		Model(&businessConnection).
-		Update("status", models.ConnectionStatusRejected).Error; err != nil {
+	if businessConnection.Status != models.ConnectionStatusInactive {
+		return nil, ports.ErrConnectionNotInactive
+	}
+	initiatorRole, err := businessRoleFor(db, businessConnection.InitiatingBusinessID, actorUserID)
+	if err != nil {
+		return nil, err
+	}
+	updates := map[string]interface{}{
+		"status":               models.ConnectionStatusPending,
+		"initiated_by_user_id": actorUserID,
+	}
+	if businessRoleRank[initiatorRole] < businessRoleRank[models.BusinessRoleAdmin] {
+		updates["initiating_business_id"] = businessConnection.ReceivingBusinessID
+		updates["receiving_business_id"] = businessConnection.InitiatingBusinessID
+	}
+	previous := businessConnection.Status
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&businessConnection).Updates(updates).Error; err != nil {
+			return err
+		}
+		return recordConnectionStatus(tx, id, &previous, models.ConnectionStatusPending, actorUserID)
+	})
+	if err != nil {
 		return nil, ports.ErrDatabase
 	}
-	if err := s.db.WithContext(ctx).
-		Preload("InitiatingBusiness").
-		Preload("ReceivingBusiness").
-		Preload("InitiatedByUser").
-		First(&businessConnection, id).Error; err != nil {
+	return s.loadBusinessConnection(ctx, id)
+}
+func (s *BusinessConnectionService) GetBusinessConnectionHistory(ctx context.Context, id uint) ([]models.BusinessConnectionHistory, error) {
+	var history []models.BusinessConnectionHistory
+	err := s.db.WithContext(ctx).
+		Where("business_connection_id = ?", id).
+		Order("created_at asc, id asc").
+		Find(&history).Error
+	if err != nil {
 		return nil, ports.ErrDatabase
 	}
-	return &businessConnection, nil
+	return history, nil
 }
 func (s *BusinessConnectionService) GetPendingConnections(ctx context.Context, businessID uint) ([]models.BusinessConnection, error) {
 	var businessConnections []models.BusinessConnection
@@ -228,3 +459,157 @@ func (s *BusinessConnectionService) GetActiveConnections(ctx context.Context, bu
 	}
 	return businessConnections, nil
 }
+const businessNetworkQuery = `
+WITH RECURSIVE network (business_id, depth, path) AS (
+	SELECT CAST(? AS UNSIGNED), 0, CAST(? AS CHAR(1000))
+	UNION ALL
+	SELECT
+		IF(bc.initiating_business_id = n.business_id, bc.receiving_business_id, bc.initiating_business_id),
+		n.depth + 1,
+		CONCAT(n.path, ',', IF(bc.initiating_business_id = n.business_id, bc.receiving_business_id, bc.initiating_business_id))
+	FROM network n
+	JOIN business_connections bc
+		ON (bc.initiating_business_id = n.business_id OR bc.receiving_business_id = n.business_id) AND bc.status = ?
+	WHERE n.depth < ?
+		AND FIND_IN_SET(IF(bc.initiating_business_id = n.business_id, bc.receiving_business_id, bc.initiating_business_id), n.path) = 0
+)
+SELECT business_id, depth, path FROM network ORDER BY depth, path`
+type businessNetworkRow struct {
+	BusinessID uint
+	Depth      int
+	Path       string
+}
+func (s *BusinessConnectionService) GetBusinessNetwork(ctx context.Context, businessID uint, depth int) (*ports.BusinessNetworkResponse, error) {
+	if depth < 1 || depth > 3 {
+		return nil, ports.ErrInvalidNetworkDepth
+	}
+	db := s.db.WithContext(ctx)
+	var business models.Business
+	if err := db.First(&business, businessID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ports.ErrBusinessNotFound
+		}
+		return nil, ports.ErrDatabase
+	}
+	var rows []businessNetworkRow
+	if err := db.Raw(businessNetworkQuery, businessID, strconv.FormatUint(uint64(businessID), 10), models.ConnectionStatusActive, depth).Scan(&rows).Error; err != nil {
+		return nil, ports.ErrDatabase
+	}
+	shortest := make(map[uint]businessNetworkRow)
+	ids := make([]uint, 0, len(rows))
+	for _, row := range rows {
+		if _, seen := shortest[row.BusinessID]; seen {
+			continue
+		}
+		shortest[row.BusinessID] = row
+		ids = append(ids, row.BusinessID)
+	}
+	var businesses []models.Business
+	if err := db.Where("id IN ?", ids).Find(&businesses).Error; err != nil {
+		return nil, ports.ErrDatabase
+	}
+	var connections []models.BusinessConnection
+	if err := db.
+		Where("initiating_business_id IN ? AND receiving_business_id IN ? AND status = ?", ids, ids, models.ConnectionStatusActive).
+		Order("id").
+		Find(&connections).Error; err != nil {
+		return nil, ports.ErrDatabase
+	}
+	neighbours := make(map[uint]map[uint]bool)
+	edges := make([]ports.BusinessNetworkEdge, 0, len(connections))
+	for _, conn := range connections {
+		for _, pair := range [][2]uint{{conn.InitiatingBusinessID, conn.ReceivingBusinessID}, {conn.ReceivingBusinessID, conn.InitiatingBusinessID}} {
+			if neighbours[pair[0]] == nil {
+				neighbours[pair[0]] = make(map[uint]bool)
+			}
+			neighbours[pair[0]][pair[1]] = true
+		}
+		edges = append(edges, ports.BusinessNetworkEdge{
+			ConnectionID:         conn.ID,
+			InitiatingBusinessID: conn.InitiatingBusinessID,
+			ReceivingBusinessID:  conn.ReceivingBusinessID,
+			ConnectionType:       conn.ConnectionType,
+			Status:               conn.Status,
+		})
+	}
+	byID := make(map[uint]models.Business, len(businesses))
+	for _, b := range businesses {
+		byID[b.ID] = b
+	}
+	nodes := make([]ports.BusinessNetworkNode, 0, len(ids))
+	for _, id := range ids {
+		b, ok := byID[id]
+		if !ok {
+			continue
+		}
+		row := shortest[id]
+		node := ports.BusinessNetworkNode{
+			BusinessID:   id,
+			Name:         b.Name,
+			BusinessType: b.BusinessType,
+			Depth:        row.Depth,
+			Via:          []uint{},
+		}
+		if hops := strings.Split(row.Path, ","); len(hops) >= 3 {
+			for _, hop := range hops[1 : len(hops)-1] {
+				hopID, err := strconv.ParseUint(hop, 10, 32)
+				if err != nil {
+					return nil, ports.ErrDatabase
+				}
+				node.Via = append(node.Via, uint(hopID))
+			}
+		}
+		if id != businessID {
+			for other := range neighbours[id] {
+				if other != businessID && neighbours[businessID][other] {
+					node.MutualConnections++
+				}
+			}
+		}
+		nodes = append(nodes, node)
+	}
+	return &ports.BusinessNetworkResponse{
+		BusinessID: businessID,
+		Depth:      depth,
+		Nodes:      nodes,
+		Edges:      edges,
+	}, nil
+}
+func activeNeighbourIDs(tx *gorm.DB, businessID uint) *gorm.DB {
+	return tx.Model(&models.BusinessConnection{}).
+		Select("IF(initiating_business_id = ?, receiving_business_id, initiating_business_id)", businessID).
+		Where("(initiating_business_id = ? OR receiving_business_id = ?) AND status = ?", businessID, businessID, models.ConnectionStatusActive)
+}
+func businessesConnected(tx *gorm.DB, businessA, businessB uint, statuses ...models.BusinessConnectionStatus) (bool, error) {
+	var count int64
+	err := tx.Model(&models.BusinessConnection{}).
+		Where("((initiating_business_id = ? AND receiving_business_id = ?) OR (initiating_business_id = ? AND receiving_business_id = ?)) AND status IN ?",
+			businessA, businessB, businessB, businessA, statuses).
+		Count(&count).Error
+	if err != nil {
+		return false, ports.ErrDatabase
+	}
+	return count > 0, nil
+}
+func (s *BusinessConnectionService) GetMutualConnections(ctx context.Context, businessID, otherBusinessID uint) ([]models.Business, error) {
+	if businessID == otherBusinessID {
+		return nil, ports.ErrCannotConnectToSelf
+	}
+	db := s.db.WithContext(ctx)
+	var count int64
+	if err := db.Model(&models.Business{}).Where("id IN ?", []uint{businessID, otherBusinessID}).Count(&count).Error; err != nil {
+		return nil, ports.ErrDatabase
+	}
+	if count < 2 {
+		return nil, ports.ErrBusinessNotFound
+	}
+	var businesses []models.Business
+	err := db.
+		Where("id IN (?) AND id IN (?)", activeNeighbourIDs(db, businessID), activeNeighbourIDs(db, otherBusinessID)).
+		Order("name asc").
+		Find(&businesses).Error
+	if err != nil {
+		return nil, ports.ErrDatabase
+	}
+	return businesses, nil
+}
@@ -0,0 +1,376 @@
+package services
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/models"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/ports"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+const businessInviteTTL = 7 * 24 * time.Hour
+var businessRoleRank = map[models.BusinessMemberRole]int{
+	models.BusinessRoleViewer: 1,
+	models.BusinessRoleEditor: 2,
+	models.BusinessRoleAdmin:  3,
+	models.BusinessRoleOwner:  4,
+}
+type BusinessMemberService struct {
+	db *gorm.DB
+}
+func NewBusinessMemberService(db *gorm.DB) *BusinessMemberService {
+	return &BusinessMemberService{db: db}
+}
+func businessRoleFor(tx *gorm.DB, businessID, userID uint) (models.BusinessMemberRole, error) {
+	var business models.Business
+	if err := tx.Select("id", "operator_user_id").First(&business, businessID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", ports.ErrBusinessNotFound
+		}
+		return "", ports.ErrDatabase
+	}
+	if business.OperatorUserID == userID {
+		return models.BusinessRoleOwner, nil
+	}
+	var member models.BusinessMember
+	err := tx.Where("business_id = ? AND user_id = ?", businessID, userID).First(&member).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", nil
+		}
+		return "", ports.ErrDatabase
+	}
+	return member.Role, nil
+}
+func requireBusinessRole(tx *gorm.DB, businessID, userID uint, minRole models.BusinessMemberRole) (models.BusinessMemberRole, error) {
+	role, err := businessRoleFor(tx, businessID, userID)
+	if err != nil {
+		return "", err
+	}
+	if businessRoleRank[role] < businessRoleRank[minRole] {
+		return "", ports.ErrForbidden
+	}
+	return role, nil
+}
+func memberBusinessIDs(tx *gorm.DB, userID uint) *gorm.DB {
+	return tx.Model(&models.BusinessMember{}).Select("business_id").Where("user_id = ?", userID)
+}
+func (s *BusinessMemberService) AuthorizeBusinessRole(ctx context.Context, businessID, userID uint, minRole models.BusinessMemberRole) error {
+	_, err := requireBusinessRole(s.db.WithContext(ctx), businessID, userID, minRole)
+	return err
+}
+func (s *BusinessMemberService) GetBusinessRole(ctx context.Context, businessID, userID uint) (models.BusinessMemberRole, error) {
+	return businessRoleFor(s.db.WithContext(ctx), businessID, userID)
+}
+func (s *BusinessMemberService) EnsureOperatorMemberships(ctx context.Context) error {
+	return s.db.WithContext(ctx).Exec(`INSERT INTO business_members (business_id, user_id, role, joined_at)
+		SELECT b.id, b.operator_user_id, ?, b.created_at FROM businesses b
+		WHERE NOT EXISTS (SELECT 1 FROM business_members bm WHERE bm.business_id = b.id AND bm.user_id = b.operator_user_id)`,
+		models.BusinessRoleOwner).Error
+}
+func (s *BusinessMemberService) GetBusinessMembers(ctx context.Context, businessID, authUserID uint) ([]models.BusinessMember, error) {
+	if _, err := requireBusinessRole(s.db.WithContext(ctx), businessID, authUserID, models.BusinessRoleViewer); err != nil {
+		return nil, err
+	}
+	var members []models.BusinessMember
+	err := s.db.WithContext(ctx).
+		Preload("User").
+		Where("business_id = ?", businessID).
+		Order("FIELD(role, 'owner', 'admin', 'editor', 'viewer'), joined_at asc").
+		Find(&members).Error
+	if err != nil {
+		return nil, ports.ErrDatabase
+	}
+	return members, nil
+}
+func findBusinessMember(tx *gorm.DB, businessID, userID uint) (*models.BusinessMember, error) {
+	var member models.BusinessMember
+	err := tx.Where("business_id = ? AND user_id = ?", businessID, userID).First(&member).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ports.ErrBusinessMemberNotFound
+		}
+		return nil, ports.ErrDatabase
+	}
+	return &member, nil
+}
+func (s *BusinessMemberService) UpdateBusinessMemberRole(ctx context.Context, businessID, targetUserID, authUserID uint, data ports.UpdateBusinessMemberRoleInput) (*models.BusinessMember, error) {
+	db := s.db.WithContext(ctx)
+	actorRole, err := requireBusinessRole(db, businessID, authUserID, models.BusinessRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	member, err := findBusinessMember(db, businessID, targetUserID)
+	if err != nil {
+		return nil, err
+	}
+	if member.Role == models.BusinessRoleOwner {
+		return nil, ports.ErrBusinessOwnerImmutable
+	}
+	if businessRoleRank[actorRole] <= businessRoleRank[member.Role] || businessRoleRank[actorRole] <= businessRoleRank[data.Role] {
+		return nil, ports.ErrBusinessRoleTooHigh
+	}
+	if err := db.Model(member).Update("role", data.Role).Error; err != nil {
+		return nil, ports.ErrDatabase
+	}
+	if err := db.Preload("User").First(member, "business_id = ? AND user_id = ?", businessID, targetUserID).Error; err != nil {
+		return nil, ports.ErrDatabase
+	}
+	return member, nil
+}
+func (s *BusinessMemberService) RemoveBusinessMember(ctx context.Context, businessID, targetUserID, authUserID uint) error {
+	db := s.db.WithContext(ctx)
+	actorRole, err := businessRoleFor(db, businessID, authUserID)
+	if err != nil {
+		return err
+	}
+	if actorRole == "" {
+		return ports.ErrForbidden
+	}
+	member, err := findBusinessMember(db, businessID, targetUserID)
+	if err != nil {
+		return err
+	}
+	if member.Role == models.BusinessRoleOwner {
+		return ports.ErrBusinessOwnerImmutable
+	}
+	if targetUserID != authUserID {
+		if businessRoleRank[actorRole] < businessRoleRank[models.BusinessRoleAdmin] {
+			return ports.ErrForbidden
+		}
+		if businessRoleRank[actorRole] <= businessRoleRank[member.Role] {
+			return ports.ErrBusinessRoleTooHigh
+		}
+	}
+	if err := db.Where("business_id = ? AND user_id = ?", businessID, targetUserID).Delete(&models.BusinessMember{}).Error; err != nil {
+		return ports.ErrDatabase
+	}
+	return nil
+}
+func (s *BusinessMemberService) TransferBusinessOwnership(ctx context.Context, businessID, authUserID uint, data ports.TransferBusinessOwnershipInput) (*models.Business, error) {
+	db := s.db.WithContext(ctx)
+	if _, err := requireBusinessRole(db, businessID, authUserID, models.BusinessRoleOwner); err != nil {
+		return nil, err
+	}
+	if data.UserID == authUserID {
+		return nil, ports.ErrInvalidOwnershipTransfer
+	}
+	if _, err := findBusinessMember(db, businessID, data.UserID); err != nil {
+		if errors.Is(err, ports.ErrBusinessMemberNotFound) {
+			return nil, ports.ErrInvalidOwnershipTransfer
+		}
+		return nil, err
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Business{}).Where("id = ?", businessID).Update("operator_user_id", data.UserID).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.BusinessMember{}).
+			Where("business_id = ? AND user_id = ?", businessID, data.UserID).
+			Update("role", models.BusinessRoleOwner).Error; err != nil {
+			return err
+		}
+		previous := models.BusinessMember{BusinessID: businessID, UserID: authUserID, Role: models.BusinessRoleAdmin, JoinedAt: time.Now()}
+		return tx.Clauses(clause.OnConflict{DoUpdates: clause.Assignments(map[string]interface{}{"role": models.BusinessRoleAdmin})}).
+			Create(&previous).Error
+	})
+	if err != nil {
+		return nil, ports.ErrDatabase
+	}
+	var business models.Business
+	if err := db.Preload("OperatorUser").First(&business, businessID).Error; err != nil {
+		return nil, ports.ErrDatabase
+	}
+	return &business, nil
+}
+func (s *BusinessMemberService) CreateBusinessInvite(ctx context.Context, businessID, authUserID uint, data ports.CreateBusinessInviteInput) (*models.BusinessInvite, error) {
+	db := s.db.WithContext(ctx)
+	actorRole, err := requireBusinessRole(db, businessID, authUserID, models.BusinessRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if businessRoleRank[actorRole] <= businessRoleRank[data.Role] {
+		return nil, ports.ErrBusinessRoleTooHigh
+	}
+	email := strings.ToLower(strings.TrimSpace(data.Email))
+	var invitee models.User
+	err = db.Where("login_email = ?", email).First(&invitee).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ports.ErrDatabase
+	}
+	if invitee.ID != 0 {
+		role, err := businessRoleFor(db, businessID, invitee.ID)
+		if err != nil {
+			return nil, err
+		}
+		if role != "" {
+			return nil, ports.ErrBusinessMemberExists
+		}
+	}
+	var pending int64
+	if err := db.Model(&models.BusinessInvite{}).
+		Where("business_id = ? AND email = ? AND status = ? AND expires_at > ?", businessID, email, models.BusinessInvitePending, time.Now()).
+		Count(&pending).Error; err != nil {
+		return nil, ports.ErrDatabase
+	}
+	if pending > 0 {
+		return nil, ports.ErrBusinessInviteExists
+	}
+	invite := models.BusinessInvite{
+		BusinessID:      businessID,
+		Email:           email,
+		Role:            data.Role,
+		Status:          models.BusinessInvitePending,
+		InvitedByUserID: authUserID,
+		ExpiresAt:       time.Now().Add(businessInviteTTL),
+	}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&invite).Error; err != nil {
+			return err
+		}
+		if invitee.ID == 0 {
+			return nil
+		}
+		var business models.Business
+		if err := tx.Select("id", "name").First(&business, businessID).Error; err != nil {
+			return err
+		}
+		entityType := models.RelatedEntityBusiness
+		notification := models.Notification{
+			SenderUserID:      &authUserID,
+			ReceiverUserID:    invitee.ID,
+			NotificationType:  models.NotifyBusinessInvite,
+			Title:             "Business invitation",
+			Message:           fmt.Sprintf("You have been invited to join %s as %s", business.Name, data.Role),
+			RelatedEntityType: &entityType,
+			RelatedEntityID:   &businessID,
+		}
+		return tx.Create(&notification).Error
+	})
+	if err != nil {
+		return nil, ports.ErrDatabase
+	}
+	return s.getInvite(db, invite.ID)
+}
+func (s *BusinessMemberService) getInvite(tx *gorm.DB, id uint) (*models.BusinessInvite, error) {
+	var invite models.BusinessInvite
+	if err := tx.Preload("Business").First(&invite, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ports.ErrBusinessInviteNotFound
+		}
+		return nil, ports.ErrDatabase
+	}
+	return &invite, nil
+}
+func (s *BusinessMemberService) GetBusinessInvites(ctx context.Context, businessID, authUserID uint) ([]models.BusinessInvite, error) {
+	if _, err := requireBusinessRole(s.db.WithContext(ctx), businessID, authUserID, models.BusinessRoleAdmin); err != nil {
+		return nil, err
+	}
+	var invites []models.BusinessInvite
+	err := s.db.WithContext(ctx).
+		Preload("Business").
+		Where("business_id = ?", businessID).
+		Order("created_at desc").
+		Find(&invites).Error
+	if err != nil {
+		return nil, ports.ErrDatabase
+	}
+	return invites, nil
+}
+func (s *BusinessMemberService) RevokeBusinessInvite(ctx context.Context, businessID, inviteID, authUserID uint) error {
+	db := s.db.WithContext(ctx)
+	if _, err := requireBusinessRole(db, businessID, authUserID, models.BusinessRoleAdmin); err != nil {
+		return err
+	}
+	invite, err := s.getInvite(db, inviteID)
+	if err != nil {
+		return err
+	}
+	if invite.BusinessID != businessID {
+		return ports.ErrBusinessInviteNotFound
+	}
+	if invite.Status != models.BusinessInvitePending {
+		return ports.ErrBusinessInviteNotPending
+	}
+	now := time.Now()
+	if err := db.Model(invite).Updates(map[string]interface{}{"status": models.BusinessInviteRevoked, "responded_at": &now}).Error; err != nil {
+		return ports.ErrDatabase
+	}
+	return nil
+}
+func (s *BusinessMemberService) GetInvitesForUser(ctx context.Context, userID uint) ([]models.BusinessInvite, error) {
+	var user models.User
+	if err := s.db.WithContext(ctx).Select("id", "login_email").First(&user, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ports.ErrUserNotFound
+		}
+		return nil, ports.ErrDatabase
+	}
+	var invites []models.BusinessInvite
+	err := s.db.WithContext(ctx).
+		Preload("Business").
+		Where("email = ? AND status = ? AND expires_at > ?", strings.ToLower(user.LoginEmail), models.BusinessInvitePending, time.Now()).
+		Order("created_at desc").
+		Find(&invites).Error
+	if err != nil {
+		return nil, ports.ErrDatabase
+	}
+	return invites, nil
+}
+func (s *BusinessMemberService) RespondToBusinessInvite(ctx context.Context, inviteID, userID uint, accept bool) (*models.BusinessInvite, error) {
+	db := s.db.WithContext(ctx)
+	invite, err := s.getInvite(db, inviteID)
+	if err != nil {
+		return nil, err
+	}
+	var user models.User
+	if err := db.Select("id", "login_email").First(&user, userID).Error; err != nil {
+		return nil, ports.ErrDatabase
+	}
+	if !strings.EqualFold(user.LoginEmail, invite.Email) {
+		return nil, ports.ErrBusinessInviteNotFound
+	}
+	if invite.Status != models.BusinessInvitePending {
+		return nil, ports.ErrBusinessInviteNotPending
+	}
+	if time.Now().After(invite.ExpiresAt) {
+		return nil, ports.ErrBusinessInviteExpired
+	}
+	status := models.BusinessInviteDeclined
+	if accept {
+		status = models.BusinessInviteAccepted
+	}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if accept {
+			role, err := businessRoleFor(tx, invite.BusinessID, userID)
+			if err != nil {
+				return err
+			}
+			if role != "" {
+				return ports.ErrBusinessMemberExists
+			}
+			member := models.BusinessMember{
+				BusinessID:      invite.BusinessID,
+				UserID:          userID,
+				Role:            invite.Role,
+				InvitedByUserID: &invite.InvitedByUserID,
+				JoinedAt:        time.Now(),
+			}
+			if err := tx.Create(&member).Error; err != nil {
+				return err
+			}
+		}
+		now := time.Now()
+		return tx.Model(invite).Updates(map[string]interface{}{"status": status, "responded_at": &now}).Error
+	})
+	if err != nil {
+		var apiErr *ports.ApiError
+		if errors.As(err, &apiErr) {
+			return nil, err
+		}
+		return nil, ports.ErrDatabase
+	}
+	return s.getInvite(db, inviteID)
+}
@@ -38,6 +38,9 @@ func (s *BusinessTagService) CreateBusinessTag(ctx context.Context, data ports.C
 	if err := s.db.WithContext(ctx).Create(&businessTag).Error; err != nil {
 		return nil, ports.ErrDatabase
 	}
+	if err := refreshBusinessCompleteness(s.db.WithContext(ctx), businessTag.BusinessID); err != nil {
+		return nil, ports.ErrDatabase
+	}
 	if err := s.db.WithContext(ctx).
 		Preload("Business").
 		First(&businessTag, businessTag.ID).Error; err != nil {
@@ -110,6 +113,11 @@ func (s *BusinessTagService) UpdateBusinessTag(ctx context.Context, id uint, dat
 		Updates(updates).Error; err != nil {
 		return nil, ports.ErrDatabase
 	}
+	if data.TagType != nil {
+		if err := refreshBusinessCompleteness(s.db.WithContext(ctx), businessTag.BusinessID); err != nil {
+			return nil, ports.ErrDatabase
+		}
+	}
 	if err := s.db.WithContext(ctx).
 		Preload("Business").
 		First(&businessTag, id).Error; err != nil {
@@ -118,6 +126,13 @@ func (s *BusinessTagService) UpdateBusinessTag(ctx context.Context, id uint, dat
 	return &businessTag, nil
 }
 func (s *BusinessTagService) DeleteBusinessTag(ctx context.Context, id uint) error {
+	var businessTag models.BusinessTag
+	if err := s.db.WithContext(ctx).First(&businessTag, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return ports.ErrBusinessTagNotFound
+		}
+		return ports.ErrDatabase
+	}
 	result := s.db.WithContext(ctx).
 		Delete(&models.BusinessTag{}, id)
 	if result.Error != nil {
@@ -126,6 +141,9 @@ func (s *BusinessTagService) DeleteBusinessTag(ctx context.Context, id uint) err
 	if result.RowsAffected == 0 {
 		return ports.ErrBusinessTagNotFound
 	}
+	if err := refreshBusinessCompleteness(s.db.WithContext(ctx), businessTag.BusinessID); err != nil {
+		return ports.ErrDatabase
+	}
 	return nil
 }
 func (s *BusinessTagService) GetTagsByType(ctx context.Context, businessID uint, tagType models.BusinessTagType) ([]models.BusinessTag, error) {
@@ -0,0 +1,305 @@
+package services
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/models"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/ports"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/pkg/verification"
+	"gorm.io/gorm"
+)
+var openVerificationStatuses = []models.VerificationStatus{models.VerificationPendingCheck, models.VerificationPendingReview}
+type BusinessVerificationService struct {
+	db       *gorm.DB
+	resolver verification.Resolver
+}
+func NewBusinessVerificationService(db *gorm.DB, resolver verification.Resolver) *BusinessVerificationService {
+	return &BusinessVerificationService{db: db, resolver: resolver}
+}
+func newVerificationToken() (string, error) {
+	buf := make([]byte, 20)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+func requirePlatformAdmin(tx *gorm.DB, userID uint) error {
+	var user models.User
+	if err := tx.Select("id", "platform_admin").First(&user, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ports.ErrPlatformAdminRequired
+		}
+		return ports.ErrDatabase
+	}
+	if !user.PlatformAdmin {
+		return ports.ErrPlatformAdminRequired
+	}
+	return nil
+}
+func notifyVerification(tx *gorm.DB, senderUserID, businessID uint, title, message string) error {
+	receivers, err := businessAdminUserIDs(tx, businessID)
+	if err != nil {
+		return err
+	}
+	entityType := models.RelatedEntityBusiness
+	for _, receiverUserID := range receivers {
+		if receiverUserID == senderUserID {
+			continue
+		}
+		notification := models.Notification{
+			SenderUserID:      &senderUserID,
+			ReceiverUserID:    receiverUserID,
+			NotificationType:  models.NotifyBusinessVerification,
+			Title:             title,
+			Message:           message,
+			RelatedEntityType: &entityType,
+			RelatedEntityID:   &businessID,
+		}
+		if err := tx.Create(&notification).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+func revokeBusinessVerification(tx *gorm.DB, businessID, userID uint, reason string) error {
+	now := time.Now()
+	if err := tx.Model(&models.BusinessVerification{}).
+		Where("business_id = ? AND status = ?", businessID, models.VerificationApproved).
+		Updates(map[string]interface{}{
+			"status":             models.VerificationRevoked,
+			"revoked_by_user_id": userID,
+			"revoked_at":         now,
+			"revocation_reason":  reason,
+		}).Error; err != nil {
+		return err
+	}
+	return tx.Model(&models.Business{}).Where("id = ?", businessID).Updates(map[string]interface{}{
+		"verified":    false,
+		"verified_at": nil,
+	}).Error
+}
+func cancelOpenVerifications(tx *gorm.DB, businessID, userID uint, reason string) error {
+	return tx.Model(&models.BusinessVerification{}).
+		Where("business_id = ? AND status IN ?", businessID, openVerificationStatuses).
+		Updates(map[string]interface{}{
+			"status":             models.VerificationRevoked,
+			"revoked_by_user_id": userID,
+			"revoked_at":         time.Now(),
+			"revocation_reason":  reason,
+		}).Error
+}
+func (s *BusinessVerificationService) loadVerification(tx *gorm.DB, id uint) (*models.BusinessVerification, error) {
+	var v models.BusinessVerification
+	if err := tx.Preload("Business").First(&v, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ports.ErrVerificationNotFound
+		}
+		return nil, ports.ErrDatabase
+	}
+	return &v, nil
+}
+func (s *BusinessVerificationService) latestVerification(tx *gorm.DB, businessID uint) (*models.BusinessVerification, error) {
+	var v models.BusinessVerification
+	if err := tx.Where("business_id = ?", businessID).Order("id desc").First(&v).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ports.ErrVerificationNotFound
+		}
+		return nil, ports.ErrDatabase
+	}
+	return &v, nil
+}
+func (s *BusinessVerificationService) RequestVerification(ctx context.Context, businessID, authUserID uint, data ports.RequestVerificationInput) (*models.BusinessVerification, error) {
+	db := s.db.WithContext(ctx)
+	if _, err := requireBusinessRole(db, businessID, authUserID, models.BusinessRoleAdmin); err != nil {
+		return nil, err
+	}
+	var business models.Business
+	if err := db.First(&business, businessID).Error; err != nil {
+		return nil, ports.ErrDatabase
+	}
+	if business.Verified {
+		return nil, ports.ErrBusinessAlreadyVerified
+	}
+	if business.Website == nil {
+		return nil, ports.ErrVerificationNoWebsite
+	}
+	domain, err := verification.DomainFromWebsite(*business.Website)
+	if err != nil {
+		return nil, ports.ErrVerificationNoWebsite
+	}
+	var open int64
+	if err := db.Model(&models.BusinessVerification{}).Where("business_id = ? AND status IN ?", businessID, openVerificationStatuses).Count(&open).Error; err != nil {
+		return nil, ports.ErrDatabase
+	}
+	if open > 0 {
+		return nil, ports.ErrVerificationInProgress
+	}
+	token, err := newVerificationToken()
+	if err != nil {
+		return nil, ports.ErrDatabase
+	}
+	v := models.BusinessVerification{
+		BusinessID:        businessID,
+		RequestedByUserID: authUserID,
+		Method:            data.Method,
+		Domain:            domain,
+		Token:             token,
+		Status:            models.VerificationPendingCheck,
+	}
+	if err := db.Create(&v).Error; err != nil {
+		return nil, ports.ErrDatabase
+	}
+	return s.loadVerification(db, v.ID)
+}
+func (s *BusinessVerificationService) GetBusinessVerification(ctx context.Context, businessID, authUserID uint) (*models.BusinessVerification, error) {
+	db := s.db.WithContext(ctx)
+	role, err := businessRoleFor(db, businessID, authUserID)
+	if err != nil {
+		return nil, err
+	}
+	if role == "" {
+		if err := requirePlatformAdmin(db, authUserID); err != nil {
+			return nil, ports.ErrForbidden
+		}
+	}
+	latest, err := s.latestVerification(db, businessID)
+	if err != nil {
+		return nil, err
+	}
+	return s.loadVerification(db, latest.ID)
+}
+func (s *BusinessVerificationService) CheckVerification(ctx context.Context, businessID, authUserID uint) (*models.BusinessVerification, error) {
+	db := s.db.WithContext(ctx)
+	if _, err := requireBusinessRole(db, businessID, authUserID, models.BusinessRoleAdmin); err != nil {
+		return nil, err
+	}
+	v, err := s.latestVerification(db, businessID)
+	if err != nil {
+		return nil, err
+	}
+	if v.Status != models.VerificationPendingCheck {
+		return nil, ports.ErrVerificationNotPending
+	}
+	var found bool
+	switch v.Method {
+	case models.VerificationMethodDNS:
+		found, err = verification.HasTXTToken(ctx, s.resolver, v.Domain, v.Token)
+	case models.VerificationMethodHTMLFile:
+		found, err = verification.HasFileToken(ctx, s.resolver, v.Domain, v.Token)
+	}
+	if err != nil || !found {
+		return nil, ports.ErrVerificationTokenMissing
+	}
+	result := db.Model(&models.BusinessVerification{}).
+		Where("id = ? AND status = ?", v.ID, models.VerificationPendingCheck).
+		Updates(map[string]interface{}{"status": models.VerificationPendingReview, "checked_at": time.Now()})
+	if result.Error != nil {
+		return nil, ports.ErrDatabase
+	}
+	if result.RowsAffected == 0 {
+		return nil, ports.ErrVerificationNotPending
+	}
+	return s.loadVerification(db, v.ID)
+}
+func (s *BusinessVerificationService) GetVerificationQueue(ctx context.Context, authUserID uint, filter ports.VerificationsFilter) ([]models.BusinessVerification, error) {
+	db := s.db.WithContext(ctx)
+	if err := requirePlatformAdmin(db, authUserID); err != nil {
+		return nil, err
+	}
+	status := models.VerificationPendingReview
+	if filter.Status != nil {
+		status = *filter.Status
+	}
+	var verifications []models.BusinessVerification
+	if err := db.Preload("Business").Where("status = ?", status).Order("created_at asc, id asc").Find(&verifications).Error; err != nil {
+		return nil, ports.ErrDatabase
+	}
+	return verifications, nil
+}
+func (s *BusinessVerificationService) ReviewVerification(ctx context.Context, id, authUserID uint, approve bool, data ports.ReviewVerificationInput) (*models.BusinessVerification, error) {
+	db := s.db.WithContext(ctx)
+	if err := requirePlatformAdmin(db, authUserID); err != nil {
+		return nil, err
+	}
+	v, err := s.loadVerification(db, id)
+	if err != nil {
+		return nil, err
+	}
+	if v.Status != models.VerificationPendingReview {
+		return nil, ports.ErrVerificationNotPending
+	}
+	now := time.Now()
+	status := models.VerificationRejected
+	if approve {
+		status = models.VerificationApproved
+	}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.BusinessVerification{}).
+			Where("id = ? AND status = ?", id, models.VerificationPendingReview).
+			Updates(map[string]interface{}{
+				"status":              status,
+				"reviewed_by_user_id": authUserID,
+				"reviewed_at":         now,
+				"review_note":         data.Note,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ports.ErrVerificationNotPending
+		}
+		if !approve {
+			return notifyVerification(tx, authUserID, v.BusinessID, "Verification rejected",
+				fmt.Sprintf("Verification of %s for %s was rejected", v.Business.Name, v.Domain))
+		}
+		if err := tx.Model(&models.Business{}).Where("id = ?", v.BusinessID).Updates(map[string]interface{}{
+			"verified":    true,
+			"verified_at": now,
+		}).Error; err != nil {
+			return err
+		}
+		return notifyVerification(tx, authUserID, v.BusinessID, "Business verified",
+			fmt.Sprintf("%s is now verified as the owner of %s", v.Business.Name, v.Domain))
+	})
+	if err != nil {
+		if errors.Is(err, ports.ErrVerificationNotPending) {
+			return nil, ports.ErrVerificationNotPending
+		}
+		return nil, ports.ErrDatabase
+	}
+	return s.loadVerification(db, id)
+}
+func (s *BusinessVerificationService) RevokeVerification(ctx context.Context, businessID, authUserID uint, data ports.RevokeVerificationInput) (*models.BusinessVerification, error) {
+	db := s.db.WithContext(ctx)
+	if err := requirePlatformAdmin(db, authUserID); err != nil {
+		return nil, err
+	}
+	var business models.Business
+	if err := db.First(&business, businessID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ports.ErrBusinessNotFound
+		}
+		return nil, ports.ErrDatabase
+	}
+	if !business.Verified {
+		return nil, ports.ErrBusinessNotVerified
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := revokeBusinessVerification(tx, businessID, authUserID, data.Reason); err != nil {
+			return err
+		}
+		return notifyVerification(tx, authUserID, businessID, "Verification revoked",
+			fmt.Sprintf("Verification of %s was revoked: %s", business.Name, data.Reason))
+	})
+	if err != nil {
+		return nil, ports.ErrDatabase
+	}
+	latest, err := s.latestVerification(db, businessID)
+	if err != nil {
+		return nil, err
+	}
+	return s.loadVerification(db, latest.ID)
+}
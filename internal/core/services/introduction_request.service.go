@@ -0,0 +1,340 @@
+package services
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/models"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/ports"
+	"gorm.io/gorm"
+)
+type IntroductionRequestService struct {
+	db *gorm.DB
+}
+func NewIntroductionRequestService(db *gorm.DB) *IntroductionRequestService {
+	return &IntroductionRequestService{db: db}
+}
+func businessAdminUserIDs(tx *gorm.DB, businessID uint) ([]uint, error) {
+	var business models.Business
+	if err := tx.Select("id", "operator_user_id").First(&business, businessID).Error; err != nil {
+		return nil, ports.ErrDatabase
+	}
+	var memberIDs []uint
+	if err := tx.Model(&models.BusinessMember{}).
+		Where("business_id = ? AND role IN ?", businessID, []models.BusinessMemberRole{models.BusinessRoleOwner, models.BusinessRoleAdmin}).
+		Pluck("user_id", &memberIDs).Error; err != nil {
+		return nil, ports.ErrDatabase
+	}
+	ids := []uint{business.OperatorUserID}
+	for _, id := range memberIDs {
+		if id != business.OperatorUserID {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+func notifyIntroduction(tx *gorm.DB, senderUserID uint, receiverUserIDs []uint, title, message string, businessID uint) error {
+	entityType := models.RelatedEntityBusiness
+	for _, receiverUserID := range receiverUserIDs {
+		if receiverUserID == senderUserID {
+			continue
+		}
+		notification := models.Notification{
+			SenderUserID:      &senderUserID,
+			ReceiverUserID:    receiverUserID,
+			NotificationType:  models.NotifyIntroductionRequest,
+			Title:             title,
+			Message:           message,
+			RelatedEntityType: &entityType,
+			RelatedEntityID:   &businessID,
+		}
+		if err := tx.Create(&notification).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+func (s *IntroductionRequestService) loadIntroduction(tx *gorm.DB, id uint) (*models.IntroductionRequest, error) {
+	var intro models.IntroductionRequest
+	err := tx.
+		Preload("RequesterBusiness").
+		Preload("IntroducerBusiness").
+		Preload("TargetBusiness").
+		First(&intro, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ports.ErrIntroductionNotFound
+		}
+		return nil, ports.ErrDatabase
+	}
+	return &intro, nil
+}
+func (s *IntroductionRequestService) CreateIntroductionRequest(ctx context.Context, authUserID uint, data ports.CreateIntroductionRequestInput) (*models.IntroductionRequest, error) {
+	db := s.db.WithContext(ctx)
+	if data.RequesterBusinessID == data.IntroducerBusinessID || data.RequesterBusinessID == data.TargetBusinessID || data.IntroducerBusinessID == data.TargetBusinessID {
+		return nil, ports.ErrInvalidIntroduction
+	}
+	if _, err := requireBusinessRole(db, data.RequesterBusinessID, authUserID, models.BusinessRoleAdmin); err != nil {
+		return nil, err
+	}
+	var businesses []models.Business
+	if err := db.Where("id IN ?", []uint{data.IntroducerBusinessID, data.TargetBusinessID}).Find(&businesses).Error; err != nil {
+		return nil, ports.ErrDatabase
+	}
+	if len(businesses) < 2 {
+		return nil, ports.ErrBusinessNotFound
+	}
+	connected, err := businessesConnected(db, data.RequesterBusinessID, data.TargetBusinessID, models.ConnectionStatusPending, models.ConnectionStatusActive)
+	if err != nil {
+		return nil, err
+	}
+	if connected {
+		return nil, ports.ErrBusinessConnectionAlreadyExists
+	}
+	for _, side := range []uint{data.RequesterBusinessID, data.TargetBusinessID} {
+		connected, err := businessesConnected(db, data.IntroducerBusinessID, side, models.ConnectionStatusActive)
+		if err != nil {
+			return nil, err
+		}
+		if !connected {
+			return nil, ports.ErrIntroducerNotMutual
+		}
+	}
+	var pending int64
+	if err := db.Model(&models.IntroductionRequest{}).
+		Where("requester_business_id = ? AND target_business_id = ? AND status IN ?",
+			data.RequesterBusinessID, data.TargetBusinessID, []models.IntroductionStatus{models.IntroPendingIntroducer, models.IntroPendingTarget}).
+		Count(&pending).Error; err != nil {
+		return nil, ports.ErrDatabase
+	}
+	if pending > 0 {
+		return nil, ports.ErrIntroductionExists
+	}
+	intro := models.IntroductionRequest{
+		RequesterBusinessID:  data.RequesterBusinessID,
+		IntroducerBusinessID: data.IntroducerBusinessID,
+		TargetBusinessID:     data.TargetBusinessID,
+		RequestedByUserID:    authUserID,
+		Message:              data.Message,
+		Status:               models.IntroPendingIntroducer,
+	}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&intro).Error; err != nil {
+			return err
+		}
+		created, err := s.loadIntroduction(tx, intro.ID)
+		if err != nil {
+			return err
+		}
+		receivers, err := businessAdminUserIDs(tx, intro.IntroducerBusinessID)
+		if err != nil {
+			return err
+		}
+		return notifyIntroduction(tx, authUserID, receivers, "Introduction request",
+			fmt.Sprintf("%s would like you to introduce them to %s", created.RequesterBusiness.Name, created.TargetBusiness.Name),
+			intro.RequesterBusinessID)
+	})
+	if err != nil {
+		return nil, ports.ErrDatabase
+	}
+	return s.loadIntroduction(db, intro.ID)
+}
+func (s *IntroductionRequestService) canView(tx *gorm.DB, intro *models.IntroductionRequest, userID uint) (bool, error) {
+	businessIDs := []uint{intro.RequesterBusinessID, intro.IntroducerBusinessID}
+	if intro.Status != models.IntroPendingIntroducer {
+		businessIDs = append(businessIDs, intro.TargetBusinessID)
+	}
+	for _, businessID := range businessIDs {
+		role, err := businessRoleFor(tx, businessID, userID)
+		if err != nil {
+			return false, err
+		}
+		if role != "" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+func (s *IntroductionRequestService) GetIntroductionRequest(ctx context.Context, id, authUserID uint) (*models.IntroductionRequest, error) {
+	db := s.db.WithContext(ctx)
+	intro, err := s.loadIntroduction(db, id)
+	if err != nil {
+		return nil, err
+	}
+	visible, err := s.canView(db, intro, authUserID)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		return nil, ports.ErrIntroductionNotFound
+	}
+	return intro, nil
+}
+func (s *IntroductionRequestService) GetBusinessIntroductions(ctx context.Context, businessID, authUserID uint, status *models.IntroductionStatus) ([]models.IntroductionRequest, error) {
+	db := s.db.WithContext(ctx)
+	if _, err := requireBusinessRole(db, businessID, authUserID, models.BusinessRoleViewer); err != nil {
+		return nil, err
+	}
+	query := db.
+		Preload("RequesterBusiness").
+		Preload("IntroducerBusiness").
+		Preload("TargetBusiness").
+		Where("requester_business_id = ? OR introducer_business_id = ? OR (target_business_id = ? AND status <> ?)",
+			businessID, businessID, businessID, models.IntroPendingIntroducer)
+	if status != nil {
+		query = query.Where("status = ?", *status)
+	}
+	var intros []models.IntroductionRequest
+	if err := query.Order("created_at desc").Find(&intros).Error; err != nil {
+		return nil, ports.ErrDatabase
+	}
+	return intros, nil
+}
+func (s *IntroductionRequestService) AcceptIntroductionRequest(ctx context.Context, id, authUserID uint) (*models.IntroductionRequest, error) {
+	return s.respondToIntroduction(ctx, id, authUserID, true)
+}
+func (s *IntroductionRequestService) DeclineIntroductionRequest(ctx context.Context, id, authUserID uint) (*models.IntroductionRequest, error) {
+	return s.respondToIntroduction(ctx, id, authUserID, false)
+}
+func (s *IntroductionRequestService) respondToIntroduction(ctx context.Context, id, authUserID uint, accept bool) (*models.IntroductionRequest, error) {
+	db := s.db.WithContext(ctx)
+	intro, err := s.loadIntroduction(db, id)
+	if err != nil {
+		return nil, err
+	}
+	var actingBusinessID uint
+	switch intro.Status {
+	case models.IntroPendingIntroducer:
+		actingBusinessID = intro.IntroducerBusinessID
+	case models.IntroPendingTarget:
+		actingBusinessID = intro.TargetBusinessID
+	default:
+		return nil, ports.ErrIntroductionNotPending
+	}
+	if _, err := requireBusinessRole(db, actingBusinessID, authUserID, models.BusinessRoleAdmin); err != nil {
+		return nil, err
+	}
+	if accept && intro.Status == models.IntroPendingIntroducer {
+		for _, side := range []uint{intro.RequesterBusinessID, intro.TargetBusinessID} {
+			connected, err := businessesConnected(db, intro.IntroducerBusinessID, side, models.ConnectionStatusActive)
+			if err != nil {
+				return nil, err
+			}
+			if !connected {
+				return nil, ports.ErrIntroducerNotMutual
+			}
+		}
+	}
+	now := time.Now()
+	err = db.Transaction(func(tx *gorm.DB) error {
+		updates := map[string]interface{}{}
+		var receivers []uint
+		var title, message string
+		switch {
+		case intro.Status == models.IntroPendingIntroducer && accept:
+			updates["status"] = models.IntroPendingTarget
+			updates["introducer_user_id"] = authUserID
+			updates["introducer_responded_at"] = &now
+			targetAdmins, err := businessAdminUserIDs(tx, intro.TargetBusinessID)
+			if err != nil {
+				return err
+			}
+			if err := notifyIntroduction(tx, authUserID, targetAdmins, "Introduction request",
+				fmt.Sprintf("%s would like to introduce you to %s", intro.IntroducerBusiness.Name, intro.RequesterBusiness.Name),
+				intro.RequesterBusinessID); err != nil {
+				return err
+			}
+			receivers = []uint{intro.RequestedByUserID}
+			title = "Introduction forwarded"
+			message = fmt.Sprintf("%s has passed your introduction request on to %s", intro.IntroducerBusiness.Name, intro.TargetBusiness.Name)
+		case intro.Status == models.IntroPendingIntroducer:
+			updates["status"] = models.IntroDeclined
+			updates["introducer_user_id"] = authUserID
+			updates["introducer_responded_at"] = &now
+			receivers = []uint{intro.RequestedByUserID}
+			title = "Introduction declined"
+			message = fmt.Sprintf("%s declined to introduce you to %s", intro.IntroducerBusiness.Name, intro.TargetBusiness.Name)
+		case accept:
+			connectionID, err := s.establishReferral(tx, intro, authUserID)
+			if err != nil {
+				return err
+			}
+			updates["status"] = models.IntroCompleted
+			updates["target_user_id"] = authUserID
+			updates["target_responded_at"] = &now
+			updates["business_connection_id"] = connectionID
+			receivers = []uint{intro.RequestedByUserID}
+			if intro.IntroducerUserID != nil {
+				receivers = append(receivers, *intro.IntroducerUserID)
+			}
+			title = "Introduction accepted"
+			message = fmt.Sprintf("%s and %s are now connected through %s", intro.RequesterBusiness.Name, intro.TargetBusiness.Name, intro.IntroducerBusiness.Name)
+		default:
+			updates["status"] = models.IntroDeclined
+			updates["target_user_id"] = authUserID
+			updates["target_responded_at"] = &now
+			receivers = []uint{intro.RequestedByUserID}
+			if intro.IntroducerUserID != nil {
+				receivers = append(receivers, *intro.IntroducerUserID)
+			}
+			title = "Introduction declined"
+			message = fmt.Sprintf("%s declined the introduction to %s", intro.TargetBusiness.Name, intro.RequesterBusiness.Name)
+		}
+		result := tx.Model(&models.IntroductionRequest{}).
+			Where("id = ? AND status = ?", intro.ID, intro.Status).
+			Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ports.ErrIntroductionNotPending
+		}
+		return notifyIntroduction(tx, authUserID, receivers, title, message, actingBusinessID)
+	})
+	if err != nil {
+		if errors.Is(err, ports.ErrIntroductionNotPending) {
+			return nil, ports.ErrIntroductionNotPending
+		}
+		return nil, ports.ErrDatabase
+	}
+	return s.loadIntroduction(db, id)
+}
+func (s *IntroductionRequestService) establishReferral(tx *gorm.DB, intro *models.IntroductionRequest, actorUserID uint) (uint, error) {
+	var existing models.BusinessConnection
+	err := tx.
+		Where("((initiating_business_id = ? AND receiving_business_id = ?) OR (initiating_business_id = ? AND receiving_business_id = ?)) AND connection_type = ?",
+			intro.RequesterBusinessID, intro.TargetBusinessID, intro.TargetBusinessID, intro.RequesterBusinessID, models.ConnectionTypeReferral).
+		First(&existing).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, err
+	}
+	notes := fmt.Sprintf("Introduced by %s", intro.IntroducerBusiness.Name)
+	if existing.ID == 0 {
+		connection := models.BusinessConnection{
+			InitiatingBusinessID: intro.RequesterBusinessID,
+			ReceivingBusinessID:  intro.TargetBusinessID,
+			ConnectionType:       models.ConnectionTypeReferral,
+			Status:               models.ConnectionStatusActive,
+			InitiatedByUserID:    intro.RequestedByUserID,
+			Notes:                &notes,
+		}
+		if err := tx.Create(&connection).Error; err != nil {
+			return 0, err
+		}
+		return connection.ID, recordConnectionStatus(tx, connection.ID, nil, models.ConnectionStatusActive, actorUserID)
+	}
+	if existing.Status == models.ConnectionStatusActive {
+		return existing.ID, nil
+	}
+	previous := existing.Status
+	if err := tx.Model(&existing).Updates(map[string]interface{}{
+		"initiating_business_id": intro.RequesterBusinessID,
+		"receiving_business_id":  intro.TargetBusinessID,
+		"status":                 models.ConnectionStatusActive,
+		"initiated_by_user_id":   intro.RequestedByUserID,
+		"notes":                  &notes,
+	}).Error; err != nil {
+		return 0, err
+	}
+	return existing.ID, recordConnectionStatus(tx, existing.ID, &previous, models.ConnectionStatusActive, actorUserID)
+}
@@ -0,0 +1,215 @@
+package services
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+	"path/filepath"
+	"time"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/models"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/ports"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/pkg/storage"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/pkg/utils"
+	"gorm.io/gorm"
+)
+const maxMediaPixels = 40_000_000
+var mediaExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+type MediaService struct {
+	db             *gorm.DB
+	storage        storage.Storage
+	maxUploadBytes int64
+}
+func NewMediaService(db *gorm.DB, store storage.Storage, maxUploadBytes int64) *MediaService {
+	return &MediaService{db: db, storage: store, maxUploadBytes: maxUploadBytes}
+}
+func (s *MediaService) MaxUploadBytes() int64 {
+	return s.maxUploadBytes
+}
+func newMediaKey(now time.Time) (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return now.UTC().Format("2006/01/") + hex.EncodeToString(buf), nil
+}
+func (s *MediaService) UploadMedia(ctx context.Context, ownerUserID uint, input ports.UploadMediaInput, filename string, r io.Reader) (*models.Media, error) {
+	data, err := io.ReadAll(io.LimitReader(r, s.maxUploadBytes+1))
+	if err != nil {
+		return nil, ports.ErrMediaInvalidImage
+	}
+	if len(data) == 0 {
+		return nil, ports.ErrMediaFileRequired
+	}
+	if int64(len(data)) > s.maxUploadBytes {
+		return nil, ports.ErrMediaTooLarge
+	}
+	contentType := http.DetectContentType(data)
+	ext, ok := mediaExtensions[contentType]
+	if !ok {
+		return nil, ports.ErrMediaUnsupportedType
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ports.ErrMediaInvalidImage
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > maxMediaPixels {
+		return nil, ports.ErrMediaDimensions
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ports.ErrMediaInvalidImage
+	}
+	purpose := models.MediaPurposeGeneral
+	if input.Purpose != "" {
+		purpose = input.Purpose
+	}
+	base, err := newMediaKey(time.Now())
+	if err != nil {
+		return nil, ports.ErrMediaStorage
+	}
+	checksum := sha256.Sum256(data)
+	media := models.Media{
+		OwnerUserID:      ownerUserID,
+		Purpose:          purpose,
+		StorageKey:       "media/" + base + "/original" + ext,
+		OriginalFilename: filepath.Base(filename),
+		ContentType:      contentType,
+		SizeBytes:        int64(len(data)),
+		Width:            cfg.Width,
+		Height:           cfg.Height,
+		Checksum:         hex.EncodeToString(checksum[:]),
+	}
+	stored := []string{}
+	cleanup := func() {
+		for _, key := range stored {
+			_ = s.storage.Delete(context.Background(), key)
+		}
+	}
+	if err := s.storage.Put(ctx, media.StorageKey, data, contentType); err != nil {
+		return nil, ports.ErrMediaStorage
+	}
+	stored = append(stored, media.StorageKey)
+	for _, spec := range utils.StandardImageVariants {
+		resized, ok := utils.RenderImageVariant(img, spec)
+		if !ok {
+			continue
+		}
+		encoded, variantType, err := utils.EncodeImage(resized, contentType)
+		if err != nil {
+			cleanup()
+			return nil, ports.ErrMediaStorage
+		}
+		variant := models.MediaVariant{
+			Name:        spec.Name,
+			StorageKey:  "media/" + base + "/" + spec.Name + mediaExtensions[variantType],
+			ContentType: variantType,
+			SizeBytes:   int64(len(encoded)),
+			Width:       resized.Bounds().Dx(),
+			Height:      resized.Bounds().Dy(),
+		}
+		if err := s.storage.Put(ctx, variant.StorageKey, encoded, variantType); err != nil {
+			cleanup()
+			return nil, ports.ErrMediaStorage
+		}
+		stored = append(stored, variant.StorageKey)
+		media.Variants = append(media.Variants, variant)
+	}
+	if err := s.db.WithContext(ctx).Create(&media).Error; err != nil {
+		cleanup()
+		return nil, ports.ErrDatabase
+	}
+	return s.GetMediaByID(ctx, media.ID)
+}
+func (s *MediaService) GetMediaByID(ctx context.Context, id uint) (*models.Media, error) {
+	var media models.Media
+	err := s.db.WithContext(ctx).
+		Preload("Variants", func(db *gorm.DB) *gorm.DB { return db.Order("width ASC") }).
+		First(&media, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ports.ErrMediaNotFound
+		}
+		return nil, ports.ErrDatabase
+	}
+	return &media, nil
+}
+func (s *MediaService) OpenMediaContent(ctx context.Context, id uint, variant string) (io.ReadCloser, string, error) {
+	media, err := s.GetMediaByID(ctx, id)
+	if err != nil {
+		return nil, "", err
+	}
+	key, contentType := media.StorageKey, media.ContentType
+	for _, v := range media.Variants {
+		if v.Name == variant {
+			key, contentType = v.StorageKey, v.ContentType
+		}
+	}
+	body, err := s.storage.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, "", ports.ErrMediaNotFound
+		}
+		return nil, "", ports.ErrMediaStorage
+	}
+	return body, contentType, nil
+}
+func (s *MediaService) DeleteMedia(ctx context.Context, id, authUserID uint) error {
+	media, err := s.GetMediaByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if media.OwnerUserID != authUserID {
+		return ports.ErrForbidden
+	}
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.User{}).Where("avatar_media_id = ?", id).Update("avatar_media_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.Business{}).Where("logo_media_id = ?", id).Update("logo_media_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.Publication{}).Where("media_id = ?", id).Update("media_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("media_id = ?", id).Delete(&models.MediaVariant{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Media{}, id).Error
+	})
+	if err != nil {
+		return ports.ErrDatabase
+	}
+	for _, v := range media.Variants {
+		_ = s.storage.Delete(ctx, v.StorageKey)
+	}
+	_ = s.storage.Delete(ctx, media.StorageKey)
+	return nil
+}
+func resolveMediaReference(tx *gorm.DB, mediaID *uint, ownerUserID uint) (*uint, error) {
+	if *mediaID == 0 {
+		return nil, nil
+	}
+	var media models.Media
+	if err := tx.Select("id", "owner_user_id").First(&media, *mediaID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ports.ErrMediaNotFound
+		}
+		return nil, ports.ErrDatabase
+	}
+	if media.OwnerUserID != ownerUserID {
+		return nil, ports.ErrMediaNotOwned
+	}
+	return &media.ID, nil
+}
@@ -2,13 +2,16 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
-	"regexp"
+	"fmt"
 	"strings"
 	"time"
 
 	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/models"
 	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/ports"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/pkg/utils"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
@@ -19,40 +22,184 @@ type PublicationService struct {
 func NewPublicationService(db *gorm.DB) *PublicationService {
 	return &PublicationService{db: db}
 }
+
+const (
+	defaultPublicationSlug = "publication"
+	maxPublicationSlugBase = 280
+	defaultFeedLimit       = 50
+	maxSlugAttempts        = 5
+)
+
 func generateSlug(title string) string {
-	slug := strings.ToLower(title)
-	re := regexp.MustCompile(`[^a-z0-9]+`)
-	slug = re.ReplaceAllString(slug, "-")
-	slug = strings.Trim(slug, "-")
+	slug := utils.Slugify(title)
+	if len(slug) > maxPublicationSlugBase {
+		slug = strings.TrimRight(slug[:maxPublicationSlugBase], "-")
+	}
+	if slug == "" {
+		return defaultPublicationSlug
+	}
 	return slug
 }
+func uniquePublicationSlug(tx *gorm.DB, base string, publicationID uint) (string, error) {
+	pattern := base + "-%"
+	var taken []string
+	if err := tx.Model(&models.Publication{}).
+		Where("(slug = ? OR slug LIKE ?) AND id <> ?", base, pattern, publicationID).
+		Pluck("slug", &taken).Error; err != nil {
+		return "", err
+	}
+	var historic []string
+	if err := tx.Model(&models.PublicationSlugHistory{}).
+		Where("(slug = ? OR slug LIKE ?) AND publication_id <> ?", base, pattern, publicationID).
+		Pluck("slug", &historic).Error; err != nil {
+		return "", err
+	}
+	used := make(map[string]bool, len(taken)+len(historic))
+	for _, slug := range append(taken, historic...) {
+		used[slug] = true
+	}
+	if !used[base] {
+		return base, nil
+	}
+	for n := 2; ; n++ {
+		candidate := fmt.Sprintf("%s-%d", base, n)
+		if !used[candidate] {
+			return candidate, nil
+		}
+	}
+}
+func isDuplicateSlug(err error) bool {
+	return strings.Contains(err.Error(), "Duplicate entry") && strings.Contains(err.Error(), "slug")
+}
+func applyRenderedContent(pub *models.Publication) error {
+	rendered, err := ports.RenderPublicationContent(pub.Content, pub.ContentFormat, pub.PublicationType)
+	if err != nil {
+		return ports.ErrPublicationContentInvalid
+	}
+	pub.ContentHTML = rendered.HTML
+	pub.GeneratedExcerpt = utils.GenerateExcerpt(rendered.Text, utils.DefaultExcerptLength)
+	pub.ReadingTimeMinutes = uint(rendered.ReadingTimeMinutes)
+	pub.TableOfContents = nil
+	if len(rendered.TableOfContents) > 0 {
+		toc, err := json.Marshal(rendered.TableOfContents)
+		if err != nil {
+			return ports.ErrPublicationContentInvalid
+		}
+		pub.TableOfContents = datatypes.JSON(toc)
+	}
+	return nil
+}
+func (s *PublicationService) RenderMissingContent(ctx context.Context) (int, error) {
+	rendered := 0
+	var batch []models.Publication
+	err := s.db.WithContext(ctx).
+		Where("(content_html IS NULL OR content_html = '') AND content <> ''").
+		FindInBatches(&batch, 200, func(tx *gorm.DB, _ int) error {
+			for i := range batch {
+				if err := applyRenderedContent(&batch[i]); err != nil {
+					continue
+				}
+				if err := s.db.WithContext(ctx).Model(&models.Publication{}).Where("id = ?", batch[i].ID).
+					UpdateColumns(map[string]interface{}{
+						"content_html":         batch[i].ContentHTML,
+						"generated_excerpt":    batch[i].GeneratedExcerpt,
+						"reading_time_minutes": batch[i].ReadingTimeMinutes,
+						"table_of_contents":    batch[i].TableOfContents,
+					}).Error; err != nil {
+					return err
+				}
+				rendered++
+			}
+			return nil
+		}).Error
+	if err != nil {
+		return rendered, ports.ErrDatabase
+	}
+	return rendered, nil
+}
 func (s *PublicationService) CreatePublication(ctx context.Context, data ports.CreatePublicationInput) (*models.Publication, error) {
 	var user models.User
 	if err := s.db.WithContext(ctx).First(&user, data.UserID).Error; err != nil {
 		return nil, ports.ErrPublicationAuthorNotFound
 	}
-	slug := generateSlug(data.Title)
+	if data.BusinessID != nil {
+		if _, err := requireBusinessRole(s.db.WithContext(ctx), *data.BusinessID, data.UserID, models.BusinessRoleEditor); err != nil {
+			return nil, err
+		}
+	}
 	published := false
 	var publishedAt *time.Time
-	if data.Published != nil && *data.Published {
+	var scheduledAt *time.Time
+	if data.ScheduledAt != nil {
+		if !data.ScheduledAt.After(time.Now()) {
+			return nil, ports.ErrInvalidScheduledAt
+		}
+		scheduledAt = data.ScheduledAt
+	} else if data.Published != nil && *data.Published {
 		published = true
 		now := time.Now()
 		publishedAt = &now
 	}
+	visibility := models.VisibilityMembers
+	if data.Visibility != nil {
+		visibility = *data.Visibility
+	}
+	contentFormat := models.ContentFormatMarkdown
+	if data.ContentFormat != nil {
+		contentFormat = *data.ContentFormat
+	}
+	var mediaID *uint
+	if data.MediaID != nil {
+		resolved, err := resolveMediaReference(s.db.WithContext(ctx), data.MediaID, data.UserID)
+		if err != nil {
+			return nil, err
+		}
+		mediaID = resolved
+	}
 	publication := models.Publication{
 		UserID:          data.UserID,
 		BusinessID:      data.BusinessID,
 		PublicationType: data.PublicationType,
 		Title:           data.Title,
-		Slug:            slug,
 		Content:         data.Content,
+		ContentFormat:   contentFormat,
 		Excerpt:         data.Excerpt,
 		Thumbnail:       data.Thumbnail,
 		VideoURL:        data.VideoURL,
+		MediaID:         mediaID,
+		Visibility:      visibility,
 		Published:       published,
 		PublishedAt:     publishedAt,
+		ScheduledAt:     scheduledAt,
+	}
+	if err := applyRenderedContent(&publication); err != nil {
+		return nil, err
+	}
+	var err error
+	for attempt := 0; attempt < maxSlugAttempts; attempt++ {
+		publication.ID = 0
+		err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			slug, err := uniquePublicationSlug(tx, generateSlug(data.Title), 0)
+			if err != nil {
+				return err
+			}
+			publication.Slug = slug
+			if err := tx.Create(&publication).Error; err != nil {
+				return err
+			}
+			if err := createPublicationRevision(tx, &publication, data.UserID); err != nil {
+				return err
+			}
+			if publication.BusinessID != nil && publication.Published {
+				return refreshBusinessCompleteness(tx, *publication.BusinessID)
+			}
+			return nil
+		})
+		if err == nil || !isDuplicateSlug(err) {
+			break
+		}
 	}
-	if err := s.db.WithContext(ctx).Create(&publication).Error; err != nil {
+	if err != nil {
 		if strings.Contains(err.Error(), "Duplicate entry") {
 			return nil, ports.ErrPublicationSlugExists
 		}
@@ -60,6 +207,25 @@ func (s *PublicationService) CreatePublication(ctx context.Context, data ports.C
 	}
 	return s.GetPublicationByID(ctx, publication.ID)
 }
+func createPublicationRevision(tx *gorm.DB, pub *models.Publication, editorUserID uint) error {
+	var latest uint
+	if err := tx.Model(&models.PublicationRevision{}).
+		Where("publication_id = ?", pub.ID).
+		Select("COALESCE(MAX(revision), 0)").
+		Scan(&latest).Error; err != nil {
+		return err
+	}
+	revision := models.PublicationRevision{
+		PublicationID: pub.ID,
+		Revision:      latest + 1,
+		EditorUserID:  editorUserID,
+		Title:         pub.Title,
+		Excerpt:       pub.Excerpt,
+		Content:       pub.Content,
+		ContentFormat: pub.ContentFormat,
+	}
+	return tx.Create(&revision).Error
+}
 func (s *PublicationService) GetPublicationByID(ctx context.Context, id uint) (*models.Publication, error) {
 	var pub models.Publication
 	err := s.db.WithContext(ctx).Preload("User").Preload("Business").First(&pub, id).Error
@@ -82,21 +248,66 @@ func (s *PublicationService) GetPublicationBySlug(ctx context.Context, slug stri
 	}
 	return &pub, nil
 }
+func (s *PublicationService) ResolvePublicationSlug(ctx context.Context, slug string) (*models.Publication, bool, error) {
+	pub, err := s.GetPublicationBySlug(ctx, slug)
+	if err == nil {
+		return pub, false, nil
+	}
+	if !errors.Is(err, ports.ErrPublicationNotFound) {
+		return nil, false, err
+	}
+	var history models.PublicationSlugHistory
+	if err := s.db.WithContext(ctx).Where("slug = ?", slug).First(&history).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, false, ports.ErrPublicationNotFound
+		}
+		return nil, false, ports.ErrDatabase
+	}
+	pub, err = s.GetPublicationByID(ctx, history.PublicationID)
+	if err != nil {
+		return nil, false, err
+	}
+	return pub, true, nil
+}
 func (s *PublicationService) UpdatePublication(ctx context.Context, id uint, data ports.UpdatePublicationInput) (*models.Publication, error) {
-	_, err := s.GetPublicationByID(ctx, id)
+	existing, err := s.GetPublicationByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 	updateData := make(map[string]interface{})
+	contentChanged := false
 	if data.Title != nil {
 		updateData["title"] = *data.Title
-		updateData["slug"] = generateSlug(*data.Title)
+		contentChanged = contentChanged || *data.Title != existing.Title
 	}
 	if data.Content != nil {
 		updateData["content"] = *data.Content
+		contentChanged = contentChanged || *data.Content != existing.Content
 	}
 	if data.Excerpt != nil {
 		updateData["excerpt"] = *data.Excerpt
+		contentChanged = contentChanged || existing.Excerpt == nil || *data.Excerpt != *existing.Excerpt
+	} else if data.ClearExcerpt {
+		updateData["excerpt"] = nil
+		contentChanged = contentChanged || existing.Excerpt != nil
+	}
+	if data.Content != nil || data.ContentFormat != nil {
+		rendered := *existing
+		if data.Content != nil {
+			rendered.Content = *data.Content
+		}
+		if data.ContentFormat != nil {
+			rendered.ContentFormat = *data.ContentFormat
+			updateData["content_format"] = *data.ContentFormat
+			contentChanged = contentChanged || *data.ContentFormat != existing.ContentFormat
+		}
+		if err := applyRenderedContent(&rendered); err != nil {
+			return nil, err
+		}
+		updateData["content_html"] = rendered.ContentHTML
+		updateData["generated_excerpt"] = rendered.GeneratedExcerpt
+		updateData["reading_time_minutes"] = rendered.ReadingTimeMinutes
+		updateData["table_of_contents"] = rendered.TableOfContents
 	}
 	if data.Thumbnail != nil {
 		updateData["thumbnail"] = *data.Thumbnail
@@ -104,18 +315,76 @@ func (s *PublicationService) UpdatePublication(ctx context.Context, id uint, dat
 	if data.VideoURL != nil {
 		updateData["video_url"] = *data.VideoURL
 	}
+	editorUserID := data.EditorUserID
+	if editorUserID == 0 {
+		editorUserID = existing.UserID
+	}
+	if data.MediaID != nil {
+		mediaID, err := resolveMediaReference(s.db.WithContext(ctx), data.MediaID, editorUserID)
+		if err != nil {
+			return nil, err
+		}
+		updateData["media_id"] = mediaID
+	}
+	if data.Visibility != nil {
+		updateData["visibility"] = *data.Visibility
+	}
 	if data.Published != nil {
 		updateData["published"] = *data.Published
+		updateData["scheduled_at"] = nil
 		if *data.Published {
 			updateData["published_at"] = time.Now()
 		} else {
 			updateData["published_at"] = nil
 		}
+	} else if data.ScheduledAt != nil {
+		if !data.ScheduledAt.After(time.Now()) {
+			return nil, ports.ErrInvalidScheduledAt
+		}
+		updateData["scheduled_at"] = *data.ScheduledAt
+		updateData["published"] = false
+		updateData["published_at"] = nil
 	}
 	if len(updateData) == 0 {
 		return nil, ports.ErrNoUpdateData
 	}
-	if err := s.db.WithContext(ctx).Model(&models.Publication{ID: id}).Updates(updateData).Error; err != nil {
+	for attempt := 0; attempt < maxSlugAttempts; attempt++ {
+		delete(updateData, "slug")
+		err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if data.Title != nil {
+				slug, err := uniquePublicationSlug(tx, generateSlug(*data.Title), id)
+				if err != nil {
+					return err
+				}
+				if slug != existing.Slug {
+					if err := retirePublicationSlug(tx, id, existing.Slug, slug); err != nil {
+						return err
+					}
+					updateData["slug"] = slug
+				}
+			}
+			if err := tx.Model(&models.Publication{ID: id}).Updates(updateData).Error; err != nil {
+				return err
+			}
+			if existing.BusinessID != nil && (data.Published != nil || data.ScheduledAt != nil) {
+				if err := refreshBusinessCompleteness(tx, *existing.BusinessID); err != nil {
+					return err
+				}
+			}
+			if !contentChanged {
+				return nil
+			}
+			var updated models.Publication
+			if err := tx.First(&updated, id).Error; err != nil {
+				return err
+			}
+			return createPublicationRevision(tx, &updated, editorUserID)
+		})
+		if err == nil || !isDuplicateSlug(err) {
+			break
+		}
+	}
+	if err != nil {
 		if strings.Contains(err.Error(), "Duplicate entry") {
 			return nil, ports.ErrPublicationSlugExists
 		}
@@ -123,23 +392,314 @@ func (s *PublicationService) UpdatePublication(ctx context.Context, id uint, dat
 	}
 	return s.GetPublicationByID(ctx, id)
 }
+func retirePublicationSlug(tx *gorm.DB, publicationID uint, oldSlug, newSlug string) error {
+	if err := tx.Where("publication_id = ? AND slug = ?", publicationID, newSlug).
+		Delete(&models.PublicationSlugHistory{}).Error; err != nil {
+		return err
+	}
+	history := models.PublicationSlugHistory{PublicationID: publicationID, Slug: oldSlug}
+	return tx.Where(models.PublicationSlugHistory{Slug: oldSlug}).FirstOrCreate(&history).Error
+}
 func (s *PublicationService) DeletePublication(ctx context.Context, id uint) error {
-	result := s.db.WithContext(ctx).Delete(&models.Publication{}, id)
-	if result.Error != nil {
+	var rowsAffected int64
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("publication_id = ?", id).Delete(&models.PublicationRevision{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("publication_id = ?", id).Delete(&models.PublicationSlugHistory{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("publication_id = ?", id).Delete(&models.PublicationReaction{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.PublicationComment{}).Where("publication_id = ?", id).Update("parent_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("publication_id = ?", id).Delete(&models.PublicationComment{}).Error; err != nil {
+			return err
+		}
+		var businessID *uint
+		if err := tx.Model(&models.Publication{}).Where("id = ?", id).Pluck("business_id", &businessID).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&models.Publication{}, id)
+		rowsAffected = result.RowsAffected
+		if result.Error != nil || businessID == nil {
+			return result.Error
+		}
+		return refreshBusinessCompleteness(tx, *businessID)
+	})
+	if err != nil {
 		return ports.ErrDatabase
 	}
-	if result.RowsAffected == 0 {
+	if rowsAffected == 0 {
 		return ports.ErrPublicationNotFound
 	}
 	return nil
 }
 
-func (s *PublicationService) FindAllPublications(ctx context.Context) ([]models.Publication, error) {
+func visiblePublicationsScope(viewerID *uint) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if viewerID == nil {
+			return db.Where("publications.published = ? AND publications.visibility = ?", true, models.VisibilityPublic)
+		}
+		connected := `EXISTS (
+			SELECT 1 FROM business_connections bc
+			JOIN businesses vb ON vb.operator_user_id = ? OR vb.id IN (SELECT business_id FROM business_members WHERE user_id = ?)
+			JOIN businesses ab ON (publications.business_id IS NOT NULL AND ab.id = publications.business_id)
+				OR (publications.business_id IS NULL AND ab.operator_user_id = publications.user_id)
+			WHERE bc.status = ?
+				AND ((bc.initiating_business_id = vb.id AND bc.receiving_business_id = ab.id)
+					OR (bc.initiating_business_id = ab.id AND bc.receiving_business_id = vb.id)))`
+		return db.Where(
+			"publications.user_id = ? OR (publications.published = ? AND ((publications.visibility <> ? AND publications.business_id IN (SELECT business_id FROM business_members WHERE user_id = ?)) OR publications.visibility IN ? OR (publications.visibility = ? AND "+connected+")))",
+			*viewerID, true, models.VisibilityPrivate, *viewerID,
+			[]models.PublicationVisibility{models.VisibilityPublic, models.VisibilityMembers},
+			models.VisibilityConnections, *viewerID, *viewerID, models.ConnectionStatusActive,
+		)
+	}
+}
+func (s *PublicationService) CanEditPublication(ctx context.Context, pub *models.Publication, userID uint) (bool, error) {
+	if pub.UserID == userID {
+		return true, nil
+	}
+	if pub.BusinessID == nil {
+		return false, nil
+	}
+	role, err := businessRoleFor(s.db.WithContext(ctx), *pub.BusinessID, userID)
+	if err != nil {
+		if errors.Is(err, ports.ErrBusinessNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return businessRoleRank[role] >= businessRoleRank[models.BusinessRoleEditor], nil
+}
+func (s *PublicationService) CanViewPublication(ctx context.Context, pub *models.Publication, viewerID *uint) (bool, error) {
+	if viewerID != nil && *viewerID == pub.UserID {
+		return true, nil
+	}
+	var count int64
+	err := s.db.WithContext(ctx).
+		Model(&models.Publication{}).
+		Scopes(visiblePublicationsScope(viewerID)).
+		Where("publications.id = ?", pub.ID).
+		Count(&count).Error
+	if err != nil {
+		return false, ports.ErrDatabase
+	}
+	return count > 0, nil
+}
+func (s *PublicationService) FindAllPublications(ctx context.Context, viewerID *uint, filters ports.PublicationsFilter) ([]models.Publication, error) {
+	var publications []models.Publication
+	query := s.db.WithContext(ctx).
+		Preload("User").
+		Preload("Business").
+		Scopes(visiblePublicationsScope(viewerID)).
+		Order("published_at desc")
+	if filters.PublicationType != nil {
+		query = query.Where("publications.publication_type = ?", *filters.PublicationType)
+	}
+	if filters.BusinessID != nil {
+		query = query.Where("publications.business_id = ?", *filters.BusinessID)
+	}
+	if filters.UserID != nil {
+		query = query.Where("publications.user_id = ?", *filters.UserID)
+	}
+	if filters.PublishedFrom != nil {
+		query = query.Where("publications.published_at >= ?", *filters.PublishedFrom)
+	}
+	if filters.PublishedTo != nil {
+		query = query.Where("publications.published_at < ?", filters.PublishedTo.AddDate(0, 0, 1))
+	}
+	if err := query.Find(&publications).Error; err != nil {
+		return nil, ports.ErrDatabase
+	}
+	return publications, nil
+}
+func (s *PublicationService) GetPublicationRevisions(ctx context.Context, publicationID uint) ([]models.PublicationRevision, error) {
+	if _, err := s.GetPublicationByID(ctx, publicationID); err != nil {
+		return nil, err
+	}
+	var revisions []models.PublicationRevision
+	err := s.db.WithContext(ctx).
+		Preload("EditorUser").
+		Where("publication_id = ?", publicationID).
+		Order("revision desc").
+		Find(&revisions).Error
+	if err != nil {
+		return nil, ports.ErrDatabase
+	}
+	return revisions, nil
+}
+func (s *PublicationService) GetPublicationRevision(ctx context.Context, publicationID, revision uint) (*models.PublicationRevision, error) {
+	var rev models.PublicationRevision
+	err := s.db.WithContext(ctx).
+		Preload("EditorUser").
+		Where("publication_id = ? AND revision = ?", publicationID, revision).
+		First(&rev).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ports.ErrPublicationRevisionNotFound
+		}
+		return nil, ports.ErrDatabase
+	}
+	return &rev, nil
+}
+func (s *PublicationService) DiffPublicationRevision(ctx context.Context, publicationID, revision uint, against *uint) (*ports.PublicationRevisionDiffResponse, error) {
+	from, err := s.GetPublicationRevision(ctx, publicationID, revision)
+	if err != nil {
+		return nil, err
+	}
+	diff := &ports.PublicationRevisionDiffResponse{
+		PublicationID: publicationID,
+		FromRevision:  from.Revision,
+		FromTitle:     from.Title,
+	}
+	toContent := ""
+	if against != nil {
+		to, err := s.GetPublicationRevision(ctx, publicationID, *against)
+		if err != nil {
+			return nil, err
+		}
+		diff.ToRevision = &to.Revision
+		diff.ToTitle = to.Title
+		toContent = to.Content
+	} else {
+		current, err := s.GetPublicationByID(ctx, publicationID)
+		if err != nil {
+			return nil, err
+		}
+		diff.ToTitle = current.Title
+		toContent = current.Content
+	}
+	diff.Lines = utils.DiffLines(from.Content, toContent)
+	return diff, nil
+}
+func (s *PublicationService) RestorePublicationRevision(ctx context.Context, publicationID, revision, editorUserID uint) (*models.Publication, error) {
+	rev, err := s.GetPublicationRevision(ctx, publicationID, revision)
+	if err != nil {
+		return nil, err
+	}
+	input := ports.UpdatePublicationInput{
+		Title:         &rev.Title,
+		Content:       &rev.Content,
+		ContentFormat: &rev.ContentFormat,
+		Excerpt:       rev.Excerpt,
+		EditorUserID:  editorUserID,
+		ClearExcerpt:  rev.Excerpt == nil,
+	}
+	return s.UpdatePublication(ctx, publicationID, input)
+}
+func (s *PublicationService) PublishScheduledPublications(ctx context.Context, now time.Time) (int64, error) {
+	var businessIDs []uint
+	if err := s.db.WithContext(ctx).
+		Model(&models.Publication{}).
+		Where("published = ? AND scheduled_at IS NOT NULL AND scheduled_at <= ? AND business_id IS NOT NULL", false, now).
+		Distinct().
+		Pluck("business_id", &businessIDs).Error; err != nil {
+		return 0, ports.ErrDatabase
+	}
+	result := s.db.WithContext(ctx).
+		Model(&models.Publication{}).
+		Where("published = ? AND scheduled_at IS NOT NULL AND scheduled_at <= ?", false, now).
+		Updates(map[string]interface{}{
+			"published":    true,
+			"published_at": gorm.Expr("scheduled_at"),
+		})
+	if result.Error != nil {
+		return 0, ports.ErrDatabase
+	}
+	if err := refreshBusinessCompleteness(s.db.WithContext(ctx), businessIDs...); err != nil {
+		return 0, ports.ErrDatabase
+	}
+	return result.RowsAffected, nil
+}
+func (s *PublicationService) GetPublicationEngagement(ctx context.Context, publicationIDs []uint) (map[uint]ports.PublicationEngagement, error) {
+	engagement := make(map[uint]ports.PublicationEngagement, len(publicationIDs))
+	if len(publicationIDs) == 0 {
+		return engagement, nil
+	}
+	for _, id := range publicationIDs {
+		engagement[id] = ports.PublicationEngagement{ReactionCounts: map[models.PublicationReactionType]int64{}}
+	}
+	var commentCounts []struct {
+		PublicationID uint
+		Count         int64
+	}
+	err := s.db.WithContext(ctx).
+		Model(&models.PublicationComment{}).
+		Select("publication_id, COUNT(*) AS count").
+		Where("publication_id IN ? AND status IN ?", publicationIDs,
+			[]models.PublicationCommentStatus{models.CommentStatusVisible, models.CommentStatusFlagged}).
+		Group("publication_id").
+		Scan(&commentCounts).Error
+	if err != nil {
+		return nil, ports.ErrDatabase
+	}
+	for _, row := range commentCounts {
+		e := engagement[row.PublicationID]
+		e.CommentCount = row.Count
+		engagement[row.PublicationID] = e
+	}
+	var reactionCounts []struct {
+		PublicationID uint
+		ReactionType  models.PublicationReactionType
+		Count         int64
+	}
+	err = s.db.WithContext(ctx).
+		Model(&models.PublicationReaction{}).
+		Select("publication_id, reaction_type, COUNT(*) AS count").
+		Where("publication_id IN ?", publicationIDs).
+		Group("publication_id, reaction_type").
+		Scan(&reactionCounts).Error
+	if err != nil {
+		return nil, ports.ErrDatabase
+	}
+	for _, row := range reactionCounts {
+		e := engagement[row.PublicationID]
+		e.ReactionCounts[row.ReactionType] = row.Count
+		e.ReactionTotal += row.Count
+		engagement[row.PublicationID] = e
+	}
+	return engagement, nil
+}
+func publicationFeedScope(filter ports.PublicationFeedFilter) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		db = db.Scopes(visiblePublicationsScope(nil))
+		if filter.PublicationType != nil {
+			db = db.Where("publications.publication_type = ?", *filter.PublicationType)
+		}
+		if filter.BusinessID != nil {
+			db = db.Where("publications.business_id = ?", *filter.BusinessID)
+		}
+		return db
+	}
+}
+func (s *PublicationService) GetPublicationFeedState(ctx context.Context, filter ports.PublicationFeedFilter) (*ports.PublicationFeedState, error) {
+	var state ports.PublicationFeedState
+	err := s.db.WithContext(ctx).
+		Model(&models.Publication{}).
+		Scopes(publicationFeedScope(filter)).
+		Select("COUNT(*) AS count, COALESCE(SUM(publications.id), 0) AS checksum, MAX(publications.updated_at) AS last_modified").
+		Scan(&state).Error
+	if err != nil {
+		return nil, ports.ErrDatabase
+	}
+	return &state, nil
+}
+func (s *PublicationService) GetPublicationFeed(ctx context.Context, filter ports.PublicationFeedFilter) ([]models.Publication, error) {
+	limit := filter.Limit
+	if limit == 0 {
+		limit = defaultFeedLimit
+	}
 	var publications []models.Publication
 	err := s.db.WithContext(ctx).
 		Preload("User").
 		Preload("Business").
-		Order("published_at desc").
+		Scopes(publicationFeedScope(filter)).
+		Order("publications.published_at desc, publications.id desc").
+		Limit(limit).
 		Find(&publications).Error
 	if err != nil {
 		return nil, ports.ErrDatabase
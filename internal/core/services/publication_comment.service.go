@@ -0,0 +1,189 @@
+package services
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/models"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/ports"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/pkg/utils"
+	"gorm.io/gorm"
+)
+type PublicationCommentService struct {
+	db *gorm.DB
+}
+func NewPublicationCommentService(db *gorm.DB) *PublicationCommentService {
+	return &PublicationCommentService{db: db}
+}
+func notifyPublicationEngagement(tx *gorm.DB, senderUserID, receiverUserID uint, notificationType models.NotificationType, title, message string, publicationID uint) error {
+	if senderUserID == receiverUserID {
+		return nil
+	}
+	entityType := models.RelatedEntityPublication
+	notification := models.Notification{
+		SenderUserID:      &senderUserID,
+		ReceiverUserID:    receiverUserID,
+		NotificationType:  notificationType,
+		Title:             title,
+		Message:           message,
+		RelatedEntityType: &entityType,
+		RelatedEntityID:   &publicationID,
+		Read:              false,
+	}
+	return tx.Create(&notification).Error
+}
+func (s *PublicationCommentService) CreateComment(ctx context.Context, data ports.CreatePublicationCommentInput) (*models.PublicationComment, error) {
+	var publication models.Publication
+	if err := s.db.WithContext(ctx).First(&publication, data.PublicationID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ports.ErrPublicationNotFound
+		}
+		return nil, ports.ErrDatabase
+	}
+	body := utils.SanitizeText(data.Body)
+	if body == "" {
+		return nil, ports.ErrCommentBodyEmpty
+	}
+	var parent *models.PublicationComment
+	if data.ParentID != nil {
+		var existing models.PublicationComment
+		err := s.db.WithContext(ctx).First(&existing, *data.ParentID).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ports.ErrInvalidParentComment
+			}
+			return nil, ports.ErrDatabase
+		}
+		if existing.PublicationID != data.PublicationID || existing.Status == models.CommentStatusDeleted {
+			return nil, ports.ErrInvalidParentComment
+		}
+		parent = &existing
+	}
+	comment := models.PublicationComment{
+		PublicationID: data.PublicationID,
+		UserID:        data.UserID,
+		ParentID:      data.ParentID,
+		Body:          body,
+		Status:        models.CommentStatusVisible,
+	}
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&comment).Error; err != nil {
+			return err
+		}
+		if err := notifyPublicationEngagement(tx, data.UserID, publication.UserID, models.NotifyPublicationComment,
+			"New comment on your publication", fmt.Sprintf("Someone commented on \"%s\"", publication.Title), publication.ID); err != nil {
+			return err
+		}
+		if parent != nil && parent.UserID != publication.UserID {
+			return notifyPublicationEngagement(tx, data.UserID, parent.UserID, models.NotifyPublicationComment,
+				"New reply to your comment", fmt.Sprintf("Someone replied to your comment on \"%s\"", publication.Title), publication.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, ports.ErrDatabase
+	}
+	return s.GetCommentByID(ctx, comment.ID)
+}
+func (s *PublicationCommentService) GetCommentByID(ctx context.Context, id uint) (*models.PublicationComment, error) {
+	var comment models.PublicationComment
+	err := s.db.WithContext(ctx).
+		Preload("User").
+		Preload("Publication").
+		First(&comment, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ports.ErrCommentNotFound
+		}
+		return nil, ports.ErrDatabase
+	}
+	return &comment, nil
+}
+func (s *PublicationCommentService) GetCommentsForPublication(ctx context.Context, publicationID uint) ([]models.PublicationComment, error) {
+	var comments []models.PublicationComment
+	err := s.db.WithContext(ctx).
+		Preload("User").
+		Where("publication_id = ?", publicationID).
+		Order("created_at asc, id asc").
+		Find(&comments).Error
+	if err != nil {
+		return nil, ports.ErrDatabase
+	}
+	return comments, nil
+}
+func (s *PublicationCommentService) UpdateComment(ctx context.Context, id uint, data ports.UpdatePublicationCommentInput) (*models.PublicationComment, error) {
+	comment, err := s.GetCommentByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if comment.Status == models.CommentStatusDeleted {
+		return nil, ports.ErrCommentDeleted
+	}
+	body := utils.SanitizeText(data.Body)
+	if body == "" {
+		return nil, ports.ErrCommentBodyEmpty
+	}
+	updateData := map[string]interface{}{
+		"body":      body,
+		"edited_at": time.Now(),
+	}
+	if err := s.db.WithContext(ctx).Model(&models.PublicationComment{ID: id}).Updates(updateData).Error; err != nil {
+		return nil, ports.ErrDatabase
+	}
+	return s.GetCommentByID(ctx, id)
+}
+func (s *PublicationCommentService) DeleteComment(ctx context.Context, id uint) error {
+	result := s.db.WithContext(ctx).
+		Model(&models.PublicationComment{}).
+		Where("id = ? AND status <> ?", id, models.CommentStatusDeleted).
+		Updates(map[string]interface{}{
+			"status":      models.CommentStatusDeleted,
+			"body":        "",
+			"flag_reason": nil,
+		})
+	if result.Error != nil {
+		return ports.ErrDatabase
+	}
+	if result.RowsAffected == 0 {
+		return ports.ErrCommentNotFound
+	}
+	return nil
+}
+func (s *PublicationCommentService) FlagComment(ctx context.Context, id uint, data ports.FlagPublicationCommentInput) (*models.PublicationComment, error) {
+	comment, err := s.GetCommentByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if comment.Status == models.CommentStatusDeleted {
+		return nil, ports.ErrCommentDeleted
+	}
+	updateData := map[string]interface{}{
+		"flag_reason": utils.SanitizeText(data.Reason),
+	}
+	if comment.Status == models.CommentStatusVisible {
+		updateData["status"] = models.CommentStatusFlagged
+	}
+	if err := s.db.WithContext(ctx).Model(&models.PublicationComment{ID: id}).Updates(updateData).Error; err != nil {
+		return nil, ports.ErrDatabase
+	}
+	return s.GetCommentByID(ctx, id)
+}
+func (s *PublicationCommentService) ModerateComment(ctx context.Context, id uint, data ports.ModeratePublicationCommentInput) (*models.PublicationComment, error) {
+	comment, err := s.GetCommentByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if comment.Status == models.CommentStatusDeleted {
+		return nil, ports.ErrCommentDeleted
+	}
+	updateData := map[string]interface{}{
+		"status": data.Status,
+	}
+	if data.Status == models.CommentStatusVisible {
+		updateData["flag_reason"] = nil
+	}
+	if err := s.db.WithContext(ctx).Model(&models.PublicationComment{ID: id}).Updates(updateData).Error; err != nil {
+		return nil, ports.ErrDatabase
+	}
+	return s.GetCommentByID(ctx, id)
+}
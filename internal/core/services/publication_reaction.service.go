@@ -0,0 +1,99 @@
+package services
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/models"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/ports"
+	"gorm.io/gorm"
+)
+type PublicationReactionService struct {
+	db *gorm.DB
+}
+func NewPublicationReactionService(db *gorm.DB) *PublicationReactionService {
+	return &PublicationReactionService{db: db}
+}
+func (s *PublicationReactionService) SetReaction(ctx context.Context, publicationID, userID uint, data ports.SetPublicationReactionInput) (*models.PublicationReaction, error) {
+	var publication models.Publication
+	if err := s.db.WithContext(ctx).First(&publication, publicationID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ports.ErrPublicationNotFound
+		}
+		return nil, ports.ErrDatabase
+	}
+	var reaction models.PublicationReaction
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("publication_id = ? AND user_id = ?", publicationID, userID).First(&reaction).Error
+		if err == nil {
+			if reaction.ReactionType == data.ReactionType {
+				return nil
+			}
+			return tx.Model(&reaction).Update("reaction_type", data.ReactionType).Error
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		reaction = models.PublicationReaction{
+			PublicationID: publicationID,
+			UserID:        userID,
+			ReactionType:  data.ReactionType,
+		}
+		if err := tx.Create(&reaction).Error; err != nil {
+			return err
+		}
+		return notifyPublicationEngagement(tx, userID, publication.UserID, models.NotifyPublicationReaction,
+			"New reaction on your publication", fmt.Sprintf("Someone reacted (%s) to \"%s\"", data.ReactionType, publication.Title), publication.ID)
+	})
+	if err != nil {
+		return nil, ports.ErrDatabase
+	}
+	if err := s.db.WithContext(ctx).First(&reaction, "publication_id = ? AND user_id = ?", publicationID, userID).Error; err != nil {
+		return nil, ports.ErrDatabase
+	}
+	return &reaction, nil
+}
+func (s *PublicationReactionService) RemoveReaction(ctx context.Context, publicationID, userID uint) error {
+	result := s.db.WithContext(ctx).
+		Where("publication_id = ? AND user_id = ?", publicationID, userID).
+		Delete(&models.PublicationReaction{})
+	if result.Error != nil {
+		return ports.ErrDatabase
+	}
+	if result.RowsAffected == 0 {
+		return ports.ErrReactionNotFound
+	}
+	return nil
+}
+func (s *PublicationReactionService) GetReactionSummary(ctx context.Context, publicationID uint, viewerID *uint) (*ports.PublicationReactionsSummaryResponse, error) {
+	var rows []struct {
+		ReactionType models.PublicationReactionType
+		Count        int64
+	}
+	err := s.db.WithContext(ctx).
+		Model(&models.PublicationReaction{}).
+		Select("reaction_type, COUNT(*) AS count").
+		Where("publication_id = ?", publicationID).
+		Group("reaction_type").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, ports.ErrDatabase
+	}
+	summary := &ports.PublicationReactionsSummaryResponse{
+		PublicationID: publicationID,
+		Counts:        make(map[models.PublicationReactionType]int64, len(rows)),
+	}
+	for _, row := range rows {
+		summary.Counts[row.ReactionType] = row.Count
+		summary.Total += row.Count
+	}
+	if viewerID != nil {
+		var reaction models.PublicationReaction
+		err := s.db.WithContext(ctx).Where("publication_id = ? AND user_id = ?", publicationID, *viewerID).First(&reaction).Error
+		if err == nil {
+			summary.ViewerReaction = &reaction.ReactionType
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ports.ErrDatabase
+		}
+	}
+	return summary, nil
+}
@@ -0,0 +1,38 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+type PublicationScheduler struct {
+	publicationService *PublicationService
+	interval           time.Duration
+}
+
+func NewPublicationScheduler(publicationService *PublicationService, interval time.Duration) *PublicationScheduler {
+	return &PublicationScheduler{publicationService: publicationService, interval: interval}
+}
+func (s *PublicationScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		s.tick(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+func (s *PublicationScheduler) tick(ctx context.Context) {
+	published, err := s.publicationService.PublishScheduledPublications(ctx, time.Now())
+	if err != nil {
+		log.Printf("Scheduled publishing failed: %v", err)
+		return
+	}
+	if published > 0 {
+		log.Printf("Published %d scheduled publication(s)", published)
+	}
+}
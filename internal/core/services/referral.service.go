@@ -0,0 +1,343 @@
+package services
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/models"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/ports"
+	"gorm.io/gorm"
+)
+const maxReferralReportSpan = 5 * 365 * 24 * time.Hour
+var referralTransitions = map[models.ReferralStatus][]models.ReferralStatus{
+	models.ReferralSent:      {models.ReferralContacted, models.ReferralWon, models.ReferralLost},
+	models.ReferralContacted: {models.ReferralWon, models.ReferralLost},
+}
+type ReferralService struct {
+	db *gorm.DB
+}
+func NewReferralService(db *gorm.DB) *ReferralService {
+	return &ReferralService{db: db}
+}
+func notifyReferral(tx *gorm.DB, senderUserID, businessID uint, title, message string, relatedBusinessID uint) error {
+	receivers, err := businessAdminUserIDs(tx, businessID)
+	if err != nil {
+		return err
+	}
+	entityType := models.RelatedEntityBusiness
+	for _, receiverUserID := range receivers {
+		if receiverUserID == senderUserID {
+			continue
+		}
+		notification := models.Notification{
+			SenderUserID:      &senderUserID,
+			ReceiverUserID:    receiverUserID,
+			NotificationType:  models.NotifyReferral,
+			Title:             title,
+			Message:           message,
+			RelatedEntityType: &entityType,
+			RelatedEntityID:   &relatedBusinessID,
+		}
+		if err := tx.Create(&notification).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+func (s *ReferralService) loadReferral(tx *gorm.DB, id uint) (*models.Referral, error) {
+	var referral models.Referral
+	err := tx.
+		Preload("ReferrerBusiness").
+		Preload("ReceiverBusiness").
+		Preload("ClientBusiness").
+		First(&referral, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ports.ErrReferralNotFound
+		}
+		return nil, ports.ErrDatabase
+	}
+	return &referral, nil
+}
+func (s *ReferralService) CreateReferral(ctx context.Context, authUserID uint, data ports.CreateReferralInput) (*models.Referral, error) {
+	db := s.db.WithContext(ctx)
+	if data.ReferrerBusinessID == data.ReceiverBusinessID {
+		return nil, ports.ErrInvalidReferral
+	}
+	if _, err := requireBusinessRole(db, data.ReferrerBusinessID, authUserID, models.BusinessRoleEditor); err != nil {
+		return nil, err
+	}
+	businessIDs := []uint{data.ReceiverBusinessID}
+	if data.ClientBusinessID != nil {
+		businessIDs = append(businessIDs, *data.ClientBusinessID)
+	}
+	var count int64
+	if err := db.Model(&models.Business{}).Where("id IN ?", businessIDs).Count(&count).Error; err != nil {
+		return nil, ports.ErrDatabase
+	}
+	if int(count) < len(businessIDs) {
+		return nil, ports.ErrBusinessNotFound
+	}
+	referral := models.Referral{
+		ReferrerBusinessID: data.ReferrerBusinessID,
+		ReceiverBusinessID: data.ReceiverBusinessID,
+		ClientBusinessID:   data.ClientBusinessID,
+		ClientName:         data.ClientName,
+		ClientContact:      data.ClientContact,
+		Status:             models.ReferralSent,
+		DealValue:          data.DealValue,
+		Notes:              data.Notes,
+		ReferredByUserID:   authUserID,
+	}
+	var connection models.BusinessConnection
+	err := db.
+		Where("((initiating_business_id = ? AND receiving_business_id = ?) OR (initiating_business_id = ? AND receiving_business_id = ?)) AND connection_type = ? AND status = ?",
+			data.ReferrerBusinessID, data.ReceiverBusinessID, data.ReceiverBusinessID, data.ReferrerBusinessID, models.ConnectionTypeReferral, models.ConnectionStatusActive).
+		First(&connection).Error
+	if err == nil {
+		referral.BusinessConnectionID = &connection.ID
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ports.ErrDatabase
+	}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&referral).Error; err != nil {
+			return err
+		}
+		var referrer models.Business
+		if err := tx.Select("id", "name").First(&referrer, data.ReferrerBusinessID).Error; err != nil {
+			return err
+		}
+		return notifyReferral(tx, authUserID, data.ReceiverBusinessID, "New referral",
+			fmt.Sprintf("%s referred %s to you", referrer.Name, data.ClientName), data.ReferrerBusinessID)
+	})
+	if err != nil {
+		return nil, ports.ErrDatabase
+	}
+	return s.loadReferral(db, referral.ID)
+}
+func (s *ReferralService) GetReferral(ctx context.Context, id, authUserID uint) (*models.Referral, error) {
+	db := s.db.WithContext(ctx)
+	referral, err := s.loadReferral(db, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, businessID := range []uint{referral.ReferrerBusinessID, referral.ReceiverBusinessID} {
+		role, err := businessRoleFor(db, businessID, authUserID)
+		if err != nil {
+			return nil, err
+		}
+		if role != "" {
+			return referral, nil
+		}
+	}
+	return nil, ports.ErrReferralNotFound
+}
+func (s *ReferralService) GetBusinessReferrals(ctx context.Context, businessID, authUserID uint, filter ports.ReferralsFilter) ([]models.Referral, error) {
+	db := s.db.WithContext(ctx)
+	if _, err := requireBusinessRole(db, businessID, authUserID, models.BusinessRoleViewer); err != nil {
+		return nil, err
+	}
+	query := db.
+		Preload("ReferrerBusiness").
+		Preload("ReceiverBusiness").
+		Preload("ClientBusiness")
+	switch {
+	case filter.Direction != nil && *filter.Direction == ports.ReferralDirectionGiven:
+		query = query.Where("referrer_business_id = ?", businessID)
+	case filter.Direction != nil && *filter.Direction == ports.ReferralDirectionReceived:
+		query = query.Where("receiver_business_id = ?", businessID)
+	default:
+		query = query.Where("referrer_business_id = ? OR receiver_business_id = ?", businessID, businessID)
+	}
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+	var referrals []models.Referral
+	if err := query.Order("created_at desc").Find(&referrals).Error; err != nil {
+		return nil, ports.ErrDatabase
+	}
+	return referrals, nil
+}
+func (s *ReferralService) UpdateReferral(ctx context.Context, id, authUserID uint, data ports.UpdateReferralInput) (*models.Referral, error) {
+	db := s.db.WithContext(ctx)
+	referral, err := s.loadReferral(db, id)
+	if err != nil {
+		return nil, err
+	}
+	referrerRole, err := businessRoleFor(db, referral.ReferrerBusinessID, authUserID)
+	if err != nil {
+		return nil, err
+	}
+	receiverRole, err := businessRoleFor(db, referral.ReceiverBusinessID, authUserID)
+	if err != nil {
+		return nil, err
+	}
+	isReceiverEditor := businessRoleRank[receiverRole] >= businessRoleRank[models.BusinessRoleEditor]
+	if !isReceiverEditor && businessRoleRank[referrerRole] < businessRoleRank[models.BusinessRoleEditor] {
+		return nil, ports.ErrForbidden
+	}
+	updates := map[string]interface{}{}
+	statusChanged := data.Status != nil && *data.Status != referral.Status
+	if statusChanged {
+		if !isReceiverEditor {
+			return nil, ports.ErrNotReferralReceiver
+		}
+		allowed, open := referralTransitions[referral.Status]
+		if !open {
+			return nil, ports.ErrReferralClosed
+		}
+		valid := false
+		for _, next := range allowed {
+			if next == *data.Status {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, ports.ErrInvalidReferralStatus
+		}
+		updates["status"] = *data.Status
+		if *data.Status == models.ReferralWon || *data.Status == models.ReferralLost {
+			updates["closed_at"] = time.Now()
+		}
+	}
+	if data.DealValue != nil {
+		updates["deal_value"] = *data.DealValue
+	}
+	if data.Notes != nil {
+		updates["notes"] = *data.Notes
+	}
+	if len(updates) == 0 {
+		return referral, nil
+	}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		query := tx.Model(&models.Referral{}).Where("id = ?", id)
+		if statusChanged {
+			query = query.Where("status = ?", referral.Status)
+		}
+		result := query.Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 && statusChanged {
+			return ports.ErrInvalidReferralStatus
+		}
+		if !statusChanged {
+			return nil
+		}
+		return notifyReferral(tx, authUserID, referral.ReferrerBusinessID, "Referral update",
+			fmt.Sprintf("%s marked your referral of %s as %s", referral.ReceiverBusiness.Name, referral.ClientName, *data.Status),
+			referral.ReceiverBusinessID)
+	})
+	if err != nil {
+		if errors.Is(err, ports.ErrInvalidReferralStatus) {
+			return nil, ports.ErrInvalidReferralStatus
+		}
+		return nil, ports.ErrDatabase
+	}
+	return s.loadReferral(db, id)
+}
+func (s *ReferralService) DeleteReferral(ctx context.Context, id, authUserID uint) error {
+	db := s.db.WithContext(ctx)
+	referral, err := s.loadReferral(db, id)
+	if err != nil {
+		return err
+	}
+	if _, err := requireBusinessRole(db, referral.ReferrerBusinessID, authUserID, models.BusinessRoleEditor); err != nil {
+		return err
+	}
+	if referral.Status != models.ReferralSent {
+		return ports.ErrReferralNotDeletable
+	}
+	if err := db.Delete(&models.Referral{}, id).Error; err != nil {
+		return ports.ErrDatabase
+	}
+	return nil
+}
+func referralPeriodStart(t time.Time, interval ports.ReferralReportInterval) time.Time {
+	month := t.Month()
+	if interval == ports.ReferralIntervalQuarter {
+		month = time.Month((int(month)-1)/3*3 + 1)
+	}
+	return time.Date(t.Year(), month, 1, 0, 0, 0, 0, t.Location())
+}
+func referralPeriodLabel(start time.Time, interval ports.ReferralReportInterval) string {
+	if interval == ports.ReferralIntervalQuarter {
+		return fmt.Sprintf("%d-Q%d", start.Year(), (int(start.Month())-1)/3+1)
+	}
+	return start.Format("2006-01")
+}
+func (s *ReferralService) GetReferralReport(ctx context.Context, businessID, authUserID uint, query ports.ReferralReportQuery) (*ports.ReferralReportResponse, error) {
+	db := s.db.WithContext(ctx)
+	if _, err := requireBusinessRole(db, businessID, authUserID, models.BusinessRoleViewer); err != nil {
+		return nil, err
+	}
+	interval := query.Interval
+	if interval == "" {
+		interval = ports.ReferralIntervalQuarter
+	}
+	now := time.Now()
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, 1)
+	if query.To != nil {
+		to = query.To.AddDate(0, 0, 1)
+	}
+	from := to.AddDate(-1, 0, 0)
+	if query.From != nil {
+		from = *query.From
+	}
+	if !from.Before(to) || to.Sub(from) > maxReferralReportSpan {
+		return nil, ports.ErrInvalidReportRange
+	}
+	var referrals []models.Referral
+	err := db.
+		Where("(referrer_business_id = ? OR receiver_business_id = ?) AND created_at >= ? AND created_at < ?", businessID, businessID, from, to).
+		Find(&referrals).Error
+	if err != nil {
+		return nil, ports.ErrDatabase
+	}
+	report := &ports.ReferralReportResponse{
+		BusinessID: businessID,
+		From:       from,
+		To:         to,
+		Interval:   interval,
+		Periods:    []ports.ReferralReportPeriod{},
+		Totals:     ports.ReferralReportPeriod{Period: "total"},
+	}
+	step := 1
+	if interval == ports.ReferralIntervalQuarter {
+		step = 3
+	}
+	index := make(map[string]int)
+	for start := referralPeriodStart(from, interval); start.Before(to); start = start.AddDate(0, step, 0) {
+		label := referralPeriodLabel(start, interval)
+		index[label] = len(report.Periods)
+		report.Periods = append(report.Periods, ports.ReferralReportPeriod{Period: label})
+	}
+	for _, referral := range referrals {
+		i, ok := index[referralPeriodLabel(referralPeriodStart(referral.CreatedAt.In(from.Location()), interval), interval)]
+		if !ok {
+			continue
+		}
+		won := referral.Status == models.ReferralWon
+		value := 0.0
+		if won && referral.DealValue != nil {
+			value = *referral.DealValue
+		}
+		for _, period := range []*ports.ReferralReportPeriod{&report.Periods[i], &report.Totals} {
+			if referral.ReferrerBusinessID == businessID {
+				period.GivenCount++
+				if won {
+					period.GivenWon++
+					period.GivenWonValue += value
+				}
+			} else {
+				period.ReceivedCount++
+				if won {
+					period.ReceivedWon++
+					period.ReceivedWonValue += value
+				}
+			}
+		}
+	}
+	return report, nil
+}
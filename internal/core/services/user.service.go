@@ -65,6 +65,13 @@ func (s *UserService) UpdateUser(ctx context.Context, id uint, data ports.UserUp
 	if data.AdkSessionID != nil {
 		updateData["adk_session_id"] = *data.AdkSessionID
 	}
+	if data.AvatarMediaID != nil {
+		avatarMediaID, err := resolveMediaReference(s.db.WithContext(ctx), data.AvatarMediaID, id)
+		if err != nil {
+			return nil, err
+		}
+		updateData["avatar_media_id"] = avatarMediaID
+	}
 	if data.EmailVerified != nil {
 		updateData["email_verified"] = *data.EmailVerified
 	}
@@ -43,6 +43,9 @@ func (s *UserSkillService) AddUserSkill(ctx context.Context, data ports.CreateUs
 	if err := s.db.WithContext(ctx).Create(&userSkill).Error; err != nil {
 		return nil, ports.ErrDatabase
 	}
+	if err := refreshOperatorCompleteness(s.db.WithContext(ctx), data.UserID); err != nil {
+		return nil, ports.ErrDatabase
+	}
 	if err := s.db.WithContext(ctx).
 		Preload("Skill").
 		Preload("User").
@@ -120,6 +123,9 @@ func (s *UserSkillService) RemoveUserSkill(ctx context.Context, userID, skillID
 	if result.RowsAffected == 0 {
 		return ports.ErrUserSkillNotFound
 	}
+	if err := refreshOperatorCompleteness(s.db.WithContext(ctx), userID); err != nil {
+		return ports.ErrDatabase
+	}
 	return nil
 }
 func (s *UserSkillService) GetUsersBySkill(ctx context.Context, skillID uint, proficiency *models.UserSkillProficiency) ([]models.UserSkill, error) {
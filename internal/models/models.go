@@ -12,6 +12,11 @@ type BusinessPhase string
 type BusinessConnectionType string
 type BusinessConnectionStatus string
 type PublicationType string
+type PublicationVisibility string
+type ContentFormat string
+type MediaPurpose string
+type PublicationCommentStatus string
+type PublicationReactionType string
 type NotificationType string
 type RelatedEntityType string
 type ProjectStatus string
@@ -21,6 +26,12 @@ type UserSkillProficiency string
 type IdeaStatus string
 type IdeaVoteType string
 type BusinessTagType string
+type BusinessMemberRole string
+type BusinessInviteStatus string
+type IntroductionStatus string
+type ReferralStatus string
+type VerificationMethod string
+type VerificationStatus string
 type DailyActivityProgressStatus string
 
 const (
@@ -48,6 +59,7 @@ const (
 	ConnectionStatusActive       BusinessConnectionStatus    = "active"
 	ConnectionStatusRejected     BusinessConnectionStatus    = "rejected"
 	ConnectionStatusInactive     BusinessConnectionStatus    = "inactive"
+	ConnectionStatusWithdrawn    BusinessConnectionStatus    = "withdrawn"
 	ProjectStatusPlanning        ProjectStatus               = "planning"
 	ProjectStatusActive          ProjectStatus               = "active"
 	ProjectStatusOnHold          ProjectStatus               = "on_hold"
@@ -67,6 +79,34 @@ const (
 	PublicationCaseStudy         PublicationType             = "case_study"
 	PublicationTestimonial       PublicationType             = "testimonial"
 	PublicationArticle           PublicationType             = "article"
+	VisibilityPublic             PublicationVisibility       = "public"
+	VisibilityMembers            PublicationVisibility       = "members"
+	VisibilityConnections        PublicationVisibility       = "connections"
+	VisibilityPrivate            PublicationVisibility       = "private"
+	ContentFormatMarkdown        ContentFormat               = "markdown"
+	ContentFormatHTML            ContentFormat               = "html"
+	ContentFormatPlaintext       ContentFormat               = "plaintext"
+	MediaPurposeGeneral          MediaPurpose                = "general"
+	MediaPurposeThumbnail        MediaPurpose                = "thumbnail"
+	MediaPurposeLogo             MediaPurpose                = "logo"
+	MediaPurposeAvatar           MediaPurpose                = "avatar"
+	CommentStatusVisible         PublicationCommentStatus    = "visible"
+	CommentStatusFlagged         PublicationCommentStatus    = "flagged"
+	CommentStatusHidden          PublicationCommentStatus    = "hidden"
+	CommentStatusDeleted         PublicationCommentStatus    = "deleted"
+	ReactionLike                 PublicationReactionType     = "like"
+	ReactionInsightful           PublicationReactionType     = "insightful"
+	ReactionCelebrate            PublicationReactionType     = "celebrate"
+	ReactionSupport              PublicationReactionType     = "support"
+	ReactionCurious              PublicationReactionType     = "curious"
+	NotifyPublicationComment     NotificationType            = "publication_comment"
+	NotifyPublicationReaction    NotificationType            = "publication_reaction"
+	NotifyBusinessInvite         NotificationType            = "business_invite"
+	NotifyIntroductionRequest    NotificationType            = "introduction_request"
+	NotifyReferral               NotificationType            = "referral"
+	NotifyBusinessVerification   NotificationType            = "business_verification"
+	RelatedEntityPublication     RelatedEntityType           = "publication"
+	RelatedEntityBusiness        RelatedEntityType           = "business"
 	IdeaStatusOpen               IdeaStatus                  = "open"
 	IdeaStatusUnderReview        IdeaStatus                  = "under_review"
 	IdeaStatusPlanned            IdeaStatus                  = "planned"
@@ -78,6 +118,29 @@ const (
 	BusinessTagClient            BusinessTagType             = "client"
 	BusinessTagService           BusinessTagType             = "service"
 	BusinessTagSpecialty         BusinessTagType             = "specialty"
+	BusinessRoleOwner            BusinessMemberRole          = "owner"
+	BusinessRoleAdmin            BusinessMemberRole          = "admin"
+	BusinessRoleEditor           BusinessMemberRole          = "editor"
+	BusinessRoleViewer           BusinessMemberRole          = "viewer"
+	BusinessInvitePending        BusinessInviteStatus        = "pending"
+	BusinessInviteAccepted       BusinessInviteStatus        = "accepted"
+	BusinessInviteDeclined       BusinessInviteStatus        = "declined"
+	BusinessInviteRevoked        BusinessInviteStatus        = "revoked"
+	IntroPendingIntroducer       IntroductionStatus          = "pending_introducer"
+	IntroPendingTarget           IntroductionStatus          = "pending_target"
+	IntroCompleted               IntroductionStatus          = "completed"
+	IntroDeclined                IntroductionStatus          = "declined"
+	ReferralSent                 ReferralStatus              = "sent"
+	ReferralContacted            ReferralStatus              = "contacted"
+	ReferralWon                  ReferralStatus              = "won"
+	ReferralLost                 ReferralStatus              = "lost"
+	VerificationMethodDNS        VerificationMethod          = "dns_txt"
+	VerificationMethodHTMLFile   VerificationMethod          = "html_file"
+	VerificationPendingCheck     VerificationStatus          = "pending_check"
+	VerificationPendingReview    VerificationStatus          = "pending_review"
+	VerificationApproved         VerificationStatus          = "approved"
+	VerificationRejected         VerificationStatus          = "rejected"
+	VerificationRevoked          VerificationStatus          = "revoked"
 	ProgressStatusNotStarted     DailyActivityProgressStatus = "not_started"
 	ProgressStatusInProgress     DailyActivityProgressStatus = "in_progress"
 	ProgressStatusCompleted      DailyActivityProgressStatus = "completed"
@@ -92,9 +155,11 @@ type User struct {
 	ContactEmail             *string `gorm:"size:254;index"`
 	ContactPhoneNo           *string `gorm:"size:20"`
 	AdkSessionID             *string `gorm:"size:128"`
+	AvatarMediaID            *uint   `gorm:"index"`
 	PasswordResetToken       []byte
 	PasswordResetRequestedAt *time.Time
 	EmailVerified            bool      `gorm:"default:false;not null"`
+	PlatformAdmin            bool      `gorm:"default:false;not null"`
 	Active                   bool      `gorm:"default:true;not null;index"`
 	CreatedAt                time.Time `gorm:"not null;default:current_timestamp"`
 	UpdatedAt                time.Time `gorm:"not null;default:current_timestamp"`
@@ -105,6 +170,7 @@ type User struct {
 	ReceivedNotifications   []Notification              `gorm:"foreignKey:ReceiverUserID"`
 	SentNotifications       []Notification              `gorm:"foreignKey:SenderUserID"`
 	ProjectMemberships      []ProjectMember             `gorm:"foreignKey:UserID"`
+	BusinessMemberships     []BusinessMember            `gorm:"foreignKey:UserID"`
 	Publications            []Publication               `gorm:"foreignKey:UserID"`
 	UserSessions            []UserSession               `gorm:"foreignKey:UserID"`
 	UserSkills              []UserSkill                 `gorm:"foreignKey:UserID"`
@@ -215,15 +281,22 @@ type Business struct {
 	State            *string          `gorm:"size:60"`
 	Country          *string          `gorm:"size:60"`
 	PostalCode       *string          `gorm:"size:20"`
+	Latitude         *float64         `gorm:"type:decimal(9,6);index:idx_businesses_geo,priority:1"`
+	Longitude        *float64         `gorm:"type:decimal(9,6);index:idx_businesses_geo,priority:2"`
+	LogoMediaID      *uint            `gorm:"index"`
 	Value            *float64         `gorm:"type:decimal(15,2)"`
 	BusinessType     BusinessType     `gorm:"type:enum('Consulting', 'Retail', 'Technology', 'Manufacturing', 'Services', 'Other');index"`
 	BusinessCategory BusinessCategory `gorm:"type:enum('B2B', 'B2C', 'Non_Profit', 'Government', 'Mixed')"`
 	BusinessPhase    BusinessPhase    `gorm:"type:enum('Startup', 'Growth', 'Mature', 'Exit')"`
 	Active           bool             `gorm:"default:true;not null;index"`
-	CreatedAt        time.Time        `gorm:"not null;default:current_timestamp"`
-	UpdatedAt        time.Time        `gorm:"not null;default:current_timestamp"`
+	Completeness     uint8            `gorm:"default:0;not null;index"`
+	Verified         bool             `gorm:"default:false;not null;index"`
+	VerifiedAt       *time.Time
+	CreatedAt        time.Time `gorm:"not null;default:current_timestamp"`
+	UpdatedAt        time.Time `gorm:"not null;default:current_timestamp"`
 
 	OperatorUser          User                 `gorm:"foreignKey:OperatorUserID"`
+	Members               []BusinessMember     `gorm:"foreignKey:BusinessID"`
 	BusinessTags          []BusinessTag        `gorm:"foreignKey:BusinessID"`
 	Projects              []Project            `gorm:"foreignKey:BusinessID"`
 	Publications          []Publication        `gorm:"foreignKey:BusinessID"`
@@ -283,29 +356,89 @@ type Skill struct {
 	UserSkills    []UserSkill    `gorm:"foreignKey:SkillID"`
 }
 type Publication struct {
-	ID              uint            `gorm:"primaryKey"`
-	UserID          uint            `gorm:"not null;index"`
-	BusinessID      *uint           `gorm:"index"`
-	PublicationType PublicationType `gorm:"type:enum('post', 'case_study', 'testimonial', 'article');index"`
-	Title           string          `gorm:"size:255;not null"`
-	Slug            string          `gorm:"size:300;not null;unique"`
-	Excerpt         *string         `gorm:"type:text"`
-	Content         string          `gorm:"type:longtext;not null"`
-	Thumbnail       *string         `gorm:"size:255"`
-	VideoURL        *string         `gorm:"size:255"`
-	Published       bool            `gorm:"default:false;not null;index"`
-	PublishedAt     *time.Time
-	CreatedAt       time.Time `gorm:"not null;default:current_timestamp"`
-	UpdatedAt       time.Time `gorm:"not null;default:current_timestamp"`
+	ID                 uint                  `gorm:"primaryKey"`
+	UserID             uint                  `gorm:"not null;index"`
+	BusinessID         *uint                 `gorm:"index"`
+	PublicationType    PublicationType       `gorm:"type:enum('post', 'case_study', 'testimonial', 'article');index"`
+	Title              string                `gorm:"size:255;not null"`
+	Slug               string                `gorm:"size:300;not null;unique"`
+	Excerpt            *string               `gorm:"type:text"`
+	GeneratedExcerpt   string                `gorm:"type:text"`
+	Content            string                `gorm:"type:longtext;not null"`
+	ContentFormat      ContentFormat         `gorm:"type:enum('markdown', 'html', 'plaintext');default:markdown;not null"`
+	ContentHTML        string                `gorm:"type:longtext"`
+	ReadingTimeMinutes uint                  `gorm:"default:0;not null"`
+	TableOfContents    datatypes.JSON        `gorm:"type:json" swaggertype:"object"`
+	Thumbnail          *string               `gorm:"size:255"`
+	VideoURL           *string               `gorm:"size:255"`
+	MediaID            *uint                 `gorm:"index"`
+	Visibility         PublicationVisibility `gorm:"type:enum('public', 'members', 'connections', 'private');default:members;not null;index"`
+	Published          bool                  `gorm:"default:false;not null;index"`
+	PublishedAt        *time.Time
+	ScheduledAt        *time.Time `gorm:"index"`
+	CreatedAt          time.Time  `gorm:"not null;default:current_timestamp"`
+	UpdatedAt          time.Time  `gorm:"not null;default:current_timestamp"`
+
+	User        User                     `gorm:"foreignKey:UserID"`
+	Business    *Business                `gorm:"foreignKey:BusinessID"`
+	Revisions   []PublicationRevision    `gorm:"foreignKey:PublicationID"`
+	SlugHistory []PublicationSlugHistory `gorm:"foreignKey:PublicationID"`
+	Comments    []PublicationComment     `gorm:"foreignKey:PublicationID"`
+	Reactions   []PublicationReaction    `gorm:"foreignKey:PublicationID"`
+}
+type PublicationComment struct {
+	ID            uint                     `gorm:"primaryKey"`
+	PublicationID uint                     `gorm:"not null;index"`
+	UserID        uint                     `gorm:"not null;index"`
+	ParentID      *uint                    `gorm:"index"`
+	Body          string                   `gorm:"type:text;not null"`
+	Status        PublicationCommentStatus `gorm:"type:enum('visible', 'flagged', 'hidden', 'deleted');default:visible;not null;index"`
+	FlagReason    *string                  `gorm:"size:255"`
+	EditedAt      *time.Time
+	CreatedAt     time.Time `gorm:"not null;default:current_timestamp"`
+	UpdatedAt     time.Time `gorm:"not null;default:current_timestamp"`
+
+	Publication Publication         `gorm:"foreignKey:PublicationID"`
+	User        User                `gorm:"foreignKey:UserID"`
+	Parent      *PublicationComment `gorm:"foreignKey:ParentID"`
+}
+type PublicationReaction struct {
+	PublicationID uint                    `gorm:"primaryKey"`
+	UserID        uint                    `gorm:"primaryKey;index"`
+	ReactionType  PublicationReactionType `gorm:"type:enum('like', 'insightful', 'celebrate', 'support', 'curious');not null"`
+	CreatedAt     time.Time               `gorm:"not null;default:current_timestamp"`
+	UpdatedAt     time.Time               `gorm:"not null;default:current_timestamp"`
+
+	Publication Publication `gorm:"foreignKey:PublicationID"`
+	User        User        `gorm:"foreignKey:UserID"`
+}
+type PublicationSlugHistory struct {
+	ID            uint      `gorm:"primaryKey"`
+	PublicationID uint      `gorm:"not null;index"`
+	Slug          string    `gorm:"size:300;not null;unique"`
+	CreatedAt     time.Time `gorm:"not null;default:current_timestamp"`
+
+	Publication Publication `gorm:"foreignKey:PublicationID"`
+}
+type PublicationRevision struct {
+	ID            uint          `gorm:"primaryKey"`
+	PublicationID uint          `gorm:"not null;uniqueIndex:uq_publication_revision"`
+	Revision      uint          `gorm:"not null;uniqueIndex:uq_publication_revision"`
+	EditorUserID  uint          `gorm:"not null;index"`
+	Title         string        `gorm:"size:255;not null"`
+	Excerpt       *string       `gorm:"type:text"`
+	Content       string        `gorm:"type:longtext;not null"`
+	ContentFormat ContentFormat `gorm:"type:enum('markdown', 'html', 'plaintext');default:markdown;not null"`
+	CreatedAt     time.Time     `gorm:"not null;default:current_timestamp"`
 
-	User     User      `gorm:"foreignKey:UserID"`
-	Business *Business `gorm:"foreignKey:BusinessID"`
+	Publication Publication `gorm:"foreignKey:PublicationID"`
+	EditorUser  User        `gorm:"foreignKey:EditorUserID"`
 }
 type Notification struct {
 	ID                uint               `gorm:"primaryKey"`
 	SenderUserID      *uint              `gorm:"index"`
 	ReceiverUserID    uint               `gorm:"not null;index"`
-	NotificationType  NotificationType   `gorm:"type:enum('connection_request', 'project_invite', 'message', 'system')"`
+	NotificationType  NotificationType   `gorm:"type:enum('connection_request', 'project_invite', 'message', 'system', 'publication_comment', 'publication_reaction', 'business_invite', 'introduction_request', 'referral', 'business_verification')"`
 	Title             string             `gorm:"size:255;not null"`
 	Message           string             `gorm:"type:text;not null"`
 	RelatedEntityType *RelatedEntityType `gorm:"type:enum('business', 'project', 'publication', 'idea')"`
@@ -343,12 +476,36 @@ type ProjectMember struct {
 	Project Project `gorm:"foreignKey:ProjectID"`
 	User    User    `gorm:"foreignKey:UserID"`
 }
+type BusinessMember struct {
+	BusinessID      uint               `gorm:"primaryKey"`
+	UserID          uint               `gorm:"primaryKey;index"`
+	Role            BusinessMemberRole `gorm:"type:enum('owner', 'admin', 'editor', 'viewer');default:viewer;not null"`
+	InvitedByUserID *uint
+	JoinedAt        time.Time `gorm:"not null;default:current_timestamp"`
+
+	Business Business `gorm:"foreignKey:BusinessID"`
+	User     User     `gorm:"foreignKey:UserID"`
+}
+type BusinessInvite struct {
+	ID              uint                 `gorm:"primaryKey"`
+	BusinessID      uint                 `gorm:"not null;index"`
+	Email           string               `gorm:"size:254;not null;index"`
+	Role            BusinessMemberRole   `gorm:"type:enum('admin', 'editor', 'viewer');default:viewer;not null"`
+	Status          BusinessInviteStatus `gorm:"type:enum('pending', 'accepted', 'declined', 'revoked');default:pending;not null;index"`
+	InvitedByUserID uint                 `gorm:"not null;index"`
+	ExpiresAt       time.Time            `gorm:"not null"`
+	RespondedAt     *time.Time
+	CreatedAt       time.Time `gorm:"not null;default:current_timestamp"`
+
+	Business      Business `gorm:"foreignKey:BusinessID"`
+	InvitedByUser User     `gorm:"foreignKey:InvitedByUserID"`
+}
 type BusinessConnection struct {
 	ID                   uint                     `gorm:"primaryKey"`
 	InitiatingBusinessID uint                     `gorm:"not null;uniqueIndex:uq_business_connections_unique;index"`
 	ReceivingBusinessID  uint                     `gorm:"not null;uniqueIndex:uq_business_connections_unique;index"`
 	ConnectionType       BusinessConnectionType   `gorm:"type:enum('Partnership', 'Supplier', 'Client', 'Referral', 'Collaboration');uniqueIndex:uq_business_connections_unique"`
-	Status               BusinessConnectionStatus `gorm:"type:enum('pending', 'active', 'rejected', 'inactive');default:pending;index"`
+	Status               BusinessConnectionStatus `gorm:"type:enum('pending', 'active', 'rejected', 'inactive', 'withdrawn');default:pending;index"`
 	InitiatedByUserID    uint                     `gorm:"not null;index"`
 	Notes                *string                  `gorm:"type:text"`
 	CreatedAt            time.Time                `gorm:"not null;default:current_timestamp"`
@@ -358,6 +515,80 @@ type BusinessConnection struct {
 	ReceivingBusiness  Business `gorm:"foreignKey:ReceivingBusinessID"`
 	InitiatedByUser    User     `gorm:"foreignKey:InitiatedByUserID"`
 }
+type IntroductionRequest struct {
+	ID                    uint               `gorm:"primaryKey"`
+	RequesterBusinessID   uint               `gorm:"not null;index"`
+	IntroducerBusinessID  uint               `gorm:"not null;index"`
+	TargetBusinessID      uint               `gorm:"not null;index"`
+	RequestedByUserID     uint               `gorm:"not null;index"`
+	Message               *string            `gorm:"type:text"`
+	Status                IntroductionStatus `gorm:"type:enum('pending_introducer', 'pending_target', 'completed', 'declined');default:pending_introducer;not null;index"`
+	IntroducerUserID      *uint
+	IntroducerRespondedAt *time.Time
+	TargetUserID          *uint
+	TargetRespondedAt     *time.Time
+	BusinessConnectionID  *uint
+	CreatedAt             time.Time `gorm:"not null;default:current_timestamp"`
+	UpdatedAt             time.Time `gorm:"not null;default:current_timestamp"`
+
+	RequesterBusiness  Business            `gorm:"foreignKey:RequesterBusinessID"`
+	IntroducerBusiness Business            `gorm:"foreignKey:IntroducerBusinessID"`
+	TargetBusiness     Business            `gorm:"foreignKey:TargetBusinessID"`
+	RequestedByUser    User                `gorm:"foreignKey:RequestedByUserID"`
+	BusinessConnection *BusinessConnection `gorm:"foreignKey:BusinessConnectionID"`
+}
+type Referral struct {
+	ID                   uint           `gorm:"primaryKey"`
+	ReferrerBusinessID   uint           `gorm:"not null;index"`
+	ReceiverBusinessID   uint           `gorm:"not null;index"`
+	ClientBusinessID     *uint          `gorm:"index"`
+	ClientName           string         `gorm:"size:150;not null"`
+	ClientContact        *string        `gorm:"size:255"`
+	Status               ReferralStatus `gorm:"type:enum('sent', 'contacted', 'won', 'lost');default:sent;not null;index"`
+	DealValue            *float64       `gorm:"type:decimal(15,2)"`
+	Notes                *string        `gorm:"type:text"`
+	ReferredByUserID     uint           `gorm:"not null;index"`
+	BusinessConnectionID *uint          `gorm:"index"`
+	ClosedAt             *time.Time
+	CreatedAt            time.Time `gorm:"not null;default:current_timestamp;index"`
+	UpdatedAt            time.Time `gorm:"not null;default:current_timestamp"`
+
+	ReferrerBusiness Business  `gorm:"foreignKey:ReferrerBusinessID"`
+	ReceiverBusiness Business  `gorm:"foreignKey:ReceiverBusinessID"`
+	ClientBusiness   *Business `gorm:"foreignKey:ClientBusinessID"`
+	ReferredByUser   User      `gorm:"foreignKey:ReferredByUserID"`
+}
+type BusinessVerification struct {
+	ID                uint               `gorm:"primaryKey"`
+	BusinessID        uint               `gorm:"not null;index"`
+	RequestedByUserID uint               `gorm:"not null;index"`
+	Method            VerificationMethod `gorm:"type:enum('dns_txt', 'html_file');not null"`
+	Domain            string             `gorm:"size:255;not null"`
+	Token             string             `gorm:"size:64;not null"`
+	Status            VerificationStatus `gorm:"type:enum('pending_check', 'pending_review', 'approved', 'rejected', 'revoked');default:pending_check;not null;index"`
+	CheckedAt         *time.Time
+	ReviewedByUserID  *uint `gorm:"index"`
+	ReviewedAt        *time.Time
+	ReviewNote        *string `gorm:"type:text"`
+	RevokedByUserID   *uint   `gorm:"index"`
+	RevokedAt         *time.Time
+	RevocationReason  *string   `gorm:"type:text"`
+	CreatedAt         time.Time `gorm:"not null;default:current_timestamp;index"`
+	UpdatedAt         time.Time `gorm:"not null;default:current_timestamp"`
+
+	Business        Business `gorm:"foreignKey:BusinessID"`
+	RequestedByUser User     `gorm:"foreignKey:RequestedByUserID"`
+}
+type BusinessConnectionHistory struct {
+	ID                   uint                      `gorm:"primaryKey"`
+	BusinessConnectionID uint                      `gorm:"not null;index"`
+	FromStatus           *BusinessConnectionStatus `gorm:"type:enum('pending', 'active', 'rejected', 'inactive', 'withdrawn')"`
+	ToStatus             BusinessConnectionStatus  `gorm:"type:enum('pending', 'active', 'rejected', 'inactive', 'withdrawn');not null;index"`
+	ChangedByUserID      *uint                     `gorm:"index"`
+	CreatedAt            time.Time                 `gorm:"not null;default:current_timestamp;index"`
+
+	ChangedByUser *User `gorm:"foreignKey:ChangedByUserID"`
+}
 type BusinessTag struct {
 	ID          uint            `gorm:"primaryKey"`
 	BusinessID  uint            `gorm:"not null;index"`
@@ -379,3 +610,30 @@ type UserSession struct {
 
 	User User `gorm:"foreignKey:UserID"`
 }
+type Media struct {
+	ID               uint         `gorm:"primaryKey"`
+	OwnerUserID      uint         `gorm:"not null;index"`
+	Purpose          MediaPurpose `gorm:"type:enum('general', 'thumbnail', 'logo', 'avatar');default:general;not null"`
+	StorageKey       string       `gorm:"size:255;not null;unique"`
+	OriginalFilename string       `gorm:"size:255"`
+	ContentType      string       `gorm:"size:100;not null"`
+	SizeBytes        int64        `gorm:"not null"`
+	Width            int          `gorm:"not null"`
+	Height           int          `gorm:"not null"`
+	Checksum         string       `gorm:"size:64;not null;index"`
+	CreatedAt        time.Time    `gorm:"not null;default:current_timestamp"`
+
+	OwnerUser User           `gorm:"foreignKey:OwnerUserID"`
+	Variants  []MediaVariant `gorm:"foreignKey:MediaID"`
+}
+type MediaVariant struct {
+	MediaID     uint   `gorm:"primaryKey"`
+	Name        string `gorm:"primaryKey;size:20"`
+	StorageKey  string `gorm:"size:255;not null"`
+	ContentType string `gorm:"size:100;not null"`
+	SizeBytes   int64  `gorm:"not null"`
+	Width       int    `gorm:"not null"`
+	Height      int    `gorm:"not null"`
+
+	Media Media `gorm:"foreignKey:MediaID"`
+}
@@ -2,6 +2,7 @@ package ports
 import (
 	"time"
 	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/models"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/pkg/geo"
 )
 type CreateBusinessInput struct {
 	OperatorUserID   uint                    `json:"operator_user_id" validate:"required"`
@@ -17,6 +18,9 @@ type CreateBusinessInput struct {
 	State            *string                 `json:"state" validate:"omitempty,max=60"`
 	Country          *string                 `json:"country" validate:"omitempty,max=60"`
 	PostalCode       *string                 `json:"postal_code" validate:"omitempty,max=20"`
+	Latitude         *float64                `json:"latitude" validate:"required_with=Longitude,omitempty,gte=-90,lte=90"`
+	Longitude        *float64                `json:"longitude" validate:"required_with=Latitude,omitempty,gte=-180,lte=180"`
+	LogoMediaID      *uint                   `json:"logo_media_id"`
 	Value            *float64                `json:"value"`
 	BusinessType     models.BusinessType     `json:"business_type" validate:"required"`
 	BusinessCategory models.BusinessCategory `json:"business_category" validate:"required"`
@@ -35,6 +39,9 @@ type UpdateBusinessInput struct {
 	State            *string                  `json:"state" validate:"omitempty,max=60"`
 	Country          *string                  `json:"country" validate:"omitempty,max=60"`
 	PostalCode       *string                  `json:"postal_code" validate:"omitempty,max=20"`
+	Latitude         *float64                 `json:"latitude" validate:"required_with=Longitude,omitempty,gte=-90,lte=90"`
+	Longitude        *float64                 `json:"longitude" validate:"required_with=Latitude,omitempty,gte=-180,lte=180"`
+	LogoMediaID      *uint                    `json:"logo_media_id"`
 	Value            *float64                 `json:"value"`
 	BusinessType     *models.BusinessType     `json:"business_type"`
 	BusinessCategory *models.BusinessCategory `json:"business_category"`
@@ -46,8 +53,35 @@ type BusinessesFilter struct {
 	BusinessCategory *models.BusinessCategory `form:"business_category"`
 	BusinessPhase    *models.BusinessPhase    `form:"business_phase"`
 	Active           *bool                    `form:"active"`
+	Verified         *bool                    `form:"verified"`
 	OperatorUserID   *uint                    `form:"operator_user_id"`
 	Search           *string                  `form:"search"`
+	Near             *string                  `form:"near"`
+	RadiusKm         *float64                 `form:"radius_km"`
+}
+const (
+	DefaultNearRadiusKm = 25.0
+	MaxNearRadiusKm     = 500.0
+)
+func NearOrigin(filters BusinessesFilter) (*geo.Point, float64, error) {
+	if filters.Near == nil {
+		if filters.RadiusKm != nil {
+			return nil, 0, ErrInvalidNearQuery
+		}
+		return nil, 0, nil
+	}
+	origin, err := geo.ParsePoint(*filters.Near)
+	if err != nil {
+		return nil, 0, ErrInvalidNearQuery
+	}
+	radius := DefaultNearRadiusKm
+	if filters.RadiusKm != nil {
+		radius = *filters.RadiusKm
+	}
+	if radius <= 0 || radius > MaxNearRadiusKm {
+		return nil, 0, ErrInvalidRadius
+	}
+	return &origin, radius, nil
 }
 type BusinessResponse struct {
 	ID               uint                    `json:"id"`
@@ -64,14 +98,41 @@ type BusinessResponse struct {
 	State            *string                 `json:"state,omitempty"`
 	Country          *string                 `json:"country,omitempty"`
 	PostalCode       *string                 `json:"postal_code,omitempty"`
+	Latitude         *float64                `json:"latitude,omitempty"`
+	Longitude        *float64                `json:"longitude,omitempty"`
+	DistanceKm       *float64                `json:"distance_km,omitempty"`
+	LogoMediaID      *uint                   `json:"logo_media_id,omitempty"`
+	LogoURL          *string                 `json:"logo_url,omitempty"`
 	Value            *float64                `json:"value,omitempty"`
 	BusinessType     models.BusinessType     `json:"business_type"`
 	BusinessCategory models.BusinessCategory `json:"business_category"`
 	BusinessPhase    models.BusinessPhase    `json:"business_phase"`
 	Active           bool                    `json:"active"`
+	Verified         bool                    `json:"verified"`
+	VerifiedAt       *time.Time              `json:"verified_at,omitempty"`
 	CreatedAt        time.Time               `json:"created_at"`
 	UpdatedAt        time.Time               `json:"updated_at"`
 	OperatorUser     *UserResponse           `json:"operator_user,omitempty"`
+	Completeness     *BusinessCompleteness   `json:"completeness,omitempty"`
+}
+type BusinessCompletenessItem string
+const (
+	CompletenessTagline        BusinessCompletenessItem = "tagline"
+	CompletenessDescription    BusinessCompletenessItem = "description"
+	CompletenessWebsite        BusinessCompletenessItem = "website"
+	CompletenessContact        BusinessCompletenessItem = "contact"
+	CompletenessLocation       BusinessCompletenessItem = "location"
+	CompletenessLogo           BusinessCompletenessItem = "logo"
+	CompletenessClientTags     BusinessCompletenessItem = "client_tags"
+	CompletenessServiceTags    BusinessCompletenessItem = "service_tags"
+	CompletenessSpecialtyTags  BusinessCompletenessItem = "specialty_tags"
+	CompletenessOperatorSkills BusinessCompletenessItem = "operator_skills"
+	CompletenessPublications   BusinessCompletenessItem = "publications"
+)
+type BusinessCompleteness struct {
+	Score     int                        `json:"score"`
+	Completed []BusinessCompletenessItem `json:"completed"`
+	Missing   []BusinessCompletenessItem `json:"missing"`
 }
 type BusinessStatsResponse struct {
 	TotalProjects     int64 `json:"total_projects"`
@@ -94,11 +155,17 @@ func MapBusinessToResponse(business *models.Business) BusinessResponse {
 		State:            business.State,
 		Country:          business.Country,
 		PostalCode:       business.PostalCode,
+		Latitude:         business.Latitude,
+		Longitude:        business.Longitude,
+		LogoMediaID:      business.LogoMediaID,
+		LogoURL:          MediaURLPtr(business.LogoMediaID, "small"),
 		Value:            business.Value,
 		BusinessType:     business.BusinessType,
 		BusinessCategory: business.BusinessCategory,
 		BusinessPhase:    business.BusinessPhase,
 		Active:           business.Active,
+		Verified:         business.Verified,
+		VerifiedAt:       business.VerifiedAt,
 		CreatedAt:        business.CreatedAt,
 		UpdatedAt:        business.UpdatedAt,
 	}
@@ -3,16 +3,25 @@ import (
 	"time"
 	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/models"
 )
+type BusinessConnectionAction string
+const (
+	ConnectionActionUpdate     BusinessConnectionAction = "update"
+	ConnectionActionRespond    BusinessConnectionAction = "respond"
+	ConnectionActionWithdraw   BusinessConnectionAction = "withdraw"
+	ConnectionActionDeactivate BusinessConnectionAction = "deactivate"
+	ConnectionActionDelete     BusinessConnectionAction = "delete"
+	ConnectionActionReactivate BusinessConnectionAction = "reactivate"
+	ConnectionActionView       BusinessConnectionAction = "view"
+)
 type CreateBusinessConnectionInput struct {
 	InitiatingBusinessID uint                          `json:"initiating_business_id" validate:"required"`
 	ReceivingBusinessID  uint                          `json:"receiving_business_id" validate:"required"`
 	ConnectionType       models.BusinessConnectionType `json:"connection_type" validate:"required,oneof=Partnership Supplier Client Referral Collaboration"`
-	InitiatedByUserID    uint                          `json:"initiated_by_user_id" validate:"required"`
+	InitiatedByUserID    uint                          `json:"-"`
 	Notes                *string                       `json:"notes,omitempty"`
 }
 type UpdateBusinessConnectionInput struct {
 	ConnectionType *models.BusinessConnectionType   `json:"connection_type,omitempty" validate:"omitempty,oneof=Partnership Supplier Client Referral Collaboration"`
-	Status         *models.BusinessConnectionStatus `json:"status,omitempty" validate:"omitempty,oneof=pending active rejected inactive"`
 	Notes          *string                          `json:"notes,omitempty"`
 }
 type BusinessConnectionResponse struct {
@@ -25,7 +34,7 @@ type BusinessConnectionResponse struct {
 	Notes                *string                         `json:"notes,omitempty"`
 	CreatedAt            time.Time                       `json:"created_at"`
 	UpdatedAt            time.Time                       `json:"updated_at"`
-	
+
 	InitiatingBusiness BusinessResponse `json:"initiating_business"`
 	ReceivingBusiness  BusinessResponse `json:"receiving_business"`
 	InitiatedByUser    UserResponse     `json:"initiated_by_user"`
@@ -34,6 +43,45 @@ type BusinessConnectionsResponse struct {
 	Connections []BusinessConnectionResponse `json:"connections"`
 	Count       int                          `json:"count"`
 }
+type BusinessConnectionHistoryResponse struct {
+	ID                   uint                             `json:"id"`
+	BusinessConnectionID uint                             `json:"business_connection_id"`
+	FromStatus           *models.BusinessConnectionStatus `json:"from_status,omitempty"`
+	ToStatus             models.BusinessConnectionStatus  `json:"to_status"`
+	ChangedByUserID      *uint                            `json:"changed_by_user_id,omitempty"`
+	CreatedAt            time.Time                        `json:"created_at"`
+}
+type BusinessConnectionHistoriesResponse struct {
+	History []BusinessConnectionHistoryResponse `json:"history"`
+	Count   int                                 `json:"count"`
+}
+type BusinessNetworkNode struct {
+	BusinessID        uint                `json:"business_id"`
+	Name              string              `json:"name"`
+	BusinessType      models.BusinessType `json:"business_type"`
+	Depth             int                 `json:"depth"`
+	MutualConnections int                 `json:"mutual_connections"`
+	Via               []uint              `json:"via"`
+}
+type BusinessNetworkEdge struct {
+	ConnectionID         uint                            `json:"connection_id"`
+	InitiatingBusinessID uint                            `json:"initiating_business_id"`
+	ReceivingBusinessID  uint                            `json:"receiving_business_id"`
+	ConnectionType       models.BusinessConnectionType   `json:"connection_type"`
+	Status               models.BusinessConnectionStatus `json:"status"`
+}
+type BusinessNetworkResponse struct {
+	BusinessID uint                  `json:"business_id"`
+	Depth      int                   `json:"depth"`
+	Nodes      []BusinessNetworkNode `json:"nodes"`
+	Edges      []BusinessNetworkEdge `json:"edges"`
+}
+type MutualConnectionsResponse struct {
+	BusinessID      uint               `json:"business_id"`
+	OtherBusinessID uint               `json:"other_business_id"`
+	Mutual          []BusinessResponse `json:"mutual"`
+	Count           int                `json:"count"`
+}
 func MapToBusinessConnectionResponse(bc *models.BusinessConnection) BusinessConnectionResponse {
 	return BusinessConnectionResponse{
 		ID:                   bc.ID,
@@ -60,3 +108,29 @@ func MapToBusinessConnectionsResponse(connections []models.BusinessConnection) B
 		Count:       len(conns),
 	}
 }
+func MapToBusinessConnectionHistoriesResponse(entries []models.BusinessConnectionHistory) BusinessConnectionHistoriesResponse {
+	history := make([]BusinessConnectionHistoryResponse, len(entries))
+	for i, entry := range entries {
+		history[i] = BusinessConnectionHistoryResponse{
+			ID:                   entry.ID,
+			BusinessConnectionID: entry.BusinessConnectionID,
+			FromStatus:           entry.FromStatus,
+			ToStatus:             entry.ToStatus,
+			ChangedByUserID:      entry.ChangedByUserID,
+			CreatedAt:            entry.CreatedAt,
+		}
+	}
+	return BusinessConnectionHistoriesResponse{History: history, Count: len(history)}
+}
+func MapToMutualConnectionsResponse(businessID, otherBusinessID uint, businesses []models.Business) MutualConnectionsResponse {
+	mutual := make([]BusinessResponse, len(businesses))
+	for i := range businesses {
+		mutual[i] = MapBusinessToResponse(&businesses[i])
+	}
+	return MutualConnectionsResponse{
+		BusinessID:      businessID,
+		OtherBusinessID: otherBusinessID,
+		Mutual:          mutual,
+		Count:           len(mutual),
+	}
+}
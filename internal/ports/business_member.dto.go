@@ -0,0 +1,87 @@
+package ports
+import (
+	"time"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/models"
+)
+type UpdateBusinessMemberRoleInput struct {
+	Role models.BusinessMemberRole `json:"role" validate:"required,oneof=admin editor viewer"`
+}
+type CreateBusinessInviteInput struct {
+	Email string                    `json:"email" validate:"required,email,max=254"`
+	Role  models.BusinessMemberRole `json:"role" validate:"required,oneof=admin editor viewer"`
+}
+type TransferBusinessOwnershipInput struct {
+	UserID uint `json:"user_id" validate:"required"`
+}
+type BusinessMemberResponse struct {
+	BusinessID      uint                      `json:"business_id"`
+	UserID          uint                      `json:"user_id"`
+	Role            models.BusinessMemberRole `json:"role"`
+	InvitedByUserID *uint                     `json:"invited_by_user_id,omitempty"`
+	JoinedAt        time.Time                 `json:"joined_at"`
+	User            *UserResponse             `json:"user,omitempty"`
+}
+type BusinessMembersResponse struct {
+	Members []BusinessMemberResponse `json:"members"`
+	Count   int                      `json:"count"`
+}
+type BusinessInviteResponse struct {
+	ID              uint                        `json:"id"`
+	BusinessID      uint                        `json:"business_id"`
+	BusinessName    string                      `json:"business_name,omitempty"`
+	Email           string                      `json:"email"`
+	Role            models.BusinessMemberRole   `json:"role"`
+	Status          models.BusinessInviteStatus `json:"status"`
+	Expired         bool                        `json:"expired"`
+	InvitedByUserID uint                        `json:"invited_by_user_id"`
+	ExpiresAt       time.Time                   `json:"expires_at"`
+	RespondedAt     *time.Time                  `json:"responded_at,omitempty"`
+	CreatedAt       time.Time                   `json:"created_at"`
+}
+type BusinessInvitesResponse struct {
+	Invites []BusinessInviteResponse `json:"invites"`
+	Count   int                      `json:"count"`
+}
+func MapBusinessMemberToResponse(member *models.BusinessMember) BusinessMemberResponse {
+	resp := BusinessMemberResponse{
+		BusinessID:      member.BusinessID,
+		UserID:          member.UserID,
+		Role:            member.Role,
+		InvitedByUserID: member.InvitedByUserID,
+		JoinedAt:        member.JoinedAt,
+	}
+	if member.User.ID != 0 {
+		userResp := MapUserToResponse(&member.User)
+		resp.User = &userResp
+	}
+	return resp
+}
+func MapBusinessMembersToResponse(members []models.BusinessMember) BusinessMembersResponse {
+	resp := make([]BusinessMemberResponse, len(members))
+	for i := range members {
+		resp[i] = MapBusinessMemberToResponse(&members[i])
+	}
+	return BusinessMembersResponse{Members: resp, Count: len(resp)}
+}
+func MapBusinessInviteToResponse(invite *models.BusinessInvite) BusinessInviteResponse {
+	return BusinessInviteResponse{
+		ID:              invite.ID,
+		BusinessID:      invite.BusinessID,
+		BusinessName:    invite.Business.Name,
+		Email:           invite.Email,
+		Role:            invite.Role,
+		Status:          invite.Status,
+		Expired:         invite.Status == models.BusinessInvitePending && time.Now().After(invite.ExpiresAt),
+		InvitedByUserID: invite.InvitedByUserID,
+		ExpiresAt:       invite.ExpiresAt,
+		RespondedAt:     invite.RespondedAt,
+		CreatedAt:       invite.CreatedAt,
+	}
+}
+func MapBusinessInvitesToResponse(invites []models.BusinessInvite) BusinessInvitesResponse {
+	resp := make([]BusinessInviteResponse, len(invites))
+	for i := range invites {
+		resp[i] = MapBusinessInviteToResponse(&invites[i])
+	}
+	return BusinessInvitesResponse{Invites: resp, Count: len(resp)}
+}
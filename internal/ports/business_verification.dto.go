@@ -0,0 +1,89 @@
+package ports
+import (
+	"time"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/models"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/pkg/verification"
+)
+type RequestVerificationInput struct {
+	Method models.VerificationMethod `json:"method" validate:"required,oneof=dns_txt html_file"`
+}
+type ReviewVerificationInput struct {
+	Note *string `json:"note,omitempty" validate:"omitempty,max=1000"`
+}
+type RevokeVerificationInput struct {
+	Reason string `json:"reason" validate:"required,min=3,max=1000"`
+}
+type VerificationsFilter struct {
+	Status *models.VerificationStatus `form:"status" validate:"omitempty,oneof=pending_check pending_review approved rejected revoked"`
+}
+type BusinessVerificationResponse struct {
+	ID                uint                      `json:"id"`
+	BusinessID        uint                      `json:"business_id"`
+	RequestedByUserID uint                      `json:"requested_by_user_id"`
+	Method            models.VerificationMethod `json:"method"`
+	Domain            string                    `json:"domain"`
+	Status            models.VerificationStatus `json:"status"`
+	TXTRecordValue    *string                   `json:"txt_record_value,omitempty"`
+	FileURL           *string                   `json:"file_url,omitempty"`
+	FileContent       *string                   `json:"file_content,omitempty"`
+	CheckedAt         *time.Time                `json:"checked_at,omitempty"`
+	ReviewedByUserID  *uint                     `json:"reviewed_by_user_id,omitempty"`
+	ReviewedAt        *time.Time                `json:"reviewed_at,omitempty"`
+	ReviewNote        *string                   `json:"review_note,omitempty"`
+	RevokedByUserID   *uint                     `json:"revoked_by_user_id,omitempty"`
+	RevokedAt         *time.Time                `json:"revoked_at,omitempty"`
+	RevocationReason  *string                   `json:"revocation_reason,omitempty"`
+	CreatedAt         time.Time                 `json:"created_at"`
+	UpdatedAt         time.Time                 `json:"updated_at"`
+	Business          *BusinessResponse         `json:"business,omitempty"`
+}
+type BusinessVerificationsResponse struct {
+	Verifications []BusinessVerificationResponse `json:"verifications"`
+	Count         int                            `json:"count"`
+}
+func MapToBusinessVerificationResponse(v *models.BusinessVerification) BusinessVerificationResponse {
+	resp := BusinessVerificationResponse{
+		ID:                v.ID,
+		BusinessID:        v.BusinessID,
+		RequestedByUserID: v.RequestedByUserID,
+		Method:            v.Method,
+		Domain:            v.Domain,
+		Status:            v.Status,
+		CheckedAt:         v.CheckedAt,
+		ReviewedByUserID:  v.ReviewedByUserID,
+		ReviewedAt:        v.ReviewedAt,
+		ReviewNote:        v.ReviewNote,
+		RevokedByUserID:   v.RevokedByUserID,
+		RevokedAt:         v.RevokedAt,
+		RevocationReason:  v.RevocationReason,
+		CreatedAt:         v.CreatedAt,
+		UpdatedAt:         v.UpdatedAt,
+	}
+	if v.Status == models.VerificationPendingCheck {
+		switch v.Method {
+		case models.VerificationMethodDNS:
+			value := verification.TXTRecordValue(v.Token)
+			resp.TXTRecordValue = &value
+		case models.VerificationMethodHTMLFile:
+			fileURL := verification.FileURL(v.Domain)
+			content := v.Token
+			resp.FileURL = &fileURL
+			resp.FileContent = &content
+		}
+	}
+	if v.Business.ID != 0 {
+		business := MapBusinessToResponse(&v.Business)
+		resp.Business = &business
+	}
+	return resp
+}
+func MapToBusinessVerificationsResponse(verifications []models.BusinessVerification) BusinessVerificationsResponse {
+	resp := make([]BusinessVerificationResponse, len(verifications))
+	for i := range verifications {
+		resp[i] = MapToBusinessVerificationResponse(&verifications[i])
+	}
+	return BusinessVerificationsResponse{
+		Verifications: resp,
+		Count:         len(resp),
+	}
+}
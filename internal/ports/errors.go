@@ -23,6 +23,18 @@ var (
 	ErrBusinessNotFound = &ApiError{StatusCode: 404, Message: "Business not found"}
 	ErrBusinessInUse    = &ApiError{StatusCode: 409, Message: "Cannot delete business, it is currently in use"}
 	ErrOperatorNotFound = &ApiError{StatusCode: 404, Message: "Operator user not found"}
+	ErrInvalidNearQuery = &ApiError{StatusCode: 400, Message: "near must be formatted as lat,lng with valid coordinates"}
+	ErrInvalidRadius    = &ApiError{StatusCode: 400, Message: "radius_km must be greater than 0 and at most 500"}
+	
+	ErrBusinessMemberNotFound   = &ApiError{StatusCode: 404, Message: "Business member not found"}
+	ErrBusinessMemberExists     = &ApiError{StatusCode: 409, Message: "User is already a member of this business"}
+	ErrBusinessOwnerImmutable   = &ApiError{StatusCode: 400, Message: "The business owner cannot be changed or removed, transfer ownership first"}
+	ErrBusinessRoleTooHigh      = &ApiError{StatusCode: 403, Message: "You cannot grant or manage a role equal to or above your own"}
+	ErrInvalidOwnershipTransfer = &ApiError{StatusCode: 400, Message: "Ownership can only be transferred to another member of the business"}
+	ErrBusinessInviteNotFound   = &ApiError{StatusCode: 404, Message: "Business invite not found"}
+	ErrBusinessInviteExists     = &ApiError{StatusCode: 409, Message: "A pending invite already exists for this email"}
+	ErrBusinessInviteNotPending = &ApiError{StatusCode: 409, Message: "Business invite is no longer pending"}
+	ErrBusinessInviteExpired    = &ApiError{StatusCode: 410, Message: "Business invite has expired"}
 	
 	ErrProjectNotFound     = &ApiError{StatusCode: 404, Message: "Project not found"}
 	ErrProjectNameExists   = &ApiError{StatusCode: 409, Message: "A project with this name already exists"}
@@ -34,9 +46,27 @@ var (
 	ErrSkillNameExists = &ApiError{StatusCode: 409, Message: "A skill with this name already exists"}
 	ErrSkillInUse      = &ApiError{StatusCode: 409, Message: "Cannot delete skill, it is currently in use"}
 	
-	ErrPublicationNotFound       = &ApiError{StatusCode: 404, Message: "Publication not found"}
-	ErrPublicationSlugExists     = &ApiError{StatusCode: 409, Message: "A publication with this title/slug already exists"}
-	ErrPublicationAuthorNotFound = &ApiError{StatusCode: 400, Message: "Author user not found"}
+	ErrPublicationNotFound         = &ApiError{StatusCode: 404, Message: "Publication not found"}
+	ErrPublicationSlugExists       = &ApiError{StatusCode: 409, Message: "A publication with this title/slug already exists"}
+	ErrPublicationAuthorNotFound   = &ApiError{StatusCode: 400, Message: "Author user not found"}
+	ErrPublicationRevisionNotFound = &ApiError{StatusCode: 404, Message: "Publication revision not found"}
+	ErrInvalidScheduledAt          = &ApiError{StatusCode: 400, Message: "Scheduled publish time must be in the future"}
+	ErrPublicationContentInvalid   = &ApiError{StatusCode: 400, Message: "Publication content could not be rendered"}
+	
+	ErrCommentNotFound      = &ApiError{StatusCode: 404, Message: "Comment not found"}
+	ErrCommentBodyEmpty     = &ApiError{StatusCode: 400, Message: "Comment body is empty"}
+	ErrInvalidParentComment = &ApiError{StatusCode: 400, Message: "Parent comment does not belong to this publication"}
+	ErrCommentDeleted       = &ApiError{StatusCode: 409, Message: "Comment has been deleted"}
+	ErrReactionNotFound     = &ApiError{StatusCode: 404, Message: "Reaction not found"}
+	
+	ErrMediaNotFound        = &ApiError{StatusCode: 404, Message: "Media not found"}
+	ErrMediaFileRequired    = &ApiError{StatusCode: 400, Message: "A file is required"}
+	ErrMediaTooLarge        = &ApiError{StatusCode: 413, Message: "File exceeds the maximum upload size"}
+	ErrMediaUnsupportedType = &ApiError{StatusCode: 415, Message: "Unsupported media type, only JPEG, PNG and GIF images are accepted"}
+	ErrMediaInvalidImage    = &ApiError{StatusCode: 400, Message: "File could not be decoded as an image"}
+	ErrMediaDimensions      = &ApiError{StatusCode: 400, Message: "Image dimensions exceed the allowed maximum"}
+	ErrMediaNotOwned        = &ApiError{StatusCode: 403, Message: "Media belongs to another user"}
+	ErrMediaStorage         = &ApiError{StatusCode: 500, Message: "Failed to store media"}
 	
 	ErrIdeaNotFound          = &ApiError{StatusCode: 404, Message: "Idea not found"}
 	ErrIdeaSubmitterNotFound = &ApiError{StatusCode: 400, Message: "Submitter user not found"}
@@ -63,6 +93,37 @@ var (
 	ErrInvalidConnectionStatus         = &ApiError{StatusCode: 400, Message: "Invalid connection status"}
 	ErrCannotConnectToSelf             = &ApiError{StatusCode: 400, Message: "Cannot create connection to same business"}
 	ErrConnectionNotPending            = &ApiError{StatusCode: 400, Message: "Connection is not in pending status"}
+	ErrNotConnectionReceiver           = &ApiError{StatusCode: 403, Message: "Only the receiving business can respond to this connection"}
+	ErrNotConnectionInitiator          = &ApiError{StatusCode: 403, Message: "Only the initiating business can withdraw this connection request"}
+	ErrNotConnectionParticipant        = &ApiError{StatusCode: 403, Message: "Not an admin of either business in this connection"}
+	ErrConnectionNotActive             = &ApiError{StatusCode: 400, Message: "Connection is not active"}
+	ErrConnectionNotInactive           = &ApiError{StatusCode: 400, Message: "Connection is not inactive"}
+	ErrConnectionCooldown              = &ApiError{StatusCode: 409, Message: "Connection request was recently rejected; please wait before requesting again"}
+	ErrRejectedConnectionNotDeletable  = &ApiError{StatusCode: 409, Message: "A rejected connection cannot be deleted"}
+	ErrInvalidNetworkDepth             = &ApiError{StatusCode: 400, Message: "Network depth must be between 1 and 3"}
+	
+	ErrIntroductionNotFound   = &ApiError{StatusCode: 404, Message: "Introduction request not found"}
+	ErrIntroductionExists     = &ApiError{StatusCode: 409, Message: "An introduction request to this business is already pending"}
+	ErrInvalidIntroduction    = &ApiError{StatusCode: 400, Message: "Requester, introducer and target must be different businesses"}
+	ErrIntroducerNotMutual    = &ApiError{StatusCode: 400, Message: "Introducer must be actively connected to both businesses"}
+	ErrIntroductionNotPending = &ApiError{StatusCode: 400, Message: "Introduction request is no longer pending"}
+	
+	ErrReferralNotFound      = &ApiError{StatusCode: 404, Message: "Referral not found"}
+	ErrInvalidReferral       = &ApiError{StatusCode: 400, Message: "Referrer and receiver must be different businesses"}
+	ErrReferralClosed        = &ApiError{StatusCode: 400, Message: "Referral has already been won or lost"}
+	ErrInvalidReferralStatus = &ApiError{StatusCode: 400, Message: "Invalid referral status transition"}
+	ErrNotReferralReceiver   = &ApiError{StatusCode: 403, Message: "Only the receiving business can update the referral status"}
+	ErrReferralNotDeletable  = &ApiError{StatusCode: 400, Message: "Only referrals that have not been actioned can be deleted"}
+	ErrInvalidReportRange    = &ApiError{StatusCode: 400, Message: "Report range is invalid"}
+	
+	ErrVerificationNotFound     = &ApiError{StatusCode: 404, Message: "Business verification not found"}
+	ErrBusinessAlreadyVerified  = &ApiError{StatusCode: 409, Message: "Business is already verified"}
+	ErrVerificationInProgress   = &ApiError{StatusCode: 409, Message: "A verification request is already in progress for this business"}
+	ErrVerificationNoWebsite    = &ApiError{StatusCode: 400, Message: "Business must have a website before it can be verified"}
+	ErrVerificationTokenMissing = &ApiError{StatusCode: 422, Message: "Verification token was not found on the business domain"}
+	ErrVerificationNotPending   = &ApiError{StatusCode: 409, Message: "Verification is not awaiting this step"}
+	ErrBusinessNotVerified      = &ApiError{StatusCode: 400, Message: "Business is not verified"}
+	ErrPlatformAdminRequired    = &ApiError{StatusCode: 403, Message: "Platform administrator access is required"}
 	
 	ErrBusinessTagNotFound      = &ApiError{StatusCode: 404, Message: "Business tag not found"}
 	ErrBusinessTagAlreadyExists = &ApiError{StatusCode: 409, Message: "Business tag already exists"}
@@ -0,0 +1,66 @@
+package ports
+import (
+	"time"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/models"
+)
+type CreateIntroductionRequestInput struct {
+	RequesterBusinessID  uint    `json:"requester_business_id" validate:"required"`
+	IntroducerBusinessID uint    `json:"introducer_business_id" validate:"required"`
+	TargetBusinessID     uint    `json:"target_business_id" validate:"required"`
+	Message              *string `json:"message,omitempty" validate:"omitempty,max=1000"`
+}
+type IntroductionRequestResponse struct {
+	ID                    uint                      `json:"id"`
+	RequesterBusinessID   uint                      `json:"requester_business_id"`
+	IntroducerBusinessID  uint                      `json:"introducer_business_id"`
+	TargetBusinessID      uint                      `json:"target_business_id"`
+	RequestedByUserID     uint                      `json:"requested_by_user_id"`
+	Message               *string                   `json:"message,omitempty"`
+	Status                models.IntroductionStatus `json:"status"`
+	IntroducerUserID      *uint                     `json:"introducer_user_id,omitempty"`
+	IntroducerRespondedAt *time.Time                `json:"introducer_responded_at,omitempty"`
+	TargetUserID          *uint                     `json:"target_user_id,omitempty"`
+	TargetRespondedAt     *time.Time                `json:"target_responded_at,omitempty"`
+	BusinessConnectionID  *uint                     `json:"business_connection_id,omitempty"`
+	CreatedAt             time.Time                 `json:"created_at"`
+	UpdatedAt             time.Time                 `json:"updated_at"`
+
+	RequesterBusiness  BusinessResponse `json:"requester_business"`
+	IntroducerBusiness BusinessResponse `json:"introducer_business"`
+	TargetBusiness     BusinessResponse `json:"target_business"`
+}
+type IntroductionRequestsResponse struct {
+	Introductions []IntroductionRequestResponse `json:"introductions"`
+	Count         int                           `json:"count"`
+}
+func MapToIntroductionRequestResponse(intro *models.IntroductionRequest) IntroductionRequestResponse {
+	return IntroductionRequestResponse{
+		ID:                    intro.ID,
+		RequesterBusinessID:   intro.RequesterBusinessID,
+		IntroducerBusinessID:  intro.IntroducerBusinessID,
+		TargetBusinessID:      intro.TargetBusinessID,
+		RequestedByUserID:     intro.RequestedByUserID,
+		Message:               intro.Message,
+		Status:                intro.Status,
+		IntroducerUserID:      intro.IntroducerUserID,
+		IntroducerRespondedAt: intro.IntroducerRespondedAt,
+		TargetUserID:          intro.TargetUserID,
+		TargetRespondedAt:     intro.TargetRespondedAt,
+		BusinessConnectionID:  intro.BusinessConnectionID,
+		CreatedAt:             intro.CreatedAt,
+		UpdatedAt:             intro.UpdatedAt,
+		RequesterBusiness:     MapBusinessToResponse(&intro.RequesterBusiness),
+		IntroducerBusiness:    MapBusinessToResponse(&intro.IntroducerBusiness),
+		TargetBusiness:        MapBusinessToResponse(&intro.TargetBusiness),
+	}
+}
+func MapToIntroductionRequestsResponse(intros []models.IntroductionRequest) IntroductionRequestsResponse {
+	resp := make([]IntroductionRequestResponse, len(intros))
+	for i := range intros {
+		resp[i] = MapToIntroductionRequestResponse(&intros[i])
+	}
+	return IntroductionRequestsResponse{
+		Introductions: resp,
+		Count:         len(resp),
+	}
+}
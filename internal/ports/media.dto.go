@@ -0,0 +1,75 @@
+package ports
+import (
+	"fmt"
+	"time"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/constants"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/models"
+)
+const MediaVariantOriginal = "original"
+type UploadMediaInput struct {
+	Purpose models.MediaPurpose `form:"purpose" validate:"omitempty,oneof=general thumbnail logo avatar"`
+}
+type MediaContentQuery struct {
+	Variant string `form:"variant" validate:"omitempty,oneof=original thumb small medium large"`
+}
+type MediaVariantResponse struct {
+	Name        string `json:"name"`
+	URL         string `json:"url"`
+	ContentType string `json:"content_type"`
+	Width       int    `json:"width"`
+	Height      int    `json:"height"`
+	SizeBytes   int64  `json:"size_bytes"`
+}
+type MediaResponse struct {
+	ID               uint                   `json:"id"`
+	OwnerUserID      uint                   `json:"owner_user_id"`
+	Purpose          models.MediaPurpose    `json:"purpose"`
+	OriginalFilename string                 `json:"original_filename,omitempty"`
+	ContentType      string                 `json:"content_type"`
+	SizeBytes        int64                  `json:"size_bytes"`
+	Width            int                    `json:"width"`
+	Height           int                    `json:"height"`
+	URL              string                 `json:"url"`
+	Variants         []MediaVariantResponse `json:"variants"`
+	CreatedAt        time.Time              `json:"created_at"`
+}
+func MediaURL(mediaID uint, variant string) string {
+	url := fmt.Sprintf("%s%s/%d/content", constants.AppRoutes.APIPrefix, constants.AppRoutes.MediaBase, mediaID)
+	if variant != "" && variant != MediaVariantOriginal {
+		url += "?variant=" + variant
+	}
+	return url
+}
+func MediaURLPtr(mediaID *uint, variant string) *string {
+	if mediaID == nil {
+		return nil
+	}
+	url := MediaURL(*mediaID, variant)
+	return &url
+}
+func MapMediaToResponse(media *models.Media) MediaResponse {
+	resp := MediaResponse{
+		ID:               media.ID,
+		OwnerUserID:      media.OwnerUserID,
+		Purpose:          media.Purpose,
+		OriginalFilename: media.OriginalFilename,
+		ContentType:      media.ContentType,
+		SizeBytes:        media.SizeBytes,
+		Width:            media.Width,
+		Height:           media.Height,
+		URL:              MediaURL(media.ID, MediaVariantOriginal),
+		Variants:         make([]MediaVariantResponse, len(media.Variants)),
+		CreatedAt:        media.CreatedAt,
+	}
+	for i, variant := range media.Variants {
+		resp.Variants[i] = MediaVariantResponse{
+			Name:        variant.Name,
+			URL:         MediaURL(media.ID, variant.Name),
+			ContentType: variant.ContentType,
+			Width:       variant.Width,
+			Height:      variant.Height,
+			SizeBytes:   variant.SizeBytes,
+		}
+	}
+	return resp
+}
@@ -1,57 +1,149 @@
 package ports
 import (
+	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
 	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/models"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/pkg/utils"
 )
 type CreatePublicationInput struct {
-	UserID          uint                   `json:"user_id" validate:"required"`
-	BusinessID      *uint                  `json:"business_id"`
-	PublicationType models.PublicationType `json:"publication_type" validate:"required"`
-	Title           string                 `json:"title" validate:"required,min=2,max=255"`
-	Content         string                 `json:"content" validate:"required"`
-	Excerpt         *string                `json:"excerpt"`
-	Thumbnail       *string                `json:"thumbnail" validate:"omitempty,url"`
-	VideoURL        *string                `json:"video_url" validate:"omitempty,url"`
-	Published       *bool                  `json:"published"`
+	UserID          uint                          `json:"user_id" validate:"required"`
+	BusinessID      *uint                         `json:"business_id"`
+	PublicationType models.PublicationType        `json:"publication_type" validate:"required"`
+	Title           string                        `json:"title" validate:"required,min=2,max=255"`
+	Content         string                        `json:"content" validate:"required"`
+	ContentFormat   *models.ContentFormat         `json:"content_format" validate:"omitempty,oneof=markdown html plaintext"`
+	Excerpt         *string                       `json:"excerpt"`
+	Thumbnail       *string                       `json:"thumbnail" validate:"omitempty,url"`
+	VideoURL        *string                       `json:"video_url" validate:"omitempty,url"`
+	MediaID         *uint                         `json:"media_id"`
+	Visibility      *models.PublicationVisibility `json:"visibility" validate:"omitempty,oneof=public members connections private"`
+	Published       *bool                         `json:"published"`
+	ScheduledAt     *time.Time                    `json:"scheduled_at"`
 }
 type UpdatePublicationInput struct {
-	Title     *string `json:"title" validate:"omitempty,min=2,max=255"`
-	Content   *string `json:"content"`
-	Excerpt   *string `json:"excerpt"`
-	Thumbnail *string `json:"thumbnail" validate:"omitempty,url"`
-	VideoURL  *string `json:"video_url" validate:"omitempty,url"`
-	Published *bool   `json:"published"`
+	Title         *string                       `json:"title" validate:"omitempty,min=2,max=255"`
+	Content       *string                       `json:"content"`
+	ContentFormat *models.ContentFormat         `json:"content_format" validate:"omitempty,oneof=markdown html plaintext"`
+	Excerpt       *string                       `json:"excerpt"`
+	Thumbnail     *string                       `json:"thumbnail" validate:"omitempty,url"`
+	VideoURL      *string                       `json:"video_url" validate:"omitempty,url"`
+	MediaID       *uint                         `json:"media_id"`
+	Visibility    *models.PublicationVisibility `json:"visibility" validate:"omitempty,oneof=public members connections private"`
+	Published     *bool                         `json:"published"`
+	ScheduledAt   *time.Time                    `json:"scheduled_at"`
+
+	EditorUserID uint `json:"-"`
+	ClearExcerpt bool `json:"-"`
+}
+type PublicationsFilter struct {
+	PublicationType *models.PublicationType `form:"publication_type"`
+	BusinessID      *uint                   `form:"business_id"`
+	UserID          *uint                   `form:"user_id"`
+	PublishedFrom   *time.Time              `form:"published_from" time_format:"2006-01-02"`
+	PublishedTo     *time.Time              `form:"published_to" time_format:"2006-01-02"`
+}
+type PublicationFeedFilter struct {
+	PublicationType *models.PublicationType `form:"publication_type" validate:"omitempty,oneof=post case_study testimonial article"`
+	BusinessID      *uint                   `form:"business_id"`
+	Limit           int                     `form:"limit" validate:"omitempty,min=1,max=100"`
+}
+type PublicationFeedState struct {
+	Count        int64
+	Checksum     uint64
+	LastModified *time.Time
 }
 type PublicationResponse struct {
-	ID              uint                   `json:"id"`
-	Slug            string                 `json:"slug"`
-	PublicationType models.PublicationType `json:"publication_type"`
-	Title           string                 `json:"title"`
-	Excerpt         *string                `json:"excerpt,omitempty"`
-	Content         string                 `json:"content"`
-	Thumbnail       *string                `json:"thumbnail,omitempty"`
-	VideoURL        *string                `json:"video_url,omitempty"`
-	Published       bool                   `json:"published"`
-	PublishedAt     *time.Time             `json:"published_at,omitempty"`
-	CreatedAt       time.Time              `json:"created_at"`
-	UpdatedAt       time.Time              `json:"updated_at"`
-	Author          UserResponse           `json:"author"`
-	Business        *BusinessResponse      `json:"business,omitempty"`
+	ID                 uint                         `json:"id"`
+	Slug               string                       `json:"slug"`
+	PublicationType    models.PublicationType       `json:"publication_type"`
+	Title              string                       `json:"title"`
+	Excerpt            *string                      `json:"excerpt,omitempty"`
+	Content            string                       `json:"content"`
+	ContentFormat      models.ContentFormat         `json:"content_format"`
+	ContentHTML        string                       `json:"content_html"`
+	ReadingTimeMinutes uint                         `json:"reading_time_minutes"`
+	TableOfContents    []utils.TOCEntry             `json:"table_of_contents,omitempty"`
+	Thumbnail          *string                      `json:"thumbnail,omitempty"`
+	VideoURL           *string                      `json:"video_url,omitempty"`
+	MediaID            *uint                        `json:"media_id,omitempty"`
+	Visibility         models.PublicationVisibility `json:"visibility"`
+	Published          bool                         `json:"published"`
+	PublishedAt        *time.Time                   `json:"published_at,omitempty"`
+	ScheduledAt        *time.Time                   `json:"scheduled_at,omitempty"`
+	CreatedAt          time.Time                    `json:"created_at"`
+	UpdatedAt          time.Time                    `json:"updated_at"`
+	Author             UserResponse                 `json:"author"`
+	Business           *BusinessResponse            `json:"business,omitempty"`
+	RedirectedFrom     *string                      `json:"redirected_from,omitempty"`
+	Engagement         *PublicationEngagement       `json:"engagement,omitempty"`
+}
+type PublicationEngagement struct {
+	CommentCount   int64                                    `json:"comment_count"`
+	ReactionCounts map[models.PublicationReactionType]int64 `json:"reaction_counts"`
+	ReactionTotal  int64                                    `json:"reaction_total"`
+}
+func RenderPublicationContent(content string, format models.ContentFormat, publicationType models.PublicationType) (*utils.RenderedContent, error) {
+	if format == "" {
+		format = models.ContentFormatMarkdown
+	}
+	rendered, err := utils.RenderContent(content, string(format))
+	if err != nil {
+		return nil, err
+	}
+	if publicationType != models.PublicationArticle {
+		rendered.TableOfContents = nil
+	}
+	return rendered, nil
+}
+func PublicationExcerpt(pub *models.Publication) string {
+	if pub.Excerpt != nil && strings.TrimSpace(*pub.Excerpt) != "" {
+		return strings.TrimSpace(*pub.Excerpt)
+	}
+	return pub.GeneratedExcerpt
 }
 func MapPublicationToResponse(pub *models.Publication) PublicationResponse {
 	resp := PublicationResponse{
-		ID:              pub.ID,
-		Slug:            pub.Slug,
-		PublicationType: pub.PublicationType,
-		Title:           pub.Title,
-		Excerpt:         pub.Excerpt,
-		Content:         pub.Content,
-		Thumbnail:       pub.Thumbnail,
-		VideoURL:        pub.VideoURL,
-		Published:       pub.Published,
-		PublishedAt:     pub.PublishedAt,
-		CreatedAt:       pub.CreatedAt,
-		UpdatedAt:       pub.UpdatedAt,
+		ID:                 pub.ID,
+		Slug:               pub.Slug,
+		PublicationType:    pub.PublicationType,
+		Title:              pub.Title,
+		Content:            pub.Content,
+		ContentFormat:      pub.ContentFormat,
+		ContentHTML:        pub.ContentHTML,
+		ReadingTimeMinutes: pub.ReadingTimeMinutes,
+		Thumbnail:          pub.Thumbnail,
+		VideoURL:           pub.VideoURL,
+		MediaID:            pub.MediaID,
+		Visibility:         pub.Visibility,
+		Published:          pub.Published,
+		PublishedAt:        pub.PublishedAt,
+		ScheduledAt:        pub.ScheduledAt,
+		CreatedAt:          pub.CreatedAt,
+		UpdatedAt:          pub.UpdatedAt,
+	}
+	if resp.Thumbnail == nil {
+		resp.Thumbnail = MediaURLPtr(pub.MediaID, "medium")
+	}
+	if resp.ContentFormat == "" {
+		resp.ContentFormat = models.ContentFormatMarkdown
+	}
+	excerpt := PublicationExcerpt(pub)
+	if pub.ContentHTML == "" && pub.Content != "" {
+		if rendered, err := RenderPublicationContent(pub.Content, pub.ContentFormat, pub.PublicationType); err == nil {
+			resp.ContentHTML = rendered.HTML
+			resp.ReadingTimeMinutes = uint(rendered.ReadingTimeMinutes)
+			resp.TableOfContents = rendered.TableOfContents
+			if excerpt == "" {
+				excerpt = utils.GenerateExcerpt(rendered.Text, utils.DefaultExcerptLength)
+			}
+		}
+	} else if pub.PublicationType == models.PublicationArticle && len(pub.TableOfContents) > 0 {
+		_ = json.Unmarshal(pub.TableOfContents, &resp.TableOfContents)
+	}
+	if excerpt != "" {
+		resp.Excerpt = &excerpt
 	}
 	if pub.User.ID != 0 {
 		resp.Author = MapUserToResponse(&pub.User)
@@ -62,3 +154,103 @@ func MapPublicationToResponse(pub *models.Publication) PublicationResponse {
 	}
 	return resp
 }
+type PublicationRevisionResponse struct {
+	ID            uint                 `json:"id"`
+	PublicationID uint                 `json:"publication_id"`
+	Revision      uint                 `json:"revision"`
+	Title         string               `json:"title"`
+	Excerpt       *string              `json:"excerpt,omitempty"`
+	Content       string               `json:"content"`
+	ContentFormat models.ContentFormat `json:"content_format"`
+	CreatedAt     time.Time            `json:"created_at"`
+	Editor        *UserResponse        `json:"editor,omitempty"`
+}
+type PublicationRevisionDiffResponse struct {
+	PublicationID uint             `json:"publication_id"`
+	FromRevision  uint             `json:"from_revision"`
+	ToRevision    *uint            `json:"to_revision,omitempty"`
+	FromTitle     string           `json:"from_title"`
+	ToTitle       string           `json:"to_title"`
+	Lines         []utils.DiffLine `json:"lines"`
+}
+func MapPublicationRevisionToResponse(rev *models.PublicationRevision) PublicationRevisionResponse {
+	resp := PublicationRevisionResponse{
+		ID:            rev.ID,
+		PublicationID: rev.PublicationID,
+		Revision:      rev.Revision,
+		Title:         rev.Title,
+		Excerpt:       rev.Excerpt,
+		Content:       rev.Content,
+		ContentFormat: rev.ContentFormat,
+		CreatedAt:     rev.CreatedAt,
+	}
+	if rev.EditorUser.ID != 0 {
+		editorResp := MapUserToResponse(&rev.EditorUser)
+		resp.Editor = &editorResp
+	}
+	return resp
+}
+func publicationFeedContent(pub *models.Publication) (string, string) {
+	if pub.ContentHTML != "" || pub.Content == "" {
+		return pub.ContentHTML, pub.GeneratedExcerpt
+	}
+	rendered, err := RenderPublicationContent(pub.Content, pub.ContentFormat, pub.PublicationType)
+	if err != nil {
+		return "", pub.GeneratedExcerpt
+	}
+	return rendered.HTML, utils.GenerateExcerpt(rendered.Text, utils.DefaultExcerptLength)
+}
+func publicationFeedSummary(pub *models.Publication, generatedExcerpt string) string {
+	if pub.Excerpt == nil || strings.TrimSpace(*pub.Excerpt) == "" {
+		return generatedExcerpt
+	}
+	rendered, err := utils.SanitizeHTML(*pub.Excerpt)
+	if err != nil {
+		return generatedExcerpt
+	}
+	return rendered.Text
+}
+func MapPublicationsToFeed(publications []models.Publication, title, siteURL, selfURL string) utils.Feed {
+	feed := utils.Feed{
+		Title:       title,
+		Description: title,
+		HomeURL:     siteURL + "/publications",
+		SelfURL:     selfURL,
+		Items:       make([]utils.FeedItem, len(publications)),
+	}
+	for i, pub := range publications {
+		contentHTML, generatedExcerpt := publicationFeedContent(&pub)
+		item := utils.FeedItem{
+			ID:          fmt.Sprintf("%s/publications/%d", siteURL, pub.ID),
+			Title:       pub.Title,
+			URL:         fmt.Sprintf("%s/publications/%s", siteURL, pub.Slug),
+			Summary:     publicationFeedSummary(&pub, generatedExcerpt),
+			ContentHTML: contentHTML,
+			Category:    string(pub.PublicationType),
+			UpdatedAt:   pub.UpdatedAt,
+		}
+		if pub.PublishedAt != nil {
+			item.PublishedAt = *pub.PublishedAt
+		} else {
+			item.PublishedAt = pub.CreatedAt
+		}
+		if pub.Thumbnail != nil {
+			item.ImageURL = *pub.Thumbnail
+		} else if pub.MediaID != nil {
+			item.ImageURL = siteURL + MediaURL(*pub.MediaID, "medium")
+		}
+		if pub.Business != nil {
+			item.Author = pub.Business.Name
+		} else if pub.User.ID != 0 {
+			item.Author = pub.User.FirstName
+			if pub.User.LastName != nil {
+				item.Author += " " + *pub.User.LastName
+			}
+		}
+		if item.UpdatedAt.After(feed.Updated) {
+			feed.Updated = item.UpdatedAt
+		}
+		feed.Items[i] = item
+	}
+	return feed
+}
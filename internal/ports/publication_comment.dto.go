@@ -0,0 +1,98 @@
+package ports
+import (
+	"time"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/models"
+)
+type CreatePublicationCommentInput struct {
+	PublicationID uint   `json:"-"`
+	UserID        uint   `json:"-"`
+	ParentID      *uint  `json:"parent_id"`
+	Body          string `json:"body" validate:"required,max=5000"`
+}
+type UpdatePublicationCommentInput struct {
+	Body string `json:"body" validate:"required,max=5000"`
+}
+type FlagPublicationCommentInput struct {
+	Reason string `json:"reason" validate:"required,max=255"`
+}
+type ModeratePublicationCommentInput struct {
+	Status models.PublicationCommentStatus `json:"status" validate:"required,oneof=visible hidden"`
+}
+type PublicationCommentResponse struct {
+	ID            uint                            `json:"id"`
+	PublicationID uint                            `json:"publication_id"`
+	ParentID      *uint                           `json:"parent_id,omitempty"`
+	Body          string                          `json:"body"`
+	Status        models.PublicationCommentStatus `json:"status"`
+	FlagReason    *string                         `json:"flag_reason,omitempty"`
+	Edited        bool                            `json:"edited"`
+	EditedAt      *time.Time                      `json:"edited_at,omitempty"`
+	CreatedAt     time.Time                       `json:"created_at"`
+	UpdatedAt     time.Time                       `json:"updated_at"`
+	Author        *UserResponse                   `json:"author,omitempty"`
+	Replies       []PublicationCommentResponse    `json:"replies"`
+}
+type PublicationCommentsResponse struct {
+	Comments []PublicationCommentResponse `json:"comments"`
+	Count    int                          `json:"count"`
+}
+func MapPublicationCommentToResponse(comment *models.PublicationComment, moderator bool) PublicationCommentResponse {
+	resp := PublicationCommentResponse{
+		ID:            comment.ID,
+		PublicationID: comment.PublicationID,
+		ParentID:      comment.ParentID,
+		Body:          comment.Body,
+		Status:        comment.Status,
+		Edited:        comment.EditedAt != nil,
+		EditedAt:      comment.EditedAt,
+		CreatedAt:     comment.CreatedAt,
+		UpdatedAt:     comment.UpdatedAt,
+		Replies:       []PublicationCommentResponse{},
+	}
+	switch {
+	case comment.Status == models.CommentStatusDeleted:
+		resp.Body = ""
+		return resp
+	case comment.Status == models.CommentStatusHidden && !moderator:
+		resp.Body = ""
+	}
+	if moderator {
+		resp.FlagReason = comment.FlagReason
+	}
+	if comment.User.ID != 0 {
+		authorResp := MapUserToResponse(&comment.User)
+		resp.Author = &authorResp
+	}
+	return resp
+}
+func MapPublicationCommentsToThread(comments []models.PublicationComment, moderator func(*models.PublicationComment) bool) PublicationCommentsResponse {
+	children := make(map[uint][]int)
+	var roots []int
+	count := 0
+	for i := range comments {
+		if comments[i].Status != models.CommentStatusDeleted && comments[i].Status != models.CommentStatusHidden {
+			count++
+		}
+		if comments[i].ParentID == nil {
+			roots = append(roots, i)
+			continue
+		}
+		children[*comments[i].ParentID] = append(children[*comments[i].ParentID], i)
+	}
+	var build func(i int) PublicationCommentResponse
+	build = func(i int) PublicationCommentResponse {
+		resp := MapPublicationCommentToResponse(&comments[i], moderator(&comments[i]))
+		for _, child := range children[comments[i].ID] {
+			resp.Replies = append(resp.Replies, build(child))
+		}
+		return resp
+	}
+	thread := make([]PublicationCommentResponse, len(roots))
+	for i, root := range roots {
+		thread[i] = build(root)
+	}
+	return PublicationCommentsResponse{
+		Comments: thread,
+		Count:    count,
+	}
+}
@@ -0,0 +1,30 @@
+package ports
+import (
+	"time"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/models"
+)
+type SetPublicationReactionInput struct {
+	ReactionType models.PublicationReactionType `json:"reaction_type" validate:"required,oneof=like insightful celebrate support curious"`
+}
+type PublicationReactionResponse struct {
+	PublicationID uint                           `json:"publication_id"`
+	UserID        uint                           `json:"user_id"`
+	ReactionType  models.PublicationReactionType `json:"reaction_type"`
+	CreatedAt     time.Time                      `json:"created_at"`
+	UpdatedAt     time.Time                      `json:"updated_at"`
+}
+type PublicationReactionsSummaryResponse struct {
+	PublicationID  uint                                     `json:"publication_id"`
+	Counts         map[models.PublicationReactionType]int64 `json:"counts"`
+	Total          int64                                    `json:"total"`
+	ViewerReaction *models.PublicationReactionType          `json:"viewer_reaction,omitempty"`
+}
+func MapPublicationReactionToResponse(reaction *models.PublicationReaction) PublicationReactionResponse {
+	return PublicationReactionResponse{
+		PublicationID: reaction.PublicationID,
+		UserID:        reaction.UserID,
+		ReactionType:  reaction.ReactionType,
+		CreatedAt:     reaction.CreatedAt,
+		UpdatedAt:     reaction.UpdatedAt,
+	}
+}
@@ -0,0 +1,112 @@
+package ports
+import (
+	"time"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/models"
+)
+type ReferralDirection string
+type ReferralReportInterval string
+const (
+	ReferralDirectionGiven    ReferralDirection      = "given"
+	ReferralDirectionReceived ReferralDirection      = "received"
+	ReferralIntervalMonth     ReferralReportInterval = "month"
+	ReferralIntervalQuarter   ReferralReportInterval = "quarter"
+)
+type CreateReferralInput struct {
+	ReferrerBusinessID uint     `json:"referrer_business_id" validate:"required"`
+	ReceiverBusinessID uint     `json:"receiver_business_id" validate:"required"`
+	ClientBusinessID   *uint    `json:"client_business_id,omitempty"`
+	ClientName         string   `json:"client_name" validate:"required,min=2,max=150"`
+	ClientContact      *string  `json:"client_contact,omitempty" validate:"omitempty,max=255"`
+	DealValue          *float64 `json:"deal_value,omitempty" validate:"omitempty,gte=0"`
+	Notes              *string  `json:"notes,omitempty"`
+}
+type UpdateReferralInput struct {
+	Status    *models.ReferralStatus `json:"status,omitempty" validate:"omitempty,oneof=sent contacted won lost"`
+	DealValue *float64               `json:"deal_value,omitempty" validate:"omitempty,gte=0"`
+	Notes     *string                `json:"notes,omitempty"`
+}
+type ReferralsFilter struct {
+	Direction *ReferralDirection     `form:"direction" validate:"omitempty,oneof=given received"`
+	Status    *models.ReferralStatus `form:"status" validate:"omitempty,oneof=sent contacted won lost"`
+}
+type ReferralReportQuery struct {
+	From     *time.Time             `form:"from" time_format:"2006-01-02"`
+	To       *time.Time             `form:"to" time_format:"2006-01-02"`
+	Interval ReferralReportInterval `form:"interval" validate:"omitempty,oneof=month quarter"`
+}
+type ReferralResponse struct {
+	ID                   uint                  `json:"id"`
+	ReferrerBusinessID   uint                  `json:"referrer_business_id"`
+	ReceiverBusinessID   uint                  `json:"receiver_business_id"`
+	ClientBusinessID     *uint                 `json:"client_business_id,omitempty"`
+	ClientName           string                `json:"client_name"`
+	ClientContact        *string               `json:"client_contact,omitempty"`
+	Status               models.ReferralStatus `json:"status"`
+	DealValue            *float64              `json:"deal_value,omitempty"`
+	Notes                *string               `json:"notes,omitempty"`
+	ReferredByUserID     uint                  `json:"referred_by_user_id"`
+	BusinessConnectionID *uint                 `json:"business_connection_id,omitempty"`
+	ClosedAt             *time.Time            `json:"closed_at,omitempty"`
+	CreatedAt            time.Time             `json:"created_at"`
+	UpdatedAt            time.Time             `json:"updated_at"`
+
+	ReferrerBusiness BusinessResponse  `json:"referrer_business"`
+	ReceiverBusiness BusinessResponse  `json:"receiver_business"`
+	ClientBusiness   *BusinessResponse `json:"client_business,omitempty"`
+}
+type ReferralsResponse struct {
+	Referrals []ReferralResponse `json:"referrals"`
+	Count     int                `json:"count"`
+}
+type ReferralReportPeriod struct {
+	Period           string  `json:"period"`
+	GivenCount       int     `json:"given_count"`
+	ReceivedCount    int     `json:"received_count"`
+	GivenWon         int     `json:"given_won"`
+	ReceivedWon      int     `json:"received_won"`
+	GivenWonValue    float64 `json:"given_won_value"`
+	ReceivedWonValue float64 `json:"received_won_value"`
+}
+type ReferralReportResponse struct {
+	BusinessID uint                   `json:"business_id"`
+	From       time.Time              `json:"from"`
+	To         time.Time              `json:"to"`
+	Interval   ReferralReportInterval `json:"interval"`
+	Periods    []ReferralReportPeriod `json:"periods"`
+	Totals     ReferralReportPeriod   `json:"totals"`
+}
+func MapToReferralResponse(referral *models.Referral) ReferralResponse {
+	resp := ReferralResponse{
+		ID:                   referral.ID,
+		ReferrerBusinessID:   referral.ReferrerBusinessID,
+		ReceiverBusinessID:   referral.ReceiverBusinessID,
+		ClientBusinessID:     referral.ClientBusinessID,
+		ClientName:           referral.ClientName,
+		ClientContact:        referral.ClientContact,
+		Status:               referral.Status,
+		DealValue:            referral.DealValue,
+		Notes:                referral.Notes,
+		ReferredByUserID:     referral.ReferredByUserID,
+		BusinessConnectionID: referral.BusinessConnectionID,
+		ClosedAt:             referral.ClosedAt,
+		CreatedAt:            referral.CreatedAt,
+		UpdatedAt:            referral.UpdatedAt,
+		ReferrerBusiness:     MapBusinessToResponse(&referral.ReferrerBusiness),
+		ReceiverBusiness:     MapBusinessToResponse(&referral.ReceiverBusiness),
+	}
+	if referral.ClientBusiness != nil {
+		client := MapBusinessToResponse(referral.ClientBusiness)
+		resp.ClientBusiness = &client
+	}
+	return resp
+}
+func MapToReferralsResponse(referrals []models.Referral) ReferralsResponse {
+	resp := make([]ReferralResponse, len(referrals))
+	for i := range referrals {
+		resp[i] = MapToReferralResponse(&referrals[i])
+	}
+	return ReferralsResponse{
+		Referrals: resp,
+		Count:     len(resp),
+	}
+}
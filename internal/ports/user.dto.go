@@ -20,6 +20,7 @@ type UserUpdateSchema struct {
 	ContactEmail   *string `json:"contact_email" validate:"omitempty,email"`
 	ContactPhoneNo *string `json:"contact_phone_no" validate:"omitempty,max=20"`
 	AdkSessionID   *string `json:"adk_session_id" validate:"omitempty,max=128"`
+	AvatarMediaID  *uint   `json:"avatar_media_id"`
 	EmailVerified  *bool   `json:"email_verified"`
 	Active         *bool   `json:"active"`
 }
@@ -30,6 +31,8 @@ type UserResponse struct {
 	LoginEmail     string    `json:"login_email"`
 	ContactEmail   *string   `json:"contact_email"`
 	ContactPhoneNo *string   `json:"contact_phone_no"`
+	AvatarMediaID  *uint     `json:"avatar_media_id,omitempty"`
+	AvatarURL      *string   `json:"avatar_url,omitempty"`
 	EmailVerified  bool      `json:"email_verified"`
 	Active         bool      `json:"active"`
 	CreatedAt      time.Time `json:"created_at"`
@@ -43,6 +46,8 @@ func MapUserToResponse(user *models.User) UserResponse {
 		LoginEmail:     user.LoginEmail,
 		ContactEmail:   user.ContactEmail,
 		ContactPhoneNo: user.ContactPhoneNo,
+		AvatarMediaID:  user.AvatarMediaID,
+		AvatarURL:      MediaURLPtr(user.AvatarMediaID, "thumb"),
 		EmailVerified:  user.EmailVerified,
 		Active:         user.Active,
 		CreatedAt:      user.CreatedAt,
@@ -0,0 +1,79 @@
+package geo
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const EarthRadiusKm = 6371.0
+
+var (
+	ErrNotFound     = errors.New("geo: location not found")
+	ErrInvalidPoint = errors.New("geo: invalid coordinates")
+)
+
+type Point struct {
+	Lat float64
+	Lng float64
+}
+
+type Address struct {
+	Address    string
+	City       string
+	State      string
+	PostalCode string
+	Country    string
+}
+
+type Geocoder interface {
+	Geocode(ctx context.Context, addr Address) (Point, error)
+}
+
+func ParsePoint(raw string) (Point, error) {
+	parts := strings.Split(raw, ",")
+	if len(parts) != 2 {
+		return Point{}, ErrInvalidPoint
+	}
+	lat, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil {
+		return Point{}, ErrInvalidPoint
+	}
+	lng, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil {
+		return Point{}, ErrInvalidPoint
+	}
+	if lat < -90 || lat > 90 || lng < -180 || lng > 180 {
+		return Point{}, ErrInvalidPoint
+	}
+	return Point{Lat: lat, Lng: lng}, nil
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func HaversineKm(a, b Point) float64 {
+	dLat := radians(b.Lat - a.Lat)
+	dLng := radians(b.Lng - a.Lng)
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(radians(a.Lat))*math.Cos(radians(b.Lat))*math.Pow(math.Sin(dLng/2), 2)
+	return 2 * EarthRadiusKm * math.Asin(math.Sqrt(h))
+}
+
+func BoundingBox(center Point, radiusKm float64) (Point, Point) {
+	dLat := radiusKm / (EarthRadiusKm * math.Pi / 180)
+	minLat := math.Max(center.Lat-dLat, -90)
+	maxLat := math.Min(center.Lat+dLat, 90)
+	cosLat := math.Cos(radians(center.Lat))
+	if minLat == -90 || maxLat == 90 || cosLat < 1e-6 {
+		return Point{Lat: minLat, Lng: -180}, Point{Lat: maxLat, Lng: 180}
+	}
+	dLng := dLat / cosLat
+	minLng, maxLng := center.Lng-dLng, center.Lng+dLng
+	if minLng < -180 || maxLng > 180 {
+		minLng, maxLng = -180, 180
+	}
+	return Point{Lat: minLat, Lng: minLng}, Point{Lat: maxLat, Lng: maxLng}
+}
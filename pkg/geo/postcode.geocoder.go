@@ -0,0 +1,103 @@
+package geo
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+//go:embed au_postcodes.csv
+var auPostcodesCSV []byte
+
+type AUPostcodeGeocoder struct {
+	centroids map[int]Point
+}
+
+func NewAUPostcodeGeocoder() *AUPostcodeGeocoder {
+	g, err := parseAUPostcodes(bytes.NewReader(auPostcodesCSV))
+	if err != nil {
+		panic(fmt.Sprintf("geo: invalid embedded postcode data: %v", err))
+	}
+	return g
+}
+
+func LoadAUPostcodeGeocoder(path string) (*AUPostcodeGeocoder, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parseAUPostcodes(f)
+}
+
+func parseAUPostcodes(r io.Reader) (*AUPostcodeGeocoder, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 3
+	reader.ReuseRecord = true
+	g := &AUPostcodeGeocoder{centroids: make(map[int]Point)}
+	line := 0
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line++
+		if line == 1 && strings.EqualFold(record[0], "postcode") {
+			continue
+		}
+		postcode, err := strconv.Atoi(strings.TrimSpace(record[0]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid postcode %q", line, record[0])
+		}
+		point, err := ParsePoint(record[1] + "," + record[2])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid coordinates", line)
+		}
+		g.centroids[postcode] = point
+	}
+	if len(g.centroids) == 0 {
+		return nil, errors.New("no postcodes found")
+	}
+	return g, nil
+}
+
+// Postcodes reports how many postcode centroids the geocoder knows about.
+func (g *AUPostcodeGeocoder) Postcodes() int {
+	return len(g.centroids)
+}
+
+func isAustralia(country string) bool {
+	switch strings.ToLower(strings.TrimSpace(country)) {
+	case "", "au", "aus", "australia":
+		return true
+	}
+	return false
+}
+
+func (g *AUPostcodeGeocoder) Geocode(ctx context.Context, addr Address) (Point, error) {
+	if !isAustralia(addr.Country) {
+		return Point{}, ErrNotFound
+	}
+	raw := strings.TrimSpace(addr.PostalCode)
+	if len(raw) < 3 || len(raw) > 4 {
+		return Point{}, ErrNotFound
+	}
+	postcode, err := strconv.Atoi(raw)
+	if err != nil {
+		return Point{}, ErrNotFound
+	}
+	if point, ok := g.centroids[postcode]; ok {
+		return point, nil
+	}
+	return Point{}, ErrNotFound
+}
@@ -0,0 +1,102 @@
+package utils
+
+import "strings"
+
+const (
+	DiffOpEqual  = "equal"
+	DiffOpInsert = "insert"
+	DiffOpDelete = "delete"
+)
+
+type DiffLine struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+// DiffLines uses Hirschberg's algorithm, so memory stays linear in the
+// number of lines instead of allocating a full LCS table.
+func DiffLines(from, to string) []DiffLine {
+	a := strings.Split(from, "\n")
+	b := strings.Split(to, "\n")
+	diff := make([]DiffLine, 0, len(a)+len(b))
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		diff = append(diff, DiffLine{Op: DiffOpEqual, Text: a[prefix]})
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	diff = diffRange(diff, a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])
+	for _, line := range a[len(a)-suffix:] {
+		diff = append(diff, DiffLine{Op: DiffOpEqual, Text: line})
+	}
+	return diff
+}
+
+func diffRange(diff []DiffLine, a, b []string) []DiffLine {
+	switch {
+	case len(a) == 0:
+		return appendLines(diff, DiffOpInsert, b)
+	case len(b) == 0:
+		return appendLines(diff, DiffOpDelete, a)
+	case len(a) == 1:
+		for j, line := range b {
+			if line == a[0] {
+				diff = appendLines(diff, DiffOpInsert, b[:j])
+				diff = append(diff, DiffLine{Op: DiffOpEqual, Text: line})
+				return appendLines(diff, DiffOpInsert, b[j+1:])
+			}
+		}
+		diff = append(diff, DiffLine{Op: DiffOpDelete, Text: a[0]})
+		return appendLines(diff, DiffOpInsert, b)
+	}
+	mid := len(a) / 2
+	forward := lcsLengths(a[:mid], b, false)
+	backward := lcsLengths(a[mid:], b, true)
+	split, best := 0, -1
+	for j := 0; j <= len(b); j++ {
+		if score := forward[j] + backward[len(b)-j]; score > best {
+			split, best = j, score
+		}
+	}
+	diff = diffRange(diff, a[:mid], b[:split])
+	return diffRange(diff, a[mid:], b[split:])
+}
+
+// lcsLengths returns the LCS length of a against every prefix of b, or
+// against every suffix of b (indexed by suffix length) when reverse is set.
+func lcsLengths(a, b []string, reverse bool) []int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for i := range a {
+		ai := a[i]
+		if reverse {
+			ai = a[len(a)-1-i]
+		}
+		for j := 1; j <= len(b); j++ {
+			bj := b[j-1]
+			if reverse {
+				bj = b[len(b)-j]
+			}
+			switch {
+			case ai == bj:
+				curr[j] = prev[j-1] + 1
+			case prev[j] >= curr[j-1]:
+				curr[j] = prev[j]
+			default:
+				curr[j] = curr[j-1]
+			}
+		}
+		prev, curr = curr, prev
+	}
+	return prev
+}
+
+func appendLines(diff []DiffLine, op string, lines []string) []DiffLine {
+	for _, line := range lines {
+		diff = append(diff, DiffLine{Op: op, Text: line})
+	}
+	return diff
+}
@@ -0,0 +1,261 @@
+package utils
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"html"
+	"mime"
+	"path"
+	"strings"
+	"time"
+)
+
+type Feed struct {
+	Title       string
+	Description string
+	HomeURL     string
+	SelfURL     string
+	Updated     time.Time
+	Items       []FeedItem
+}
+
+type FeedItem struct {
+	ID          string
+	Title       string
+	URL         string
+	Summary     string
+	ContentHTML string
+	Author      string
+	Category    string
+	ImageURL    string
+	PublishedAt time.Time
+	UpdatedAt   time.Time
+}
+
+type rssDocument struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	AtomNS  string     `xml:"xmlns:atom,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	AtomLink      rssLink   `xml:"atom:link"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type rssItem struct {
+	Title       string        `xml:"title"`
+	Link        string        `xml:"link"`
+	GUID        rssGUID       `xml:"guid"`
+	Description string        `xml:"description,omitempty"`
+	Category    string        `xml:"category,omitempty"`
+	PubDate     string        `xml:"pubDate"`
+	Enclosure   *rssEnclosure `xml:"enclosure,omitempty"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Length int    `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomEntry struct {
+	Title     string        `xml:"title"`
+	ID        string        `xml:"id"`
+	Links     []atomLink    `xml:"link"`
+	Published string        `xml:"published"`
+	Updated   string        `xml:"updated"`
+	Summary   string        `xml:"summary,omitempty"`
+	Author    *atomAuthor   `xml:"author,omitempty"`
+	Category  *atomCategory `xml:"category,omitempty"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type jsonFeed struct {
+	Version     string         `json:"version"`
+	Title       string         `json:"title"`
+	HomePageURL string         `json:"home_page_url,omitempty"`
+	FeedURL     string         `json:"feed_url,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Items       []jsonFeedItem `json:"items"`
+}
+
+type jsonFeedItem struct {
+	ID            string               `json:"id"`
+	URL           string               `json:"url,omitempty"`
+	Title         string               `json:"title"`
+	Summary       string               `json:"summary,omitempty"`
+	ContentHTML   string               `json:"content_html"`
+	Image         string               `json:"image,omitempty"`
+	DatePublished string               `json:"date_published"`
+	DateModified  string               `json:"date_modified"`
+	Authors       []jsonFeedAuthor     `json:"authors,omitempty"`
+	Tags          []string             `json:"tags,omitempty"`
+	Attachments   []jsonFeedAttachment `json:"attachments,omitempty"`
+}
+
+type jsonFeedAuthor struct {
+	Name string `json:"name"`
+}
+
+type jsonFeedAttachment struct {
+	URL      string `json:"url"`
+	MimeType string `json:"mime_type"`
+}
+
+func imageMimeType(url string) string {
+	ext := strings.ToLower(path.Ext(strings.SplitN(url, "?", 2)[0]))
+	if mimeType := mime.TypeByExtension(ext); strings.HasPrefix(mimeType, "image/") {
+		return mimeType
+	}
+	return "image/jpeg"
+}
+
+func RenderRSS(feed Feed) ([]byte, error) {
+	doc := rssDocument{
+		Version: "2.0",
+		AtomNS:  "http://www.w3.org/2005/Atom",
+		Channel: rssChannel{
+			Title:       feed.Title,
+			Link:        feed.HomeURL,
+			Description: feed.Description,
+			AtomLink:    rssLink{Href: feed.SelfURL, Rel: "self", Type: "application/rss+xml"},
+			Items:       make([]rssItem, len(feed.Items)),
+		},
+	}
+	if !feed.Updated.IsZero() {
+		doc.Channel.LastBuildDate = feed.Updated.UTC().Format(time.RFC1123Z)
+	}
+	for i, item := range feed.Items {
+		entry := rssItem{
+			Title:       item.Title,
+			Link:        item.URL,
+			GUID:        rssGUID{IsPermaLink: item.ID == item.URL, Value: item.ID},
+			Description: html.EscapeString(item.Summary),
+			Category:    item.Category,
+			PubDate:     item.PublishedAt.UTC().Format(time.RFC1123Z),
+		}
+		if item.ImageURL != "" {
+			entry.Enclosure = &rssEnclosure{URL: item.ImageURL, Length: 0, Type: imageMimeType(item.ImageURL)}
+		}
+		doc.Channel.Items[i] = entry
+	}
+	out, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), out...), nil
+}
+
+func RenderAtom(feed Feed) ([]byte, error) {
+	if feed.Updated.IsZero() {
+		feed.Updated = time.Now()
+	}
+	doc := atomFeed{
+		Title:   feed.Title,
+		ID:      feed.SelfURL,
+		Updated: feed.Updated.UTC().Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: feed.SelfURL, Rel: "self", Type: "application/atom+xml"},
+			{Href: feed.HomeURL, Rel: "alternate", Type: "text/html"},
+		},
+		Entries: make([]atomEntry, len(feed.Items)),
+	}
+	for i, item := range feed.Items {
+		entry := atomEntry{
+			Title:     item.Title,
+			ID:        item.ID,
+			Links:     []atomLink{{Href: item.URL, Rel: "alternate", Type: "text/html"}},
+			Published: item.PublishedAt.UTC().Format(time.RFC3339),
+			Updated:   item.UpdatedAt.UTC().Format(time.RFC3339),
+			Summary:   item.Summary,
+		}
+		if item.ImageURL != "" {
+			entry.Links = append(entry.Links, atomLink{Href: item.ImageURL, Rel: "enclosure", Type: imageMimeType(item.ImageURL)})
+		}
+		if item.Author != "" {
+			entry.Author = &atomAuthor{Name: item.Author}
+		}
+		if item.Category != "" {
+			entry.Category = &atomCategory{Term: item.Category}
+		}
+		doc.Entries[i] = entry
+	}
+	out, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), out...), nil
+}
+
+func RenderJSONFeed(feed Feed) ([]byte, error) {
+	doc := jsonFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       feed.Title,
+		HomePageURL: feed.HomeURL,
+		FeedURL:     feed.SelfURL,
+		Description: feed.Description,
+		Items:       make([]jsonFeedItem, len(feed.Items)),
+	}
+	for i, item := range feed.Items {
+		entry := jsonFeedItem{
+			ID:            item.ID,
+			URL:           item.URL,
+			Title:         item.Title,
+			Summary:       item.Summary,
+			ContentHTML:   item.ContentHTML,
+			Image:         item.ImageURL,
+			DatePublished: item.PublishedAt.UTC().Format(time.RFC3339),
+			DateModified:  item.UpdatedAt.UTC().Format(time.RFC3339),
+		}
+		if item.Author != "" {
+			entry.Authors = []jsonFeedAuthor{{Name: item.Author}}
+		}
+		if item.Category != "" {
+			entry.Tags = []string{item.Category}
+		}
+		if item.ImageURL != "" {
+			entry.Attachments = []jsonFeedAttachment{{URL: item.ImageURL, MimeType: imageMimeType(item.ImageURL)}}
+		}
+		doc.Items[i] = entry
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
@@ -0,0 +1,261 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const (
+	wordsPerMinute       = 200
+	DefaultExcerptLength = 200
+)
+
+type TOCEntry struct {
+	Level int    `json:"level"`
+	ID    string `json:"id"`
+	Text  string `json:"text"`
+}
+
+type RenderedContent struct {
+	HTML               string
+	Text               string
+	TableOfContents    []TOCEntry
+	WordCount          int
+	ReadingTimeMinutes int
+}
+
+var allowedTags = map[atom.Atom][]string{
+	atom.P: nil, atom.Br: nil, atom.Hr: nil,
+	atom.H1: {"id"}, atom.H2: {"id"}, atom.H3: {"id"}, atom.H4: {"id"}, atom.H5: {"id"}, atom.H6: {"id"},
+	atom.Strong: nil, atom.B: nil, atom.Em: nil, atom.I: nil, atom.U: nil, atom.S: nil, atom.Del: nil,
+	atom.Sub: nil, atom.Sup: nil, atom.Mark: nil, atom.Small: nil, atom.Span: nil,
+	atom.Blockquote: nil, atom.Pre: nil, atom.Code: {"class"},
+	atom.Ul: nil, atom.Ol: {"start"}, atom.Li: nil,
+	atom.A: {"href", "title"}, atom.Img: {"src", "alt", "title", "width", "height"},
+	atom.Table: nil, atom.Thead: nil, atom.Tbody: nil, atom.Tr: nil, atom.Th: {"align"}, atom.Td: {"align"},
+	atom.Figure: nil, atom.Figcaption: nil,
+}
+
+var droppedTags = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Iframe: true, atom.Object: true, atom.Embed: true,
+	atom.Noscript: true, atom.Template: true, atom.Textarea: true, atom.Select: true, atom.Svg: true, atom.Math: true,
+	atom.Head: true, atom.Title: true,
+}
+
+var voidTags = map[atom.Atom]bool{atom.Br: true, atom.Hr: true, atom.Img: true}
+
+var inlineTags = map[atom.Atom]bool{
+	atom.Strong: true, atom.B: true, atom.Em: true, atom.I: true, atom.U: true, atom.S: true, atom.Del: true,
+	atom.Sub: true, atom.Sup: true, atom.Mark: true, atom.Small: true, atom.Span: true, atom.Code: true, atom.A: true,
+}
+
+var (
+	codeClassPattern = regexp.MustCompile(`^language-[a-zA-Z0-9_+-]+$`)
+	numericPattern   = regexp.MustCompile(`^[0-9]{1,4}$`)
+	alignPattern     = regexp.MustCompile(`^(left|right|center)$`)
+	whitespaceRun    = regexp.MustCompile(`\s+`)
+)
+
+func safeURL(raw string, allowMailto bool) (string, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", false
+	}
+	if strings.HasPrefix(raw, "#") || (strings.HasPrefix(raw, "/") && !strings.HasPrefix(raw, "//")) {
+		return raw, true
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return "", false
+	}
+	switch strings.ToLower(parsed.Scheme) {
+	case "http", "https":
+		return raw, true
+	case "mailto":
+		return raw, allowMailto
+	}
+	return "", false
+}
+
+func sanitizeAttr(key, val string) (string, bool) {
+	switch key {
+	case "href":
+		return safeURL(val, true)
+	case "src":
+		return safeURL(val, false)
+	case "class":
+		return val, codeClassPattern.MatchString(val)
+	case "start", "width", "height":
+		return val, numericPattern.MatchString(val)
+	case "align":
+		return val, alignPattern.MatchString(val)
+	}
+	return val, true
+}
+
+type htmlSanitizer struct {
+	out       strings.Builder
+	text      strings.Builder
+	toc       []TOCEntry
+	headingID map[string]int
+}
+
+func nodeText(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	if n.Type == html.ElementNode && droppedTags[n.DataAtom] {
+		return ""
+	}
+	var b strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		b.WriteString(nodeText(child))
+	}
+	return b.String()
+}
+
+func headingLevel(tag atom.Atom) int {
+	switch tag {
+	case atom.H1:
+		return 1
+	case atom.H2:
+		return 2
+	case atom.H3:
+		return 3
+	case atom.H4:
+		return 4
+	case atom.H5:
+		return 5
+	case atom.H6:
+		return 6
+	}
+	return 0
+}
+
+func (s *htmlSanitizer) uniqueHeadingID(text string) string {
+	base := Slugify(text)
+	if base == "" {
+		base = "section"
+	}
+	s.headingID[base]++
+	if count := s.headingID[base]; count > 1 {
+		return fmt.Sprintf("%s-%d", base, count)
+	}
+	return base
+}
+
+func (s *htmlSanitizer) walk(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		s.out.WriteString(html.EscapeString(n.Data))
+		s.text.WriteString(n.Data)
+		return
+	case html.ElementNode:
+	default:
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			s.walk(child)
+		}
+		return
+	}
+	if droppedTags[n.DataAtom] {
+		return
+	}
+	allowedAttrs, allowed := allowedTags[n.DataAtom]
+	if !allowed {
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			s.walk(child)
+		}
+		s.text.WriteString(" ")
+		return
+	}
+	s.out.WriteString("<" + n.Data)
+	if level := headingLevel(n.DataAtom); level > 0 {
+		text := strings.TrimSpace(whitespaceRun.ReplaceAllString(nodeText(n), " "))
+		id := s.uniqueHeadingID(text)
+		s.out.WriteString(` id="` + html.EscapeString(id) + `"`)
+		s.toc = append(s.toc, TOCEntry{Level: level, ID: id, Text: text})
+	} else {
+		for _, attr := range n.Attr {
+			if attr.Namespace != "" || !containsString(allowedAttrs, attr.Key) {
+				continue
+			}
+			if val, ok := sanitizeAttr(attr.Key, attr.Val); ok {
+				s.out.WriteString(" " + attr.Key + `="` + html.EscapeString(val) + `"`)
+			}
+		}
+		if n.DataAtom == atom.A {
+			s.out.WriteString(` rel="nofollow noopener noreferrer"`)
+		}
+	}
+	s.out.WriteString(">")
+	if voidTags[n.DataAtom] {
+		s.text.WriteString(" ")
+		return
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		s.walk(child)
+	}
+	s.out.WriteString("</" + n.Data + ">")
+	if !inlineTags[n.DataAtom] {
+		s.text.WriteString(" ")
+	}
+}
+
+func containsString(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
+
+func SanitizeHTML(raw string) (*RenderedContent, error) {
+	body := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := html.ParseFragment(strings.NewReader(raw), body)
+	if err != nil {
+		return nil, err
+	}
+	s := &htmlSanitizer{headingID: make(map[string]int)}
+	for _, n := range nodes {
+		s.walk(n)
+	}
+	text := strings.TrimSpace(whitespaceRun.ReplaceAllString(s.text.String(), " "))
+	words := len(strings.Fields(text))
+	return &RenderedContent{
+		HTML:               s.out.String(),
+		Text:               text,
+		TableOfContents:    s.toc,
+		WordCount:          words,
+		ReadingTimeMinutes: int(math.Ceil(float64(words) / wordsPerMinute)),
+	}, nil
+}
+
+func RenderContent(content, format string) (*RenderedContent, error) {
+	switch format {
+	case "html":
+		return SanitizeHTML(content)
+	case "plaintext":
+		return SanitizeHTML(RenderPlaintext(content))
+	}
+	return SanitizeHTML(RenderMarkdown(content))
+}
+
+func GenerateExcerpt(text string, maxLen int) string {
+	text = strings.TrimSpace(whitespaceRun.ReplaceAllString(text, " "))
+	runes := []rune(text)
+	if len(runes) <= maxLen {
+		return text
+	}
+	cut := string(runes[:maxLen])
+	if i := strings.LastIndex(cut, " "); i > maxLen/2 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " .,;:-") + "…"
+}
@@ -0,0 +1,122 @@
+package utils
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+)
+
+type ImageVariantSpec struct {
+	Name   string
+	Width  int
+	Height int
+	Crop   bool
+}
+
+var StandardImageVariants = []ImageVariantSpec{
+	{Name: "thumb", Width: 150, Height: 150, Crop: true},
+	{Name: "small", Width: 320, Height: 320},
+	{Name: "medium", Width: 800, Height: 800},
+	{Name: "large", Width: 1600, Height: 1600},
+}
+
+func FitWithin(srcW, srcH, maxW, maxH int) (int, int) {
+	if srcW <= maxW && srcH <= maxH {
+		return srcW, srcH
+	}
+	if srcW*maxH > srcH*maxW {
+		h := srcH * maxW / srcW
+		if h < 1 {
+			h = 1
+		}
+		return maxW, h
+	}
+	w := srcW * maxH / srcH
+	if w < 1 {
+		w = 1
+	}
+	return w, maxH
+}
+
+func CropToAspect(img image.Image, w, h int) image.Image {
+	b := img.Bounds()
+	srcW, srcH := b.Dx(), b.Dy()
+	cropW, cropH := srcW, srcH
+	if srcW*h > srcH*w {
+		cropW = srcH * w / h
+	} else {
+		cropH = srcW * h / w
+	}
+	x0 := b.Min.X + (srcW-cropW)/2
+	y0 := b.Min.Y + (srcH-cropH)/2
+	dst := image.NewRGBA(image.Rect(0, 0, cropW, cropH))
+	draw.Draw(dst, dst.Bounds(), img, image.Pt(x0, y0), draw.Src)
+	return dst
+}
+
+func ResizeImage(img image.Image, w, h int) *image.RGBA {
+	src := image.NewRGBA(image.Rect(0, 0, img.Bounds().Dx(), img.Bounds().Dy()))
+	draw.Draw(src, src.Bounds(), img, img.Bounds().Min, draw.Src)
+	srcW, srcH := src.Bounds().Dx(), src.Bounds().Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		y0 := y * srcH / h
+		y1 := (y + 1) * srcH / h
+		if y1 <= y0 {
+			y1 = y0 + 1
+		}
+		for x := 0; x < w; x++ {
+			x0 := x * srcW / w
+			x1 := (x + 1) * srcW / w
+			if x1 <= x0 {
+				x1 = x0 + 1
+			}
+			var r, g, b, a, n uint32
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					p := row[sx*4 : sx*4+4]
+					r += uint32(p[0])
+					g += uint32(p[1])
+					b += uint32(p[2])
+					a += uint32(p[3])
+					n++
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{R: uint8(r / n), G: uint8(g / n), B: uint8(b / n), A: uint8(a / n)})
+		}
+	}
+	return dst
+}
+
+func RenderImageVariant(img image.Image, spec ImageVariantSpec) (*image.RGBA, bool) {
+	b := img.Bounds()
+	if spec.Crop {
+		if b.Dx() < spec.Width || b.Dy() < spec.Height {
+			return nil, false
+		}
+		return ResizeImage(CropToAspect(img, spec.Width, spec.Height), spec.Width, spec.Height), true
+	}
+	if b.Dx() <= spec.Width && b.Dy() <= spec.Height {
+		return nil, false
+	}
+	w, h := FitWithin(b.Dx(), b.Dy(), spec.Width, spec.Height)
+	return ResizeImage(img, w, h), true
+}
+
+func EncodeImage(img image.Image, contentType string) ([]byte, string, error) {
+	var buf bytes.Buffer
+	if contentType == "image/jpeg" {
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 85}); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), "image/jpeg", nil
+	}
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), "image/png", nil
+}
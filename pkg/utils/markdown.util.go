@@ -0,0 +1,287 @@
+package utils
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	mdHeading     = regexp.MustCompile(`^(#{1,6})[ \t]+(.*?)[ \t]*#*[ \t]*$`)
+	mdFence       = regexp.MustCompile("^[ \t]{0,3}(```+|~~~+)[ \t]*([a-zA-Z0-9_+-]*)")
+	mdRule        = regexp.MustCompile(`^[ \t]{0,3}([-*_])([ \t]*[-*_]){2,}[ \t]*$`)
+	mdBulletItem  = regexp.MustCompile(`^([ \t]*)[-*+][ \t]+(.*)$`)
+	mdOrderedItem = regexp.MustCompile(`^([ \t]*)([0-9]{1,9})[.)][ \t]+(.*)$`)
+	mdQuote       = regexp.MustCompile(`^[ \t]{0,3}>[ \t]?(.*)$`)
+	mdImage       = regexp.MustCompile(`!\[([^\]]*)\]\(([^)\s]+)(?:\s+&#34;([^&]*)&#34;)?\)`)
+	mdLink        = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)(?:\s+&#34;([^&]*)&#34;)?\)`)
+	mdAutoLink    = regexp.MustCompile(`&lt;(https?://[^\s&]+)&gt;`)
+	mdStrong      = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	mdEmStar      = regexp.MustCompile(`\*([^*\s][^*]*)\*`)
+	mdEmUnder     = regexp.MustCompile(`(^|[^\w])_([^_\s][^_]*)_($|[^\w])`)
+	mdStrike      = regexp.MustCompile(`~~([^~]+)~~`)
+	mdHardBreak   = regexp.MustCompile(`( {2,}|\\)\n`)
+)
+
+type markdownRenderer struct {
+	out strings.Builder
+}
+
+func RenderMarkdown(src string) string {
+	r := &markdownRenderer{}
+	lines := strings.Split(strings.ReplaceAll(strings.ReplaceAll(src, "\r\n", "\n"), "\t", "    "), "\n")
+	r.renderBlocks(lines)
+	return r.out.String()
+}
+
+func isBlank(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+func indentWidth(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+func (r *markdownRenderer) renderBlocks(lines []string) {
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		switch {
+		case isBlank(line):
+			i++
+		case mdFence.MatchString(line):
+			i = r.renderFence(lines, i)
+		case mdHeading.MatchString(strings.TrimLeft(line, " ")):
+			m := mdHeading.FindStringSubmatch(strings.TrimLeft(line, " "))
+			level := strconv.Itoa(len(m[1]))
+			r.out.WriteString("<h" + level + ">" + renderInline(m[2]) + "</h" + level + ">\n")
+			i++
+		case mdRule.MatchString(line):
+			r.out.WriteString("<hr>\n")
+			i++
+		case mdQuote.MatchString(line):
+			i = r.renderQuote(lines, i)
+		case mdBulletItem.MatchString(line) || mdOrderedItem.MatchString(line):
+			i = r.renderList(lines, i)
+		default:
+			i = r.renderParagraph(lines, i)
+		}
+	}
+}
+
+func (r *markdownRenderer) renderFence(lines []string, start int) int {
+	m := mdFence.FindStringSubmatch(lines[start])
+	marker := m[1]
+	var code []string
+	i := start + 1
+	for ; i < len(lines); i++ {
+		if strings.HasPrefix(strings.TrimSpace(lines[i]), marker) {
+			i++
+			break
+		}
+		code = append(code, lines[i])
+	}
+	r.out.WriteString("<pre><code")
+	if m[2] != "" {
+		r.out.WriteString(` class="language-` + m[2] + `"`)
+	}
+	r.out.WriteString(">" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>\n")
+	return i
+}
+
+func (r *markdownRenderer) renderQuote(lines []string, start int) int {
+	var inner []string
+	i := start
+	for ; i < len(lines); i++ {
+		if m := mdQuote.FindStringSubmatch(lines[i]); m != nil {
+			inner = append(inner, m[1])
+			continue
+		}
+		if isBlank(lines[i]) || len(inner) == 0 {
+			break
+		}
+		inner = append(inner, lines[i])
+	}
+	nested := &markdownRenderer{}
+	nested.renderBlocks(inner)
+	r.out.WriteString("<blockquote>\n" + nested.out.String() + "</blockquote>\n")
+	return i
+}
+
+func listItemMatch(line string) (indent int, ordered bool, start string, content string, ok bool) {
+	if m := mdBulletItem.FindStringSubmatch(line); m != nil {
+		return len(m[1]), false, "", m[2], true
+	}
+	if m := mdOrderedItem.FindStringSubmatch(line); m != nil {
+		return len(m[1]), true, m[2], m[3], true
+	}
+	return 0, false, "", "", false
+}
+
+func (r *markdownRenderer) renderList(lines []string, start int) int {
+	baseIndent, ordered, startNum, _, _ := listItemMatch(lines[start])
+	tag := "ul"
+	if ordered {
+		tag = "ol"
+	}
+	r.out.WriteString("<" + tag)
+	if ordered && startNum != "1" {
+		if n, err := strconv.Atoi(startNum); err == nil {
+			r.out.WriteString(` start="` + strconv.Itoa(n) + `"`)
+		}
+	}
+	r.out.WriteString(">\n")
+	i := start
+	for i < len(lines) {
+		indent, itemOrdered, _, content, ok := listItemMatch(lines[i])
+		if !ok || indent != baseIndent || itemOrdered != ordered {
+			break
+		}
+		item := []string{content}
+		i++
+		for i < len(lines) {
+			if isBlank(lines[i]) {
+				if i+1 < len(lines) && indentWidth(lines[i+1]) > baseIndent && !isBlank(lines[i+1]) {
+					item = append(item, "")
+					i++
+					continue
+				}
+				break
+			}
+			if nextIndent, _, _, _, ok := listItemMatch(lines[i]); ok && nextIndent <= baseIndent {
+				break
+			}
+			if indentWidth(lines[i]) <= baseIndent && !isItem(lines[i]) && len(item) > 0 && isBlank(item[len(item)-1]) {
+				break
+			}
+			item = append(item, strings.TrimPrefix(lines[i], strings.Repeat(" ", minInt(indentWidth(lines[i]), baseIndent+2))))
+			i++
+		}
+		r.out.WriteString("<li>" + renderListItem(item) + "</li>\n")
+		if i < len(lines) && isBlank(lines[i]) {
+			if i+1 < len(lines) {
+				if nextIndent, nextOrdered, _, _, ok := listItemMatch(lines[i+1]); ok && nextIndent == baseIndent && nextOrdered == ordered {
+					i++
+				}
+			}
+		}
+	}
+	r.out.WriteString("</" + tag + ">\n")
+	return i
+}
+
+func isItem(line string) bool {
+	_, _, _, _, ok := listItemMatch(line)
+	return ok
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func renderListItem(item []string) string {
+	simple := true
+	for _, line := range item[1:] {
+		if isBlank(line) || isItem(line) || mdFence.MatchString(line) || mdQuote.MatchString(line) {
+			simple = false
+			break
+		}
+	}
+	if simple {
+		return renderInline(strings.Join(item, "\n"))
+	}
+	nested := &markdownRenderer{}
+	var lead []string
+	rest := item
+	for len(rest) > 0 && !isBlank(rest[0]) && !(len(lead) > 0 && (isItem(rest[0]) || mdFence.MatchString(rest[0]))) {
+		lead = append(lead, rest[0])
+		rest = rest[1:]
+	}
+	nested.out.WriteString(renderInline(strings.Join(lead, "\n")))
+	if len(rest) > 0 {
+		nested.out.WriteString("\n")
+		nested.renderBlocks(rest)
+	}
+	return nested.out.String()
+}
+
+func (r *markdownRenderer) renderParagraph(lines []string, start int) int {
+	var para []string
+	i := start
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if isBlank(line) || mdFence.MatchString(line) || mdRule.MatchString(line) || mdQuote.MatchString(line) ||
+			mdHeading.MatchString(strings.TrimLeft(line, " ")) || (len(para) > 0 && isItem(line)) {
+			break
+		}
+		para = append(para, strings.TrimSpace(line))
+	}
+	if len(para) == 0 {
+		para = append(para, strings.TrimSpace(lines[i]))
+		i++
+	}
+	r.out.WriteString("<p>" + renderInline(strings.Join(para, "\n")) + "</p>\n")
+	return i
+}
+
+func renderInline(text string) string {
+	var b strings.Builder
+	segments := strings.Split(text, "`")
+	if len(segments)%2 == 0 {
+		segments[len(segments)-2] += "`" + segments[len(segments)-1]
+		segments = segments[:len(segments)-1]
+	}
+	for i, segment := range segments {
+		if i%2 == 1 {
+			b.WriteString("<code>" + html.EscapeString(segment) + "</code>")
+			continue
+		}
+		b.WriteString(renderInlineText(segment))
+	}
+	return b.String()
+}
+
+func renderInlineText(text string) string {
+	s := html.EscapeString(text)
+	s = mdImage.ReplaceAllStringFunc(s, func(match string) string {
+		m := mdImage.FindStringSubmatch(match)
+		out := `<img src="` + m[2] + `" alt="` + m[1] + `"`
+		if m[3] != "" {
+			out += ` title="` + m[3] + `"`
+		}
+		return out + ">"
+	})
+	s = mdLink.ReplaceAllStringFunc(s, func(match string) string {
+		m := mdLink.FindStringSubmatch(match)
+		out := `<a href="` + m[2] + `"`
+		if m[3] != "" {
+			out += ` title="` + m[3] + `"`
+		}
+		return out + ">" + m[1] + "</a>"
+	})
+	s = mdAutoLink.ReplaceAllString(s, `<a href="$1">$1</a>`)
+	s = mdStrong.ReplaceAllStringFunc(s, func(match string) string {
+		m := mdStrong.FindStringSubmatch(match)
+		return "<strong>" + m[1] + m[2] + "</strong>"
+	})
+	s = mdEmStar.ReplaceAllString(s, "<em>$1</em>")
+	s = mdEmUnder.ReplaceAllString(s, "$1<em>$2</em>$3")
+	s = mdStrike.ReplaceAllString(s, "<del>$1</del>")
+	s = mdHardBreak.ReplaceAllString(s, "<br>\n")
+	return s
+}
+
+func RenderPlaintext(src string) string {
+	var b strings.Builder
+	for _, para := range regexp.MustCompile(`\n[ \t]*\n`).Split(strings.ReplaceAll(src, "\r\n", "\n"), -1) {
+		para = strings.TrimSpace(para)
+		if para == "" {
+			continue
+		}
+		b.WriteString("<p>" + strings.ReplaceAll(html.EscapeString(para), "\n", "<br>\n") + "</p>\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var (
+	excessNewlines = regexp.MustCompile(`\n{3,}`)
+	textEscaper    = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+)
+
+// SanitizeText keeps only the text of s, dropping markup and unsafe blocks
+// with the same parser as SanitizeHTML, then escapes what is left so that
+// text which still looks like a tag can never be rendered as one.
+func SanitizeText(s string) string {
+	body := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := html.ParseFragment(strings.NewReader(s), body)
+	if err != nil {
+		return ""
+	}
+	var text strings.Builder
+	for _, n := range nodes {
+		text.WriteString(nodeText(n))
+	}
+	s = strings.ReplaceAll(text.String(), "\r\n", "\n")
+	s = strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\t' {
+			return r
+		}
+		if unicode.IsControl(r) || r == unicode.ReplacementChar {
+			return -1
+		}
+		return r
+	}, s)
+	s = excessNewlines.ReplaceAllString(s, "\n\n")
+	return textEscaper.Replace(strings.TrimSpace(s))
+}
@@ -0,0 +1,76 @@
+package verification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+const maxVerificationFileBytes = 4 << 10
+
+var ErrBlockedAddress = errors.New("verification: refusing to connect to a non-public address")
+
+type NetResolver struct {
+	resolver *net.Resolver
+	client   *http.Client
+}
+
+func NewNetResolver(timeout time.Duration) *NetResolver {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || !ip.IsGlobalUnicast() || ip.IsPrivate() || ip.IsLoopback() {
+				return ErrBlockedAddress
+			}
+			return nil
+		},
+	}
+	return &NetResolver{
+		resolver: net.DefaultResolver,
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: &http.Transport{DialContext: dialer.DialContext},
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if len(via) >= 3 {
+					return http.ErrUseLastResponse
+				}
+				return nil
+			},
+		},
+	}
+}
+
+func (r *NetResolver) LookupTXT(ctx context.Context, domain string) ([]string, error) {
+	records, err := r.resolver.LookupTXT(ctx, domain)
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return nil, nil
+	}
+	return records, err
+}
+
+func (r *NetResolver) FetchFile(ctx context.Context, domain, path string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://"+domain+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("verification: unexpected status %d fetching %s", resp.StatusCode, path)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxVerificationFileBytes))
+}
@@ -0,0 +1,68 @@
+package verification
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+)
+
+const (
+	TXTRecordPrefix = "tia-verification="
+	WellKnownPath   = "/.well-known/tia-verification.txt"
+)
+
+var ErrInvalidWebsite = errors.New("verification: website has no usable domain")
+
+type Resolver interface {
+	LookupTXT(ctx context.Context, domain string) ([]string, error)
+	FetchFile(ctx context.Context, domain, path string) ([]byte, error)
+}
+
+func DomainFromWebsite(website string) (string, error) {
+	raw := strings.TrimSpace(website)
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return "", ErrInvalidWebsite
+	}
+	host := strings.ToLower(strings.TrimSuffix(parsed.Hostname(), "."))
+	host = strings.TrimPrefix(host, "www.")
+	if host == "" || !strings.Contains(host, ".") {
+		return "", ErrInvalidWebsite
+	}
+	return host, nil
+}
+
+func TXTRecordValue(token string) string {
+	return TXTRecordPrefix + token
+}
+
+func FileURL(domain string) string {
+	return "https://" + domain + WellKnownPath
+}
+
+func HasTXTToken(ctx context.Context, r Resolver, domain, token string) (bool, error) {
+	records, err := r.LookupTXT(ctx, domain)
+	if err != nil {
+		return false, err
+	}
+	expected := TXTRecordValue(token)
+	for _, record := range records {
+		if strings.TrimSpace(record) == expected {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func HasFileToken(ctx context.Context, r Resolver, domain, token string) (bool, error) {
+	body, err := r.FetchFile(ctx, domain, WellKnownPath)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(bytes.TrimSpace(body), []byte(token)), nil
+}
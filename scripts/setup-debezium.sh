@@ -31,7 +31,7 @@ CONNECTOR_CONFIG='{
     "key.converter": "org.apache.kafka.connect.json.JsonConverter",
     "value.converter": "org.apache.kafka.connect.json.JsonConverter",
     "key.converter.schemas.enable": "false",
-    "value.converter.schemas.enable": "false",
+    "value.converter.schemas.enable": "true",
     "snapshot.mode": "initial",
     "binlog.buffer.size": "8192",
     "max.batch.size": "2048",
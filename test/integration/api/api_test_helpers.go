@@ -3,17 +3,23 @@ package main
 import (
 	"bytes"
 	"encoding/json"
+	"log"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/api/handlers"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/api/middleware"
 	routes "github.com/TIA-PARTNERS-GROUP/tia-api/internal/api/routes"
 	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/constants"
 	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/core/services"
 	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/models"
 	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/ports"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/pkg/storage"
 	"github.com/TIA-PARTNERS-GROUP/tia-api/pkg/utils"
 	testutil "github.com/TIA-PARTNERS-GROUP/tia-api/test/test_util"
 	"github.com/gin-gonic/gin"
@@ -24,9 +30,13 @@ func SetupRouter() *gin.Engine {
 
 	userService := services.NewUserService(testutil.TestDB)
 	authService := services.NewAuthService(testutil.TestDB)
-	businessService := services.NewBusinessService(testutil.TestDB)
+	businessService := services.NewBusinessService(testutil.TestDB, testutil.TestGeocoder)
 	businessConnectionService := services.NewBusinessConnectionService(testutil.TestDB)
 	businessTagService := services.NewBusinessTagService(testutil.TestDB)
+	businessMemberService := services.NewBusinessMemberService(testutil.TestDB)
+	introductionRequestService := services.NewIntroductionRequestService(testutil.TestDB)
+	referralService := services.NewReferralService(testutil.TestDB)
+	businessVerificationService := services.NewBusinessVerificationService(testutil.TestDB, testutil.TestResolver)
 	dailyActivityService := services.NewDailyActivityService(testutil.TestDB)
 	dailyActivityEnrolmentService := services.NewDailyActivityEnrolmentService(testutil.TestDB)
 	eventService := services.NewEventService(testutil.TestDB)
@@ -40,6 +50,13 @@ func SetupRouter() *gin.Engine {
 	projectRegionService := services.NewProjectRegionService(testutil.TestDB)       
 	projectSkillService := services.NewProjectSkillService(testutil.TestDB)         
 	publicationService := services.NewPublicationService(testutil.TestDB)           
+	publicationCommentService := services.NewPublicationCommentService(testutil.TestDB)
+	publicationReactionService := services.NewPublicationReactionService(testutil.TestDB)
+	mediaStorage, err := storage.NewLocalStorage(filepath.Join(os.TempDir(), "tia-api-test-media"))
+	if err != nil {
+		log.Fatalf("Failed to create test media storage: %v", err)
+	}
+	mediaService := services.NewMediaService(testutil.TestDB, mediaStorage, 2<<20)
 	skillService := services.NewSkillService(testutil.TestDB)                       
 	subscriptionService := services.NewSubscriptionService(testutil.TestDB)         
 	userSubscriptionService := services.NewUserSubscriptionService(testutil.TestDB) 
@@ -49,8 +66,12 @@ func SetupRouter() *gin.Engine {
 	userHandler := handlers.NewUserHandler(userService, &constants.AppRoutes)
 	authHandler := handlers.NewAuthHandler(authService, &constants.AppRoutes)
 	businessHandler := handlers.NewBusinessHandler(businessService, &constants.AppRoutes)
-	businessConnectionHandler := handlers.NewBusinessConnectionHandler(businessConnectionService, &constants.AppRoutes)
-	businessTagHandler := handlers.NewBusinessTagHandler(businessTagService, &constants.AppRoutes)
+	businessConnectionHandler := handlers.NewBusinessConnectionHandler(businessConnectionService, businessMemberService, &constants.AppRoutes)
+	businessTagHandler := handlers.NewBusinessTagHandler(businessTagService, businessMemberService, &constants.AppRoutes)
+	businessMemberHandler := handlers.NewBusinessMemberHandler(businessMemberService, &constants.AppRoutes)
+	introductionRequestHandler := handlers.NewIntroductionRequestHandler(introductionRequestService, &constants.AppRoutes)
+	referralHandler := handlers.NewReferralHandler(referralService, &constants.AppRoutes)
+	businessVerificationHandler := handlers.NewBusinessVerificationHandler(businessVerificationService, &constants.AppRoutes)
 	dailyActivityHandler := handlers.NewDailyActivityHandler(dailyActivityService, &constants.AppRoutes)
 	dailyActivityEnrolmentHandler := handlers.NewDailyActivityEnrolmentHandler(dailyActivityEnrolmentService, &constants.AppRoutes)
 	eventHandler := handlers.NewEventHandler(eventService, &constants.AppRoutes)
@@ -64,6 +85,10 @@ func SetupRouter() *gin.Engine {
 	projectRegionHandler := handlers.NewProjectRegionHandler(projectRegionService, projectService, &constants.AppRoutes)          
 	projectSkillHandler := handlers.NewProjectSkillHandler(projectSkillService, projectService, &constants.AppRoutes)             
 	publicationHandler := handlers.NewPublicationHandler(publicationService, &constants.AppRoutes)                                
+	publicationCommentHandler := handlers.NewPublicationCommentHandler(publicationCommentService, publicationService, &constants.AppRoutes)
+	publicationReactionHandler := handlers.NewPublicationReactionHandler(publicationReactionService, publicationService, &constants.AppRoutes)
+	publicationFeedHandler := handlers.NewPublicationFeedHandler(publicationService, "https://example.test", time.Minute, &constants.AppRoutes)
+	mediaHandler := handlers.NewMediaHandler(mediaService, &constants.AppRoutes)
 	skillHandler := handlers.NewSkillHandler(skillService, &constants.AppRoutes)                                                  
 	subscriptionHandler := handlers.NewSubscriptionHandler(subscriptionService, &constants.AppRoutes)                             
 	userSubscriptionHandler := handlers.NewUserSubscriptionHandler(userSubscriptionService, &constants.AppRoutes)                 
@@ -98,12 +123,17 @@ func SetupRouter() *gin.Engine {
 
 	deps := &routes.RouterDependencies{
 		AuthMiddleware:                authMiddlewareForTest,
+		OptionalAuthMiddleware:        middleware.OptionalAuthMiddleware(authService, &constants.AppRoutes),
 		UserHandler:                   userHandler,
 		AuthHandler:                   authHandler,
 		BusinessHandler:               businessHandler,
 		ProjectHandler:                projectHandler,
 		BusinessConnectionHandler:     businessConnectionHandler,
 		BusinessTagHandler:            businessTagHandler,
+		BusinessMemberHandler:         businessMemberHandler,
+		IntroductionRequestHandler:    introductionRequestHandler,
+		ReferralHandler:               referralHandler,
+		BusinessVerificationHandler:   businessVerificationHandler,
 		DailyActivityHandler:          dailyActivityHandler,
 		DailyActivityEnrolmentHandler: dailyActivityEnrolmentHandler,
 		EventHandler:                  eventHandler,
@@ -116,6 +146,10 @@ func SetupRouter() *gin.Engine {
 		ProjectRegionHandler:          projectRegionHandler,    
 		ProjectSkillHandler:           projectSkillHandler,     
 		PublicationHandler:            publicationHandler,      
+		PublicationCommentHandler:     publicationCommentHandler,
+		PublicationReactionHandler:    publicationReactionHandler,
+		PublicationFeedHandler:        publicationFeedHandler,
+		MediaHandler:                  mediaHandler,
 		SkillHandler:                  skillHandler,            
 		SubscriptionHandler:           subscriptionHandler,     
 		UserSubscriptionHandler:       userSubscriptionHandler, 
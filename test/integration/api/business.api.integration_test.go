@@ -42,6 +42,13 @@ func TestBusinessAPI_Integration_CRUD(t *testing.T) {
 	w = httptest.NewRecorder()
 	router.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusOK, w.Code)
+	var fetchedBusiness ports.BusinessResponse
+	json.Unmarshal(w.Body.Bytes(), &fetchedBusiness)
+	if assert.NotNil(t, fetchedBusiness.Completeness) {
+		assert.Equal(t, 0, fetchedBusiness.Completeness.Score)
+		assert.Contains(t, fetchedBusiness.Completeness.Missing, ports.CompletenessTagline)
+		assert.Contains(t, fetchedBusiness.Completeness.Missing, ports.CompletenessServiceTags)
+	}
 	
 	req, _ = http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/%d", constApiBase, createdBusiness.ID), nil)
 	req.Header.Set("Authorization", "Bearer "+token)
@@ -54,3 +61,48 @@ func TestBusinessAPI_Integration_CRUD(t *testing.T) {
 	router.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusNotFound, w.Code)
 }
+func TestBusinessAPI_Integration_NearSearch(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	router := SetupRouter()
+	user, token := CreateTestUserAndLogin(t, router, "geoowner@api.com", "ValidPassword123!")
+	constApiBase := constants.AppRoutes.APIPrefix + constants.AppRoutes.BusinessBase
+	for _, postcode := range []string{"3000", "2026", "2000"} {
+		code := postcode
+		createDTO := ports.CreateBusinessInput{
+			OperatorUserID:   user.ID,
+			Name:             "Geo Corp " + code,
+			PostalCode:       &code,
+			BusinessType:     models.BusinessTypeServices,
+			BusinessCategory: models.BusinessCategoryB2B,
+			BusinessPhase:    models.BusinessPhaseGrowth,
+		}
+		body, _ := json.Marshal(createDTO)
+		req, _ := http.NewRequest(http.MethodPost, constApiBase, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	}
+	
+	req, _ := http.NewRequest(http.MethodGet, constApiBase+"?near=-33.8688,151.2093&radius_km=20", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var businesses []ports.BusinessResponse
+	json.Unmarshal(w.Body.Bytes(), &businesses)
+	if assert.Len(t, businesses, 2) {
+		assert.Equal(t, "Geo Corp 2000", businesses[0].Name)
+		assert.Equal(t, "Geo Corp 2026", businesses[1].Name)
+		if assert.NotNil(t, businesses[1].DistanceKm) {
+			assert.InDelta(t, 6.6, *businesses[1].DistanceKm, 1)
+		}
+	}
+	
+	for _, query := range []string{"?near=sydney", "?near=-33.8688,151.2093&radius_km=0", "?radius_km=10"} {
+		req, _ = http.NewRequest(http.MethodGet, constApiBase+query, nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
@@ -72,3 +72,219 @@ func TestBusinessConnectionAPI_Integration_Lifecycle(t *testing.T) {
 		assert.Equal(t, models.ConnectionStatusActive, acceptedConn.Status)
 	})
 }
+func TestBusinessConnectionAPI_Integration_Authorization(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	router := SetupRouter()
+	userA, tokenA := CreateTestUserAndLogin(t, router, "policya@conn.com", "ValidPass123!")
+	userB, tokenB := CreateTestUserAndLogin(t, router, "policyb@conn.com", "ValidPass123!")
+	_, tokenC := CreateTestUserAndLogin(t, router, "policyc@conn.com", "ValidPass123!")
+	bizA := models.Business{Name: "Policy Biz A", OperatorUserID: userA.ID, BusinessType: "Other", BusinessCategory: "Mixed", BusinessPhase: "Growth"}
+	testutil.TestDB.Create(&bizA)
+	bizB := models.Business{Name: "Policy Biz B", OperatorUserID: userB.ID, BusinessType: "Other", BusinessCategory: "Mixed", BusinessPhase: "Growth"}
+	testutil.TestDB.Create(&bizB)
+	constConnectBase := constants.AppRoutes.APIPrefix + constants.AppRoutes.ConnectBase
+	send := func(method, url, token string, body []byte) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, url, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	connectionURL := func(route string, id uint) string {
+		return constConnectBase + strings.Replace(route, ":id", fmt.Sprintf("%d", id), 1)
+	}
+	createBody := func(from, to uint, connType models.BusinessConnectionType) []byte {
+		body, _ := json.Marshal(map[string]interface{}{
+			"initiating_business_id": from,
+			"receiving_business_id":  to,
+			"connection_type":        connType,
+			"initiated_by_user_id":   userB.ID,
+		})
+		return body
+	}
+	var pending ports.BusinessConnectionResponse
+	t.Run("Create Requires Initiating Business Admin", func(t *testing.T) {
+		w := send(http.MethodPost, constConnectBase, tokenC, createBody(bizA.ID, bizB.ID, models.ConnectionTypePartnership))
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		w = send(http.MethodPost, constConnectBase, tokenA, createBody(bizA.ID, bizB.ID, models.ConnectionTypePartnership))
+		assert.Equal(t, http.StatusCreated, w.Code)
+		json.Unmarshal(w.Body.Bytes(), &pending)
+		assert.Equal(t, userA.ID, pending.InitiatedByUserID, "initiator comes from the token, not the body")
+	})
+	t.Run("Only Receiver Can Respond", func(t *testing.T) {
+		w := send(http.MethodPost, connectionURL(constants.AppRoutes.ConnectAccept, pending.ID), tokenA, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		w = send(http.MethodPost, connectionURL(constants.AppRoutes.ConnectReject, pending.ID), tokenA, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		w = send(http.MethodPost, connectionURL(constants.AppRoutes.ConnectAccept, pending.ID), tokenC, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		w = send(http.MethodPut, connectionURL(constants.AppRoutes.ParamID, pending.ID), tokenB, []byte(`{"status":"active"}`))
+		assert.Equal(t, http.StatusBadRequest, w.Code, "status changes only go through the lifecycle endpoints")
+		var stored models.BusinessConnection
+		testutil.TestDB.First(&stored, pending.ID)
+		assert.Equal(t, models.ConnectionStatusPending, stored.Status)
+	})
+	t.Run("Only Initiator Can Withdraw", func(t *testing.T) {
+		w := send(http.MethodDelete, connectionURL(constants.AppRoutes.ParamID, pending.ID), tokenB, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		w = send(http.MethodDelete, connectionURL(constants.AppRoutes.ParamID, pending.ID), tokenC, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		w = send(http.MethodDelete, connectionURL(constants.AppRoutes.ParamID, pending.ID), tokenA, nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+	t.Run("Either Side Can Deactivate", func(t *testing.T) {
+		w := send(http.MethodPost, constConnectBase, tokenA, createBody(bizA.ID, bizB.ID, models.ConnectionTypeSupplier))
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var conn ports.BusinessConnectionResponse
+		json.Unmarshal(w.Body.Bytes(), &conn)
+		w = send(http.MethodPost, connectionURL(constants.AppRoutes.ConnectAccept, conn.ID), tokenB, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		w = send(http.MethodPost, connectionURL(constants.AppRoutes.ConnectDeactivate, conn.ID), tokenC, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		w = send(http.MethodPost, connectionURL(constants.AppRoutes.ConnectDeactivate, conn.ID), tokenA, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		w = send(http.MethodDelete, connectionURL(constants.AppRoutes.ParamID, conn.ID), tokenB, nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+}
+func TestBusinessConnectionAPI_Integration_LifecycleEndpoints(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	router := SetupRouter()
+	userA, tokenA := CreateTestUserAndLogin(t, router, "lifea@conn.com", "ValidPass123!")
+	userB, tokenB := CreateTestUserAndLogin(t, router, "lifeb@conn.com", "ValidPass123!")
+	_, tokenC := CreateTestUserAndLogin(t, router, "lifec@conn.com", "ValidPass123!")
+	bizA := models.Business{Name: "Life Biz A", OperatorUserID: userA.ID, BusinessType: "Other", BusinessCategory: "Mixed", BusinessPhase: "Growth"}
+	testutil.TestDB.Create(&bizA)
+	bizB := models.Business{Name: "Life Biz B", OperatorUserID: userB.ID, BusinessType: "Other", BusinessCategory: "Mixed", BusinessPhase: "Growth"}
+	testutil.TestDB.Create(&bizB)
+	constConnectBase := constants.AppRoutes.APIPrefix + constants.AppRoutes.ConnectBase
+	send := func(method, url, token string, body []byte) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, url, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	connectionURL := func(route string, id uint) string {
+		return constConnectBase + strings.Replace(route, ":id", fmt.Sprintf("%d", id), 1)
+	}
+	createBody := func(from, to uint) []byte {
+		body, _ := json.Marshal(map[string]interface{}{
+			"initiating_business_id": from,
+			"receiving_business_id":  to,
+			"connection_type":        models.ConnectionTypePartnership,
+		})
+		return body
+	}
+	var conn ports.BusinessConnectionResponse
+	t.Run("Withdraw And Re-Request", func(t *testing.T) {
+		w := send(http.MethodPost, constConnectBase, tokenA, createBody(bizA.ID, bizB.ID))
+		assert.Equal(t, http.StatusCreated, w.Code)
+		json.Unmarshal(w.Body.Bytes(), &conn)
+		w = send(http.MethodPost, constConnectBase, tokenB, createBody(bizB.ID, bizA.ID))
+		assert.Equal(t, http.StatusConflict, w.Code)
+		w = send(http.MethodPost, connectionURL(constants.AppRoutes.ConnectWithdraw, conn.ID), tokenB, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		w = send(http.MethodPost, connectionURL(constants.AppRoutes.ConnectWithdraw, conn.ID), tokenA, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		w = send(http.MethodPost, constConnectBase, tokenB, createBody(bizB.ID, bizA.ID))
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var reopened ports.BusinessConnectionResponse
+		json.Unmarshal(w.Body.Bytes(), &reopened)
+		assert.Equal(t, conn.ID, reopened.ID)
+		assert.Equal(t, bizB.ID, reopened.InitiatingBusiness.ID)
+	})
+	t.Run("Deactivate And Reactivate", func(t *testing.T) {
+		w := send(http.MethodPost, connectionURL(constants.AppRoutes.ConnectDeactivate, conn.ID), tokenA, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		w = send(http.MethodPost, connectionURL(constants.AppRoutes.ConnectAccept, conn.ID), tokenA, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		w = send(http.MethodPost, connectionURL(constants.AppRoutes.ConnectDeactivate, conn.ID), tokenC, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		w = send(http.MethodPost, connectionURL(constants.AppRoutes.ConnectDeactivate, conn.ID), tokenA, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		w = send(http.MethodPost, connectionURL(constants.AppRoutes.ConnectReactivate, conn.ID), tokenA, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var reactivated ports.BusinessConnectionResponse
+		json.Unmarshal(w.Body.Bytes(), &reactivated)
+		assert.Equal(t, models.ConnectionStatusPending, reactivated.Status)
+		assert.Equal(t, bizA.ID, reactivated.InitiatingBusiness.ID)
+		w = send(http.MethodPost, connectionURL(constants.AppRoutes.ConnectReactivate, conn.ID), tokenA, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("History", func(t *testing.T) {
+		w := send(http.MethodGet, connectionURL(constants.AppRoutes.ConnectHistory, conn.ID), tokenC, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		w = send(http.MethodGet, connectionURL(constants.AppRoutes.ConnectHistory, conn.ID), tokenB, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var history ports.BusinessConnectionHistoriesResponse
+		json.Unmarshal(w.Body.Bytes(), &history)
+		assert.Equal(t, 6, history.Count)
+		assert.Equal(t, models.ConnectionStatusPending, history.History[history.Count-1].ToStatus)
+	})
+	t.Run("Rejected Connection Cannot Be Deleted", func(t *testing.T) {
+		w := send(http.MethodPost, connectionURL(constants.AppRoutes.ConnectReject, conn.ID), tokenB, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		w = send(http.MethodDelete, connectionURL(constants.AppRoutes.ParamID, conn.ID), tokenA, nil)
+		assert.Equal(t, http.StatusConflict, w.Code)
+		var stored models.BusinessConnection
+		assert.NoError(t, testutil.TestDB.First(&stored, conn.ID).Error)
+		assert.Equal(t, models.ConnectionStatusRejected, stored.Status)
+	})
+}
+func TestBusinessConnectionAPI_Integration_Network(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	router := SetupRouter()
+	user, token := CreateTestUserAndLogin(t, router, "network@conn.com", "ValidPass123!")
+	bizA := models.Business{Name: "Network Biz A", OperatorUserID: user.ID, BusinessType: "Other", BusinessCategory: "Mixed", BusinessPhase: "Growth"}
+	testutil.TestDB.Create(&bizA)
+	bizB := models.Business{Name: "Network Biz B", OperatorUserID: user.ID, BusinessType: "Other", BusinessCategory: "Mixed", BusinessPhase: "Growth"}
+	testutil.TestDB.Create(&bizB)
+	bizC := models.Business{Name: "Network Biz C", OperatorUserID: user.ID, BusinessType: "Other", BusinessCategory: "Mixed", BusinessPhase: "Growth"}
+	testutil.TestDB.Create(&bizC)
+	testutil.TestDB.Create(&models.BusinessConnection{InitiatingBusinessID: bizA.ID, ReceivingBusinessID: bizB.ID, ConnectionType: models.ConnectionTypeClient, Status: models.ConnectionStatusActive, InitiatedByUserID: user.ID})
+	testutil.TestDB.Create(&models.BusinessConnection{InitiatingBusinessID: bizB.ID, ReceivingBusinessID: bizC.ID, ConnectionType: models.ConnectionTypeSupplier, Status: models.ConnectionStatusActive, InitiatedByUserID: user.ID})
+	networkURL := constants.AppRoutes.APIPrefix + constants.AppRoutes.BusinessBase + strings.Replace(constants.AppRoutes.BusinessNetwork, ":id", fmt.Sprintf("%d", bizA.ID), 1)
+	get := func(url, token string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodGet, url, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	t.Run("Default Depth", func(t *testing.T) {
+		w := get(networkURL, token)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var network ports.BusinessNetworkResponse
+		json.Unmarshal(w.Body.Bytes(), &network)
+		assert.Equal(t, 2, network.Depth)
+		assert.Len(t, network.Nodes, 3)
+		assert.Len(t, network.Edges, 2)
+		for _, node := range network.Nodes {
+			switch node.BusinessID {
+			case bizA.ID:
+				assert.Equal(t, 0, node.Depth)
+				assert.Empty(t, node.Via)
+			case bizC.ID:
+				assert.Equal(t, []uint{bizB.ID}, node.Via)
+			}
+		}
+	})
+	t.Run("Single Hop", func(t *testing.T) {
+		w := get(networkURL+"?depth=1", token)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var network ports.BusinessNetworkResponse
+		json.Unmarshal(w.Body.Bytes(), &network)
+		assert.Len(t, network.Nodes, 2)
+		assert.Len(t, network.Edges, 1)
+	})
+	t.Run("Invalid Requests", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, get(networkURL+"?depth=9", token).Code)
+		assert.Equal(t, http.StatusBadRequest, get(networkURL+"?depth=abc", token).Code)
+		assert.Equal(t, http.StatusUnauthorized, get(networkURL, "").Code)
+	})
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/constants"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/models"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/ports"
+	testutil "github.com/TIA-PARTNERS-GROUP/tia-api/test/test_util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBusinessMemberAPI_Integration_TeamLifecycle(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	router := SetupRouter()
+
+	constBizBase := constants.AppRoutes.APIPrefix + constants.AppRoutes.BusinessBase
+	constInviteBase := constants.AppRoutes.APIPrefix + constants.AppRoutes.BusinessInviteBase
+	constTagBase := constants.AppRoutes.APIPrefix + constants.AppRoutes.TagsBase
+
+	owner, ownerToken := CreateTestUserAndLogin(t, router, "team.owner@test.com", "ValidPass123!")
+	member, memberToken := CreateTestUserAndLogin(t, router, "team.member@test.com", "ValidPass123!")
+	_, outsiderToken := CreateTestUserAndLogin(t, router, "team.outsider@test.com", "ValidPass123!")
+
+	biz := models.Business{Name: "Team Biz", OperatorUserID: owner.ID, BusinessType: "Other", BusinessCategory: "Mixed", BusinessPhase: "Growth"}
+	testutil.TestDB.Create(&biz)
+	testutil.TestDB.Create(&models.BusinessMember{BusinessID: biz.ID, UserID: owner.ID, Role: models.BusinessRoleOwner})
+	bizPath := func(route string) string {
+		return constBizBase + strings.Replace(route, ":id", fmt.Sprintf("%d", biz.ID), 1)
+	}
+
+	doJSON := func(method, url, token string, payload interface{}) *httptest.ResponseRecorder {
+		var body *bytes.Buffer
+		if payload != nil {
+			data, _ := json.Marshal(payload)
+			body = bytes.NewBuffer(data)
+		} else {
+			body = bytes.NewBuffer(nil)
+		}
+		req, _ := http.NewRequest(method, url, body)
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	var invite ports.BusinessInviteResponse
+
+	t.Run("Invite Member", func(t *testing.T) {
+		input := ports.CreateBusinessInviteInput{Email: "team.member@test.com", Role: models.BusinessRoleViewer}
+		w := doJSON(http.MethodPost, bizPath(constants.AppRoutes.BusinessInvites), outsiderToken, input)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = doJSON(http.MethodPost, bizPath(constants.AppRoutes.BusinessInvites), ownerToken, input)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		json.Unmarshal(w.Body.Bytes(), &invite)
+		assert.Equal(t, models.BusinessInvitePending, invite.Status)
+
+		w = doJSON(http.MethodPost, bizPath(constants.AppRoutes.BusinessInvites), ownerToken, input)
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		w = doJSON(http.MethodPost, bizPath(constants.AppRoutes.BusinessInvites), "", input)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("Accept Invite", func(t *testing.T) {
+		w := doJSON(http.MethodGet, constInviteBase, memberToken, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var mine ports.BusinessInvitesResponse
+		json.Unmarshal(w.Body.Bytes(), &mine)
+		require.Equal(t, 1, mine.Count)
+		assert.Equal(t, "Team Biz", mine.Invites[0].BusinessName)
+
+		acceptPath := constInviteBase + strings.Replace(constants.AppRoutes.ConnectAccept, ":id", fmt.Sprintf("%d", invite.ID), 1)
+		w = doJSON(http.MethodPost, acceptPath, outsiderToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = doJSON(http.MethodPost, acceptPath, memberToken, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = doJSON(http.MethodPost, acceptPath, memberToken, nil)
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		w = doJSON(http.MethodGet, bizPath(constants.AppRoutes.BusinessMembers), memberToken, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var members ports.BusinessMembersResponse
+		json.Unmarshal(w.Body.Bytes(), &members)
+		assert.Equal(t, 2, members.Count)
+
+		w = doJSON(http.MethodGet, bizPath(constants.AppRoutes.BusinessMembers), outsiderToken, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Viewer Cannot Tag Until Promoted", func(t *testing.T) {
+		tagInput := ports.CreateBusinessTagInput{TagType: models.BusinessTagService, Description: "Team Tag"}
+		w := doJSON(http.MethodPost, bizPath(constants.AppRoutes.BusinessTags), memberToken, tagInput)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		memberPath := bizPath(strings.Replace(constants.AppRoutes.BusinessMember, ":userID", fmt.Sprintf("%d", member.ID), 1))
+		w = doJSON(http.MethodPut, memberPath, ownerToken, ports.UpdateBusinessMemberRoleInput{Role: "owner"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = doJSON(http.MethodPut, memberPath, ownerToken, ports.UpdateBusinessMemberRoleInput{Role: models.BusinessRoleEditor})
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = doJSON(http.MethodPost, bizPath(constants.AppRoutes.BusinessTags), memberToken, tagInput)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var tag ports.BusinessTagResponse
+		json.Unmarshal(w.Body.Bytes(), &tag)
+
+		w = doJSON(http.MethodDelete, fmt.Sprintf("%s/%d", constTagBase, tag.ID), outsiderToken, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		w = doJSON(http.MethodDelete, fmt.Sprintf("%s/%d", constTagBase, tag.ID), memberToken, nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("Transfer Ownership", func(t *testing.T) {
+		transferPath := bizPath(constants.AppRoutes.BusinessTransfer)
+		w := doJSON(http.MethodPost, transferPath, memberToken, ports.TransferBusinessOwnershipInput{UserID: member.ID})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = doJSON(http.MethodPost, transferPath, ownerToken, ports.TransferBusinessOwnershipInput{UserID: member.ID})
+		assert.Equal(t, http.StatusOK, w.Code)
+		var business ports.BusinessResponse
+		json.Unmarshal(w.Body.Bytes(), &business)
+		assert.Equal(t, member.ID, business.OperatorUserID)
+
+		ownerPath := bizPath(strings.Replace(constants.AppRoutes.BusinessMember, ":userID", fmt.Sprintf("%d", owner.ID), 1))
+		w = doJSON(http.MethodDelete, ownerPath, ownerToken, nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+
+		w = doJSON(http.MethodGet, bizPath(constants.AppRoutes.BusinessMembers), ownerToken, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
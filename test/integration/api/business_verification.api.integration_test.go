@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/constants"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/models"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/ports"
+	testutil "github.com/TIA-PARTNERS-GROUP/tia-api/test/test_util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBusinessVerificationAPI_Integration_Workflow(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	testutil.TestResolver.Reset()
+	router := SetupRouter()
+
+	constBizBase := constants.AppRoutes.APIPrefix + constants.AppRoutes.BusinessBase
+	constVerificationBase := constants.AppRoutes.APIPrefix + constants.AppRoutes.VerificationBase
+
+	owner, ownerToken := CreateTestUserAndLogin(t, router, "owner@verifyapi.com", "ValidPass123!")
+	admin, adminToken := CreateTestUserAndLogin(t, router, "admin@verifyapi.com", "ValidPass123!")
+	testutil.TestDB.Model(&models.User{}).Where("id = ?", admin.ID).Update("platform_admin", true)
+
+	website := "https://verifyapi.com"
+	business := models.Business{Name: "Verify API", OperatorUserID: owner.ID, Website: &website, BusinessType: "Other", BusinessCategory: "Mixed", BusinessPhase: "Growth"}
+	testutil.TestDB.Create(&business)
+
+	doJSON := func(method, url, token string, payload interface{}) *httptest.ResponseRecorder {
+		body := bytes.NewBuffer(nil)
+		if payload != nil {
+			data, _ := json.Marshal(payload)
+			body = bytes.NewBuffer(data)
+		}
+		req, _ := http.NewRequest(method, url, body)
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	bizPath := func(route string) string {
+		return constBizBase + strings.Replace(route, ":id", fmt.Sprintf("%d", business.ID), 1)
+	}
+	verificationPath := func(route string, id uint) string {
+		return constVerificationBase + strings.Replace(route, ":id", fmt.Sprintf("%d", id), 1)
+	}
+
+	var request ports.BusinessVerificationResponse
+
+	t.Run("Request And Check", func(t *testing.T) {
+		w := doJSON(http.MethodPost, bizPath(constants.AppRoutes.BusinessVerify), ownerToken, ports.RequestVerificationInput{Method: "carrier_pigeon"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = doJSON(http.MethodPost, bizPath(constants.AppRoutes.BusinessVerify), ownerToken, ports.RequestVerificationInput{Method: models.VerificationMethodDNS})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		json.Unmarshal(w.Body.Bytes(), &request)
+		require.NotNil(t, request.TXTRecordValue)
+
+		w = doJSON(http.MethodPost, bizPath(constants.AppRoutes.BusinessVerCheck), ownerToken, nil)
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+		testutil.TestResolver.SetTXT("verifyapi.com", *request.TXTRecordValue)
+		w = doJSON(http.MethodPost, bizPath(constants.AppRoutes.BusinessVerCheck), ownerToken, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		json.Unmarshal(w.Body.Bytes(), &request)
+		assert.Equal(t, models.VerificationPendingReview, request.Status)
+		assert.Nil(t, request.TXTRecordValue)
+	})
+
+	t.Run("Admin Review", func(t *testing.T) {
+		w := doJSON(http.MethodGet, constVerificationBase, ownerToken, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = doJSON(http.MethodGet, constVerificationBase, adminToken, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var queue ports.BusinessVerificationsResponse
+		json.Unmarshal(w.Body.Bytes(), &queue)
+		assert.Equal(t, 1, queue.Count)
+
+		w = doJSON(http.MethodPost, verificationPath(constants.AppRoutes.VerifyApprove, request.ID), adminToken, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = doJSON(http.MethodGet, fmt.Sprintf("%s/%d", constBizBase, business.ID), "", nil)
+		var fetched ports.BusinessResponse
+		json.Unmarshal(w.Body.Bytes(), &fetched)
+		assert.True(t, fetched.Verified)
+		assert.NotNil(t, fetched.VerifiedAt)
+
+		w = doJSON(http.MethodGet, constBizBase+"?verified=true", "", nil)
+		var verified []ports.BusinessResponse
+		json.Unmarshal(w.Body.Bytes(), &verified)
+		assert.Len(t, verified, 1)
+	})
+
+	t.Run("Revoke", func(t *testing.T) {
+		w := doJSON(http.MethodPost, bizPath(constants.AppRoutes.BusinessVerRevoke), adminToken, ports.RevokeVerificationInput{})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = doJSON(http.MethodPost, bizPath(constants.AppRoutes.BusinessVerRevoke), ownerToken, ports.RevokeVerificationInput{Reason: "Not allowed"})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = doJSON(http.MethodPost, bizPath(constants.AppRoutes.BusinessVerRevoke), adminToken, ports.RevokeVerificationInput{Reason: "Misrepresented ownership"})
+		assert.Equal(t, http.StatusOK, w.Code)
+		json.Unmarshal(w.Body.Bytes(), &request)
+		assert.Equal(t, models.VerificationRevoked, request.Status)
+
+		w = doJSON(http.MethodGet, bizPath(constants.AppRoutes.BusinessVerify), ownerToken, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/constants"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/models"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/ports"
+	testutil "github.com/TIA-PARTNERS-GROUP/tia-api/test/test_util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntroductionRequestAPI_Integration_Workflow(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	router := SetupRouter()
+
+	constBizBase := constants.AppRoutes.APIPrefix + constants.AppRoutes.BusinessBase
+	constIntroBase := constants.AppRoutes.APIPrefix + constants.AppRoutes.IntroductionBase
+
+	requester, requesterToken := CreateTestUserAndLogin(t, router, "requester@intro.com", "ValidPass123!")
+	introducer, introducerToken := CreateTestUserAndLogin(t, router, "introducer@intro.com", "ValidPass123!")
+	target, targetToken := CreateTestUserAndLogin(t, router, "target@intro.com", "ValidPass123!")
+
+	bizR := models.Business{Name: "Intro Requester", OperatorUserID: requester.ID, BusinessType: "Other", BusinessCategory: "Mixed", BusinessPhase: "Growth"}
+	testutil.TestDB.Create(&bizR)
+	bizI := models.Business{Name: "Intro Introducer", OperatorUserID: introducer.ID, BusinessType: "Other", BusinessCategory: "Mixed", BusinessPhase: "Growth"}
+	testutil.TestDB.Create(&bizI)
+	bizT := models.Business{Name: "Intro Target", OperatorUserID: target.ID, BusinessType: "Other", BusinessCategory: "Mixed", BusinessPhase: "Growth"}
+	testutil.TestDB.Create(&bizT)
+	testutil.TestDB.Create(&models.BusinessConnection{InitiatingBusinessID: bizR.ID, ReceivingBusinessID: bizI.ID, ConnectionType: models.ConnectionTypePartnership, Status: models.ConnectionStatusActive, InitiatedByUserID: requester.ID})
+	testutil.TestDB.Create(&models.BusinessConnection{InitiatingBusinessID: bizI.ID, ReceivingBusinessID: bizT.ID, ConnectionType: models.ConnectionTypeSupplier, Status: models.ConnectionStatusActive, InitiatedByUserID: introducer.ID})
+
+	doJSON := func(method, url, token string, payload interface{}) *httptest.ResponseRecorder {
+		body := bytes.NewBuffer(nil)
+		if payload != nil {
+			data, _ := json.Marshal(payload)
+			body = bytes.NewBuffer(data)
+		}
+		req, _ := http.NewRequest(method, url, body)
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	introPath := func(route string, id uint) string {
+		return constIntroBase + strings.Replace(route, ":id", fmt.Sprintf("%d", id), 1)
+	}
+
+	t.Run("Mutual Connections", func(t *testing.T) {
+		path := strings.Replace(constants.AppRoutes.BusinessMutual, ":id", fmt.Sprintf("%d", bizR.ID), 1)
+		path = strings.Replace(path, ":otherID", fmt.Sprintf("%d", bizT.ID), 1)
+		w := doJSON(http.MethodGet, constBizBase+path, requesterToken, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var mutual ports.MutualConnectionsResponse
+		json.Unmarshal(w.Body.Bytes(), &mutual)
+		require.Equal(t, 1, mutual.Count)
+		assert.Equal(t, bizI.ID, mutual.Mutual[0].ID)
+	})
+
+	var intro ports.IntroductionRequestResponse
+	input := ports.CreateIntroductionRequestInput{RequesterBusinessID: bizR.ID, IntroducerBusinessID: bizI.ID, TargetBusinessID: bizT.ID}
+
+	t.Run("Request Introduction", func(t *testing.T) {
+		w := doJSON(http.MethodPost, constIntroBase, targetToken, input)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = doJSON(http.MethodPost, constIntroBase, requesterToken, input)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		json.Unmarshal(w.Body.Bytes(), &intro)
+		assert.Equal(t, models.IntroPendingIntroducer, intro.Status)
+
+		w = doJSON(http.MethodPost, constIntroBase, requesterToken, input)
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		w = doJSON(http.MethodGet, introPath(constants.AppRoutes.ParamID, intro.ID), targetToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Introducer Forwards", func(t *testing.T) {
+		w := doJSON(http.MethodPost, introPath(constants.AppRoutes.ConnectAccept, intro.ID), requesterToken, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = doJSON(http.MethodPost, introPath(constants.AppRoutes.ConnectAccept, intro.ID), introducerToken, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		json.Unmarshal(w.Body.Bytes(), &intro)
+		assert.Equal(t, models.IntroPendingTarget, intro.Status)
+
+		intros := constBizBase + strings.Replace(constants.AppRoutes.BusinessIntros, ":id", fmt.Sprintf("%d", bizT.ID), 1)
+		w = doJSON(http.MethodGet, intros+"?status=pending_target", targetToken, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var list ports.IntroductionRequestsResponse
+		json.Unmarshal(w.Body.Bytes(), &list)
+		assert.Equal(t, 1, list.Count)
+	})
+
+	t.Run("Target Accepts", func(t *testing.T) {
+		w := doJSON(http.MethodPost, introPath(constants.AppRoutes.ConnectAccept, intro.ID), targetToken, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		json.Unmarshal(w.Body.Bytes(), &intro)
+		assert.Equal(t, models.IntroCompleted, intro.Status)
+		require.NotNil(t, intro.BusinessConnectionID)
+
+		w = doJSON(http.MethodGet, constants.AppRoutes.APIPrefix+constants.AppRoutes.ConnectBase+fmt.Sprintf("/%d", *intro.BusinessConnectionID), requesterToken, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var conn ports.BusinessConnectionResponse
+		json.Unmarshal(w.Body.Bytes(), &conn)
+		assert.Equal(t, models.ConnectionTypeReferral, conn.ConnectionType)
+		assert.Equal(t, models.ConnectionStatusActive, conn.Status)
+
+		w = doJSON(http.MethodPost, introPath(constants.AppRoutes.InviteDecline, intro.ID), targetToken, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
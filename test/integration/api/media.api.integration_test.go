@@ -0,0 +1,185 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/constants"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/models"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/ports"
+	testutil "github.com/TIA-PARTNERS-GROUP/tia-api/test/test_util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createMultipartImage(t *testing.T, filename string, data []byte, purpose string) (*bytes.Buffer, string) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", filename)
+	require.NoError(t, err)
+	part.Write(data)
+	if purpose != "" {
+		writer.WriteField("purpose", purpose)
+	}
+	require.NoError(t, writer.Close())
+	return body, writer.FormDataContentType()
+}
+
+func TestMediaAPI_Integration_UploadAndServe(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	router := SetupRouter()
+
+	constApiPrefix := constants.AppRoutes.APIPrefix
+	constMediaBase := constApiPrefix + constants.AppRoutes.MediaBase
+	constUserBase := constApiPrefix + constants.AppRoutes.UsersBase
+
+	owner, ownerToken := CreateTestUserAndLogin(t, router, "media.owner@test.com", "ValidPass123!")
+	other, otherToken := CreateTestUserAndLogin(t, router, "media.other@test.com", "ValidPass123!")
+
+	img := image.NewRGBA(image.Rect(0, 0, 640, 480))
+	for y := 0; y < 480; y++ {
+		for x := 0; x < 640; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 64, A: 255})
+		}
+	}
+	var pngData bytes.Buffer
+	require.NoError(t, png.Encode(&pngData, img))
+
+	var uploaded ports.MediaResponse
+
+	t.Run("Upload Media", func(t *testing.T) {
+		body, contentType := createMultipartImage(t, "avatar.png", pngData.Bytes(), string(models.MediaPurposeAvatar))
+		req, _ := http.NewRequest(http.MethodPost, constMediaBase, body)
+		req.Header.Set("Content-Type", contentType)
+		req.Header.Set("Authorization", "Bearer "+ownerToken)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		json.Unmarshal(w.Body.Bytes(), &uploaded)
+		assert.NotZero(t, uploaded.ID)
+		assert.Equal(t, owner.ID, uploaded.OwnerUserID)
+		assert.Equal(t, models.MediaPurposeAvatar, uploaded.Purpose)
+		assert.Equal(t, "image/png", uploaded.ContentType)
+		assert.Equal(t, ports.MediaURL(uploaded.ID, ports.MediaVariantOriginal), uploaded.URL)
+		names := []string{}
+		for _, v := range uploaded.Variants {
+			names = append(names, v.Name)
+		}
+		assert.Equal(t, []string{"thumb", "small"}, names)
+	})
+
+	t.Run("Upload Rejects Invalid Files", func(t *testing.T) {
+		body, contentType := createMultipartImage(t, "notes.png", []byte("plain text, not an image"), "")
+		req, _ := http.NewRequest(http.MethodPost, constMediaBase, body)
+		req.Header.Set("Content-Type", contentType)
+		req.Header.Set("Authorization", "Bearer "+ownerToken)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+
+		req2, _ := http.NewRequest(http.MethodPost, constMediaBase, bytes.NewBufferString("{}"))
+		req2.Header.Set("Content-Type", "application/json")
+		req2.Header.Set("Authorization", "Bearer "+ownerToken)
+		w2 := httptest.NewRecorder()
+		router.ServeHTTP(w2, req2)
+		assert.Equal(t, http.StatusBadRequest, w2.Code)
+
+		body3, contentType3 := createMultipartImage(t, "big.png", make([]byte, 5<<19), "")
+		req3, _ := http.NewRequest(http.MethodPost, constMediaBase, body3)
+		req3.Header.Set("Content-Type", contentType3)
+		req3.Header.Set("Authorization", "Bearer "+ownerToken)
+		w3 := httptest.NewRecorder()
+		router.ServeHTTP(w3, req3)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w3.Code)
+
+		body4, contentType4 := createMultipartImage(t, "avatar.png", pngData.Bytes(), "")
+		req4, _ := http.NewRequest(http.MethodPost, constMediaBase, body4)
+		req4.Header.Set("Content-Type", contentType4)
+		w4 := httptest.NewRecorder()
+		router.ServeHTTP(w4, req4)
+		assert.Equal(t, http.StatusUnauthorized, w4.Code)
+	})
+
+	t.Run("Get Media And Content", func(t *testing.T) {
+		url := fmt.Sprintf("%s/%d", constMediaBase, uploaded.ID)
+		req, _ := http.NewRequest(http.MethodGet, url, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		req2, _ := http.NewRequest(http.MethodGet, url+"/content?variant=thumb", nil)
+		w2 := httptest.NewRecorder()
+		router.ServeHTTP(w2, req2)
+		assert.Equal(t, http.StatusOK, w2.Code)
+		assert.Equal(t, "image/png", w2.Header().Get("Content-Type"))
+		assert.Equal(t, "nosniff", w2.Header().Get("X-Content-Type-Options"))
+		cfg, _, err := image.DecodeConfig(w2.Body)
+		require.NoError(t, err)
+		assert.Equal(t, 150, cfg.Width)
+		assert.Equal(t, 150, cfg.Height)
+
+		req3, _ := http.NewRequest(http.MethodGet, url+"/content?variant=huge", nil)
+		w3 := httptest.NewRecorder()
+		router.ServeHTTP(w3, req3)
+		assert.Equal(t, http.StatusBadRequest, w3.Code)
+
+		req4, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/%d/content", constMediaBase, uploaded.ID+999), nil)
+		w4 := httptest.NewRecorder()
+		router.ServeHTTP(w4, req4)
+		assert.Equal(t, http.StatusNotFound, w4.Code)
+	})
+
+	t.Run("Reference Media As Avatar", func(t *testing.T) {
+		updateDTO := fmt.Sprintf(`{"avatar_media_id": %d}`, uploaded.ID)
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/%d", constUserBase, other.ID), bytes.NewBufferString(updateDTO))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+otherToken)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		req2, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/%d", constUserBase, owner.ID), bytes.NewBufferString(updateDTO))
+		req2.Header.Set("Content-Type", "application/json")
+		req2.Header.Set("Authorization", "Bearer "+ownerToken)
+		w2 := httptest.NewRecorder()
+		router.ServeHTTP(w2, req2)
+		assert.Equal(t, http.StatusOK, w2.Code)
+		var updatedUser ports.UserResponse
+		json.Unmarshal(w2.Body.Bytes(), &updatedUser)
+		require.NotNil(t, updatedUser.AvatarURL)
+		assert.Equal(t, ports.MediaURL(uploaded.ID, "thumb"), *updatedUser.AvatarURL)
+	})
+
+	t.Run("Delete Media", func(t *testing.T) {
+		url := fmt.Sprintf("%s/%d", constMediaBase, uploaded.ID)
+		req, _ := http.NewRequest(http.MethodDelete, url, nil)
+		req.Header.Set("Authorization", "Bearer "+otherToken)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		req2, _ := http.NewRequest(http.MethodDelete, url, nil)
+		req2.Header.Set("Authorization", "Bearer "+ownerToken)
+		w2 := httptest.NewRecorder()
+		router.ServeHTTP(w2, req2)
+		assert.Equal(t, http.StatusNoContent, w2.Code)
+
+		var reloaded models.User
+		testutil.TestDB.First(&reloaded, owner.ID)
+		assert.Nil(t, reloaded.AvatarMediaID)
+
+		req3, _ := http.NewRequest(http.MethodGet, url, nil)
+		w3 := httptest.NewRecorder()
+		router.ServeHTTP(w3, req3)
+		assert.Equal(t, http.StatusNotFound, w3.Code)
+	})
+}
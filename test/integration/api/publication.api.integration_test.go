@@ -55,7 +55,12 @@ func TestPublicationAPI_Integration_CRUD(t *testing.T) {
 		req2.Header.Set("Authorization", "Bearer "+authorToken)
 		w2 := httptest.NewRecorder()
 		router.ServeHTTP(w2, req2)
-		assert.Equal(t, http.StatusConflict, w2.Code)
+		assert.Equal(t, http.StatusCreated, w2.Code)
+		var duplicateTitlePub ports.PublicationResponse
+		json.Unmarshal(w2.Body.Bytes(), &duplicateTitlePub)
+		assert.Equal(t, "my-first-great-article-2", duplicateTitlePub.Slug)
+		testutil.TestDB.Delete(&models.PublicationRevision{}, "publication_id = ?", duplicateTitlePub.ID)
+		testutil.TestDB.Delete(&models.Publication{}, duplicateTitlePub.ID)
 
 		createDTO.Title = "Other User's Article"
 		createDTO.UserID = 9999
@@ -169,3 +174,357 @@ func TestPublicationAPI_Integration_CRUD(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, w.Code)
 	})
 }
+
+func TestPublicationAPI_Integration_Revisions(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	router := SetupRouter()
+
+	constPubBase := constants.AppRoutes.APIPrefix + constants.AppRoutes.PublicationBase
+
+	authorUser, authorToken := CreateTestUserAndLogin(t, router, "rev.author@test.com", "ValidPass123!")
+	_, otherToken := CreateTestUserAndLogin(t, router, "rev.other@test.com", "ValidPass123!")
+
+	var createdPub ports.PublicationResponse
+	createDTO := ports.CreatePublicationInput{
+		UserID:          authorUser.ID,
+		PublicationType: models.PublicationArticle,
+		Title:           "Revisioned Article",
+		Content:         "First draft.",
+	}
+	body, _ := json.Marshal(createDTO)
+	req, _ := http.NewRequest(http.MethodPost, constPubBase, bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+authorToken)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	json.Unmarshal(w.Body.Bytes(), &createdPub)
+
+	t.Run("Update Content Creates Revision", func(t *testing.T) {
+		updateDTO := ports.UpdatePublicationInput{Content: StrPtr("Second draft.")}
+		body, _ := json.Marshal(updateDTO)
+		url := fmt.Sprintf("%s/%d", constPubBase, createdPub.ID)
+		req, _ := http.NewRequest(http.MethodPut, url, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+authorToken)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		url = fmt.Sprintf("%s/%d/revisions", constPubBase, createdPub.ID)
+		req, _ = http.NewRequest(http.MethodGet, url, nil)
+		req.Header.Set("Authorization", "Bearer "+authorToken)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var revisions []ports.PublicationRevisionResponse
+		json.Unmarshal(w.Body.Bytes(), &revisions)
+		assert.Len(t, revisions, 2)
+		assert.Equal(t, "Second draft.", revisions[0].Content)
+	})
+
+	t.Run("List Revisions - Forbidden (Not Author)", func(t *testing.T) {
+		url := fmt.Sprintf("%s/%d/revisions", constPubBase, createdPub.ID)
+		req, _ := http.NewRequest(http.MethodGet, url, nil)
+		req.Header.Set("Authorization", "Bearer "+otherToken)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Diff Revision Against Current", func(t *testing.T) {
+		url := fmt.Sprintf("%s/%d/revisions/1/diff", constPubBase, createdPub.ID)
+		req, _ := http.NewRequest(http.MethodGet, url, nil)
+		req.Header.Set("Authorization", "Bearer "+authorToken)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var diff ports.PublicationRevisionDiffResponse
+		json.Unmarshal(w.Body.Bytes(), &diff)
+		assert.Equal(t, uint(1), diff.FromRevision)
+		assert.Len(t, diff.Lines, 2)
+	})
+
+	t.Run("Restore Revision", func(t *testing.T) {
+		url := fmt.Sprintf("%s/%d/revisions/1/restore", constPubBase, createdPub.ID)
+		req, _ := http.NewRequest(http.MethodPost, url, nil)
+		req.Header.Set("Authorization", "Bearer "+authorToken)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var restored ports.PublicationResponse
+		json.Unmarshal(w.Body.Bytes(), &restored)
+		assert.Equal(t, "First draft.", restored.Content)
+	})
+
+	t.Run("Restore Missing Revision", func(t *testing.T) {
+		url := fmt.Sprintf("%s/%d/revisions/42/restore", constPubBase, createdPub.ID)
+		req, _ := http.NewRequest(http.MethodPost, url, nil)
+		req.Header.Set("Authorization", "Bearer "+authorToken)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestPublicationAPI_Integration_SlugRedirect(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	router := SetupRouter()
+
+	constPubBase := constants.AppRoutes.APIPrefix + constants.AppRoutes.PublicationBase
+
+	authorUser, authorToken := CreateTestUserAndLogin(t, router, "slug.author@test.com", "ValidPass123!")
+
+	var createdPub ports.PublicationResponse
+	createDTO := ports.CreatePublicationInput{
+		UserID:          authorUser.ID,
+		PublicationType: models.PublicationCaseStudy,
+		Title:           "Café Déjà Vu",
+		Content:         "Content.",
+	}
+	body, _ := json.Marshal(createDTO)
+	req, _ := http.NewRequest(http.MethodPost, constPubBase, bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+authorToken)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	json.Unmarshal(w.Body.Bytes(), &createdPub)
+	assert.Equal(t, "cafe-deja-vu", createdPub.Slug)
+
+	updateDTO := ports.UpdatePublicationInput{Title: StrPtr("Renamed Case Study")}
+	body, _ = json.Marshal(updateDTO)
+	req, _ = http.NewRequest(http.MethodPut, fmt.Sprintf("%s/%d", constPubBase, createdPub.ID), bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+authorToken)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	t.Run("Old Slug Resolves With Redirect Indicator", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, constPubBase+"/slug/cafe-deja-vu", nil)
+		req.Header.Set("Authorization", "Bearer "+authorToken)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var fetched ports.PublicationResponse
+		json.Unmarshal(w.Body.Bytes(), &fetched)
+		assert.Equal(t, createdPub.ID, fetched.ID)
+		assert.Equal(t, "renamed-case-study", fetched.Slug)
+		assert.NotNil(t, fetched.RedirectedFrom)
+		assert.Equal(t, constPubBase+"/slug/renamed-case-study", w.Header().Get("Location"))
+	})
+
+	t.Run("Current Slug Has No Redirect Indicator", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, constPubBase+"/slug/renamed-case-study", nil)
+		req.Header.Set("Authorization", "Bearer "+authorToken)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var fetched ports.PublicationResponse
+		json.Unmarshal(w.Body.Bytes(), &fetched)
+		assert.Nil(t, fetched.RedirectedFrom)
+		assert.Empty(t, w.Header().Get("Location"))
+	})
+}
+
+func TestPublicationAPI_Integration_Visibility(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	router := SetupRouter()
+
+	constPubBase := constants.AppRoutes.APIPrefix + constants.AppRoutes.PublicationBase
+
+	authorUser, authorToken := CreateTestUserAndLogin(t, router, "vis.author@test.com", "ValidPass123!")
+	connectedUser, connectedToken := CreateTestUserAndLogin(t, router, "vis.connected@test.com", "ValidPass123!")
+	_, memberToken := CreateTestUserAndLogin(t, router, "vis.member@test.com", "ValidPass123!")
+
+	authorBiz := models.Business{Name: "Author Biz", OperatorUserID: authorUser.ID, BusinessType: "Other", BusinessCategory: "Mixed", BusinessPhase: "Growth"}
+	testutil.TestDB.Create(&authorBiz)
+	connectedBiz := models.Business{Name: "Connected Biz", OperatorUserID: connectedUser.ID, BusinessType: "Other", BusinessCategory: "Mixed", BusinessPhase: "Growth"}
+	testutil.TestDB.Create(&connectedBiz)
+	testutil.TestDB.Create(&models.BusinessConnection{
+		InitiatingBusinessID: connectedBiz.ID,
+		ReceivingBusinessID:  authorBiz.ID,
+		ConnectionType:       models.ConnectionTypePartnership,
+		Status:               models.ConnectionStatusActive,
+		InitiatedByUserID:    connectedUser.ID,
+	})
+
+	create := func(title string, visibility models.PublicationVisibility, published bool) ports.PublicationResponse {
+		createDTO := ports.CreatePublicationInput{
+			UserID:          authorUser.ID,
+			BusinessID:      &authorBiz.ID,
+			PublicationType: models.PublicationCaseStudy,
+			Title:           title,
+			Content:         "Content.",
+			Visibility:      &visibility,
+			Published:       BoolPtr(published),
+		}
+		body, _ := json.Marshal(createDTO)
+		req, _ := http.NewRequest(http.MethodPost, constPubBase, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+authorToken)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var created ports.PublicationResponse
+		json.Unmarshal(w.Body.Bytes(), &created)
+		return created
+	}
+	public := create("Public Case Study", models.VisibilityPublic, true)
+	members := create("Members Case Study", models.VisibilityMembers, true)
+	connections := create("Connections Case Study", models.VisibilityConnections, true)
+	private := create("Private Case Study", models.VisibilityPrivate, true)
+	draft := create("Draft Case Study", models.VisibilityPublic, false)
+
+	listFor := func(token string) map[uint]bool {
+		req, _ := http.NewRequest(http.MethodGet, constPubBase, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var publications []ports.PublicationResponse
+		json.Unmarshal(w.Body.Bytes(), &publications)
+		ids := make(map[uint]bool)
+		for _, pub := range publications {
+			ids[pub.ID] = true
+		}
+		return ids
+	}
+
+	t.Run("Anonymous Sees Only Public Published", func(t *testing.T) {
+		ids := listFor("")
+		assert.Equal(t, map[uint]bool{public.ID: true}, ids)
+
+		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/id/%d", constPubBase, members.ID), nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		req, _ = http.NewRequest(http.MethodGet, constPubBase+"/slug/"+public.Slug, nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Member Sees Public And Members", func(t *testing.T) {
+		ids := listFor(memberToken)
+		assert.Equal(t, map[uint]bool{public.ID: true, members.ID: true}, ids)
+	})
+
+	t.Run("Connected User Also Sees Connections Only", func(t *testing.T) {
+		ids := listFor(connectedToken)
+		assert.Equal(t, map[uint]bool{public.ID: true, members.ID: true, connections.ID: true}, ids)
+	})
+
+	t.Run("Author Sees Everything Including Drafts", func(t *testing.T) {
+		ids := listFor(authorToken)
+		assert.Len(t, ids, 5)
+		assert.True(t, ids[private.ID])
+		assert.True(t, ids[draft.ID])
+	})
+
+	t.Run("Draft Hidden From Others", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/id/%d", constPubBase, draft.ID), nil)
+		req.Header.Set("Authorization", "Bearer "+connectedToken)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Invalid Token Is Rejected", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, constPubBase, nil)
+		req.Header.Set("Authorization", "Bearer not-a-token")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("Filter By Type And Business", func(t *testing.T) {
+		url := fmt.Sprintf("%s?publication_type=%s&business_id=%d", constPubBase, models.PublicationCaseStudy, authorBiz.ID)
+		req, _ := http.NewRequest(http.MethodGet, url, nil)
+		req.Header.Set("Authorization", "Bearer "+memberToken)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var publications []ports.PublicationResponse
+		json.Unmarshal(w.Body.Bytes(), &publications)
+		assert.Len(t, publications, 2)
+
+		req, _ = http.NewRequest(http.MethodGet, constPubBase+"?publication_type="+string(models.PublicationPost), nil)
+		req.Header.Set("Authorization", "Bearer "+memberToken)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		json.Unmarshal(w.Body.Bytes(), &publications)
+		assert.Len(t, publications, 0)
+	})
+}
+
+func TestPublicationAPI_Integration_ContentRendering(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	router := SetupRouter()
+
+	constPubBase := constants.AppRoutes.APIPrefix + constants.AppRoutes.PublicationBase
+
+	authorUser, authorToken := CreateTestUserAndLogin(t, router, "render.author@test.com", "ValidPass123!")
+
+	var createdPub ports.PublicationResponse
+	createDTO := ports.CreatePublicationInput{
+		UserID:          authorUser.ID,
+		PublicationType: models.PublicationArticle,
+		Title:           "Rendered Article",
+		Content:         "# Overview\n\nIntro with *emphasis*.\n\n## Details\n\n<script>alert(1)</script>",
+	}
+	body, _ := json.Marshal(createDTO)
+	req, _ := http.NewRequest(http.MethodPost, constPubBase, bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+authorToken)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	json.Unmarshal(w.Body.Bytes(), &createdPub)
+
+	t.Run("Markdown Is Rendered With TOC And Excerpt", func(t *testing.T) {
+		assert.Equal(t, models.ContentFormatMarkdown, createdPub.ContentFormat)
+		assert.Contains(t, createdPub.ContentHTML, `<h1 id="overview">Overview</h1>`)
+		assert.Contains(t, createdPub.ContentHTML, "<em>emphasis</em>")
+		assert.NotContains(t, createdPub.ContentHTML, "<script>")
+		assert.Equal(t, uint(1), createdPub.ReadingTimeMinutes)
+		assert.Len(t, createdPub.TableOfContents, 2)
+		assert.Equal(t, "details", createdPub.TableOfContents[1].ID)
+		if assert.NotNil(t, createdPub.Excerpt) {
+			assert.Contains(t, *createdPub.Excerpt, "Intro with emphasis.")
+		}
+	})
+
+	t.Run("Switching To HTML Sanitises Content", func(t *testing.T) {
+		format := models.ContentFormatHTML
+		updateDTO := ports.UpdatePublicationInput{
+			Content:       StrPtr(`<h2>Title</h2><p style="color:red">Body <img src="javascript:x" onerror="alert(1)"></p>`),
+			ContentFormat: &format,
+		}
+		body, _ := json.Marshal(updateDTO)
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/%d", constPubBase, createdPub.ID), bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+authorToken)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var updated ports.PublicationResponse
+		json.Unmarshal(w.Body.Bytes(), &updated)
+		assert.Equal(t, models.ContentFormatHTML, updated.ContentFormat)
+		assert.Equal(t, `<h2 id="title">Title</h2><p>Body <img></p>`, updated.ContentHTML)
+	})
+
+	t.Run("Invalid Content Format Rejected", func(t *testing.T) {
+		body := []byte(`{"content_format": "rtf"}`)
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/%d", constPubBase, createdPub.ID), bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+authorToken)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
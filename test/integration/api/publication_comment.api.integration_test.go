@@ -0,0 +1,161 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/constants"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/models"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/ports"
+	testutil "github.com/TIA-PARTNERS-GROUP/tia-api/test/test_util"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func CreateTestPublicationHelper(t *testing.T, router *gin.Engine, author models.User, authorToken, title string, visibility models.PublicationVisibility) ports.PublicationResponse {
+	constPubBase := constants.AppRoutes.APIPrefix + constants.AppRoutes.PublicationBase
+	createDTO := ports.CreatePublicationInput{
+		UserID:          author.ID,
+		PublicationType: models.PublicationCaseStudy,
+		Title:           title,
+		Content:         "Content.",
+		Visibility:      &visibility,
+		Published:       BoolPtr(true),
+	}
+	body, _ := json.Marshal(createDTO)
+	req, _ := http.NewRequest(http.MethodPost, constPubBase, bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+authorToken)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code, "Publication creation for test failed")
+	var created ports.PublicationResponse
+	json.Unmarshal(w.Body.Bytes(), &created)
+	return created
+}
+
+func TestPublicationCommentAPI_Integration(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	router := SetupRouter()
+
+	constPubBase := constants.AppRoutes.APIPrefix + constants.AppRoutes.PublicationBase
+
+	authorUser, authorToken := CreateTestUserAndLogin(t, router, "comment.author@test.com", "ValidPass123!")
+	readerUser, readerToken := CreateTestUserAndLogin(t, router, "comment.reader@test.com", "ValidPass123!")
+	_, otherToken := CreateTestUserAndLogin(t, router, "comment.other@test.com", "ValidPass123!")
+
+	pub := CreateTestPublicationHelper(t, router, authorUser, authorToken, "Commentable Case Study", models.VisibilityPublic)
+	private := CreateTestPublicationHelper(t, router, authorUser, authorToken, "Private Case Study", models.VisibilityPrivate)
+	commentsURL := fmt.Sprintf("%s/%d/comments", constPubBase, pub.ID)
+
+	send := func(method, url, token string, payload interface{}) *httptest.ResponseRecorder {
+		var body []byte
+		if payload != nil {
+			body, _ = json.Marshal(payload)
+		}
+		req, _ := http.NewRequest(method, url, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	var rootComment ports.PublicationCommentResponse
+	var replyComment ports.PublicationCommentResponse
+
+	t.Run("Create Comment Sanitises Body", func(t *testing.T) {
+		w := send(http.MethodPost, commentsURL, readerToken, ports.CreatePublicationCommentInput{Body: "<em>Loved</em> this<script>x()</script>"})
+		assert.Equal(t, http.StatusCreated, w.Code)
+		json.Unmarshal(w.Body.Bytes(), &rootComment)
+		assert.Equal(t, "Loved this", rootComment.Body)
+		assert.Equal(t, readerUser.ID, rootComment.Author.ID)
+
+		var notifications int64
+		testutil.TestDB.Model(&models.Notification{}).Where("receiver_user_id = ? AND notification_type = ?", authorUser.ID, models.NotifyPublicationComment).Count(&notifications)
+		assert.Equal(t, int64(1), notifications)
+	})
+
+	t.Run("Reply And List Thread Anonymously", func(t *testing.T) {
+		w := send(http.MethodPost, commentsURL, otherToken, ports.CreatePublicationCommentInput{ParentID: &rootComment.ID, Body: "Me too"})
+		assert.Equal(t, http.StatusCreated, w.Code)
+		json.Unmarshal(w.Body.Bytes(), &replyComment)
+
+		w = send(http.MethodGet, commentsURL, "", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var thread ports.PublicationCommentsResponse
+		json.Unmarshal(w.Body.Bytes(), &thread)
+		assert.Equal(t, 2, thread.Count)
+		assert.Len(t, thread.Comments, 1)
+		assert.Len(t, thread.Comments[0].Replies, 1)
+		assert.Equal(t, replyComment.ID, thread.Comments[0].Replies[0].ID)
+	})
+
+	t.Run("Comment Counts On Publication", func(t *testing.T) {
+		w := send(http.MethodGet, fmt.Sprintf("%s/id/%d", constPubBase, pub.ID), "", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp ports.PublicationResponse
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		assert.NotNil(t, resp.Engagement)
+		assert.Equal(t, int64(2), resp.Engagement.CommentCount)
+	})
+
+	t.Run("Cannot Comment On Invisible Publication", func(t *testing.T) {
+		w := send(http.MethodPost, fmt.Sprintf("%s/%d/comments", constPubBase, private.ID), readerToken, ports.CreatePublicationCommentInput{Body: "Hello"})
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Only Author Can Edit", func(t *testing.T) {
+		url := fmt.Sprintf("%s/comments/%d", constPubBase, rootComment.ID)
+		w := send(http.MethodPut, url, otherToken, ports.UpdatePublicationCommentInput{Body: "Hijacked"})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = send(http.MethodPut, url, readerToken, ports.UpdatePublicationCommentInput{Body: "Loved this a lot"})
+		assert.Equal(t, http.StatusOK, w.Code)
+		var updated ports.PublicationCommentResponse
+		json.Unmarshal(w.Body.Bytes(), &updated)
+		assert.True(t, updated.Edited)
+		assert.Equal(t, "Loved this a lot", updated.Body)
+	})
+
+	t.Run("Flag And Moderate", func(t *testing.T) {
+		w := send(http.MethodPost, fmt.Sprintf("%s/comments/%d/flag", constPubBase, replyComment.ID), readerToken, ports.FlagPublicationCommentInput{Reason: "Off topic"})
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		modURL := fmt.Sprintf("%s/comments/%d/moderation", constPubBase, replyComment.ID)
+		w = send(http.MethodPut, modURL, readerToken, ports.ModeratePublicationCommentInput{Status: models.CommentStatusHidden})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = send(http.MethodPut, modURL, authorToken, ports.ModeratePublicationCommentInput{Status: models.CommentStatusHidden})
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = send(http.MethodGet, commentsURL, readerToken, nil)
+		var thread ports.PublicationCommentsResponse
+		json.Unmarshal(w.Body.Bytes(), &thread)
+		assert.Equal(t, 1, thread.Count)
+		hidden := thread.Comments[0].Replies[0]
+		assert.Equal(t, models.CommentStatusHidden, hidden.Status)
+		assert.Equal(t, "", hidden.Body)
+	})
+
+	t.Run("Publication Author Can Delete Any Comment", func(t *testing.T) {
+		url := fmt.Sprintf("%s/comments/%d", constPubBase, rootComment.ID)
+		w := send(http.MethodDelete, url, otherToken, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = send(http.MethodDelete, url, authorToken, nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+
+		w = send(http.MethodGet, commentsURL, "", nil)
+		var thread ports.PublicationCommentsResponse
+		json.Unmarshal(w.Body.Bytes(), &thread)
+		assert.Equal(t, models.CommentStatusDeleted, thread.Comments[0].Status)
+		assert.Nil(t, thread.Comments[0].Author)
+	})
+}
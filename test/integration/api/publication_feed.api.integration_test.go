@@ -0,0 +1,136 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/constants"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/models"
+	testutil "github.com/TIA-PARTNERS-GROUP/tia-api/test/test_util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublicationFeedAPI_Integration(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	router := SetupRouter()
+
+	constPubBase := constants.AppRoutes.APIPrefix + constants.AppRoutes.PublicationBase
+
+	authorUser, authorToken := CreateTestUserAndLogin(t, router, "feed.author@test.com", "ValidPass123!")
+	biz := models.Business{Name: "Feed Biz", OperatorUserID: authorUser.ID, BusinessType: "Other", BusinessCategory: "Mixed", BusinessPhase: "Growth"}
+	testutil.TestDB.Create(&biz)
+
+	caseStudy := CreateTestPublicationHelper(t, router, authorUser, authorToken, "Feed Case Study", models.VisibilityPublic)
+	thumbnail := "https://cdn.example.test/case-study.png"
+	testutil.TestDB.Model(&models.Publication{}).Where("id = ?", caseStudy.ID).Updates(map[string]interface{}{"thumbnail": thumbnail, "business_id": biz.ID})
+	CreateTestPublicationHelper(t, router, authorUser, authorToken, "Members Only Case Study", models.VisibilityMembers)
+
+	get := func(url string, headers map[string]string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodGet, url, nil)
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("RSS Contains Only Public Items With Enclosure", func(t *testing.T) {
+		w := get(constPubBase+"/feed.rss", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.True(t, strings.HasPrefix(w.Header().Get("Content-Type"), "application/rss+xml"))
+		assert.NotEmpty(t, w.Header().Get("ETag"))
+		assert.NotEmpty(t, w.Header().Get("Last-Modified"))
+
+		var doc struct {
+			Items []struct {
+				Title     string `xml:"title"`
+				Link      string `xml:"link"`
+				Enclosure struct {
+					URL  string `xml:"url,attr"`
+					Type string `xml:"type,attr"`
+				} `xml:"enclosure"`
+			} `xml:"channel>item"`
+		}
+		assert.NoError(t, xml.Unmarshal(w.Body.Bytes(), &doc))
+		assert.Len(t, doc.Items, 1)
+		assert.Equal(t, "Feed Case Study", doc.Items[0].Title)
+		assert.Equal(t, "https://example.test/publications/"+caseStudy.Slug, doc.Items[0].Link)
+		assert.Equal(t, thumbnail, doc.Items[0].Enclosure.URL)
+		assert.Equal(t, "image/png", doc.Items[0].Enclosure.Type)
+	})
+
+	t.Run("Atom And JSON Feeds", func(t *testing.T) {
+		w := get(constPubBase+"/feed.atom", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.True(t, strings.HasPrefix(w.Header().Get("Content-Type"), "application/atom+xml"))
+		assert.Contains(t, w.Body.String(), "<entry>")
+
+		w = get(constPubBase+"/feed.json", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var doc struct {
+			Version string `json:"version"`
+			Items   []struct {
+				Title       string `json:"title"`
+				Image       string `json:"image"`
+				Summary     string `json:"summary"`
+				ContentHTML string `json:"content_html"`
+				Authors     []struct {
+					Name string `json:"name"`
+				} `json:"authors"`
+			} `json:"items"`
+		}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+		assert.Equal(t, "https://jsonfeed.org/version/1.1", doc.Version)
+		assert.Len(t, doc.Items, 1)
+		assert.Equal(t, thumbnail, doc.Items[0].Image)
+		assert.Equal(t, "Feed Biz", doc.Items[0].Authors[0].Name)
+
+		var stored models.Publication
+		testutil.TestDB.First(&stored, caseStudy.ID)
+		assert.Equal(t, stored.ContentHTML, doc.Items[0].ContentHTML)
+		assert.Equal(t, stored.GeneratedExcerpt, doc.Items[0].Summary)
+		assert.NotContains(t, w.Body.String(), "content_text")
+	})
+
+	t.Run("Filters", func(t *testing.T) {
+		w := get(fmt.Sprintf("%s/feed.json?business_id=%d", constPubBase, biz.ID+1), nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"items": []`)
+
+		w = get(constPubBase+"/feed.json?publication_type=post", nil)
+		assert.Contains(t, w.Body.String(), `"items": []`)
+
+		w = get(constPubBase+"/feed.json?publication_type=bogus", nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Conditional Requests", func(t *testing.T) {
+		w := get(constPubBase+"/feed.rss", nil)
+		etag := w.Header().Get("ETag")
+		lastModified := w.Header().Get("Last-Modified")
+
+		w = get(constPubBase+"/feed.rss", map[string]string{"If-None-Match": etag})
+		assert.Equal(t, http.StatusNotModified, w.Code)
+		assert.Empty(t, w.Body.String())
+
+		w = get(constPubBase+"/feed.rss", map[string]string{"If-Modified-Since": lastModified})
+		assert.Equal(t, http.StatusNotModified, w.Code)
+
+		w = get(constPubBase+"/feed.atom", map[string]string{"If-None-Match": etag})
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		time.Sleep(1100 * time.Millisecond)
+		CreateTestPublicationHelper(t, router, authorUser, authorToken, "Another Public Case Study", models.VisibilityPublic)
+		w = get(constPubBase+"/feed.rss", map[string]string{"If-None-Match": etag})
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotEqual(t, etag, w.Header().Get("ETag"))
+		assert.Contains(t, w.Body.String(), "Another Public Case Study")
+	})
+}
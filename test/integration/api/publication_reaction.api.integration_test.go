@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/constants"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/models"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/ports"
+	testutil "github.com/TIA-PARTNERS-GROUP/tia-api/test/test_util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublicationReactionAPI_Integration(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	router := SetupRouter()
+
+	constPubBase := constants.AppRoutes.APIPrefix + constants.AppRoutes.PublicationBase
+
+	authorUser, authorToken := CreateTestUserAndLogin(t, router, "reaction.author@test.com", "ValidPass123!")
+	_, readerToken := CreateTestUserAndLogin(t, router, "reaction.reader@test.com", "ValidPass123!")
+
+	pub := CreateTestPublicationHelper(t, router, authorUser, authorToken, "Reactable Testimonial", models.VisibilityPublic)
+	reactionsURL := fmt.Sprintf("%s/%d/reactions", constPubBase, pub.ID)
+
+	react := func(token string, reactionType models.PublicationReactionType) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(ports.SetPublicationReactionInput{ReactionType: reactionType})
+		req, _ := http.NewRequest(http.MethodPut, reactionsURL, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Set Reaction Requires Auth", func(t *testing.T) {
+		body, _ := json.Marshal(ports.SetPublicationReactionInput{ReactionType: models.ReactionLike})
+		req, _ := http.NewRequest(http.MethodPut, reactionsURL, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("Invalid Reaction Type", func(t *testing.T) {
+		w := react(readerToken, "dislike")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("One Reaction Per User", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, react(readerToken, models.ReactionLike).Code)
+		assert.Equal(t, http.StatusOK, react(readerToken, models.ReactionCurious).Code)
+		assert.Equal(t, http.StatusOK, react(authorToken, models.ReactionCurious).Code)
+
+		req, _ := http.NewRequest(http.MethodGet, reactionsURL, nil)
+		req.Header.Set("Authorization", "Bearer "+readerToken)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var summary ports.PublicationReactionsSummaryResponse
+		json.Unmarshal(w.Body.Bytes(), &summary)
+		assert.Equal(t, int64(2), summary.Total)
+		assert.Equal(t, int64(2), summary.Counts[models.ReactionCurious])
+		assert.Equal(t, models.ReactionCurious, *summary.ViewerReaction)
+
+		var notifications int64
+		testutil.TestDB.Model(&models.Notification{}).Where("receiver_user_id = ? AND notification_type = ?", authorUser.ID, models.NotifyPublicationReaction).Count(&notifications)
+		assert.Equal(t, int64(1), notifications)
+	})
+
+	t.Run("Counts Surface On Publication List", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, constPubBase, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var publications []ports.PublicationResponse
+		json.Unmarshal(w.Body.Bytes(), &publications)
+		assert.Len(t, publications, 1)
+		assert.Equal(t, int64(2), publications[0].Engagement.ReactionTotal)
+	})
+
+	t.Run("Remove Reaction", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodDelete, reactionsURL, nil)
+		req.Header.Set("Authorization", "Bearer "+readerToken)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+
+		req, _ = http.NewRequest(http.MethodDelete, reactionsURL, nil)
+		req.Header.Set("Authorization", "Bearer "+readerToken)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/constants"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/models"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/ports"
+	testutil "github.com/TIA-PARTNERS-GROUP/tia-api/test/test_util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReferralAPI_Integration_Ledger(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	router := SetupRouter()
+
+	constBizBase := constants.AppRoutes.APIPrefix + constants.AppRoutes.BusinessBase
+	constReferralBase := constants.AppRoutes.APIPrefix + constants.AppRoutes.ReferralBase
+
+	referrer, referrerToken := CreateTestUserAndLogin(t, router, "referrer@ledger.com", "ValidPass123!")
+	receiver, receiverToken := CreateTestUserAndLogin(t, router, "receiver@ledger.com", "ValidPass123!")
+	_, outsiderToken := CreateTestUserAndLogin(t, router, "outsider@ledger.com", "ValidPass123!")
+
+	bizA := models.Business{Name: "Ledger Referrer", OperatorUserID: referrer.ID, BusinessType: "Other", BusinessCategory: "Mixed", BusinessPhase: "Growth"}
+	testutil.TestDB.Create(&bizA)
+	bizB := models.Business{Name: "Ledger Receiver", OperatorUserID: receiver.ID, BusinessType: "Other", BusinessCategory: "Mixed", BusinessPhase: "Growth"}
+	testutil.TestDB.Create(&bizB)
+
+	doJSON := func(method, url, token string, payload interface{}) *httptest.ResponseRecorder {
+		body := bytes.NewBuffer(nil)
+		if payload != nil {
+			data, _ := json.Marshal(payload)
+			body = bytes.NewBuffer(data)
+		}
+		req, _ := http.NewRequest(method, url, body)
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	bizPath := func(route string, id uint) string {
+		return constBizBase + strings.Replace(route, ":id", fmt.Sprintf("%d", id), 1)
+	}
+
+	var referral ports.ReferralResponse
+
+	t.Run("Record Referral", func(t *testing.T) {
+		input := ports.CreateReferralInput{ReferrerBusinessID: bizA.ID, ReceiverBusinessID: bizB.ID, ClientName: "Widget Works"}
+		w := doJSON(http.MethodPost, constReferralBase, outsiderToken, input)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = doJSON(http.MethodPost, constReferralBase, referrerToken, ports.CreateReferralInput{ReferrerBusinessID: bizA.ID, ReceiverBusinessID: bizB.ID})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = doJSON(http.MethodPost, constReferralBase, referrerToken, input)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		json.Unmarshal(w.Body.Bytes(), &referral)
+		assert.Equal(t, models.ReferralSent, referral.Status)
+
+		w = doJSON(http.MethodGet, fmt.Sprintf("%s/%d", constReferralBase, referral.ID), outsiderToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Receiver Marks Won", func(t *testing.T) {
+		won := models.ReferralWon
+		value := 5000.0
+		w := doJSON(http.MethodPut, fmt.Sprintf("%s/%d", constReferralBase, referral.ID), referrerToken, ports.UpdateReferralInput{Status: &won})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = doJSON(http.MethodPut, fmt.Sprintf("%s/%d", constReferralBase, referral.ID), receiverToken, ports.UpdateReferralInput{Status: &won, DealValue: &value})
+		assert.Equal(t, http.StatusOK, w.Code)
+		json.Unmarshal(w.Body.Bytes(), &referral)
+		assert.Equal(t, models.ReferralWon, referral.Status)
+
+		w = doJSON(http.MethodDelete, fmt.Sprintf("%s/%d", constReferralBase, referral.ID), referrerToken, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("List And Report", func(t *testing.T) {
+		w := doJSON(http.MethodGet, bizPath(constants.AppRoutes.BusinessReferrals, bizB.ID)+"?direction=received", receiverToken, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var list ports.ReferralsResponse
+		json.Unmarshal(w.Body.Bytes(), &list)
+		assert.Equal(t, 1, list.Count)
+
+		w = doJSON(http.MethodGet, bizPath(constants.AppRoutes.BusinessReferrals, bizB.ID)+"?direction=sideways", receiverToken, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = doJSON(http.MethodGet, bizPath(constants.AppRoutes.BusinessRefReport, bizA.ID)+"?interval=month", referrerToken, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var report ports.ReferralReportResponse
+		json.Unmarshal(w.Body.Bytes(), &report)
+		assert.Equal(t, 1, report.Totals.GivenCount)
+		assert.Equal(t, 1, report.Totals.GivenWon)
+		assert.Equal(t, 5000.0, report.Totals.GivenWonValue)
+
+		w = doJSON(http.MethodGet, bizPath(constants.AppRoutes.BusinessRefReport, bizA.ID)+"?from=not-a-date", referrerToken, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = doJSON(http.MethodGet, bizPath(constants.AppRoutes.BusinessRefReport, bizA.ID), outsiderToken, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
@@ -10,7 +10,7 @@ import (
 )
 func TestBusinessService_Integration_CreateAndGet(t *testing.T) {
 	testutil.CleanupTestDB(t, testutil.TestDB)
-	businessService := services.NewBusinessService(testutil.TestDB)
+	businessService := services.NewBusinessService(testutil.TestDB, testutil.TestGeocoder)
 	operator := models.User{FirstName: "Biz", LoginEmail: "biz@owner.com", Active: true}
 	testutil.TestDB.Create(&operator)
 	createDTO := ports.CreateBusinessInput{
@@ -34,7 +34,7 @@ func TestBusinessService_Integration_CreateAndGet(t *testing.T) {
 }
 func TestBusinessService_Integration_DeleteBusiness(t *testing.T) {
 	testutil.CleanupTestDB(t, testutil.TestDB)
-	businessService := services.NewBusinessService(testutil.TestDB)
+	businessService := services.NewBusinessService(testutil.TestDB, testutil.TestGeocoder)
 	operator := models.User{FirstName: "BizDel", LoginEmail: "bizdel@owner.com", Active: true}
 	testutil.TestDB.Create(&operator)
 	otherUser := models.User{FirstName: "Other", LoginEmail: "other@user.com", Active: true}
@@ -79,7 +79,7 @@ func TestBusinessService_Integration_DeleteBusiness(t *testing.T) {
 }
 func TestBusinessService_Integration_UpdateBusiness(t *testing.T) {
 	testutil.CleanupTestDB(t, testutil.TestDB)
-	businessService := services.NewBusinessService(testutil.TestDB)
+	businessService := services.NewBusinessService(testutil.TestDB, testutil.TestGeocoder)
 	operator := models.User{FirstName: "BizUpd", LoginEmail: "bizupd@owner.com", Active: true}
 	testutil.TestDB.Create(&operator)
 	otherUser := models.User{FirstName: "OtherUpd", LoginEmail: "otherupd@user.com", Active: true}
@@ -128,7 +128,7 @@ func TestBusinessService_Integration_UpdateBusiness(t *testing.T) {
 }
 func TestBusinessService_Integration_GetUserBusinesses(t *testing.T) {
 	testutil.CleanupTestDB(t, testutil.TestDB)
-	businessService := services.NewBusinessService(testutil.TestDB)
+	businessService := services.NewBusinessService(testutil.TestDB, testutil.TestGeocoder)
 	user1 := models.User{FirstName: "User1", LoginEmail: "user1@biz.com", Active: true}
 	testutil.TestDB.Create(&user1)
 	user2 := models.User{FirstName: "User2", LoginEmail: "user2@biz.com", Active: true}
@@ -142,7 +142,7 @@ func TestBusinessService_Integration_GetUserBusinesses(t *testing.T) {
 }
 func TestBusinessService_Integration_ToggleBusinessStatus(t *testing.T) {
 	testutil.CleanupTestDB(t, testutil.TestDB)
-	businessService := services.NewBusinessService(testutil.TestDB)
+	businessService := services.NewBusinessService(testutil.TestDB, testutil.TestGeocoder)
 	operator := models.User{FirstName: "ToggleUser", LoginEmail: "toggle@user.com", Active: true}
 	testutil.TestDB.Create(&operator)
 	business := models.Business{Name: "Toggle Biz", OperatorUserID: operator.ID, Active: true, BusinessType: "Other", BusinessCategory: "Mixed", BusinessPhase: "Growth"}
@@ -154,3 +154,164 @@ func TestBusinessService_Integration_ToggleBusinessStatus(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, updatedBiz.Active)
 }
+func TestBusinessService_Integration_Completeness(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	ctx := context.Background()
+	businessService := services.NewBusinessService(testutil.TestDB, testutil.TestGeocoder)
+	tagService := services.NewBusinessTagService(testutil.TestDB)
+	userSkillService := services.NewUserSkillService(testutil.TestDB)
+	pubService := services.NewPublicationService(testutil.TestDB)
+	operator := models.User{FirstName: "Complete", LoginEmail: "complete@owner.com", Active: true}
+	testutil.TestDB.Create(&operator)
+	tagline := "We build things"
+	description := "A business that builds things"
+	business, err := businessService.CreateBusiness(ctx, ports.CreateBusinessInput{
+		OperatorUserID:   operator.ID,
+		Name:             "Completeness Co",
+		Tagline:          &tagline,
+		Description:      &description,
+		BusinessType:     models.BusinessTypeTechnology,
+		BusinessCategory: models.BusinessCategoryB2B,
+		BusinessPhase:    models.BusinessPhaseStartup,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(18), business.Completeness)
+	completeness, err := businessService.GetBusinessCompleteness(ctx, business)
+	assert.NoError(t, err)
+	assert.Equal(t, 18, completeness.Score)
+	assert.ElementsMatch(t, []ports.BusinessCompletenessItem{ports.CompletenessTagline, ports.CompletenessDescription}, completeness.Completed)
+	assert.Contains(t, completeness.Missing, ports.CompletenessClientTags)
+	assert.Contains(t, completeness.Missing, ports.CompletenessOperatorSkills)
+	assert.Contains(t, completeness.Missing, ports.CompletenessPublications)
+	storedScore := func() uint8 {
+		var stored models.Business
+		testutil.TestDB.First(&stored, business.ID)
+		return stored.Completeness
+	}
+	tag, err := tagService.CreateBusinessTag(ctx, ports.CreateBusinessTagInput{BusinessID: business.ID, TagType: models.BusinessTagClient, Description: "Startups"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(27), storedScore())
+	skill := models.Skill{Name: "Completeness Skill", Category: "Programming", Active: true}
+	testutil.TestDB.Create(&skill)
+	_, err = userSkillService.AddUserSkill(ctx, ports.CreateUserSkillInput{UserID: operator.ID, SkillID: skill.ID, ProficiencyLevel: models.ProficiencyAdvanced})
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(36), storedScore())
+	published := true
+	_, err = pubService.CreatePublication(ctx, ports.CreatePublicationInput{
+		UserID:          operator.ID,
+		BusinessID:      &business.ID,
+		PublicationType: models.PublicationArticle,
+		Title:           "Completeness Case Study",
+		Content:         "How we completed our profile",
+		Published:       &published,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(45), storedScore())
+	assert.NoError(t, tagService.DeleteBusinessTag(ctx, tag.ID))
+	assert.Equal(t, uint8(36), storedScore())
+	testutil.TestDB.Model(&models.Business{}).Where("id = ?", business.ID).UpdateColumn("completeness", 0)
+	completeness, err = businessService.GetBusinessCompleteness(ctx, business)
+	assert.NoError(t, err)
+	assert.Equal(t, 36, completeness.Score)
+	assert.NotContains(t, completeness.Missing, ports.CompletenessPublications)
+	assert.Equal(t, uint8(0), storedScore(), "reading completeness does not write it back")
+}
+func TestBusinessService_Integration_GeoSearch(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	ctx := context.Background()
+	businessService := services.NewBusinessService(testutil.TestDB, testutil.TestGeocoder)
+	operator := models.User{FirstName: "Geo", LoginEmail: "geo@owner.com", Active: true}
+	testutil.TestDB.Create(&operator)
+	create := func(name, postcode string) *models.Business {
+		country := "Australia"
+		business, err := businessService.CreateBusiness(ctx, ports.CreateBusinessInput{
+			OperatorUserID:   operator.ID,
+			Name:             name,
+			PostalCode:       &postcode,
+			Country:          &country,
+			BusinessType:     models.BusinessTypeServices,
+			BusinessCategory: models.BusinessCategoryB2B,
+			BusinessPhase:    models.BusinessPhaseGrowth,
+		})
+		assert.NoError(t, err)
+		return business
+	}
+	cbd := create("Sydney CBD Co", "2000")
+	bondi := create("Bondi Co", "2026")
+	parramatta := create("Parramatta Co", "2150")
+	melbourne := create("Melbourne Co", "3000")
+	assert.NotNil(t, cbd.Latitude)
+	assert.InDelta(t, -33.8688, *cbd.Latitude, 0.001)
+	assert.NotNil(t, melbourne.Longitude)
+	lat, lng := -27.4698, 153.0251
+	manual, err := businessService.CreateBusiness(ctx, ports.CreateBusinessInput{
+		OperatorUserID:   operator.ID,
+		Name:             "Manual Co",
+		Latitude:         &lat,
+		Longitude:        &lng,
+		BusinessType:     models.BusinessTypeServices,
+		BusinessCategory: models.BusinessCategoryB2B,
+		BusinessPhase:    models.BusinessPhaseGrowth,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, lat, *manual.Latitude)
+	unknown := create("Overseas Co", "ABC")
+	assert.Nil(t, unknown.Latitude)
+	unlisted := create("Unlisted Postcode Co", "2999")
+	assert.Nil(t, unlisted.Latitude, "postcodes without a centroid are not guessed from nearby postcodes")
+	near := "-33.8688,151.2093"
+	results, err := businessService.GetBusinesses(ctx, ports.BusinessesFilter{Near: &near})
+	assert.NoError(t, err)
+	names := []string{}
+	for _, b := range results {
+		names = append(names, b.Name)
+	}
+	assert.Equal(t, []string{cbd.Name, bondi.Name, parramatta.Name}, names)
+	radius := 10.0
+	results, err = businessService.GetBusinesses(ctx, ports.BusinessesFilter{Near: &near, RadiusKm: &radius})
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	radius = 1000
+	results, err = businessService.GetBusinesses(ctx, ports.BusinessesFilter{Near: &near, RadiusKm: &radius})
+	assert.ErrorIs(t, err, ports.ErrInvalidRadius)
+	assert.Nil(t, results)
+	bad := "north,south"
+	_, err = businessService.GetBusinesses(ctx, ports.BusinessesFilter{Near: &bad})
+	assert.ErrorIs(t, err, ports.ErrInvalidNearQuery)
+	postcode := "3000"
+	moved, err := businessService.UpdateBusiness(ctx, parramatta.ID, operator.ID, ports.UpdateBusinessInput{PostalCode: &postcode})
+	assert.NoError(t, err)
+	assert.InDelta(t, *melbourne.Latitude, *moved.Latitude, 0.001)
+	results, err = businessService.GetBusinesses(ctx, ports.BusinessesFilter{Near: &near})
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+}
+func TestBusinessService_Integration_GeocodeMissingBusinesses(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	ctx := context.Background()
+	businessService := services.NewBusinessService(testutil.TestDB, testutil.TestGeocoder)
+	operator := models.User{FirstName: "Legacy", LoginEmail: "legacy@geo.com", Active: true}
+	testutil.TestDB.Create(&operator)
+	legacy := func(name, postcode string) models.Business {
+		b := models.Business{Name: name, OperatorUserID: operator.ID, PostalCode: &postcode, BusinessType: models.BusinessTypeOther, BusinessCategory: models.BusinessCategoryMixed, BusinessPhase: models.BusinessPhaseGrowth}
+		testutil.TestDB.Create(&b)
+		return b
+	}
+	sydney := legacy("Legacy Sydney", "2000")
+	unlisted := legacy("Legacy Unlisted", "2999")
+	located, unlocated, err := businessService.GeocodeMissingBusinesses(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, located)
+	assert.Equal(t, 1, unlocated)
+	var stored, missing models.Business
+	testutil.TestDB.First(&stored, sydney.ID)
+	if assert.NotNil(t, stored.Latitude) {
+		assert.InDelta(t, -33.8688, *stored.Latitude, 0.001)
+	}
+	testutil.TestDB.First(&missing, unlisted.ID)
+	assert.Nil(t, missing.Latitude)
+	located, unlocated, err = businessService.GeocodeMissingBusinesses(ctx)
+	assert.NoError(t, err)
+	assert.Zero(t, located)
+	assert.Equal(t, 1, unlocated)
+}
@@ -2,11 +2,13 @@ package main
 import (
 	"context"
 	"testing"
+	"time"
 	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/core/services"
 	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/models"
 	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/ports"
 	testutil "github.com/TIA-PARTNERS-GROUP/tia-api/test/test_util"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 func TestBusinessConnectionService_Integration_CreateAndGet(t *testing.T) {
 	testutil.CleanupTestDB(t, testutil.TestDB)
@@ -146,6 +148,16 @@ func TestBusinessConnectionService_Integration_UpdateAndDelete(t *testing.T) {
 	}
 	connection, err := businessConnectionService.CreateBusinessConnection(context.Background(), createDTO)
 	assert.NoError(t, err)
+	partnershipType := models.ConnectionTypePartnership
+	_, err = businessConnectionService.CreateBusinessConnection(context.Background(), ports.CreateBusinessConnectionInput{
+		InitiatingBusinessID: business2.ID,
+		ReceivingBusinessID:  business1.ID,
+		ConnectionType:       partnershipType,
+		InitiatedByUserID:    user.ID,
+	})
+	assert.NoError(t, err)
+	_, err = businessConnectionService.UpdateBusinessConnection(context.Background(), connection.ID, ports.UpdateBusinessConnectionInput{ConnectionType: &partnershipType})
+	assert.ErrorIs(t, err, ports.ErrBusinessConnectionAlreadyExists)
 	newNotes := "Updated notes about this connection"
 	collaborationType := models.ConnectionTypeCollaboration
 	updateDTO := ports.UpdateBusinessConnectionInput{
@@ -195,7 +207,7 @@ func TestBusinessConnectionService_Integration_AcceptAndReject(t *testing.T) {
 	connection, err := businessConnectionService.CreateBusinessConnection(context.Background(), createDTO)
 	assert.NoError(t, err)
 	assert.Equal(t, models.ConnectionStatusPending, connection.Status)
-	acceptedConnection, err := businessConnectionService.AcceptBusinessConnection(context.Background(), connection.ID)
+	acceptedConnection, err := businessConnectionService.AcceptBusinessConnection(context.Background(), connection.ID, user.ID)
 	assert.NoError(t, err)
 	assert.NotNil(t, acceptedConnection)
 	assert.Equal(t, models.ConnectionStatusActive, acceptedConnection.Status)
@@ -207,7 +219,7 @@ func TestBusinessConnectionService_Integration_AcceptAndReject(t *testing.T) {
 	}
 	connection2, err := businessConnectionService.CreateBusinessConnection(context.Background(), createDTO2)
 	assert.NoError(t, err)
-	rejectedConnection, err := businessConnectionService.RejectBusinessConnection(context.Background(), connection2.ID)
+	rejectedConnection, err := businessConnectionService.RejectBusinessConnection(context.Background(), connection2.ID, user.ID)
 	assert.NoError(t, err)
 	assert.NotNil(t, rejectedConnection)
 	assert.Equal(t, models.ConnectionStatusRejected, rejectedConnection.Status)
@@ -366,9 +378,257 @@ func TestBusinessConnectionService_Integration_AcceptNonPendingConnection(t *tes
 	}
 	connection, err := businessConnectionService.CreateBusinessConnection(context.Background(), createDTO)
 	assert.NoError(t, err)
-	_, err = businessConnectionService.AcceptBusinessConnection(context.Background(), connection.ID)
+	_, err = businessConnectionService.AcceptBusinessConnection(context.Background(), connection.ID, user.ID)
 	assert.NoError(t, err)
-	_, err = businessConnectionService.AcceptBusinessConnection(context.Background(), connection.ID)
+	_, err = businessConnectionService.AcceptBusinessConnection(context.Background(), connection.ID, user.ID)
 	assert.Error(t, err)
 	assert.Equal(t, ports.ErrConnectionNotPending, err)
 }
+func TestBusinessConnectionService_Integration_AuthorizeAction(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	businessConnectionService := services.NewBusinessConnectionService(testutil.TestDB)
+	ctx := context.Background()
+	ownerA := models.User{FirstName: "OwnerA", LoginEmail: "ownera@policy.com", Active: true}
+	ownerB := models.User{FirstName: "OwnerB", LoginEmail: "ownerb@policy.com", Active: true}
+	editorB := models.User{FirstName: "EditorB", LoginEmail: "editorb@policy.com", Active: true}
+	testutil.TestDB.Create(&ownerA)
+	testutil.TestDB.Create(&ownerB)
+	testutil.TestDB.Create(&editorB)
+	bizA := models.Business{Name: "Policy A", OperatorUserID: ownerA.ID, BusinessType: models.BusinessTypeOther, BusinessCategory: models.BusinessCategoryMixed, BusinessPhase: models.BusinessPhaseGrowth}
+	bizB := models.Business{Name: "Policy B", OperatorUserID: ownerB.ID, BusinessType: models.BusinessTypeOther, BusinessCategory: models.BusinessCategoryMixed, BusinessPhase: models.BusinessPhaseGrowth}
+	testutil.TestDB.Create(&bizA)
+	testutil.TestDB.Create(&bizB)
+	testutil.TestDB.Create(&models.BusinessMember{BusinessID: bizB.ID, UserID: editorB.ID, Role: models.BusinessRoleEditor})
+	conn := models.BusinessConnection{InitiatingBusinessID: bizA.ID, ReceivingBusinessID: bizB.ID, ConnectionType: models.ConnectionTypePartnership, Status: models.ConnectionStatusPending, InitiatedByUserID: ownerA.ID}
+	testutil.TestDB.Create(&conn)
+	_, err := businessConnectionService.AuthorizeBusinessConnectionAction(ctx, conn.ID, ownerB.ID, ports.ConnectionActionRespond)
+	assert.NoError(t, err)
+	_, err = businessConnectionService.AuthorizeBusinessConnectionAction(ctx, conn.ID, ownerA.ID, ports.ConnectionActionRespond)
+	assert.ErrorIs(t, err, ports.ErrNotConnectionReceiver)
+	_, err = businessConnectionService.AuthorizeBusinessConnectionAction(ctx, conn.ID, editorB.ID, ports.ConnectionActionRespond)
+	assert.ErrorIs(t, err, ports.ErrNotConnectionReceiver)
+	_, err = businessConnectionService.AuthorizeBusinessConnectionAction(ctx, conn.ID, ownerA.ID, ports.ConnectionActionWithdraw)
+	assert.NoError(t, err)
+	_, err = businessConnectionService.AuthorizeBusinessConnectionAction(ctx, conn.ID, ownerB.ID, ports.ConnectionActionDelete)
+	assert.ErrorIs(t, err, ports.ErrNotConnectionInitiator)
+	_, err = businessConnectionService.AuthorizeBusinessConnectionAction(ctx, conn.ID, editorB.ID, ports.ConnectionActionDeactivate)
+	assert.ErrorIs(t, err, ports.ErrNotConnectionParticipant)
+	testutil.TestDB.Model(&conn).Update("status", models.ConnectionStatusActive)
+	_, err = businessConnectionService.AuthorizeBusinessConnectionAction(ctx, conn.ID, ownerB.ID, ports.ConnectionActionDelete)
+	assert.NoError(t, err)
+	_, err = businessConnectionService.AuthorizeBusinessConnectionAction(ctx, conn.ID+999, ownerA.ID, ports.ConnectionActionUpdate)
+	assert.ErrorIs(t, err, ports.ErrBusinessConnectionNotFound)
+}
+func TestBusinessConnectionService_Integration_LifecycleAndHistory(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	businessConnectionService := services.NewBusinessConnectionService(testutil.TestDB)
+	ctx := context.Background()
+	ownerA := models.User{FirstName: "OwnerA", LoginEmail: "ownera@lifecycle.com", Active: true}
+	ownerB := models.User{FirstName: "OwnerB", LoginEmail: "ownerb@lifecycle.com", Active: true}
+	testutil.TestDB.Create(&ownerA)
+	testutil.TestDB.Create(&ownerB)
+	bizA := models.Business{Name: "Lifecycle A", OperatorUserID: ownerA.ID, BusinessType: models.BusinessTypeOther, BusinessCategory: models.BusinessCategoryMixed, BusinessPhase: models.BusinessPhaseGrowth}
+	bizB := models.Business{Name: "Lifecycle B", OperatorUserID: ownerB.ID, BusinessType: models.BusinessTypeOther, BusinessCategory: models.BusinessCategoryMixed, BusinessPhase: models.BusinessPhaseGrowth}
+	testutil.TestDB.Create(&bizA)
+	testutil.TestDB.Create(&bizB)
+	aToB := ports.CreateBusinessConnectionInput{InitiatingBusinessID: bizA.ID, ReceivingBusinessID: bizB.ID, ConnectionType: models.ConnectionTypePartnership, InitiatedByUserID: ownerA.ID}
+	bToA := ports.CreateBusinessConnectionInput{InitiatingBusinessID: bizB.ID, ReceivingBusinessID: bizA.ID, ConnectionType: models.ConnectionTypePartnership, InitiatedByUserID: ownerB.ID}
+	conn, err := businessConnectionService.CreateBusinessConnection(ctx, aToB)
+	require.NoError(t, err)
+	_, err = businessConnectionService.CreateBusinessConnection(ctx, bToA)
+	assert.ErrorIs(t, err, ports.ErrBusinessConnectionAlreadyExists)
+	withdrawn, err := businessConnectionService.WithdrawBusinessConnection(ctx, conn.ID, ownerA.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ConnectionStatusWithdrawn, withdrawn.Status)
+	_, err = businessConnectionService.DeactivateBusinessConnection(ctx, conn.ID, ownerA.ID)
+	assert.ErrorIs(t, err, ports.ErrConnectionNotActive)
+	reopened, err := businessConnectionService.CreateBusinessConnection(ctx, aToB)
+	require.NoError(t, err)
+	assert.Equal(t, conn.ID, reopened.ID)
+	assert.Equal(t, models.ConnectionStatusPending, reopened.Status)
+	_, err = businessConnectionService.RejectBusinessConnection(ctx, conn.ID, ownerB.ID)
+	require.NoError(t, err)
+	_, err = businessConnectionService.CreateBusinessConnection(ctx, aToB)
+	assert.ErrorIs(t, err, ports.ErrConnectionCooldown)
+	testutil.TestDB.Model(&models.BusinessConnectionHistory{}).
+		Where("business_connection_id = ? AND to_status = ?", conn.ID, models.ConnectionStatusRejected).
+		Update("created_at", time.Now().Add(-31*24*time.Hour))
+	reopened, err = businessConnectionService.CreateBusinessConnection(ctx, aToB)
+	require.NoError(t, err)
+	assert.Equal(t, conn.ID, reopened.ID)
+	_, err = businessConnectionService.RejectBusinessConnection(ctx, conn.ID, ownerB.ID)
+	require.NoError(t, err)
+	reopened, err = businessConnectionService.CreateBusinessConnection(ctx, bToA)
+	require.NoError(t, err, "the business that rejected the request is not held to the cooldown")
+	assert.Equal(t, conn.ID, reopened.ID)
+	assert.Equal(t, bizB.ID, reopened.InitiatingBusinessID)
+	_, err = businessConnectionService.AcceptBusinessConnection(ctx, conn.ID, ownerA.ID)
+	require.NoError(t, err)
+	_, err = businessConnectionService.ReactivateBusinessConnection(ctx, conn.ID, ownerA.ID)
+	assert.ErrorIs(t, err, ports.ErrConnectionNotInactive)
+	_, err = businessConnectionService.DeactivateBusinessConnection(ctx, conn.ID, ownerB.ID)
+	require.NoError(t, err)
+	reactivated, err := businessConnectionService.ReactivateBusinessConnection(ctx, conn.ID, ownerA.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ConnectionStatusPending, reactivated.Status)
+	assert.Equal(t, bizA.ID, reactivated.InitiatingBusinessID)
+	assert.Equal(t, bizB.ID, reactivated.ReceivingBusinessID)
+	assert.Equal(t, ownerA.ID, reactivated.InitiatedByUserID)
+	history, err := businessConnectionService.GetBusinessConnectionHistory(ctx, conn.ID)
+	require.NoError(t, err)
+	expected := []models.BusinessConnectionStatus{
+		models.ConnectionStatusPending,
+		models.ConnectionStatusWithdrawn,
+		models.ConnectionStatusPending,
+		models.ConnectionStatusRejected,
+		models.ConnectionStatusPending,
+		models.ConnectionStatusRejected,
+		models.ConnectionStatusPending,
+		models.ConnectionStatusActive,
+		models.ConnectionStatusInactive,
+		models.ConnectionStatusPending,
+	}
+	require.Len(t, history, len(expected))
+	assert.Nil(t, history[0].FromStatus)
+	for i, status := range expected {
+		assert.Equal(t, status, history[i].ToStatus)
+	}
+	require.NoError(t, businessConnectionService.DeleteBusinessConnection(ctx, conn.ID))
+	history, err = businessConnectionService.GetBusinessConnectionHistory(ctx, conn.ID)
+	require.NoError(t, err)
+	assert.Len(t, history, len(expected), "deleting a connection keeps its status history")
+}
+func TestBusinessConnectionService_Integration_DeleteGuards(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	businessConnectionService := services.NewBusinessConnectionService(testutil.TestDB)
+	ctx := context.Background()
+	ownerA := models.User{FirstName: "OwnerA", LoginEmail: "ownera@deleteguard.com", Active: true}
+	ownerB := models.User{FirstName: "OwnerB", LoginEmail: "ownerb@deleteguard.com", Active: true}
+	testutil.TestDB.Create(&ownerA)
+	testutil.TestDB.Create(&ownerB)
+	bizA := models.Business{Name: "Guard A", OperatorUserID: ownerA.ID, BusinessType: models.BusinessTypeOther, BusinessCategory: models.BusinessCategoryMixed, BusinessPhase: models.BusinessPhaseGrowth}
+	bizB := models.Business{Name: "Guard B", OperatorUserID: ownerB.ID, BusinessType: models.BusinessTypeOther, BusinessCategory: models.BusinessCategoryMixed, BusinessPhase: models.BusinessPhaseGrowth}
+	bizC := models.Business{Name: "Guard C", OperatorUserID: ownerB.ID, BusinessType: models.BusinessTypeOther, BusinessCategory: models.BusinessCategoryMixed, BusinessPhase: models.BusinessPhaseGrowth}
+	testutil.TestDB.Create(&bizA)
+	testutil.TestDB.Create(&bizB)
+	testutil.TestDB.Create(&bizC)
+
+	rejected, err := businessConnectionService.CreateBusinessConnection(ctx, ports.CreateBusinessConnectionInput{InitiatingBusinessID: bizA.ID, ReceivingBusinessID: bizB.ID, ConnectionType: models.ConnectionTypePartnership, InitiatedByUserID: ownerA.ID})
+	require.NoError(t, err)
+	_, err = businessConnectionService.RejectBusinessConnection(ctx, rejected.ID, ownerB.ID)
+	require.NoError(t, err)
+	_, err = businessConnectionService.AuthorizeBusinessConnectionAction(ctx, rejected.ID, ownerA.ID, ports.ConnectionActionDelete)
+	assert.ErrorIs(t, err, ports.ErrRejectedConnectionNotDeletable)
+	assert.ErrorIs(t, businessConnectionService.DeleteBusinessConnection(ctx, rejected.ID), ports.ErrRejectedConnectionNotDeletable)
+	_, err = businessConnectionService.CreateBusinessConnection(ctx, ports.CreateBusinessConnectionInput{InitiatingBusinessID: bizA.ID, ReceivingBusinessID: bizB.ID, ConnectionType: models.ConnectionTypePartnership, InitiatedByUserID: ownerA.ID})
+	assert.ErrorIs(t, err, ports.ErrConnectionCooldown)
+
+	introduced, err := businessConnectionService.CreateBusinessConnection(ctx, ports.CreateBusinessConnectionInput{InitiatingBusinessID: bizA.ID, ReceivingBusinessID: bizC.ID, ConnectionType: models.ConnectionTypePartnership, InitiatedByUserID: ownerA.ID})
+	require.NoError(t, err)
+	introduction := models.IntroductionRequest{RequesterBusinessID: bizA.ID, IntroducerBusinessID: bizB.ID, TargetBusinessID: bizC.ID, RequestedByUserID: ownerA.ID, Status: models.IntroCompleted, BusinessConnectionID: &introduced.ID}
+	require.NoError(t, testutil.TestDB.Create(&introduction).Error)
+	referral := models.Referral{ReferrerBusinessID: bizA.ID, ReceiverBusinessID: bizC.ID, ClientName: "Client", ReferredByUserID: ownerA.ID, BusinessConnectionID: &introduced.ID}
+	require.NoError(t, testutil.TestDB.Create(&referral).Error)
+	require.NoError(t, businessConnectionService.DeleteBusinessConnection(ctx, introduced.ID))
+	require.NoError(t, testutil.TestDB.First(&introduction, introduction.ID).Error)
+	assert.Nil(t, introduction.BusinessConnectionID)
+	require.NoError(t, testutil.TestDB.First(&referral, referral.ID).Error)
+	assert.Nil(t, referral.BusinessConnectionID)
+	history, err := businessConnectionService.GetBusinessConnectionHistory(ctx, introduced.ID)
+	require.NoError(t, err)
+	assert.Len(t, history, 1)
+}
+func TestBusinessConnectionService_Integration_MergeDuplicateConnections(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	businessConnectionService := services.NewBusinessConnectionService(testutil.TestDB)
+	ctx := context.Background()
+	owner := models.User{FirstName: "Owner", LoginEmail: "owner@mergedup.com", Active: true}
+	testutil.TestDB.Create(&owner)
+	bizA := models.Business{Name: "Merge A", OperatorUserID: owner.ID, BusinessType: models.BusinessTypeOther, BusinessCategory: models.BusinessCategoryMixed, BusinessPhase: models.BusinessPhaseGrowth}
+	bizB := models.Business{Name: "Merge B", OperatorUserID: owner.ID, BusinessType: models.BusinessTypeOther, BusinessCategory: models.BusinessCategoryMixed, BusinessPhase: models.BusinessPhaseGrowth}
+	testutil.TestDB.Create(&bizA)
+	testutil.TestDB.Create(&bizB)
+	older := models.BusinessConnection{InitiatingBusinessID: bizA.ID, ReceivingBusinessID: bizB.ID, ConnectionType: models.ConnectionTypePartnership, Status: models.ConnectionStatusRejected, InitiatedByUserID: owner.ID, UpdatedAt: time.Now().Add(-time.Hour)}
+	newer := models.BusinessConnection{InitiatingBusinessID: bizB.ID, ReceivingBusinessID: bizA.ID, ConnectionType: models.ConnectionTypePartnership, Status: models.ConnectionStatusWithdrawn, InitiatedByUserID: owner.ID}
+	other := models.BusinessConnection{InitiatingBusinessID: bizB.ID, ReceivingBusinessID: bizA.ID, ConnectionType: models.ConnectionTypeSupplier, Status: models.ConnectionStatusActive, InitiatedByUserID: owner.ID}
+	require.NoError(t, testutil.TestDB.Create(&older).Error)
+	require.NoError(t, testutil.TestDB.Create(&newer).Error)
+	require.NoError(t, testutil.TestDB.Create(&other).Error)
+	require.NoError(t, testutil.TestDB.Create(&models.BusinessConnectionHistory{BusinessConnectionID: older.ID, ToStatus: models.ConnectionStatusRejected}).Error)
+
+	merged, err := businessConnectionService.MergeDuplicateConnections(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, merged)
+	_, err = businessConnectionService.GetBusinessConnection(ctx, older.ID)
+	assert.ErrorIs(t, err, ports.ErrBusinessConnectionNotFound)
+	history, err := businessConnectionService.GetBusinessConnectionHistory(ctx, newer.ID)
+	require.NoError(t, err)
+	assert.Len(t, history, 1)
+	_, err = businessConnectionService.GetBusinessConnection(ctx, other.ID)
+	assert.NoError(t, err)
+
+	reopened, err := businessConnectionService.CreateBusinessConnection(ctx, ports.CreateBusinessConnectionInput{InitiatingBusinessID: bizA.ID, ReceivingBusinessID: bizB.ID, ConnectionType: models.ConnectionTypePartnership, InitiatedByUserID: owner.ID})
+	require.NoError(t, err)
+	assert.Equal(t, newer.ID, reopened.ID)
+
+	merged, err = businessConnectionService.MergeDuplicateConnections(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, merged)
+}
+func TestBusinessConnectionService_Integration_GetBusinessNetwork(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	businessConnectionService := services.NewBusinessConnectionService(testutil.TestDB)
+	ctx := context.Background()
+	user := models.User{FirstName: "Networker", LoginEmail: "networker@business.com", Active: true}
+	testutil.TestDB.Create(&user)
+	newBusiness := func(name string) models.Business {
+		b := models.Business{Name: name, OperatorUserID: user.ID, BusinessType: models.BusinessTypeOther, BusinessCategory: models.BusinessCategoryMixed, BusinessPhase: models.BusinessPhaseGrowth}
+		testutil.TestDB.Create(&b)
+		return b
+	}
+	bizA, bizB, bizC, bizD, bizE, bizF := newBusiness("Net A"), newBusiness("Net B"), newBusiness("Net C"), newBusiness("Net D"), newBusiness("Net E"), newBusiness("Net F")
+	connect := func(from, to models.Business, status models.BusinessConnectionStatus) {
+		testutil.TestDB.Create(&models.BusinessConnection{InitiatingBusinessID: from.ID, ReceivingBusinessID: to.ID, ConnectionType: models.ConnectionTypePartnership, Status: status, InitiatedByUserID: user.ID})
+	}
+	connect(bizA, bizB, models.ConnectionStatusActive)
+	connect(bizE, bizA, models.ConnectionStatusActive)
+	connect(bizB, bizC, models.ConnectionStatusActive)
+	connect(bizC, bizE, models.ConnectionStatusActive)
+	connect(bizC, bizD, models.ConnectionStatusActive)
+	connect(bizA, bizF, models.ConnectionStatusPending)
+	network, err := businessConnectionService.GetBusinessNetwork(ctx, bizA.ID, 2)
+	require.NoError(t, err)
+	nodes := make(map[uint]ports.BusinessNetworkNode)
+	for _, node := range network.Nodes {
+		nodes[node.BusinessID] = node
+	}
+	require.Len(t, nodes, 4)
+	assert.Equal(t, 0, nodes[bizA.ID].Depth)
+	assert.Empty(t, nodes[bizA.ID].Via)
+	assert.Equal(t, 1, nodes[bizB.ID].Depth)
+	assert.Equal(t, 1, nodes[bizE.ID].Depth)
+	assert.Equal(t, 2, nodes[bizC.ID].Depth)
+	assert.Equal(t, 2, nodes[bizC.ID].MutualConnections)
+	assert.Equal(t, 0, nodes[bizB.ID].MutualConnections)
+	require.Len(t, nodes[bizC.ID].Via, 1)
+	assert.Contains(t, []uint{bizB.ID, bizE.ID}, nodes[bizC.ID].Via[0])
+	assert.Empty(t, nodes[bizB.ID].Via)
+	assert.NotContains(t, nodes, bizD.ID)
+	assert.NotContains(t, nodes, bizF.ID)
+	assert.Len(t, network.Edges, 4)
+	network, err = businessConnectionService.GetBusinessNetwork(ctx, bizA.ID, 3)
+	require.NoError(t, err)
+	assert.Len(t, network.Nodes, 5)
+	assert.Len(t, network.Edges, 5)
+	for _, node := range network.Nodes {
+		if node.BusinessID == bizD.ID {
+			assert.Equal(t, 3, node.Depth)
+			assert.Len(t, node.Via, 2)
+			assert.Equal(t, 0, node.MutualConnections)
+		}
+	}
+	_, err = businessConnectionService.GetBusinessNetwork(ctx, bizA.ID, 4)
+	assert.ErrorIs(t, err, ports.ErrInvalidNetworkDepth)
+	_, err = businessConnectionService.GetBusinessNetwork(ctx, bizF.ID+999, 2)
+	assert.ErrorIs(t, err, ports.ErrBusinessNotFound)
+}
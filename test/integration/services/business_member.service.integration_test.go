@@ -0,0 +1,215 @@
+package main
+import (
+	"context"
+	"testing"
+	"time"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/core/services"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/models"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/ports"
+	testutil "github.com/TIA-PARTNERS-GROUP/tia-api/test/test_util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+func createTeamBusiness(t *testing.T, ownerID uint) *models.Business {
+	business, err := services.NewBusinessService(testutil.TestDB, testutil.TestGeocoder).CreateBusiness(context.Background(), ports.CreateBusinessInput{
+		OperatorUserID:   ownerID,
+		Name:             "Team Co",
+		BusinessType:     models.BusinessTypeConsulting,
+		BusinessCategory: models.BusinessCategoryB2B,
+		BusinessPhase:    models.BusinessPhaseStartup,
+	})
+	require.NoError(t, err)
+	return business
+}
+func TestBusinessMemberService_Integration_InviteAndRespond(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	memberService := services.NewBusinessMemberService(testutil.TestDB)
+	ctx := context.Background()
+	owner := models.User{FirstName: "Owner", LoginEmail: "owner@team.com", Active: true}
+	invitee := models.User{FirstName: "Invitee", LoginEmail: "invitee@team.com", Active: true}
+	stranger := models.User{FirstName: "Stranger", LoginEmail: "stranger@team.com", Active: true}
+	testutil.TestDB.Create(&owner)
+	testutil.TestDB.Create(&invitee)
+	testutil.TestDB.Create(&stranger)
+	business := createTeamBusiness(t, owner.ID)
+	role, err := memberService.GetBusinessRole(ctx, business.ID, owner.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.BusinessRoleOwner, role)
+	_, err = memberService.CreateBusinessInvite(ctx, business.ID, stranger.ID, ports.CreateBusinessInviteInput{Email: "someone@team.com", Role: models.BusinessRoleViewer})
+	assert.ErrorIs(t, err, ports.ErrForbidden)
+	invite, err := memberService.CreateBusinessInvite(ctx, business.ID, owner.ID, ports.CreateBusinessInviteInput{Email: "Invitee@Team.com", Role: models.BusinessRoleEditor})
+	require.NoError(t, err)
+	assert.Equal(t, "invitee@team.com", invite.Email)
+	assert.Equal(t, models.BusinessInvitePending, invite.Status)
+	assert.WithinDuration(t, time.Now().Add(7*24*time.Hour), invite.ExpiresAt, time.Minute)
+	_, err = memberService.CreateBusinessInvite(ctx, business.ID, owner.ID, ports.CreateBusinessInviteInput{Email: "invitee@team.com", Role: models.BusinessRoleViewer})
+	assert.ErrorIs(t, err, ports.ErrBusinessInviteExists)
+	_, err = memberService.CreateBusinessInvite(ctx, business.ID, owner.ID, ports.CreateBusinessInviteInput{Email: "owner@team.com", Role: models.BusinessRoleViewer})
+	assert.ErrorIs(t, err, ports.ErrBusinessMemberExists)
+	var notifications int64
+	testutil.TestDB.Model(&models.Notification{}).Where("receiver_user_id = ? AND notification_type = ?", invitee.ID, models.NotifyBusinessInvite).Count(&notifications)
+	assert.Equal(t, int64(1), notifications)
+	pending, err := memberService.GetInvitesForUser(ctx, invitee.ID)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "Team Co", pending[0].Business.Name)
+	_, err = memberService.RespondToBusinessInvite(ctx, invite.ID, stranger.ID, true)
+	assert.ErrorIs(t, err, ports.ErrBusinessInviteNotFound)
+	accepted, err := memberService.RespondToBusinessInvite(ctx, invite.ID, invitee.ID, true)
+	require.NoError(t, err)
+	assert.Equal(t, models.BusinessInviteAccepted, accepted.Status)
+	assert.NotNil(t, accepted.RespondedAt)
+	role, err = memberService.GetBusinessRole(ctx, business.ID, invitee.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.BusinessRoleEditor, role)
+	_, err = memberService.RespondToBusinessInvite(ctx, invite.ID, invitee.ID, false)
+	assert.ErrorIs(t, err, ports.ErrBusinessInviteNotPending)
+	expired, err := memberService.CreateBusinessInvite(ctx, business.ID, owner.ID, ports.CreateBusinessInviteInput{Email: "stranger@team.com", Role: models.BusinessRoleViewer})
+	require.NoError(t, err)
+	testutil.TestDB.Model(expired).Update("expires_at", time.Now().Add(-time.Hour))
+	_, err = memberService.RespondToBusinessInvite(ctx, expired.ID, stranger.ID, true)
+	assert.ErrorIs(t, err, ports.ErrBusinessInviteExpired)
+	pending, err = memberService.GetInvitesForUser(ctx, stranger.ID)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+	notAllowed, err := memberService.CreateBusinessInvite(ctx, business.ID, invitee.ID, ports.CreateBusinessInviteInput{Email: "other@team.com", Role: models.BusinessRoleViewer})
+	assert.ErrorIs(t, err, ports.ErrForbidden)
+	assert.Nil(t, notAllowed)
+}
+func TestBusinessMemberService_Integration_RolesAndRemoval(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	memberService := services.NewBusinessMemberService(testutil.TestDB)
+	ctx := context.Background()
+	owner := models.User{FirstName: "Owner", LoginEmail: "owner@team.com", Active: true}
+	admin := models.User{FirstName: "Admin", LoginEmail: "admin@team.com", Active: true}
+	editor := models.User{FirstName: "Editor", LoginEmail: "editor@team.com", Active: true}
+	viewer := models.User{FirstName: "Viewer", LoginEmail: "viewer@team.com", Active: true}
+	testutil.TestDB.Create(&owner)
+	testutil.TestDB.Create(&admin)
+	testutil.TestDB.Create(&editor)
+	testutil.TestDB.Create(&viewer)
+	business := createTeamBusiness(t, owner.ID)
+	testutil.TestDB.Create(&models.BusinessMember{BusinessID: business.ID, UserID: admin.ID, Role: models.BusinessRoleAdmin, JoinedAt: time.Now()})
+	testutil.TestDB.Create(&models.BusinessMember{BusinessID: business.ID, UserID: editor.ID, Role: models.BusinessRoleEditor, JoinedAt: time.Now()})
+	testutil.TestDB.Create(&models.BusinessMember{BusinessID: business.ID, UserID: viewer.ID, Role: models.BusinessRoleViewer, JoinedAt: time.Now()})
+	members, err := memberService.GetBusinessMembers(ctx, business.ID, viewer.ID)
+	require.NoError(t, err)
+	require.Len(t, members, 4)
+	assert.Equal(t, owner.ID, members[0].UserID)
+	assert.Equal(t, viewer.ID, members[3].UserID)
+	_, err = memberService.UpdateBusinessMemberRole(ctx, business.ID, viewer.ID, editor.ID, ports.UpdateBusinessMemberRoleInput{Role: models.BusinessRoleEditor})
+	assert.ErrorIs(t, err, ports.ErrForbidden)
+	_, err = memberService.UpdateBusinessMemberRole(ctx, business.ID, viewer.ID, admin.ID, ports.UpdateBusinessMemberRoleInput{Role: models.BusinessRoleAdmin})
+	assert.ErrorIs(t, err, ports.ErrBusinessRoleTooHigh)
+	_, err = memberService.UpdateBusinessMemberRole(ctx, business.ID, owner.ID, admin.ID, ports.UpdateBusinessMemberRoleInput{Role: models.BusinessRoleViewer})
+	assert.ErrorIs(t, err, ports.ErrBusinessOwnerImmutable)
+	updated, err := memberService.UpdateBusinessMemberRole(ctx, business.ID, viewer.ID, admin.ID, ports.UpdateBusinessMemberRoleInput{Role: models.BusinessRoleEditor})
+	require.NoError(t, err)
+	assert.Equal(t, models.BusinessRoleEditor, updated.Role)
+	_, err = memberService.UpdateBusinessMemberRole(ctx, business.ID, admin.ID, owner.ID, ports.UpdateBusinessMemberRoleInput{Role: models.BusinessRoleViewer})
+	require.NoError(t, err)
+	err = memberService.RemoveBusinessMember(ctx, business.ID, editor.ID, admin.ID)
+	assert.ErrorIs(t, err, ports.ErrForbidden)
+	err = memberService.RemoveBusinessMember(ctx, business.ID, owner.ID, owner.ID)
+	assert.ErrorIs(t, err, ports.ErrBusinessOwnerImmutable)
+	require.NoError(t, memberService.RemoveBusinessMember(ctx, business.ID, admin.ID, admin.ID))
+	require.NoError(t, memberService.RemoveBusinessMember(ctx, business.ID, editor.ID, owner.ID))
+	err = memberService.RemoveBusinessMember(ctx, business.ID, editor.ID, owner.ID)
+	assert.ErrorIs(t, err, ports.ErrBusinessMemberNotFound)
+	members, err = memberService.GetBusinessMembers(ctx, business.ID, owner.ID)
+	require.NoError(t, err)
+	assert.Len(t, members, 2)
+	_, err = memberService.GetBusinessMembers(ctx, business.ID, admin.ID)
+	assert.ErrorIs(t, err, ports.ErrForbidden)
+}
+func TestBusinessMemberService_Integration_TransferOwnership(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	memberService := services.NewBusinessMemberService(testutil.TestDB)
+	businessService := services.NewBusinessService(testutil.TestDB, testutil.TestGeocoder)
+	ctx := context.Background()
+	owner := models.User{FirstName: "Owner", LoginEmail: "owner@team.com", Active: true}
+	admin := models.User{FirstName: "Admin", LoginEmail: "admin@team.com", Active: true}
+	outsider := models.User{FirstName: "Outsider", LoginEmail: "outsider@team.com", Active: true}
+	testutil.TestDB.Create(&owner)
+	testutil.TestDB.Create(&admin)
+	testutil.TestDB.Create(&outsider)
+	business := createTeamBusiness(t, owner.ID)
+	testutil.TestDB.Create(&models.BusinessMember{BusinessID: business.ID, UserID: admin.ID, Role: models.BusinessRoleAdmin, JoinedAt: time.Now()})
+	_, err := memberService.TransferBusinessOwnership(ctx, business.ID, admin.ID, ports.TransferBusinessOwnershipInput{UserID: admin.ID})
+	assert.ErrorIs(t, err, ports.ErrForbidden)
+	_, err = memberService.TransferBusinessOwnership(ctx, business.ID, owner.ID, ports.TransferBusinessOwnershipInput{UserID: outsider.ID})
+	assert.ErrorIs(t, err, ports.ErrInvalidOwnershipTransfer)
+	transferred, err := memberService.TransferBusinessOwnership(ctx, business.ID, owner.ID, ports.TransferBusinessOwnershipInput{UserID: admin.ID})
+	require.NoError(t, err)
+	assert.Equal(t, admin.ID, transferred.OperatorUserID)
+	role, err := memberService.GetBusinessRole(ctx, business.ID, owner.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.BusinessRoleAdmin, role)
+	role, err = memberService.GetBusinessRole(ctx, business.ID, admin.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.BusinessRoleOwner, role)
+	err = businessService.DeleteBusiness(ctx, business.ID, owner.ID)
+	assert.ErrorIs(t, err, ports.ErrForbidden)
+	name := "Renamed By Former Owner"
+	_, err = businessService.UpdateBusiness(ctx, business.ID, owner.ID, ports.UpdateBusinessInput{Name: &name})
+	require.NoError(t, err)
+	require.NoError(t, businessService.DeleteBusiness(ctx, business.ID, admin.ID))
+	var memberCount int64
+	testutil.TestDB.Model(&models.BusinessMember{}).Where("business_id = ?", business.ID).Count(&memberCount)
+	assert.Zero(t, memberCount)
+}
+func TestBusinessMemberService_Integration_RoleBasedAuthorization(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	memberService := services.NewBusinessMemberService(testutil.TestDB)
+	businessService := services.NewBusinessService(testutil.TestDB, testutil.TestGeocoder)
+	pubService := services.NewPublicationService(testutil.TestDB)
+	ctx := context.Background()
+	owner := models.User{FirstName: "Owner", LoginEmail: "owner@team.com", Active: true}
+	editor := models.User{FirstName: "Editor", LoginEmail: "editor@team.com", Active: true}
+	viewer := models.User{FirstName: "Viewer", LoginEmail: "viewer@team.com", Active: true}
+	testutil.TestDB.Create(&owner)
+	testutil.TestDB.Create(&editor)
+	testutil.TestDB.Create(&viewer)
+	business := createTeamBusiness(t, owner.ID)
+	testutil.TestDB.Create(&models.BusinessMember{BusinessID: business.ID, UserID: editor.ID, Role: models.BusinessRoleEditor, JoinedAt: time.Now()})
+	testutil.TestDB.Create(&models.BusinessMember{BusinessID: business.ID, UserID: viewer.ID, Role: models.BusinessRoleViewer, JoinedAt: time.Now()})
+	name := "Editor Rename"
+	_, err := businessService.UpdateBusiness(ctx, business.ID, editor.ID, ports.UpdateBusinessInput{Name: &name})
+	assert.ErrorIs(t, err, ports.ErrForbidden)
+	assert.NoError(t, memberService.AuthorizeBusinessRole(ctx, business.ID, editor.ID, models.BusinessRoleEditor))
+	assert.ErrorIs(t, memberService.AuthorizeBusinessRole(ctx, business.ID, viewer.ID, models.BusinessRoleEditor), ports.ErrForbidden)
+	assert.ErrorIs(t, memberService.AuthorizeBusinessRole(ctx, business.ID+999, owner.ID, models.BusinessRoleViewer), ports.ErrBusinessNotFound)
+	_, err = pubService.CreatePublication(ctx, ports.CreatePublicationInput{UserID: viewer.ID, BusinessID: &business.ID, PublicationType: models.PublicationPost, Title: "Viewer Post", Content: "Body"})
+	assert.ErrorIs(t, err, ports.ErrForbidden)
+	pub, err := pubService.CreatePublication(ctx, ports.CreatePublicationInput{UserID: owner.ID, BusinessID: &business.ID, PublicationType: models.PublicationPost, Title: "Team Post", Content: "Body"})
+	require.NoError(t, err)
+	canEdit, err := pubService.CanEditPublication(ctx, pub, editor.ID)
+	require.NoError(t, err)
+	assert.True(t, canEdit)
+	canEdit, err = pubService.CanEditPublication(ctx, pub, viewer.ID)
+	require.NoError(t, err)
+	assert.False(t, canEdit)
+	canView, err := pubService.CanViewPublication(ctx, pub, &viewer.ID)
+	require.NoError(t, err)
+	assert.False(t, canView, "team drafts are only visible to their author")
+	businesses, err := businessService.GetUserBusinesses(ctx, viewer.ID)
+	require.NoError(t, err)
+	require.Len(t, businesses, 1)
+	assert.Equal(t, business.ID, businesses[0].ID)
+}
+func TestBusinessMemberService_Integration_EnsureOperatorMemberships(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	memberService := services.NewBusinessMemberService(testutil.TestDB)
+	ctx := context.Background()
+	owner := models.User{FirstName: "Owner", LoginEmail: "owner@team.com", Active: true}
+	testutil.TestDB.Create(&owner)
+	legacy := models.Business{Name: "Legacy", OperatorUserID: owner.ID, BusinessType: models.BusinessTypeOther, BusinessCategory: models.BusinessCategoryMixed, BusinessPhase: models.BusinessPhaseGrowth}
+	testutil.TestDB.Create(&legacy)
+	require.NoError(t, memberService.EnsureOperatorMemberships(ctx))
+	require.NoError(t, memberService.EnsureOperatorMemberships(ctx))
+	var members []models.BusinessMember
+	testutil.TestDB.Where("business_id = ?", legacy.ID).Find(&members)
+	require.Len(t, members, 1)
+	assert.Equal(t, owner.ID, members[0].UserID)
+	assert.Equal(t, models.BusinessRoleOwner, members[0].Role)
+}
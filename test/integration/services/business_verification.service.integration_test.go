@@ -0,0 +1,125 @@
+package main
+import (
+	"context"
+	"testing"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/core/services"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/models"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/ports"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/pkg/verification"
+	testutil "github.com/TIA-PARTNERS-GROUP/tia-api/test/test_util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+func TestBusinessVerificationService_Integration_Workflow(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	resolver := testutil.NewStubResolver()
+	verificationService := services.NewBusinessVerificationService(testutil.TestDB, resolver)
+	businessService := services.NewBusinessService(testutil.TestDB, testutil.TestGeocoder)
+	ctx := context.Background()
+	owner := models.User{FirstName: "Owner", LoginEmail: "owner@verify.com", Active: true}
+	admin := models.User{FirstName: "Admin", LoginEmail: "admin@verify.com", Active: true, PlatformAdmin: true}
+	testutil.TestDB.Create(&owner)
+	testutil.TestDB.Create(&admin)
+	website := "https://www.verify-me.com.au/about"
+	business := models.Business{Name: "Verify Me", OperatorUserID: owner.ID, Website: &website, BusinessType: models.BusinessTypeOther, BusinessCategory: models.BusinessCategoryMixed, BusinessPhase: models.BusinessPhaseGrowth}
+	testutil.TestDB.Create(&business)
+	_, err := verificationService.RequestVerification(ctx, business.ID, admin.ID, ports.RequestVerificationInput{Method: models.VerificationMethodDNS})
+	assert.ErrorIs(t, err, ports.ErrForbidden)
+	request, err := verificationService.RequestVerification(ctx, business.ID, owner.ID, ports.RequestVerificationInput{Method: models.VerificationMethodDNS})
+	require.NoError(t, err)
+	assert.Equal(t, "verify-me.com.au", request.Domain)
+	assert.Equal(t, models.VerificationPendingCheck, request.Status)
+	_, err = verificationService.RequestVerification(ctx, business.ID, owner.ID, ports.RequestVerificationInput{Method: models.VerificationMethodHTMLFile})
+	assert.ErrorIs(t, err, ports.ErrVerificationInProgress)
+	_, err = verificationService.CheckVerification(ctx, business.ID, owner.ID)
+	assert.ErrorIs(t, err, ports.ErrVerificationTokenMissing)
+	resolver.SetTXT("verify-me.com.au", "v=spf1 -all", verification.TXTRecordValue(request.Token))
+	request, err = verificationService.CheckVerification(ctx, business.ID, owner.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.VerificationPendingReview, request.Status)
+	assert.NotNil(t, request.CheckedAt)
+	_, err = verificationService.GetVerificationQueue(ctx, owner.ID, ports.VerificationsFilter{})
+	assert.ErrorIs(t, err, ports.ErrPlatformAdminRequired)
+	queue, err := verificationService.GetVerificationQueue(ctx, admin.ID, ports.VerificationsFilter{})
+	require.NoError(t, err)
+	require.Len(t, queue, 1)
+	assert.Equal(t, request.ID, queue[0].ID)
+	_, err = verificationService.ReviewVerification(ctx, request.ID, owner.ID, true, ports.ReviewVerificationInput{})
+	assert.ErrorIs(t, err, ports.ErrPlatformAdminRequired)
+	request, err = verificationService.ReviewVerification(ctx, request.ID, admin.ID, true, ports.ReviewVerificationInput{})
+	require.NoError(t, err)
+	assert.Equal(t, models.VerificationApproved, request.Status)
+	assert.True(t, request.Business.Verified)
+	_, err = verificationService.ReviewVerification(ctx, request.ID, admin.ID, false, ports.ReviewVerificationInput{})
+	assert.ErrorIs(t, err, ports.ErrVerificationNotPending)
+	var notified int64
+	testutil.TestDB.Model(&models.Notification{}).Where("receiver_user_id = ? AND notification_type = ?", owner.ID, models.NotifyBusinessVerification).Count(&notified)
+	assert.Equal(t, int64(1), notified)
+	verified := true
+	list, err := businessService.GetBusinesses(ctx, ports.BusinessesFilter{Verified: &verified})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, business.ID, list[0].ID)
+	_, err = verificationService.RequestVerification(ctx, business.ID, owner.ID, ports.RequestVerificationInput{Method: models.VerificationMethodDNS})
+	assert.ErrorIs(t, err, ports.ErrBusinessAlreadyVerified)
+	_, err = verificationService.RevokeVerification(ctx, business.ID, owner.ID, ports.RevokeVerificationInput{Reason: "Self revoke"})
+	assert.ErrorIs(t, err, ports.ErrPlatformAdminRequired)
+	request, err = verificationService.RevokeVerification(ctx, business.ID, admin.ID, ports.RevokeVerificationInput{Reason: "Domain sold"})
+	require.NoError(t, err)
+	assert.Equal(t, models.VerificationRevoked, request.Status)
+	require.NotNil(t, request.RevocationReason)
+	assert.Equal(t, "Domain sold", *request.RevocationReason)
+	assert.False(t, request.Business.Verified)
+	_, err = verificationService.RevokeVerification(ctx, business.ID, admin.ID, ports.RevokeVerificationInput{Reason: "Again"})
+	assert.ErrorIs(t, err, ports.ErrBusinessNotVerified)
+}
+func TestBusinessVerificationService_Integration_FileMethodAndWebsiteChange(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	resolver := testutil.NewStubResolver()
+	verificationService := services.NewBusinessVerificationService(testutil.TestDB, resolver)
+	businessService := services.NewBusinessService(testutil.TestDB, testutil.TestGeocoder)
+	ctx := context.Background()
+	owner := models.User{FirstName: "Owner", LoginEmail: "owner@file-verify.com", Active: true}
+	admin := models.User{FirstName: "Admin", LoginEmail: "admin@file-verify.com", Active: true, PlatformAdmin: true}
+	testutil.TestDB.Create(&owner)
+	testutil.TestDB.Create(&admin)
+	business := models.Business{Name: "No Website", OperatorUserID: owner.ID, BusinessType: models.BusinessTypeOther, BusinessCategory: models.BusinessCategoryMixed, BusinessPhase: models.BusinessPhaseGrowth}
+	testutil.TestDB.Create(&business)
+	_, err := verificationService.RequestVerification(ctx, business.ID, owner.ID, ports.RequestVerificationInput{Method: models.VerificationMethodHTMLFile})
+	assert.ErrorIs(t, err, ports.ErrVerificationNoWebsite)
+	website := "file-verify.com"
+	_, err = businessService.UpdateBusiness(ctx, business.ID, owner.ID, ports.UpdateBusinessInput{Website: &website})
+	require.NoError(t, err)
+	request, err := verificationService.RequestVerification(ctx, business.ID, owner.ID, ports.RequestVerificationInput{Method: models.VerificationMethodHTMLFile})
+	require.NoError(t, err)
+	resolver.SetFile("file-verify.com", verification.WellKnownPath, []byte("wrong-token\n"))
+	_, err = verificationService.CheckVerification(ctx, business.ID, owner.ID)
+	assert.ErrorIs(t, err, ports.ErrVerificationTokenMissing)
+	resolver.SetFile("file-verify.com", verification.WellKnownPath, []byte(request.Token+"\n"))
+	_, err = verificationService.CheckVerification(ctx, business.ID, owner.ID)
+	require.NoError(t, err)
+	_, err = verificationService.ReviewVerification(ctx, request.ID, admin.ID, true, ports.ReviewVerificationInput{})
+	require.NoError(t, err)
+	sameDomain := "https://www.file-verify.com/contact"
+	updated, err := businessService.UpdateBusiness(ctx, business.ID, owner.ID, ports.UpdateBusinessInput{Website: &sameDomain})
+	require.NoError(t, err)
+	assert.True(t, updated.Verified)
+	newDomain := "https://another-domain.com"
+	updated, err = businessService.UpdateBusiness(ctx, business.ID, owner.ID, ports.UpdateBusinessInput{Website: &newDomain})
+	require.NoError(t, err)
+	assert.False(t, updated.Verified)
+	latest, err := verificationService.GetBusinessVerification(ctx, business.ID, owner.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.VerificationRevoked, latest.Status)
+	pending, err := verificationService.RequestVerification(ctx, business.ID, owner.ID, ports.RequestVerificationInput{Method: models.VerificationMethodHTMLFile})
+	require.NoError(t, err)
+	assert.Equal(t, "another-domain.com", pending.Domain)
+	thirdDomain := "https://third-domain.com"
+	_, err = businessService.UpdateBusiness(ctx, business.ID, owner.ID, ports.UpdateBusinessInput{Website: &thirdDomain})
+	require.NoError(t, err)
+	latest, err = verificationService.GetBusinessVerification(ctx, business.ID, owner.ID)
+	require.NoError(t, err)
+	assert.Equal(t, pending.ID, latest.ID)
+	assert.Equal(t, models.VerificationRevoked, latest.Status, "open verifications for the old domain are cancelled")
+	assert.Equal(t, "Website domain changed", *latest.RevocationReason)
+}
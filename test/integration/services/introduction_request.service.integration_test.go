@@ -0,0 +1,123 @@
+package main
+import (
+	"context"
+	"testing"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/core/services"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/models"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/ports"
+	testutil "github.com/TIA-PARTNERS-GROUP/tia-api/test/test_util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+type introductionFixture struct {
+	requesterOwner, introducerOwner, targetOwner models.User
+	requester, introducer, target                models.Business
+}
+func setupIntroductionFixture(t *testing.T) introductionFixture {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	var f introductionFixture
+	f.requesterOwner = models.User{FirstName: "Requester", LoginEmail: "requester@intro.com", Active: true}
+	f.introducerOwner = models.User{FirstName: "Introducer", LoginEmail: "introducer@intro.com", Active: true}
+	f.targetOwner = models.User{FirstName: "Target", LoginEmail: "target@intro.com", Active: true}
+	testutil.TestDB.Create(&f.requesterOwner)
+	testutil.TestDB.Create(&f.introducerOwner)
+	testutil.TestDB.Create(&f.targetOwner)
+	f.requester = models.Business{Name: "Requester Co", OperatorUserID: f.requesterOwner.ID, BusinessType: models.BusinessTypeOther, BusinessCategory: models.BusinessCategoryMixed, BusinessPhase: models.BusinessPhaseGrowth}
+	f.introducer = models.Business{Name: "Introducer Co", OperatorUserID: f.introducerOwner.ID, BusinessType: models.BusinessTypeOther, BusinessCategory: models.BusinessCategoryMixed, BusinessPhase: models.BusinessPhaseGrowth}
+	f.target = models.Business{Name: "Target Co", OperatorUserID: f.targetOwner.ID, BusinessType: models.BusinessTypeOther, BusinessCategory: models.BusinessCategoryMixed, BusinessPhase: models.BusinessPhaseGrowth}
+	testutil.TestDB.Create(&f.requester)
+	testutil.TestDB.Create(&f.introducer)
+	testutil.TestDB.Create(&f.target)
+	testutil.TestDB.Create(&models.BusinessConnection{InitiatingBusinessID: f.requester.ID, ReceivingBusinessID: f.introducer.ID, ConnectionType: models.ConnectionTypePartnership, Status: models.ConnectionStatusActive, InitiatedByUserID: f.requesterOwner.ID})
+	testutil.TestDB.Create(&models.BusinessConnection{InitiatingBusinessID: f.target.ID, ReceivingBusinessID: f.introducer.ID, ConnectionType: models.ConnectionTypeClient, Status: models.ConnectionStatusActive, InitiatedByUserID: f.targetOwner.ID})
+	return f
+}
+func (f introductionFixture) input() ports.CreateIntroductionRequestInput {
+	return ports.CreateIntroductionRequestInput{RequesterBusinessID: f.requester.ID, IntroducerBusinessID: f.introducer.ID, TargetBusinessID: f.target.ID}
+}
+func TestBusinessConnectionService_Integration_GetMutualConnections(t *testing.T) {
+	f := setupIntroductionFixture(t)
+	connectionService := services.NewBusinessConnectionService(testutil.TestDB)
+	ctx := context.Background()
+	mutual, err := connectionService.GetMutualConnections(ctx, f.requester.ID, f.target.ID)
+	require.NoError(t, err)
+	require.Len(t, mutual, 1)
+	assert.Equal(t, f.introducer.ID, mutual[0].ID)
+	mutual, err = connectionService.GetMutualConnections(ctx, f.requester.ID, f.introducer.ID)
+	require.NoError(t, err)
+	assert.Empty(t, mutual)
+	_, err = connectionService.GetMutualConnections(ctx, f.requester.ID, f.requester.ID)
+	assert.ErrorIs(t, err, ports.ErrCannotConnectToSelf)
+	_, err = connectionService.GetMutualConnections(ctx, f.requester.ID, f.target.ID+999)
+	assert.ErrorIs(t, err, ports.ErrBusinessNotFound)
+}
+func TestIntroductionRequestService_Integration_CompletesWithReferral(t *testing.T) {
+	f := setupIntroductionFixture(t)
+	introService := services.NewIntroductionRequestService(testutil.TestDB)
+	ctx := context.Background()
+	intro, err := introService.CreateIntroductionRequest(ctx, f.requesterOwner.ID, f.input())
+	require.NoError(t, err)
+	assert.Equal(t, models.IntroPendingIntroducer, intro.Status)
+	_, err = introService.CreateIntroductionRequest(ctx, f.requesterOwner.ID, f.input())
+	assert.ErrorIs(t, err, ports.ErrIntroductionExists)
+	var notified int64
+	testutil.TestDB.Model(&models.Notification{}).Where("receiver_user_id = ? AND notification_type = ?", f.introducerOwner.ID, models.NotifyIntroductionRequest).Count(&notified)
+	assert.Equal(t, int64(1), notified)
+	_, err = introService.GetIntroductionRequest(ctx, intro.ID, f.targetOwner.ID)
+	assert.ErrorIs(t, err, ports.ErrIntroductionNotFound)
+	_, err = introService.AcceptIntroductionRequest(ctx, intro.ID, f.targetOwner.ID)
+	assert.ErrorIs(t, err, ports.ErrForbidden)
+	intro, err = introService.AcceptIntroductionRequest(ctx, intro.ID, f.introducerOwner.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.IntroPendingTarget, intro.Status)
+	require.NotNil(t, intro.IntroducerUserID)
+	assert.Equal(t, f.introducerOwner.ID, *intro.IntroducerUserID)
+	_, err = introService.GetIntroductionRequest(ctx, intro.ID, f.targetOwner.ID)
+	assert.NoError(t, err)
+	_, err = introService.AcceptIntroductionRequest(ctx, intro.ID, f.introducerOwner.ID)
+	assert.ErrorIs(t, err, ports.ErrForbidden)
+	intro, err = introService.AcceptIntroductionRequest(ctx, intro.ID, f.targetOwner.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.IntroCompleted, intro.Status)
+	require.NotNil(t, intro.BusinessConnectionID)
+	var referral models.BusinessConnection
+	require.NoError(t, testutil.TestDB.First(&referral, *intro.BusinessConnectionID).Error)
+	assert.Equal(t, models.ConnectionTypeReferral, referral.ConnectionType)
+	assert.Equal(t, models.ConnectionStatusActive, referral.Status)
+	assert.Equal(t, f.requester.ID, referral.InitiatingBusinessID)
+	assert.Equal(t, f.target.ID, referral.ReceivingBusinessID)
+	testutil.TestDB.Model(&models.Notification{}).Where("receiver_user_id = ? AND notification_type = ?", f.requesterOwner.ID, models.NotifyIntroductionRequest).Count(&notified)
+	assert.Equal(t, int64(2), notified)
+	_, err = introService.DeclineIntroductionRequest(ctx, intro.ID, f.targetOwner.ID)
+	assert.ErrorIs(t, err, ports.ErrIntroductionNotPending)
+	_, err = introService.CreateIntroductionRequest(ctx, f.requesterOwner.ID, f.input())
+	assert.ErrorIs(t, err, ports.ErrBusinessConnectionAlreadyExists)
+	intros, err := introService.GetBusinessIntroductions(ctx, f.target.ID, f.targetOwner.ID, nil)
+	require.NoError(t, err)
+	assert.Len(t, intros, 1)
+}
+func TestIntroductionRequestService_Integration_Validation(t *testing.T) {
+	f := setupIntroductionFixture(t)
+	introService := services.NewIntroductionRequestService(testutil.TestDB)
+	ctx := context.Background()
+	_, err := introService.CreateIntroductionRequest(ctx, f.introducerOwner.ID, f.input())
+	assert.ErrorIs(t, err, ports.ErrForbidden)
+	invalid := f.input()
+	invalid.TargetBusinessID = f.requester.ID
+	_, err = introService.CreateIntroductionRequest(ctx, f.requesterOwner.ID, invalid)
+	assert.ErrorIs(t, err, ports.ErrInvalidIntroduction)
+	testutil.TestDB.Model(&models.BusinessConnection{}).Where("initiating_business_id = ?", f.target.ID).Update("status", models.ConnectionStatusInactive)
+	_, err = introService.CreateIntroductionRequest(ctx, f.requesterOwner.ID, f.input())
+	assert.ErrorIs(t, err, ports.ErrIntroducerNotMutual)
+	testutil.TestDB.Model(&models.BusinessConnection{}).Where("initiating_business_id = ?", f.target.ID).Update("status", models.ConnectionStatusActive)
+	intro, err := introService.CreateIntroductionRequest(ctx, f.requesterOwner.ID, f.input())
+	require.NoError(t, err)
+	intro, err = introService.DeclineIntroductionRequest(ctx, intro.ID, f.introducerOwner.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.IntroDeclined, intro.Status)
+	assert.Nil(t, intro.BusinessConnectionID)
+	_, err = introService.AcceptIntroductionRequest(ctx, intro.ID, f.introducerOwner.ID)
+	assert.ErrorIs(t, err, ports.ErrIntroductionNotPending)
+	_, err = introService.CreateIntroductionRequest(ctx, f.requesterOwner.ID, f.input())
+	assert.NoError(t, err, "a declined introduction can be requested again")
+}
@@ -0,0 +1,229 @@
+package main
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/core/services"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/models"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/ports"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/pkg/storage"
+	testutil "github.com/TIA-PARTNERS-GROUP/tia-api/test/test_util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+func testPNG(t *testing.T, w, h int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+func newTestMediaService(t *testing.T) *services.MediaService {
+	store, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+	return services.NewMediaService(testutil.TestDB, store, 2<<20)
+}
+func TestMediaService_Integration_UploadGeneratesVariants(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	mediaService := newTestMediaService(t)
+	ctx := context.Background()
+	owner := models.User{FirstName: "Owner", LoginEmail: "owner@media.com", Active: true}
+	testutil.TestDB.Create(&owner)
+	media, err := mediaService.UploadMedia(ctx, owner.ID, ports.UploadMediaInput{Purpose: models.MediaPurposeThumbnail}, "../../cover.png", bytes.NewReader(testPNG(t, 1000, 600)))
+	require.NoError(t, err)
+	assert.Equal(t, owner.ID, media.OwnerUserID)
+	assert.Equal(t, models.MediaPurposeThumbnail, media.Purpose)
+	assert.Equal(t, "cover.png", media.OriginalFilename)
+	assert.Equal(t, "image/png", media.ContentType)
+	assert.Equal(t, 1000, media.Width)
+	assert.Equal(t, 600, media.Height)
+	assert.Len(t, media.Checksum, 64)
+	sizes := map[string][2]int{}
+	for _, v := range media.Variants {
+		sizes[v.Name] = [2]int{v.Width, v.Height}
+	}
+	assert.Equal(t, map[string][2]int{"thumb": {150, 150}, "small": {320, 192}, "medium": {800, 480}}, sizes)
+	body, contentType, err := mediaService.OpenMediaContent(ctx, media.ID, "small")
+	require.NoError(t, err)
+	cfg, _, err := image.DecodeConfig(body)
+	body.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", contentType)
+	assert.Equal(t, 320, cfg.Width)
+	assert.Equal(t, 192, cfg.Height)
+	body, _, err = mediaService.OpenMediaContent(ctx, media.ID, "large")
+	require.NoError(t, err)
+	cfg, _, err = image.DecodeConfig(body)
+	body.Close()
+	require.NoError(t, err)
+	assert.Equal(t, 1000, cfg.Width, "missing variant falls back to the original")
+	_, _, err = mediaService.OpenMediaContent(ctx, media.ID+999, "")
+	assert.ErrorIs(t, err, ports.ErrMediaNotFound)
+}
+func TestMediaService_Integration_UploadValidation(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	ctx := context.Background()
+	owner := models.User{FirstName: "Owner", LoginEmail: "owner@media.com", Active: true}
+	testutil.TestDB.Create(&owner)
+	store, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+	mediaService := services.NewMediaService(testutil.TestDB, store, 4096)
+	_, err = mediaService.UploadMedia(ctx, owner.ID, ports.UploadMediaInput{}, "empty.png", bytes.NewReader(nil))
+	assert.ErrorIs(t, err, ports.ErrMediaFileRequired)
+	_, err = mediaService.UploadMedia(ctx, owner.ID, ports.UploadMediaInput{}, "notes.png", strings.NewReader("just some text pretending to be an image"))
+	assert.ErrorIs(t, err, ports.ErrMediaUnsupportedType)
+	_, err = mediaService.UploadMedia(ctx, owner.ID, ports.UploadMediaInput{}, "broken.png", strings.NewReader("\x89PNG\r\n\x1a\ngarbage"))
+	assert.ErrorIs(t, err, ports.ErrMediaInvalidImage)
+	_, err = mediaService.UploadMedia(ctx, owner.ID, ports.UploadMediaInput{}, "big.png", bytes.NewReader(testPNG(t, 400, 400)))
+	assert.ErrorIs(t, err, ports.ErrMediaTooLarge)
+	var count int64
+	testutil.TestDB.Model(&models.Media{}).Count(&count)
+	assert.Zero(t, count)
+}
+func TestMediaService_Integration_ReferencesAndDelete(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	mediaService := newTestMediaService(t)
+	userService := services.NewUserService(testutil.TestDB)
+	businessService := services.NewBusinessService(testutil.TestDB, testutil.TestGeocoder)
+	pubService := services.NewPublicationService(testutil.TestDB)
+	ctx := context.Background()
+	owner := models.User{FirstName: "Owner", LoginEmail: "owner@media.com", Active: true}
+	other := models.User{FirstName: "Other", LoginEmail: "other@media.com", Active: true}
+	testutil.TestDB.Create(&owner)
+	testutil.TestDB.Create(&other)
+	media, err := mediaService.UploadMedia(ctx, owner.ID, ports.UploadMediaInput{}, "photo.png", bytes.NewReader(testPNG(t, 400, 300)))
+	require.NoError(t, err)
+	_, err = userService.UpdateUser(ctx, other.ID, ports.UserUpdateSchema{AvatarMediaID: &media.ID})
+	assert.ErrorIs(t, err, ports.ErrMediaNotOwned)
+	missingID := media.ID + 999
+	_, err = userService.UpdateUser(ctx, owner.ID, ports.UserUpdateSchema{AvatarMediaID: &missingID})
+	assert.ErrorIs(t, err, ports.ErrMediaNotFound)
+	updatedUser, err := userService.UpdateUser(ctx, owner.ID, ports.UserUpdateSchema{AvatarMediaID: &media.ID})
+	require.NoError(t, err)
+	require.NotNil(t, updatedUser.AvatarMediaID)
+	assert.Equal(t, media.ID, *updatedUser.AvatarMediaID)
+	business, err := businessService.CreateBusiness(ctx, ports.CreateBusinessInput{
+		OperatorUserID:   owner.ID,
+		Name:             "Media Co",
+		BusinessType:     models.BusinessTypeConsulting,
+		BusinessCategory: models.BusinessCategoryB2B,
+		BusinessPhase:    models.BusinessPhaseStartup,
+		LogoMediaID:      &media.ID,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, business.LogoMediaID)
+	published := true
+	pub, err := pubService.CreatePublication(ctx, ports.CreatePublicationInput{
+		UserID:          owner.ID,
+		PublicationType: models.PublicationPost,
+		Title:           "With Image",
+		Content:         "Body",
+		Published:       &published,
+		MediaID:         &media.ID,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, pub.MediaID)
+	assert.Equal(t, ports.MediaURL(media.ID, "medium"), *ports.MapPublicationToResponse(pub).Thumbnail)
+	otherMedia, err := mediaService.UploadMedia(ctx, other.ID, ports.UploadMediaInput{}, "edit.png", bytes.NewReader(testPNG(t, 400, 300)))
+	require.NoError(t, err)
+	shared, err := pubService.CreatePublication(ctx, ports.CreatePublicationInput{
+		UserID:          owner.ID,
+		PublicationType: models.PublicationPost,
+		Title:           "Edited By Someone Else",
+		Content:         "Body",
+	})
+	require.NoError(t, err)
+	edited, err := pubService.UpdatePublication(ctx, shared.ID, ports.UpdatePublicationInput{MediaID: &otherMedia.ID, EditorUserID: other.ID})
+	require.NoError(t, err, "editors attach media they uploaded themselves")
+	require.NotNil(t, edited.MediaID)
+	assert.Equal(t, otherMedia.ID, *edited.MediaID)
+	_, err = pubService.UpdatePublication(ctx, shared.ID, ports.UpdatePublicationInput{MediaID: &media.ID, EditorUserID: other.ID})
+	assert.ErrorIs(t, err, ports.ErrMediaNotOwned)
+	err = mediaService.DeleteMedia(ctx, media.ID, other.ID)
+	assert.ErrorIs(t, err, ports.ErrForbidden)
+	require.NoError(t, mediaService.DeleteMedia(ctx, media.ID, owner.ID))
+	_, err = mediaService.GetMediaByID(ctx, media.ID)
+	assert.ErrorIs(t, err, ports.ErrMediaNotFound)
+	var reloadedUser models.User
+	testutil.TestDB.First(&reloadedUser, owner.ID)
+	assert.Nil(t, reloadedUser.AvatarMediaID)
+	var reloadedBusiness models.Business
+	testutil.TestDB.First(&reloadedBusiness, business.ID)
+	assert.Nil(t, reloadedBusiness.LogoMediaID)
+	var reloadedPub models.Publication
+	testutil.TestDB.First(&reloadedPub, pub.ID)
+	assert.Nil(t, reloadedPub.MediaID)
+	var variantCount int64
+	testutil.TestDB.Model(&models.MediaVariant{}).Where("media_id = ?", media.ID).Count(&variantCount)
+	assert.Zero(t, variantCount)
+}
+func TestMediaService_Integration_S3Storage(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	ctx := context.Background()
+	var mu sync.Mutex
+	objects := map[string][]byte{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=test-access/") || r.Header.Get("X-Amz-Date") == "" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		sum := sha256.Sum256(body)
+		if r.Header.Get("X-Amz-Content-Sha256") != hex.EncodeToString(sum[:]) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.Method {
+		case http.MethodPut:
+			objects[r.URL.Path] = body
+		case http.MethodGet:
+			data, ok := objects[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write(data)
+		case http.MethodDelete:
+			delete(objects, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+	store, err := storage.NewS3Storage(storage.S3Config{Endpoint: server.URL, Bucket: "tia-media", AccessKey: "test-access", SecretKey: "test-secret"}, server.Client())
+	require.NoError(t, err)
+	mediaService := services.NewMediaService(testutil.TestDB, store, 2<<20)
+	owner := models.User{FirstName: "Owner", LoginEmail: "owner@media.com", Active: true}
+	testutil.TestDB.Create(&owner)
+	media, err := mediaService.UploadMedia(ctx, owner.ID, ports.UploadMediaInput{}, "logo.png", bytes.NewReader(testPNG(t, 200, 200)))
+	require.NoError(t, err)
+	assert.Len(t, objects, 1+len(media.Variants))
+	assert.Contains(t, objects, "/tia-media/"+media.StorageKey)
+	body, _, err := mediaService.OpenMediaContent(ctx, media.ID, "thumb")
+	require.NoError(t, err)
+	cfg, _, err := image.DecodeConfig(body)
+	body.Close()
+	require.NoError(t, err)
+	assert.Equal(t, 150, cfg.Width)
+	_, err = store.Get(ctx, "media/missing.png")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+	_, err = store.Get(ctx, "../escape.png")
+	assert.ErrorIs(t, err, storage.ErrInvalidKey)
+	require.NoError(t, mediaService.DeleteMedia(ctx, media.ID, owner.ID))
+	assert.Empty(t, objects)
+}
@@ -1,11 +1,14 @@
 package main
 import (
 	"context"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/core/services"
 	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/models"
 	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/ports"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/pkg/utils"
 	testutil "github.com/TIA-PARTNERS-GROUP/tia-api/test/test_util"
 	"github.com/stretchr/testify/assert"
 )
@@ -47,6 +50,8 @@ func TestPublicationService_Integration_UpdatePublication(t *testing.T) {
 		Title:           "Original Title",
 		Slug:            "original-title",
 		Content:         "Original content.",
+		ContentFormat:   models.ContentFormatMarkdown,
+		Visibility:      models.VisibilityMembers,
 		Published:       false,
 	}
 	testutil.TestDB.Select("*").Create(&pub)
@@ -69,6 +74,8 @@ func TestPublicationService_Integration_Publishing(t *testing.T) {
 		Title:           "To Be Published",
 		Slug:            "to-be-published",
 		Content:         "Content.",
+		ContentFormat:   models.ContentFormatMarkdown,
+		Visibility:      models.VisibilityMembers,
 		Published:       false,
 	}
 	testutil.TestDB.Select("*").Create(&pub)
@@ -98,3 +105,291 @@ func TestPublicationService_Integration_DeletePublication(t *testing.T) {
 	err := pubService.DeletePublication(context.Background(), pub.ID)
 	assert.NoError(t, err)
 }
+func TestPublicationService_Integration_RevisionHistory(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	pubService := services.NewPublicationService(testutil.TestDB)
+	author := models.User{FirstName: "Author", LoginEmail: "author@pub.com", Active: true}
+	testutil.TestDB.Create(&author)
+	editor := models.User{FirstName: "Editor", LoginEmail: "editor@pub.com", Active: true}
+	testutil.TestDB.Create(&editor)
+	createdPub, err := pubService.CreatePublication(context.Background(), ports.CreatePublicationInput{
+		UserID:          author.ID,
+		PublicationType: models.PublicationArticle,
+		Title:           "Versioned Article",
+		Content:         "line one\nline two",
+	})
+	assert.NoError(t, err)
+	newContent := "line one\nline two changed"
+	_, err = pubService.UpdatePublication(context.Background(), createdPub.ID, ports.UpdatePublicationInput{
+		Content:      &newContent,
+		EditorUserID: editor.ID,
+	})
+	assert.NoError(t, err)
+	thumbnail := "https://example.com/thumb.png"
+	_, err = pubService.UpdatePublication(context.Background(), createdPub.ID, ports.UpdatePublicationInput{Thumbnail: &thumbnail})
+	assert.NoError(t, err)
+	revisions, err := pubService.GetPublicationRevisions(context.Background(), createdPub.ID)
+	assert.NoError(t, err)
+	assert.Len(t, revisions, 2)
+	assert.Equal(t, uint(2), revisions[0].Revision)
+	assert.Equal(t, editor.ID, revisions[0].EditorUserID)
+	assert.Equal(t, "Editor", revisions[0].EditorUser.FirstName)
+	assert.Equal(t, uint(1), revisions[1].Revision)
+	assert.Equal(t, author.ID, revisions[1].EditorUserID)
+	diff, err := pubService.DiffPublicationRevision(context.Background(), createdPub.ID, 1, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, diff.ToRevision)
+	assert.Equal(t, []utils.DiffLine{
+		{Op: utils.DiffOpEqual, Text: "line one"},
+		{Op: utils.DiffOpDelete, Text: "line two"},
+		{Op: utils.DiffOpInsert, Text: "line two changed"},
+	}, diff.Lines)
+	excerpt := "A hand-written excerpt"
+	_, err = pubService.UpdatePublication(context.Background(), createdPub.ID, ports.UpdatePublicationInput{Excerpt: &excerpt})
+	assert.NoError(t, err)
+	restored, err := pubService.RestorePublicationRevision(context.Background(), createdPub.ID, 1, author.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "line one\nline two", restored.Content)
+	assert.Nil(t, restored.Excerpt, "restoring a revision without an excerpt clears it")
+	revisions, err = pubService.GetPublicationRevisions(context.Background(), createdPub.ID)
+	assert.NoError(t, err)
+	assert.Len(t, revisions, 4)
+	assert.Nil(t, revisions[0].Excerpt)
+	assert.Equal(t, "line one\nline two", revisions[0].Content)
+	_, err = pubService.GetPublicationRevision(context.Background(), createdPub.ID, 99)
+	assert.ErrorIs(t, err, ports.ErrPublicationRevisionNotFound)
+	err = pubService.DeletePublication(context.Background(), createdPub.ID)
+	assert.NoError(t, err)
+}
+func TestPublicationService_Integration_ScheduledPublishing(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	pubService := services.NewPublicationService(testutil.TestDB)
+	author := models.User{FirstName: "Author", LoginEmail: "author@pub.com", Active: true}
+	testutil.TestDB.Create(&author)
+	past := time.Now().Add(-time.Hour)
+	_, err := pubService.CreatePublication(context.Background(), ports.CreatePublicationInput{
+		UserID:          author.ID,
+		PublicationType: models.PublicationPost,
+		Title:           "Too Late",
+		Content:         "Content.",
+		ScheduledAt:     &past,
+	})
+	assert.ErrorIs(t, err, ports.ErrInvalidScheduledAt)
+	scheduledAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	published := true
+	scheduled, err := pubService.CreatePublication(context.Background(), ports.CreatePublicationInput{
+		UserID:          author.ID,
+		PublicationType: models.PublicationPost,
+		Title:           "Scheduled Post",
+		Content:         "Content.",
+		Published:       &published,
+		ScheduledAt:     &scheduledAt,
+	})
+	assert.NoError(t, err)
+	assert.False(t, scheduled.Published)
+	assert.Nil(t, scheduled.PublishedAt)
+	assert.NotNil(t, scheduled.ScheduledAt)
+	count, err := pubService.PublishScheduledPublications(context.Background(), time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+	count, err = pubService.PublishScheduledPublications(context.Background(), scheduledAt.Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	fetched, err := pubService.GetPublicationByID(context.Background(), scheduled.ID)
+	assert.NoError(t, err)
+	assert.True(t, fetched.Published)
+	assert.NotNil(t, fetched.PublishedAt)
+	assert.WithinDuration(t, scheduledAt, *fetched.PublishedAt, time.Second)
+}
+func TestPublicationService_Integration_SlugCollisionsAndHistory(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	pubService := services.NewPublicationService(testutil.TestDB)
+	author := models.User{FirstName: "Author", LoginEmail: "author@pub.com", Active: true}
+	testutil.TestDB.Create(&author)
+	create := func(title string) *models.Publication {
+		pub, err := pubService.CreatePublication(context.Background(), ports.CreatePublicationInput{
+			UserID:          author.ID,
+			PublicationType: models.PublicationPost,
+			Title:           title,
+			Content:         "Content.",
+		})
+		assert.NoError(t, err)
+		return pub
+	}
+	first := create("Same Title")
+	second := create("Same Title")
+	third := create("Same Title")
+	assert.Equal(t, "same-title", first.Slug)
+	assert.Equal(t, "same-title-2", second.Slug)
+	assert.Equal(t, "same-title-3", third.Slug)
+	assert.Equal(t, "uber-strasse", create("Über Straße").Slug)
+	assert.Equal(t, "privet-mir", create("Привет, мир").Slug)
+	assert.Equal(t, "publication", create("你好").Slug)
+	renamed := "Fresh Title"
+	updated, err := pubService.UpdatePublication(context.Background(), first.ID, ports.UpdatePublicationInput{Title: &renamed})
+	assert.NoError(t, err)
+	assert.Equal(t, "fresh-title", updated.Slug)
+	resolved, redirected, err := pubService.ResolvePublicationSlug(context.Background(), "same-title")
+	assert.NoError(t, err)
+	assert.True(t, redirected)
+	assert.Equal(t, first.ID, resolved.ID)
+	assert.Equal(t, "same-title-4", create("Same Title").Slug)
+	original := "Same Title"
+	restored, err := pubService.UpdatePublication(context.Background(), first.ID, ports.UpdatePublicationInput{Title: &original})
+	assert.NoError(t, err)
+	assert.Equal(t, "same-title", restored.Slug)
+	resolved, redirected, err = pubService.ResolvePublicationSlug(context.Background(), "fresh-title")
+	assert.NoError(t, err)
+	assert.True(t, redirected)
+	assert.Equal(t, first.ID, resolved.ID)
+	_, _, err = pubService.ResolvePublicationSlug(context.Background(), "never-existed")
+	assert.ErrorIs(t, err, ports.ErrPublicationNotFound)
+}
+func TestPublicationService_Integration_ConcurrentSlugCollisions(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	pubService := services.NewPublicationService(testutil.TestDB)
+	author := models.User{FirstName: "Author", LoginEmail: "author@pub.com", Active: true}
+	testutil.TestDB.Create(&author)
+	const writers = 4
+	slugs := make([]string, writers)
+	errs := make([]error, writers)
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			pub, err := pubService.CreatePublication(context.Background(), ports.CreatePublicationInput{
+				UserID:          author.ID,
+				PublicationType: models.PublicationPost,
+				Title:           "Racing Title",
+				Content:         "Content.",
+			})
+			errs[i] = err
+			if err == nil {
+				slugs[i] = pub.Slug
+			}
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		assert.NoError(t, err)
+	}
+	assert.ElementsMatch(t, []string{"racing-title", "racing-title-2", "racing-title-3", "racing-title-4"}, slugs)
+}
+func TestPublicationService_Integration_FindAllFilters(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	pubService := services.NewPublicationService(testutil.TestDB)
+	author := models.User{FirstName: "Author", LoginEmail: "author@pub.com", Active: true}
+	testutil.TestDB.Create(&author)
+	other := models.User{FirstName: "Other", LoginEmail: "other@pub.com", Active: true}
+	testutil.TestDB.Create(&other)
+	lastYear := time.Now().AddDate(-1, 0, 0)
+	old := models.Publication{UserID: author.ID, PublicationType: models.PublicationArticle, Title: "Old", Slug: "old", Content: "c", Visibility: models.VisibilityPublic, Published: true, PublishedAt: &lastYear}
+	testutil.TestDB.Create(&old)
+	now := time.Now()
+	recent := models.Publication{UserID: author.ID, PublicationType: models.PublicationPost, Title: "Recent", Slug: "recent", Content: "c", Visibility: models.VisibilityPublic, Published: true, PublishedAt: &now}
+	testutil.TestDB.Create(&recent)
+	byOther := models.Publication{UserID: other.ID, PublicationType: models.PublicationPost, Title: "By Other", Slug: "by-other", Content: "c", Visibility: models.VisibilityMembers, Published: true, PublishedAt: &now}
+	testutil.TestDB.Create(&byOther)
+	anonymous, err := pubService.FindAllPublications(context.Background(), nil, ports.PublicationsFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, anonymous, 2)
+	from := time.Now().AddDate(0, 0, -7)
+	recentOnly, err := pubService.FindAllPublications(context.Background(), &other.ID, ports.PublicationsFilter{PublishedFrom: &from})
+	assert.NoError(t, err)
+	assert.Len(t, recentOnly, 2)
+	to := time.Now().AddDate(0, -1, 0)
+	oldOnly, err := pubService.FindAllPublications(context.Background(), &other.ID, ports.PublicationsFilter{PublishedTo: &to})
+	assert.NoError(t, err)
+	assert.Len(t, oldOnly, 1)
+	assert.Equal(t, old.ID, oldOnly[0].ID)
+	byAuthor, err := pubService.FindAllPublications(context.Background(), &other.ID, ports.PublicationsFilter{UserID: &other.ID})
+	assert.NoError(t, err)
+	assert.Len(t, byAuthor, 1)
+	postType := models.PublicationPost
+	posts, err := pubService.FindAllPublications(context.Background(), nil, ports.PublicationsFilter{PublicationType: &postType})
+	assert.NoError(t, err)
+	assert.Len(t, posts, 1)
+	assert.Equal(t, recent.ID, posts[0].ID)
+}
+func TestPublicationService_Integration_RenderMissingContent(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	pubService := services.NewPublicationService(testutil.TestDB)
+	author := models.User{FirstName: "Author", LoginEmail: "author@pub.com", Active: true}
+	testutil.TestDB.Create(&author)
+	ctx := context.Background()
+	legacy := models.Publication{UserID: author.ID, PublicationType: models.PublicationPost, Title: "Legacy", Slug: "legacy", Content: "Some **legacy** text", ContentFormat: models.ContentFormatMarkdown, Visibility: models.VisibilityPublic, Published: true}
+	assert.NoError(t, testutil.TestDB.Create(&legacy).Error)
+
+	feed := ports.MapPublicationsToFeed([]models.Publication{legacy}, "Feed", "https://example.test", "https://example.test/feed.json")
+	assert.Contains(t, feed.Items[0].ContentHTML, "<strong>legacy</strong>")
+	assert.Equal(t, "Some legacy text", feed.Items[0].Summary)
+
+	rendered, err := pubService.RenderMissingContent(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, rendered)
+	var stored models.Publication
+	testutil.TestDB.First(&stored, legacy.ID)
+	assert.Contains(t, stored.ContentHTML, "<strong>legacy</strong>")
+	assert.Equal(t, "Some legacy text", stored.GeneratedExcerpt)
+	rendered, err = pubService.RenderMissingContent(ctx)
+	assert.NoError(t, err)
+	assert.Zero(t, rendered)
+}
+func TestPublicationService_Integration_ContentRendering(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	pubService := services.NewPublicationService(testutil.TestDB)
+	author := models.User{FirstName: "Author", LoginEmail: "author@pub.com", Active: true}
+	testutil.TestDB.Create(&author)
+	ctx := context.Background()
+	article, err := pubService.CreatePublication(ctx, ports.CreatePublicationInput{
+		UserID:          author.ID,
+		PublicationType: models.PublicationArticle,
+		Title:           "Rendered Article",
+		Content:         "# Getting Started\n\nSome **bold** words and a [link](https://example.com).\n\n## Next Steps\n\n- one\n- two",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, models.ContentFormatMarkdown, article.ContentFormat)
+	assert.Contains(t, article.ContentHTML, `<h1 id="getting-started">Getting Started</h1>`)
+	assert.Contains(t, article.ContentHTML, "<strong>bold</strong>")
+	assert.Contains(t, article.ContentHTML, `<a href="https://example.com" rel="nofollow noopener noreferrer">link</a>`)
+	assert.Contains(t, article.ContentHTML, "<li>two</li>")
+	assert.Equal(t, uint(1), article.ReadingTimeMinutes)
+	assert.Nil(t, article.Excerpt)
+	assert.True(t, strings.HasPrefix(article.GeneratedExcerpt, "Getting Started Some bold words"))
+	resp := ports.MapPublicationToResponse(article)
+	assert.Equal(t, []utils.TOCEntry{{Level: 1, ID: "getting-started", Text: "Getting Started"}, {Level: 2, ID: "next-steps", Text: "Next Steps"}}, resp.TableOfContents)
+	assert.NotNil(t, resp.Excerpt)
+	assert.Equal(t, article.GeneratedExcerpt, *resp.Excerpt)
+	format := models.ContentFormatHTML
+	unsafe := `<p onclick="steal()">Hello <a href="javascript:alert(1)">there</a></p><script>alert(1)</script><iframe src="https://evil.example"></iframe>`
+	updated, err := pubService.UpdatePublication(ctx, article.ID, ports.UpdatePublicationInput{Content: &unsafe, ContentFormat: &format})
+	assert.NoError(t, err)
+	assert.Equal(t, models.ContentFormatHTML, updated.ContentFormat)
+	assert.Equal(t, `<p>Hello <a rel="nofollow noopener noreferrer">there</a></p>`, updated.ContentHTML)
+	assert.Equal(t, "Hello there", updated.GeneratedExcerpt)
+	assert.Nil(t, ports.MapPublicationToResponse(updated).TableOfContents)
+	revisions, err := pubService.GetPublicationRevisions(ctx, article.ID)
+	assert.NoError(t, err)
+	assert.Len(t, revisions, 2)
+	assert.Equal(t, models.ContentFormatHTML, revisions[0].ContentFormat)
+	restored, err := pubService.RestorePublicationRevision(ctx, article.ID, 1, author.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.ContentFormatMarkdown, restored.ContentFormat)
+	assert.Contains(t, restored.ContentHTML, `<h2 id="next-steps">Next Steps</h2>`)
+	excerpt := "Hand-written summary"
+	plaintext := models.ContentFormatPlaintext
+	post, err := pubService.CreatePublication(ctx, ports.CreatePublicationInput{
+		UserID:          author.ID,
+		PublicationType: models.PublicationPost,
+		Title:           "Plain Post",
+		Content:         "Line one <b>\nLine two\n\n# Not a heading",
+		ContentFormat:   &plaintext,
+		Excerpt:         &excerpt,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "<p>Line one &lt;b&gt;<br>\nLine two</p>\n<p># Not a heading</p>\n", post.ContentHTML)
+	postResp := ports.MapPublicationToResponse(post)
+	assert.Equal(t, "Hand-written summary", *postResp.Excerpt)
+	assert.Nil(t, postResp.TableOfContents)
+}
@@ -0,0 +1,108 @@
+package main
+import (
+	"context"
+	"testing"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/core/services"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/models"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/ports"
+	testutil "github.com/TIA-PARTNERS-GROUP/tia-api/test/test_util"
+	"github.com/stretchr/testify/assert"
+)
+func TestPublicationCommentService_Integration_ThreadAndModeration(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	ctx := context.Background()
+	commentService := services.NewPublicationCommentService(testutil.TestDB)
+	pubService := services.NewPublicationService(testutil.TestDB)
+	author := models.User{FirstName: "Author", LoginEmail: "author@comment.com", Active: true}
+	testutil.TestDB.Create(&author)
+	reader := models.User{FirstName: "Reader", LoginEmail: "reader@comment.com", Active: true}
+	testutil.TestDB.Create(&reader)
+	replier := models.User{FirstName: "Replier", LoginEmail: "replier@comment.com", Active: true}
+	testutil.TestDB.Create(&replier)
+	pub := models.Publication{
+		UserID:          author.ID,
+		PublicationType: models.PublicationCaseStudy,
+		Title:           "Commented Case Study",
+		Slug:            "commented-case-study",
+		Content:         "Content.",
+		Visibility:      models.VisibilityPublic,
+		Published:       true,
+	}
+	testutil.TestDB.Create(&pub)
+
+	root, err := commentService.CreateComment(ctx, ports.CreatePublicationCommentInput{
+		PublicationID: pub.ID,
+		UserID:        reader.ID,
+		Body:          "  Great <b>work</b>!<script>alert(1)</script>\x00  ",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "Great work!", root.Body)
+	assert.Equal(t, models.CommentStatusVisible, root.Status)
+
+	reply, err := commentService.CreateComment(ctx, ports.CreatePublicationCommentInput{
+		PublicationID: pub.ID,
+		UserID:        replier.ID,
+		ParentID:      &root.ID,
+		Body:          "Agreed.",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, root.ID, *reply.ParentID)
+
+	var authorNotifications, readerNotifications int64
+	testutil.TestDB.Model(&models.Notification{}).Where("receiver_user_id = ? AND notification_type = ?", author.ID, models.NotifyPublicationComment).Count(&authorNotifications)
+	testutil.TestDB.Model(&models.Notification{}).Where("receiver_user_id = ? AND notification_type = ?", reader.ID, models.NotifyPublicationComment).Count(&readerNotifications)
+	assert.Equal(t, int64(2), authorNotifications)
+	assert.Equal(t, int64(1), readerNotifications)
+
+	_, err = commentService.CreateComment(ctx, ports.CreatePublicationCommentInput{PublicationID: pub.ID, UserID: reader.ID, Body: "<p></p>"})
+	assert.ErrorIs(t, err, ports.ErrCommentBodyEmpty)
+
+	other := models.Publication{UserID: author.ID, PublicationType: models.PublicationPost, Title: "Other", Slug: "other", Content: "Other."}
+	testutil.TestDB.Create(&other)
+	_, err = commentService.CreateComment(ctx, ports.CreatePublicationCommentInput{PublicationID: other.ID, UserID: reader.ID, ParentID: &root.ID, Body: "Wrong thread"})
+	assert.ErrorIs(t, err, ports.ErrInvalidParentComment)
+
+	edited, err := commentService.UpdateComment(ctx, root.ID, ports.UpdatePublicationCommentInput{Body: "<<a>img src=x onerror=alert(1)>"})
+	assert.NoError(t, err)
+	assert.Equal(t, "&lt;img src=x onerror=alert(1)&gt;", edited.Body)
+
+	edited, err = commentService.UpdateComment(ctx, root.ID, ports.UpdatePublicationCommentInput{Body: "Great work, really!"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Great work, really!", edited.Body)
+	assert.NotNil(t, edited.EditedAt)
+
+	flagged, err := commentService.FlagComment(ctx, reply.ID, ports.FlagPublicationCommentInput{Reason: "Spam"})
+	assert.NoError(t, err)
+	assert.Equal(t, models.CommentStatusFlagged, flagged.Status)
+	assert.Equal(t, "Spam", *flagged.FlagReason)
+
+	hidden, err := commentService.ModerateComment(ctx, reply.ID, ports.ModeratePublicationCommentInput{Status: models.CommentStatusHidden})
+	assert.NoError(t, err)
+	assert.Equal(t, models.CommentStatusHidden, hidden.Status)
+
+	engagement, err := pubService.GetPublicationEngagement(ctx, []uint{pub.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), engagement[pub.ID].CommentCount)
+
+	err = commentService.DeleteComment(ctx, root.ID)
+	assert.NoError(t, err)
+	err = commentService.DeleteComment(ctx, root.ID)
+	assert.ErrorIs(t, err, ports.ErrCommentNotFound)
+	_, err = commentService.UpdateComment(ctx, root.ID, ports.UpdatePublicationCommentInput{Body: "Back again"})
+	assert.ErrorIs(t, err, ports.ErrCommentDeleted)
+
+	comments, err := commentService.GetCommentsForPublication(ctx, pub.ID)
+	assert.NoError(t, err)
+	thread := ports.MapPublicationCommentsToThread(comments, func(*models.PublicationComment) bool { return false })
+	assert.Len(t, thread.Comments, 1)
+	assert.Equal(t, "", thread.Comments[0].Body)
+	assert.Len(t, thread.Comments[0].Replies, 1)
+	assert.Equal(t, "", thread.Comments[0].Replies[0].Body)
+	assert.Equal(t, 0, thread.Count)
+
+	err = pubService.DeletePublication(ctx, pub.ID)
+	assert.NoError(t, err)
+	var remaining int64
+	testutil.TestDB.Model(&models.PublicationComment{}).Where("publication_id = ?", pub.ID).Count(&remaining)
+	assert.Zero(t, remaining)
+}
@@ -0,0 +1,72 @@
+package main
+import (
+	"context"
+	"testing"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/core/services"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/models"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/ports"
+	testutil "github.com/TIA-PARTNERS-GROUP/tia-api/test/test_util"
+	"github.com/stretchr/testify/assert"
+)
+func TestPublicationReactionService_Integration_SetAndCount(t *testing.T) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	ctx := context.Background()
+	reactionService := services.NewPublicationReactionService(testutil.TestDB)
+	pubService := services.NewPublicationService(testutil.TestDB)
+	author := models.User{FirstName: "Author", LoginEmail: "author@reaction.com", Active: true}
+	testutil.TestDB.Create(&author)
+	fan := models.User{FirstName: "Fan", LoginEmail: "fan@reaction.com", Active: true}
+	testutil.TestDB.Create(&fan)
+	critic := models.User{FirstName: "Critic", LoginEmail: "critic@reaction.com", Active: true}
+	testutil.TestDB.Create(&critic)
+	pub := models.Publication{
+		UserID:          author.ID,
+		PublicationType: models.PublicationTestimonial,
+		Title:           "Reacted Testimonial",
+		Slug:            "reacted-testimonial",
+		Content:         "Content.",
+		Visibility:      models.VisibilityPublic,
+		Published:       true,
+	}
+	testutil.TestDB.Create(&pub)
+
+	reaction, err := reactionService.SetReaction(ctx, pub.ID, fan.ID, ports.SetPublicationReactionInput{ReactionType: models.ReactionLike})
+	assert.NoError(t, err)
+	assert.Equal(t, models.ReactionLike, reaction.ReactionType)
+
+	reaction, err = reactionService.SetReaction(ctx, pub.ID, fan.ID, ports.SetPublicationReactionInput{ReactionType: models.ReactionInsightful})
+	assert.NoError(t, err)
+	assert.Equal(t, models.ReactionInsightful, reaction.ReactionType)
+
+	_, err = reactionService.SetReaction(ctx, pub.ID, critic.ID, ports.SetPublicationReactionInput{ReactionType: models.ReactionInsightful})
+	assert.NoError(t, err)
+	_, err = reactionService.SetReaction(ctx, pub.ID, author.ID, ports.SetPublicationReactionInput{ReactionType: models.ReactionCelebrate})
+	assert.NoError(t, err)
+
+	var count int64
+	testutil.TestDB.Model(&models.PublicationReaction{}).Where("publication_id = ? AND user_id = ?", pub.ID, fan.ID).Count(&count)
+	assert.Equal(t, int64(1), count)
+
+	var notifications int64
+	testutil.TestDB.Model(&models.Notification{}).Where("receiver_user_id = ? AND notification_type = ?", author.ID, models.NotifyPublicationReaction).Count(&notifications)
+	assert.Equal(t, int64(2), notifications)
+
+	summary, err := reactionService.GetReactionSummary(ctx, pub.ID, &fan.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), summary.Total)
+	assert.Equal(t, int64(2), summary.Counts[models.ReactionInsightful])
+	assert.Equal(t, int64(1), summary.Counts[models.ReactionCelebrate])
+	assert.Equal(t, models.ReactionInsightful, *summary.ViewerReaction)
+
+	engagement, err := pubService.GetPublicationEngagement(ctx, []uint{pub.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), engagement[pub.ID].ReactionTotal)
+
+	err = reactionService.RemoveReaction(ctx, pub.ID, fan.ID)
+	assert.NoError(t, err)
+	err = reactionService.RemoveReaction(ctx, pub.ID, fan.ID)
+	assert.ErrorIs(t, err, ports.ErrReactionNotFound)
+
+	_, err = reactionService.SetReaction(ctx, 99999, fan.ID, ports.SetPublicationReactionInput{ReactionType: models.ReactionLike})
+	assert.ErrorIs(t, err, ports.ErrPublicationNotFound)
+}
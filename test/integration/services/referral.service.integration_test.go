@@ -0,0 +1,120 @@
+package main
+import (
+	"context"
+	"testing"
+	"time"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/core/services"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/models"
+	"github.com/TIA-PARTNERS-GROUP/tia-api/internal/ports"
+	testutil "github.com/TIA-PARTNERS-GROUP/tia-api/test/test_util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+func setupReferralBusinesses(t *testing.T) (models.User, models.User, models.Business, models.Business) {
+	testutil.CleanupTestDB(t, testutil.TestDB)
+	referrerOwner := models.User{FirstName: "Referrer", LoginEmail: "referrer@referral.com", Active: true}
+	receiverOwner := models.User{FirstName: "Receiver", LoginEmail: "receiver@referral.com", Active: true}
+	testutil.TestDB.Create(&referrerOwner)
+	testutil.TestDB.Create(&receiverOwner)
+	referrer := models.Business{Name: "Referrer Co", OperatorUserID: referrerOwner.ID, BusinessType: models.BusinessTypeOther, BusinessCategory: models.BusinessCategoryMixed, BusinessPhase: models.BusinessPhaseGrowth}
+	receiver := models.Business{Name: "Receiver Co", OperatorUserID: receiverOwner.ID, BusinessType: models.BusinessTypeOther, BusinessCategory: models.BusinessCategoryMixed, BusinessPhase: models.BusinessPhaseGrowth}
+	testutil.TestDB.Create(&referrer)
+	testutil.TestDB.Create(&receiver)
+	return referrerOwner, receiverOwner, referrer, receiver
+}
+func TestReferralService_Integration_Lifecycle(t *testing.T) {
+	referrerOwner, receiverOwner, referrer, receiver := setupReferralBusinesses(t)
+	referralService := services.NewReferralService(testutil.TestDB)
+	ctx := context.Background()
+	conn := models.BusinessConnection{InitiatingBusinessID: receiver.ID, ReceivingBusinessID: referrer.ID, ConnectionType: models.ConnectionTypeReferral, Status: models.ConnectionStatusActive, InitiatedByUserID: receiverOwner.ID}
+	testutil.TestDB.Create(&conn)
+	input := ports.CreateReferralInput{ReferrerBusinessID: referrer.ID, ReceiverBusinessID: receiver.ID, ClientName: "Acme Client"}
+	_, err := referralService.CreateReferral(ctx, receiverOwner.ID, input)
+	assert.ErrorIs(t, err, ports.ErrForbidden)
+	referral, err := referralService.CreateReferral(ctx, referrerOwner.ID, input)
+	require.NoError(t, err)
+	assert.Equal(t, models.ReferralSent, referral.Status)
+	require.NotNil(t, referral.BusinessConnectionID)
+	assert.Equal(t, conn.ID, *referral.BusinessConnectionID)
+	var notified int64
+	testutil.TestDB.Model(&models.Notification{}).Where("receiver_user_id = ? AND notification_type = ?", receiverOwner.ID, models.NotifyReferral).Count(&notified)
+	assert.Equal(t, int64(1), notified)
+	contacted := models.ReferralContacted
+	_, err = referralService.UpdateReferral(ctx, referral.ID, referrerOwner.ID, ports.UpdateReferralInput{Status: &contacted})
+	assert.ErrorIs(t, err, ports.ErrNotReferralReceiver)
+	referral, err = referralService.UpdateReferral(ctx, referral.ID, receiverOwner.ID, ports.UpdateReferralInput{Status: &contacted})
+	require.NoError(t, err)
+	assert.Equal(t, models.ReferralContacted, referral.Status)
+	assert.ErrorIs(t, referralService.DeleteReferral(ctx, referral.ID, referrerOwner.ID), ports.ErrReferralNotDeletable)
+	sent := models.ReferralSent
+	_, err = referralService.UpdateReferral(ctx, referral.ID, receiverOwner.ID, ports.UpdateReferralInput{Status: &sent})
+	assert.ErrorIs(t, err, ports.ErrInvalidReferralStatus)
+	won := models.ReferralWon
+	value := 12500.0
+	referral, err = referralService.UpdateReferral(ctx, referral.ID, receiverOwner.ID, ports.UpdateReferralInput{Status: &won, DealValue: &value})
+	require.NoError(t, err)
+	assert.Equal(t, models.ReferralWon, referral.Status)
+	assert.NotNil(t, referral.ClosedAt)
+	require.NotNil(t, referral.DealValue)
+	assert.Equal(t, value, *referral.DealValue)
+	lost := models.ReferralLost
+	_, err = referralService.UpdateReferral(ctx, referral.ID, receiverOwner.ID, ports.UpdateReferralInput{Status: &lost})
+	assert.ErrorIs(t, err, ports.ErrReferralClosed)
+	testutil.TestDB.Model(&models.Notification{}).Where("receiver_user_id = ? AND notification_type = ?", referrerOwner.ID, models.NotifyReferral).Count(&notified)
+	assert.Equal(t, int64(2), notified)
+	notes := "Signed a 12 month contract"
+	referral, err = referralService.UpdateReferral(ctx, referral.ID, referrerOwner.ID, ports.UpdateReferralInput{Notes: &notes})
+	require.NoError(t, err)
+	require.NotNil(t, referral.Notes)
+	assert.Equal(t, notes, *referral.Notes)
+	pending, err := referralService.CreateReferral(ctx, referrerOwner.ID, input)
+	require.NoError(t, err)
+	assert.NoError(t, referralService.DeleteReferral(ctx, pending.ID, referrerOwner.ID))
+	_, err = referralService.GetReferral(ctx, pending.ID, referrerOwner.ID)
+	assert.ErrorIs(t, err, ports.ErrReferralNotFound)
+	given := ports.ReferralDirectionGiven
+	list, err := referralService.GetBusinessReferrals(ctx, referrer.ID, referrerOwner.ID, ports.ReferralsFilter{Direction: &given})
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+	received := ports.ReferralDirectionReceived
+	list, err = referralService.GetBusinessReferrals(ctx, referrer.ID, referrerOwner.ID, ports.ReferralsFilter{Direction: &received})
+	require.NoError(t, err)
+	assert.Empty(t, list)
+	_, err = referralService.GetBusinessReferrals(ctx, referrer.ID, receiverOwner.ID, ports.ReferralsFilter{})
+	assert.ErrorIs(t, err, ports.ErrForbidden)
+}
+func TestReferralService_Integration_Report(t *testing.T) {
+	referrerOwner, receiverOwner, referrer, receiver := setupReferralBusinesses(t)
+	referralService := services.NewReferralService(testutil.TestDB)
+	ctx := context.Background()
+	value := 1000.0
+	seed := []models.Referral{
+		{ReferrerBusinessID: referrer.ID, ReceiverBusinessID: receiver.ID, ClientName: "Q1 Given Won", Status: models.ReferralWon, DealValue: &value, ReferredByUserID: referrerOwner.ID, CreatedAt: time.Date(2026, 2, 10, 12, 0, 0, 0, time.Local)},
+		{ReferrerBusinessID: referrer.ID, ReceiverBusinessID: receiver.ID, ClientName: "Q1 Given Lost", Status: models.ReferralLost, DealValue: &value, ReferredByUserID: referrerOwner.ID, CreatedAt: time.Date(2026, 3, 5, 12, 0, 0, 0, time.Local)},
+		{ReferrerBusinessID: receiver.ID, ReceiverBusinessID: referrer.ID, ClientName: "Q2 Received", Status: models.ReferralSent, ReferredByUserID: receiverOwner.ID, CreatedAt: time.Date(2026, 5, 20, 12, 0, 0, 0, time.Local)},
+		{ReferrerBusinessID: receiver.ID, ReceiverBusinessID: referrer.ID, ClientName: "Out Of Range", Status: models.ReferralSent, ReferredByUserID: receiverOwner.ID, CreatedAt: time.Date(2025, 6, 1, 12, 0, 0, 0, time.Local)},
+	}
+	for i := range seed {
+		require.NoError(t, testutil.TestDB.Create(&seed[i]).Error)
+	}
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local)
+	to := time.Date(2026, 6, 30, 0, 0, 0, 0, time.Local)
+	report, err := referralService.GetReferralReport(ctx, referrer.ID, referrerOwner.ID, ports.ReferralReportQuery{From: &from, To: &to, Interval: ports.ReferralIntervalQuarter})
+	require.NoError(t, err)
+	require.Len(t, report.Periods, 2)
+	assert.Equal(t, "2026-Q1", report.Periods[0].Period)
+	assert.Equal(t, 2, report.Periods[0].GivenCount)
+	assert.Equal(t, 1, report.Periods[0].GivenWon)
+	assert.Equal(t, value, report.Periods[0].GivenWonValue)
+	assert.Equal(t, "2026-Q2", report.Periods[1].Period)
+	assert.Equal(t, 1, report.Periods[1].ReceivedCount)
+	assert.Equal(t, 2, report.Totals.GivenCount)
+	assert.Equal(t, 1, report.Totals.ReceivedCount)
+	report, err = referralService.GetReferralReport(ctx, referrer.ID, referrerOwner.ID, ports.ReferralReportQuery{From: &from, To: &to, Interval: ports.ReferralIntervalMonth})
+	require.NoError(t, err)
+	assert.Len(t, report.Periods, 6)
+	assert.Equal(t, "2026-02", report.Periods[1].Period)
+	assert.Equal(t, 1, report.Periods[1].GivenCount)
+	_, err = referralService.GetReferralReport(ctx, referrer.ID, referrerOwner.ID, ports.ReferralReportQuery{From: &to, To: &from})
+	assert.ErrorIs(t, err, ports.ErrInvalidReportRange)
+}
@@ -25,7 +25,18 @@ func SetupTestDB() {
 		&models.L2EResponse{}, &models.Subscription{}, &models.UserSubscription{},
 		&models.UserDailyActivityProgress{}, &models.Event{}, &models.DailyActivity{},
 		&models.DailyActivityEnrolment{}, &models.Region{}, &models.ProjectRegion{},
-		&models.InferredConnection{},
+		&models.InferredConnection{}, &models.PublicationRevision{},
+		&models.PublicationSlugHistory{},
+		&models.PublicationComment{},
+		&models.PublicationReaction{},
+		&models.Media{},
+		&models.MediaVariant{},
+		&models.BusinessMember{},
+		&models.BusinessInvite{},
+		&models.BusinessConnectionHistory{},
+		&models.IntroductionRequest{},
+		&models.Referral{},
+		&models.BusinessVerification{},
 	}
 	if err := db.AutoMigrate(allModels...); err != nil {
 		log.Fatalf("Failed to migrate database for tests: %v", err)
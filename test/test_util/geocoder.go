@@ -0,0 +1,3 @@
+package testutil
+import "github.com/TIA-PARTNERS-GROUP/tia-api/pkg/geo"
+var TestGeocoder = geo.NewAUPostcodeGeocoder()
@@ -0,0 +1,45 @@
+package testutil
+import (
+	"context"
+	"errors"
+	"sync"
+)
+var TestResolver = NewStubResolver()
+type StubResolver struct {
+	mu    sync.Mutex
+	txt   map[string][]string
+	files map[string][]byte
+}
+func NewStubResolver() *StubResolver {
+	return &StubResolver{txt: map[string][]string{}, files: map[string][]byte{}}
+}
+func (r *StubResolver) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.txt = map[string][]string{}
+	r.files = map[string][]byte{}
+}
+func (r *StubResolver) SetTXT(domain string, records ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.txt[domain] = records
+}
+func (r *StubResolver) SetFile(domain, path string, body []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.files[domain+path] = body
+}
+func (r *StubResolver) LookupTXT(ctx context.Context, domain string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.txt[domain], nil
+}
+func (r *StubResolver) FetchFile(ctx context.Context, domain, path string) ([]byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	body, ok := r.files[domain+path]
+	if !ok {
+		return nil, errors.New("stub resolver: file not found")
+	}
+	return body, nil
+}